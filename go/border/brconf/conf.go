@@ -18,6 +18,7 @@
 package brconf
 
 import (
+	"os"
 	"path/filepath"
 
 	"github.com/scionproto/scion/go/lib/addr"
@@ -40,6 +41,9 @@ type BRConf struct {
 	MasterKeys keyconf.Master
 	// Dir is the configuration directory.
 	Dir string
+	// Policer is the ingress policer configuration. It is nil if policing is
+	// disabled.
+	Policer *PolicerConf
 }
 
 // Load sets up the configuration, loading it from the supplied config directory.
@@ -53,6 +57,9 @@ func Load(id, confDir string) (*BRConf, error) {
 	if err := conf.loadMasterKeys(); err != nil {
 		return nil, err
 	}
+	if err := conf.loadPolicer(); err != nil {
+		return nil, err
+	}
 	return conf, nil
 }
 
@@ -62,6 +69,7 @@ func WithNewTopo(id string, topo topology.Topology, oldConf *BRConf) (*BRConf, e
 	conf := &BRConf{
 		Dir:        oldConf.Dir,
 		MasterKeys: oldConf.MasterKeys,
+		Policer:    oldConf.Policer,
 	}
 	if err := conf.initTopo(id, topo); err != nil {
 		return nil, common.NewBasicError("Unable to initialize topo", err)
@@ -106,3 +114,13 @@ func (cfg *BRConf) loadMasterKeys() error {
 	}
 	return nil
 }
+
+func (cfg *BRConf) loadPolicer() error {
+	path := filepath.Join(cfg.Dir, PolicerFile)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil
+	}
+	var err error
+	cfg.Policer, err = LoadPolicer(path)
+	return err
+}
@@ -17,13 +17,19 @@ package brconf
 import (
 	"io"
 	"strings"
+	"time"
 
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/config"
 	"github.com/scionproto/scion/go/lib/env"
 	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/util"
 )
 
+// DefaultDrainWindow is the default duration an interface is drained for.
+const DefaultDrainWindow = time.Minute
+
 var _ config.Config = (*Config)(nil)
 
 // Config is the border router configuration that is loaded from file.
@@ -76,15 +82,30 @@ type BR struct {
 	// RollbackFailAction indicates the action that should be taken
 	// if the rollback fails.
 	RollbackFailAction FailAction `toml:"rollback_fail_action,omitempty"`
+	// CaptureDir is the directory packet captures are written to. Packet
+	// capture is disabled if it is empty.
+	CaptureDir string `toml:"capture_dir,omitempty"`
+	// DrainWindow is the default duration an interface is drained for, if
+	// the drain request does not specify one.
+	DrainWindow util.DurWrap `toml:"drain_window,omitempty"`
+	// DrainSCIONDs are the addresses of the local SCIONDs that are notified
+	// about the revocations of draining interfaces.
+	DrainSCIONDs []string `toml:"drain_sciond_addresses,omitempty"`
 }
 
 func (cfg *BR) InitDefaults() {
 	if cfg.RollbackFailAction != FailActionContinue {
 		cfg.RollbackFailAction = FailActionFatal
 	}
+	if cfg.DrainWindow.Duration == 0 {
+		cfg.DrainWindow.Duration = DefaultDrainWindow
+	}
 }
 
 func (cfg *BR) Validate() error {
+	if cfg.DrainWindow.Duration < 0 {
+		return serrors.New("drain_window must not be negative", "value", cfg.DrainWindow)
+	}
 	return cfg.RollbackFailAction.Validate()
 }
 
@@ -54,4 +54,7 @@ func CheckTestConfig(t *testing.T, cfg *Config, id string) {
 
 func CheckTestBRConfig(t *testing.T, cfg *BR) {
 	assert.Equal(t, FailActionFatal, cfg.RollbackFailAction)
+	assert.Empty(t, cfg.CaptureDir)
+	assert.Equal(t, DefaultDrainWindow, cfg.DrainWindow.Duration)
+	assert.Empty(t, cfg.DrainSCIONDs)
 }
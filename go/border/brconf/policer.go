@@ -0,0 +1,194 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package brconf
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// PolicerFile is the name of the optional policer configuration file in the
+// configuration directory.
+const PolicerFile = "policer.json"
+
+// PolicerConf is the configuration of the ingress policer. Each packet is
+// checked against the limits of its ingress interface, its source ISD-AS and
+// its traffic class. A packet is only admitted if it conforms to all
+// applicable limits.
+type PolicerConf struct {
+	// Action is the action taken for non-conforming packets.
+	Action PolicerAction
+	// Interfaces contains the limits per ingress interface. The local
+	// interface has ID 0.
+	Interfaces map[common.IFIDType]RateLimit
+	// DefaultInterface is applied to all interfaces that are not listed in
+	// Interfaces, each with its own bucket.
+	DefaultInterface *RateLimit
+	// SourceIAs contains the limits per source ISD-AS.
+	SourceIAs map[addr.IA]RateLimit
+	// DefaultSourceIA is applied to all source ISD-ASes that are not listed in
+	// SourceIAs, each with its own bucket. The number of these buckets is
+	// bounded. Once the bound is reached, further source ISD-ASes share a
+	// single bucket.
+	DefaultSourceIA *RateLimit
+	// TrafficClasses contains the limits per traffic class.
+	TrafficClasses map[TrafficClass]RateLimit
+}
+
+// LoadPolicer loads the policer configuration from the given file.
+func LoadPolicer(path string) (*PolicerConf, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, serrors.WrapStr("unable to read policer config", err, "path", path)
+	}
+	cfg := &PolicerConf{}
+	if err := json.Unmarshal(raw, cfg); err != nil {
+		return nil, serrors.WrapStr("unable to parse policer config", err, "path", path)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, serrors.WrapStr("invalid policer config", err, "path", path)
+	}
+	return cfg, nil
+}
+
+// Validate validates the policer configuration and sets the defaults.
+func (cfg *PolicerConf) Validate() error {
+	if cfg.Action == "" {
+		cfg.Action = PolicerActionDrop
+	}
+	if err := cfg.Action.Validate(); err != nil {
+		return err
+	}
+	for ifid, l := range cfg.Interfaces {
+		if err := l.Validate(); err != nil {
+			return serrors.WithCtx(err, "ifid", ifid)
+		}
+	}
+	for ia, l := range cfg.SourceIAs {
+		if err := l.Validate(); err != nil {
+			return serrors.WithCtx(err, "ia", ia)
+		}
+	}
+	for tc, l := range cfg.TrafficClasses {
+		if err := tc.Validate(); err != nil {
+			return err
+		}
+		if err := l.Validate(); err != nil {
+			return serrors.WithCtx(err, "class", tc)
+		}
+	}
+	for _, l := range []*RateLimit{cfg.DefaultInterface, cfg.DefaultSourceIA} {
+		if l == nil {
+			continue
+		}
+		if err := l.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RateLimit defines a token-bucket rate limit. A zero rate means that the
+// respective dimension is not limited.
+type RateLimit struct {
+	// PPS is the sustained rate in packets per second.
+	PPS uint64
+	// BPS is the sustained rate in bytes per second.
+	BPS uint64
+	// PktBurst is the bucket size in packets. Defaults to PPS.
+	PktBurst uint64
+	// ByteBurst is the bucket size in bytes. Defaults to BPS.
+	ByteBurst uint64
+}
+
+// Validate checks that the bursts are consistent with the rates.
+func (l RateLimit) Validate() error {
+	if l.PPS == 0 && l.PktBurst != 0 {
+		return serrors.New("PktBurst set without PPS")
+	}
+	if l.BPS == 0 && l.ByteBurst != 0 {
+		return serrors.New("ByteBurst set without BPS")
+	}
+	return nil
+}
+
+// PolicerAction is the action taken for non-conforming packets.
+type PolicerAction string
+
+const (
+	// PolicerActionDrop indicates that non-conforming packets are silently
+	// dropped.
+	PolicerActionDrop PolicerAction = "drop"
+	// PolicerActionSCMP indicates that non-conforming packets are dropped and
+	// an SCMP error is sent to the source.
+	PolicerActionSCMP PolicerAction = "scmp"
+)
+
+func (a PolicerAction) Validate() error {
+	switch a {
+	case PolicerActionDrop, PolicerActionSCMP:
+		return nil
+	default:
+		return serrors.New("Unknown PolicerAction", "input", a)
+	}
+}
+
+func (a *PolicerAction) UnmarshalText(text []byte) error {
+	*a = PolicerAction(strings.ToLower(string(text)))
+	return a.Validate()
+}
+
+// TrafficClass classifies packets by their L4 protocol.
+type TrafficClass string
+
+const (
+	TrafficClassSCMP  TrafficClass = "scmp"
+	TrafficClassUDP   TrafficClass = "udp"
+	TrafficClassTCP   TrafficClass = "tcp"
+	TrafficClassOther TrafficClass = "other"
+)
+
+// TrafficClassFromL4 returns the traffic class of the given L4 protocol.
+func TrafficClassFromL4(l4 common.L4ProtocolType) TrafficClass {
+	switch l4 {
+	case common.L4SCMP:
+		return TrafficClassSCMP
+	case common.L4UDP:
+		return TrafficClassUDP
+	case common.L4TCP:
+		return TrafficClassTCP
+	default:
+		return TrafficClassOther
+	}
+}
+
+func (tc TrafficClass) Validate() error {
+	switch tc {
+	case TrafficClassSCMP, TrafficClassUDP, TrafficClassTCP, TrafficClassOther:
+		return nil
+	default:
+		return serrors.New("Unknown TrafficClass", "input", tc)
+	}
+}
+
+func (tc *TrafficClass) UnmarshalText(text []byte) error {
+	*tc = TrafficClass(strings.ToLower(string(text)))
+	return tc.Validate()
+}
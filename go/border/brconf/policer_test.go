@@ -0,0 +1,82 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package brconf
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestLoadPolicer(t *testing.T) {
+	cfg, err := LoadPolicer("testdata/policer.json")
+	require.NoError(t, err)
+	expected := &PolicerConf{
+		Action: PolicerActionSCMP,
+		Interfaces: map[common.IFIDType]RateLimit{
+			1: {PPS: 1000, BPS: 1000000},
+			2: {PPS: 10, PktBurst: 20},
+		},
+		DefaultInterface: &RateLimit{PPS: 100},
+		SourceIAs: map[addr.IA]RateLimit{
+			xtest.MustParseIA("1-ff00:0:110"): {BPS: 500000, ByteBurst: 10000},
+		},
+		TrafficClasses: map[TrafficClass]RateLimit{
+			TrafficClassSCMP: {PPS: 50},
+		},
+	}
+	assert.Equal(t, expected, cfg)
+}
+
+func TestPolicerConfValidate(t *testing.T) {
+	tests := map[string]struct {
+		Cfg       PolicerConf
+		Assertion assert.ErrorAssertionFunc
+	}{
+		"empty defaults to drop": {
+			Assertion: assert.NoError,
+		},
+		"invalid action": {
+			Cfg:       PolicerConf{Action: "reject"},
+			Assertion: assert.Error,
+		},
+		"burst without rate": {
+			Cfg: PolicerConf{
+				Interfaces: map[common.IFIDType]RateLimit{1: {PktBurst: 10}},
+			},
+			Assertion: assert.Error,
+		},
+		"invalid default": {
+			Cfg:       PolicerConf{DefaultSourceIA: &RateLimit{ByteBurst: 10}},
+			Assertion: assert.Error,
+		},
+		"invalid traffic class": {
+			Cfg: PolicerConf{
+				TrafficClasses: map[TrafficClass]RateLimit{"icmp": {PPS: 1}},
+			},
+			Assertion: assert.Error,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test.Assertion(t, test.Cfg.Validate())
+		})
+	}
+}
@@ -20,4 +20,17 @@ const brSample = `
 # Action that should be taken when an error occurs during a context rollback.
 # (fatal | continue) (default fatal)
 rollback_fail_action = "fatal"
+
+# Directory that packet captures started through the /capture HTTP endpoint
+# are written to. Packet capture is disabled if empty. (default "")
+capture_dir = ""
+
+# Default duration an interface is drained for, if a drain request through the
+# /drain HTTP endpoint does not specify a window. (default 1m)
+drain_window = "1m"
+
+# Addresses of the local SCIONDs that are notified about the revocations of
+# draining interfaces. The revocations are issued by the control service, which
+# also sends them to the path service. (default [])
+drain_sciond_addresses = []
 `
@@ -0,0 +1,400 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package capture implements a packet capture tap for the border router
+// sockets. Captured packets are written to a ring of pcapng files. Each packet
+// is wrapped in a synthesized IP/UDP header built from the underlay addresses,
+// such that the captures can be decoded with the standard SCION dissector. The
+// rpkt debug ID is stored as packet comment, to allow correlating captures
+// with the router logs.
+//
+// The tap is disabled by default. It is started and stopped at runtime with
+// Start and Stop. When disabled, the overhead on the packet processing path is
+// a single atomic load.
+package capture
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+
+	"github.com/scionproto/scion/go/border/rctx"
+	"github.com/scionproto/scion/go/border/rpkt"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+const (
+	// DefaultMaxFileSize is the default maximum size of a single capture file.
+	DefaultMaxFileSize = 64 << 20
+	// DefaultMaxFiles is the default number of capture files that are kept.
+	DefaultMaxFiles = 4
+	// flushInterval is the maximum time buffered packets are held in memory.
+	flushInterval = time.Second
+)
+
+// prefixRe matches the allowed file name prefixes. It excludes path
+// separators, such that captures can not be written outside of the capture
+// directory.
+var prefixRe = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// Direction is the direction of a captured packet.
+type Direction string
+
+const (
+	DirIn  Direction = "in"
+	DirOut Direction = "out"
+)
+
+// Config is the configuration of a capture.
+type Config struct {
+	// Dir is the directory the capture files are written to.
+	Dir string
+	// Prefix is the file name prefix of the capture files.
+	Prefix string
+	// MaxFileSize is the size in bytes after which a new capture file is
+	// started.
+	MaxFileSize int64
+	// MaxFiles is the number of capture files that are kept. The oldest file
+	// is removed when a new file is started.
+	MaxFiles int
+	// Filter selects the captured packets.
+	Filter Filter
+}
+
+// InitDefaults sets the default values for unset fields.
+func (cfg *Config) InitDefaults() {
+	if cfg.Prefix == "" {
+		cfg.Prefix = "br"
+	}
+	if cfg.MaxFileSize == 0 {
+		cfg.MaxFileSize = DefaultMaxFileSize
+	}
+	if cfg.MaxFiles == 0 {
+		cfg.MaxFiles = DefaultMaxFiles
+	}
+}
+
+// Validate validates the configuration.
+func (cfg *Config) Validate() error {
+	if cfg.Dir == "" {
+		return serrors.New("capture directory must be set")
+	}
+	if !prefixRe.MatchString(cfg.Prefix) || strings.Contains(cfg.Prefix, "..") {
+		return serrors.New("invalid capture file prefix", "prefix", cfg.Prefix)
+	}
+	if cfg.MaxFileSize < 0 || cfg.MaxFiles < 0 {
+		return serrors.New("capture limits must not be negative",
+			"max_file_size", cfg.MaxFileSize, "max_files", cfg.MaxFiles)
+	}
+	return cfg.Filter.Validate()
+}
+
+// Status describes the state of the capture tap.
+type Status struct {
+	Active bool
+	Config *Config  `json:",omitempty"`
+	Files  []string `json:",omitempty"`
+	// Pkts is the number of captured packets.
+	Pkts uint64
+	// Errors is the number of packets that could not be written.
+	Errors uint64
+}
+
+// current is the active tap.
+var current atomic.Value
+
+// startMtx serializes Start and Stop.
+var startMtx sync.Mutex
+
+// Start starts a new capture, replacing the running capture, if any.
+func Start(cfg Config) error {
+	cfg.InitDefaults()
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	t, err := newTap(cfg)
+	if err != nil {
+		return err
+	}
+	startMtx.Lock()
+	defer startMtx.Unlock()
+	old := get()
+	current.Store(t)
+	log.Info("Packet capture started", "dir", cfg.Dir)
+	if old != nil {
+		return old.close()
+	}
+	return nil
+}
+
+// Stop stops the running capture, if any.
+func Stop() error {
+	startMtx.Lock()
+	defer startMtx.Unlock()
+	old := get()
+	if old == nil {
+		return nil
+	}
+	current.Store((*tap)(nil))
+	log.Info("Packet capture stopped", "pkts", atomic.LoadUint64(&old.pkts))
+	return old.close()
+}
+
+// GetStatus returns the status of the capture tap.
+func GetStatus() Status {
+	t := get()
+	if t == nil {
+		return Status{}
+	}
+	return t.status()
+}
+
+// Input captures a packet received on the given socket, if a capture is
+// running and the packet matches the filter. It must be called after the rpkt
+// debug ID has been assigned.
+func Input(s *rctx.Sock, rp *rpkt.RtrPkt) {
+	if t := get(); t != nil {
+		t.capture(s, DirIn, rp.Ingress.Src, rp.Ingress.Dst, rp.TimeIn, rp.Id, rp.Raw)
+	}
+}
+
+// Output captures a packet sent on the given socket, if a capture is running
+// and the packet matches the filter.
+func Output(s *rctx.Sock, erp *rpkt.EgressRtrPkt) {
+	if t := get(); t != nil {
+		dst := s.Conn.RemoteAddr()
+		if dst == nil {
+			dst = erp.Dst
+		}
+		t.capture(s, DirOut, s.Conn.LocalAddr(), dst, time.Now(), erp.Rp.Id, erp.Rp.Raw)
+	}
+}
+
+func get() *tap {
+	t, _ := current.Load().(*tap)
+	return t
+}
+
+type intfKey struct {
+	ifid common.IFIDType
+	dir  Direction
+}
+
+// tap writes matching packets to a ring of pcapng files.
+type tap struct {
+	// pkts and errors are accessed atomically, and must be 64-bit aligned.
+	pkts   uint64
+	errors uint64
+	cfg    Config
+
+	mtx       sync.Mutex
+	file      *os.File
+	writer    *ngWriter
+	written   int64
+	files     []string
+	fileIdx   int
+	intfs     map[intfKey]int
+	lastFlush time.Time
+	serBuf    gopacket.SerializeBuffer
+}
+
+func newTap(cfg Config) (*tap, error) {
+	if err := os.MkdirAll(cfg.Dir, 0755); err != nil {
+		return nil, serrors.WrapStr("unable to create capture directory", err, "dir", cfg.Dir)
+	}
+	t := &tap{cfg: cfg, serBuf: gopacket.NewSerializeBuffer()}
+	if err := t.rotate(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (t *tap) capture(s *rctx.Sock, dir Direction, src, dst *net.UDPAddr, ts time.Time,
+	id string, raw common.RawBytes) {
+
+	if !t.cfg.Filter.match(s.Ifid, dir, raw) {
+		return
+	}
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	if t.writer == nil {
+		return
+	}
+	if err := t.write(s.Ifid, dir, src, dst, ts, id, raw); err != nil {
+		atomic.AddUint64(&t.errors, 1)
+		log.Debug("Unable to capture packet", "err", err)
+		return
+	}
+	atomic.AddUint64(&t.pkts, 1)
+}
+
+func (t *tap) write(ifid common.IFIDType, dir Direction, src, dst *net.UDPAddr,
+	ts time.Time, id string, raw common.RawBytes) error {
+
+	if t.written >= t.cfg.MaxFileSize {
+		if err := t.rotate(); err != nil {
+			return err
+		}
+	}
+	key := intfKey{ifid: ifid, dir: dir}
+	idx, ok := t.intfs[key]
+	if !ok {
+		idx = len(t.intfs)
+		name := fmt.Sprintf("%s-%s", ifidName(ifid), dir)
+		if err := t.writer.addInterface(name); err != nil {
+			return err
+		}
+		t.intfs[key] = idx
+	}
+	data, err := t.frame(src, dst, raw)
+	if err != nil {
+		return err
+	}
+	comment := fmt.Sprintf("rpkt=%s ifid=%s dir=%s", id, ifidName(ifid), dir)
+	if err := t.writer.writePacket(idx, ts, data, comment); err != nil {
+		return err
+	}
+	t.written += int64(len(data))
+	if now := time.Now(); now.Sub(t.lastFlush) > flushInterval {
+		t.lastFlush = now
+		return t.writer.flush()
+	}
+	return nil
+}
+
+// frame wraps the raw SCION packet in an IP/UDP header built from the
+// underlay addresses.
+func (t *tap) frame(src, dst *net.UDPAddr, raw common.RawBytes) ([]byte, error) {
+	if src == nil || dst == nil {
+		return nil, serrors.New("missing underlay address", "src", src, "dst", dst)
+	}
+	udp := &layers.UDP{
+		SrcPort: layers.UDPPort(src.Port),
+		DstPort: layers.UDPPort(dst.Port),
+	}
+	var ip gopacket.SerializableLayer
+	if src.IP.To4() != nil && dst.IP.To4() != nil {
+		ip4 := &layers.IPv4{
+			Version:  4,
+			TTL:      64,
+			Protocol: layers.IPProtocolUDP,
+			SrcIP:    src.IP.To4(),
+			DstIP:    dst.IP.To4(),
+		}
+		udp.SetNetworkLayerForChecksum(ip4)
+		ip = ip4
+	} else {
+		ip6 := &layers.IPv6{
+			Version:    6,
+			HopLimit:   64,
+			NextHeader: layers.IPProtocolUDP,
+			SrcIP:      src.IP.To16(),
+			DstIP:      dst.IP.To16(),
+		}
+		udp.SetNetworkLayerForChecksum(ip6)
+		ip = ip6
+	}
+	opts := gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}
+	if err := gopacket.SerializeLayers(t.serBuf, opts, ip, udp,
+		gopacket.Payload(raw)); err != nil {
+		return nil, err
+	}
+	return t.serBuf.Bytes(), nil
+}
+
+// rotate closes the current capture file, if any, and starts a new one. If
+// the number of files exceeds the limit, the oldest file is removed.
+func (t *tap) rotate() error {
+	if err := t.closeFile(); err != nil {
+		log.Error("Unable to close capture file", "err", err)
+	}
+	name := filepath.Join(t.cfg.Dir, fmt.Sprintf("%s-%s-%d.pcapng", t.cfg.Prefix,
+		time.Now().UTC().Format("20060102T150405"), t.fileIdx))
+	if filepath.Dir(name) != filepath.Clean(t.cfg.Dir) {
+		return serrors.New("capture file outside of capture directory", "file", name,
+			"dir", t.cfg.Dir)
+	}
+	t.fileIdx++
+	f, err := os.Create(name)
+	if err != nil {
+		return serrors.WrapStr("unable to create capture file", err, "file", name)
+	}
+	w, err := newNgWriter(f)
+	if err != nil {
+		f.Close()
+		return serrors.WrapStr("unable to write capture file header", err, "file", name)
+	}
+	t.file = f
+	t.writer = w
+	t.written = 0
+	t.intfs = make(map[intfKey]int)
+	t.files = append(t.files, name)
+	for len(t.files) > t.cfg.MaxFiles {
+		if err := os.Remove(t.files[0]); err != nil {
+			log.Error("Unable to remove capture file", "file", t.files[0], "err", err)
+		}
+		t.files = t.files[1:]
+	}
+	return nil
+}
+
+func (t *tap) closeFile() error {
+	if t.file == nil {
+		return nil
+	}
+	f := t.file
+	flushErr := t.writer.flush()
+	t.file, t.writer = nil, nil
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return flushErr
+}
+
+func (t *tap) close() error {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	return t.closeFile()
+}
+
+func (t *tap) status() Status {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	cfg := t.cfg
+	return Status{
+		Active: true,
+		Config: &cfg,
+		Files:  append([]string(nil), t.files...),
+		Pkts:   atomic.LoadUint64(&t.pkts),
+		Errors: atomic.LoadUint64(&t.errors),
+	}
+}
+
+func ifidName(ifid common.IFIDType) string {
+	if ifid == 0 {
+		return "loc"
+	}
+	return ifid.String()
+}
@@ -0,0 +1,205 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package capture
+
+import (
+	"encoding/binary"
+	"io/ioutil"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/border/rctx"
+	"github.com/scionproto/scion/go/border/rpkt"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/scmp"
+	"github.com/scionproto/scion/go/lib/spkt"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestFilterMatch(t *testing.T) {
+	src := xtest.MustParseIA("1-ff00:0:110")
+	dst := xtest.MustParseIA("2-ff00:0:220")
+	udpPkt := rawPkt(src, dst, common.L4UDP)
+	scmpPkt := rawPkt(src, dst, common.L4SCMP)
+	tests := map[string]struct {
+		Filter   Filter
+		IfID     common.IFIDType
+		Dir      Direction
+		Raw      common.RawBytes
+		Expected bool
+	}{
+		"empty filter": {
+			Raw:      udpPkt,
+			Expected: true,
+		},
+		"ifid match": {
+			Filter:   Filter{IfIDs: []common.IFIDType{1, 2}},
+			IfID:     2,
+			Raw:      udpPkt,
+			Expected: true,
+		},
+		"ifid mismatch": {
+			Filter: Filter{IfIDs: []common.IFIDType{1, 2}},
+			IfID:   3,
+			Raw:    udpPkt,
+		},
+		"direction mismatch": {
+			Filter: Filter{Direction: DirOut},
+			Dir:    DirIn,
+			Raw:    udpPkt,
+		},
+		"ia wildcard match": {
+			Filter:   Filter{SrcIA: addr.IA{I: 1}, DstIA: dst},
+			Raw:      udpPkt,
+			Expected: true,
+		},
+		"ia mismatch": {
+			Filter: Filter{SrcIA: dst},
+			Raw:    udpPkt,
+		},
+		"scmp class match": {
+			Filter:   Filter{SCMPClasses: []scmp.Class{scmp.C_Path}},
+			Raw:      scmpPkt,
+			Expected: true,
+		},
+		"scmp filter on udp packet": {
+			Filter: Filter{SCMPClasses: []scmp.Class{scmp.C_Path}},
+			Raw:    udpPkt,
+		},
+		"truncated packet": {
+			Filter: Filter{SrcIA: src},
+			Raw:    udpPkt[:10],
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := test.Dir
+			if dir == "" {
+				dir = DirIn
+			}
+			assert.Equal(t, test.Expected, test.Filter.match(test.IfID, dir, test.Raw))
+		})
+	}
+}
+
+func TestCapture(t *testing.T) {
+	dir, cleanF := xtest.MustTempDir("", "capture")
+	defer cleanF()
+	require.NoError(t, Start(Config{Dir: dir, MaxFiles: 2, MaxFileSize: 1}))
+	defer Stop()
+
+	src := xtest.MustParseIA("1-ff00:0:110")
+	dst := xtest.MustParseIA("2-ff00:0:220")
+	sock := &rctx.Sock{Ifid: 1}
+	for i := 0; i < 3; i++ {
+		rp := rpkt.NewRtrPkt()
+		rp.Raw = rawPkt(src, dst, common.L4UDP)
+		rp.Id = "test"
+		rp.TimeIn = time.Now()
+		rp.Ingress.Src = &net.UDPAddr{IP: net.IP{192, 0, 2, 1}, Port: 50000}
+		rp.Ingress.Dst = &net.UDPAddr{IP: net.IP{192, 0, 2, 2}, Port: 50001}
+		Input(sock, rp)
+	}
+	status := GetStatus()
+	assert.True(t, status.Active)
+	assert.Equal(t, uint64(3), status.Pkts)
+	// Every packet exceeds the file size, so only the last two files are kept.
+	require.Len(t, status.Files, 2)
+	require.NoError(t, Stop())
+	assert.False(t, GetStatus().Active)
+
+	raw, err := ioutil.ReadFile(status.Files[1])
+	require.NoError(t, err)
+	blocks := readBlocks(t, raw)
+	require.Len(t, blocks, 3)
+	assert.Equal(t, blockTypeSectionHeader, blocks[0].Type)
+	assert.Equal(t, blockTypeInterface, blocks[1].Type)
+	assert.Equal(t, blockTypeEnhancedPacket, blocks[2].Type)
+
+	epb := blocks[2].Body
+	capLen := binary.LittleEndian.Uint32(epb[12:16])
+	data := epb[20 : 20+capLen]
+	pkt := gopacket.NewPacket(data, layers.LayerTypeIPv4, gopacket.Default)
+	udp, ok := pkt.Layer(layers.LayerTypeUDP).(*layers.UDP)
+	require.True(t, ok)
+	assert.Equal(t, layers.UDPPort(50001), udp.DstPort)
+	assert.Equal(t, []byte(rawPkt(src, dst, common.L4UDP)), udp.Payload)
+	// The first option is the comment containing the debug ID.
+	opts := epb[20+pad4(int(capLen)):]
+	assert.Equal(t, optionComment, binary.LittleEndian.Uint16(opts[0:2]))
+	commentLen := binary.LittleEndian.Uint16(opts[2:4])
+	assert.Equal(t, "rpkt=test ifid=1 dir=in", string(opts[4:4+commentLen]))
+}
+
+func TestStartInvalid(t *testing.T) {
+	assert.Error(t, Start(Config{}))
+	dir, cleanF := xtest.MustTempDir("", "capture")
+	defer cleanF()
+	assert.Error(t, Start(Config{Dir: dir, Filter: Filter{Direction: "sideways"}}))
+	for _, prefix := range []string{"../x", "a/b", "..", "br..x", `a\b`, "a b"} {
+		assert.Error(t, Start(Config{Dir: dir, Prefix: prefix}), prefix)
+	}
+	assert.False(t, GetStatus().Active)
+}
+
+type block struct {
+	Type uint32
+	Body []byte
+}
+
+// readBlocks splits a pcapng file into its blocks.
+func readBlocks(t *testing.T, raw []byte) []block {
+	var blocks []block
+	for len(raw) > 0 {
+		require.True(t, len(raw) >= blockHeaderAndTrailerSize)
+		l := int(binary.LittleEndian.Uint32(raw[4:8]))
+		require.True(t, len(raw) >= l)
+		require.Equal(t, uint32(l), binary.LittleEndian.Uint32(raw[l-4:l]))
+		blocks = append(blocks, block{
+			Type: binary.LittleEndian.Uint32(raw[0:4]),
+			Body: raw[8 : l-4],
+		})
+		raw = raw[l:]
+	}
+	return blocks
+}
+
+// rawPkt creates a minimal raw SCION packet with an empty path and 4 byte
+// long L4 header.
+func rawPkt(src, dst addr.IA, l4 common.L4ProtocolType) common.RawBytes {
+	hdrLen := spkt.CmnHdrLen + 2*addr.IABytes + 2*addr.HostLenIPv4
+	raw := make(common.RawBytes, hdrLen+4)
+	cmnHdr := spkt.CmnHdr{
+		DstType:  addr.HostTypeIPv4,
+		SrcType:  addr.HostTypeIPv4,
+		TotalLen: uint16(len(raw)),
+		HdrLen:   uint8(hdrLen / common.LineLen),
+		NextHdr:  l4,
+	}
+	cmnHdr.Write(raw)
+	dst.Write(raw[spkt.CmnHdrLen:])
+	src.Write(raw[spkt.CmnHdrLen+addr.IABytes:])
+	if l4 == common.L4SCMP {
+		binary.BigEndian.PutUint16(raw[hdrLen:], uint16(scmp.C_Path))
+	}
+	return raw
+}
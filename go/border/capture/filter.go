@@ -0,0 +1,128 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package capture
+
+import (
+	"encoding/binary"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/scmp"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/spkt"
+)
+
+// Filter selects the packets that are captured. Empty fields match all
+// packets.
+type Filter struct {
+	// IfIDs is the list of interfaces to capture on. The local interface has
+	// ID 0.
+	IfIDs []common.IFIDType `json:",omitempty"`
+	// Direction restricts the capture to one direction.
+	Direction Direction `json:",omitempty"`
+	// SrcIA matches the source ISD-AS. Wildcards are supported.
+	SrcIA addr.IA `json:",omitempty"`
+	// DstIA matches the destination ISD-AS. Wildcards are supported.
+	DstIA addr.IA `json:",omitempty"`
+	// SCMPClasses restricts the capture to SCMP packets of the given classes.
+	SCMPClasses []scmp.Class `json:",omitempty"`
+}
+
+// Validate validates the filter.
+func (f *Filter) Validate() error {
+	switch f.Direction {
+	case "", DirIn, DirOut:
+		return nil
+	default:
+		return serrors.New("invalid direction", "dir", f.Direction)
+	}
+}
+
+// match checks whether the packet matches the filter. The raw packet is only
+// inspected if the filter requires it. The packet is parsed independently of
+// rpkt, since packets on the output path can be shared between goroutines.
+func (f *Filter) match(ifid common.IFIDType, dir Direction, raw common.RawBytes) bool {
+	if f.Direction != "" && f.Direction != dir {
+		return false
+	}
+	if len(f.IfIDs) > 0 && !containsIfID(f.IfIDs, ifid) {
+		return false
+	}
+	if f.SrcIA.IsZero() && f.DstIA.IsZero() && len(f.SCMPClasses) == 0 {
+		return true
+	}
+	if len(raw) < spkt.CmnHdrLen+2*addr.IABytes {
+		return false
+	}
+	dstIA := addr.IAFromRaw(raw[spkt.CmnHdrLen:])
+	srcIA := addr.IAFromRaw(raw[spkt.CmnHdrLen+addr.IABytes:])
+	if !matchIA(f.DstIA, dstIA) || !matchIA(f.SrcIA, srcIA) {
+		return false
+	}
+	if len(f.SCMPClasses) == 0 {
+		return true
+	}
+	class, ok := scmpClass(raw)
+	if !ok {
+		return false
+	}
+	for _, c := range f.SCMPClasses {
+		if c == class {
+			return true
+		}
+	}
+	return false
+}
+
+// scmpClass returns the SCMP class of the packet, if it is an SCMP packet.
+func scmpClass(raw common.RawBytes) (scmp.Class, bool) {
+	cmnHdr, err := spkt.CmnHdrFromRaw(raw)
+	if err != nil {
+		return 0, false
+	}
+	nextHdr := cmnHdr.NextHdr
+	offset := cmnHdr.HdrLenBytes()
+	for nextHdr == common.HopByHopClass || nextHdr == common.End2EndClass {
+		if len(raw) < offset+common.LineLen {
+			return 0, false
+		}
+		hdrLen := int(raw[offset+1]) * common.LineLen
+		if hdrLen == 0 {
+			return 0, false
+		}
+		nextHdr = common.L4ProtocolType(raw[offset])
+		offset += hdrLen
+	}
+	if nextHdr != common.L4SCMP || len(raw) < offset+2 {
+		return 0, false
+	}
+	return scmp.Class(binary.BigEndian.Uint16(raw[offset:])), true
+}
+
+func matchIA(filter, ia addr.IA) bool {
+	if filter.I != 0 && filter.I != ia.I {
+		return false
+	}
+	return filter.A == 0 || filter.A == ia.A
+}
+
+func containsIfID(ifids []common.IFIDType, ifid common.IFIDType) bool {
+	for _, i := range ifids {
+		if i == ifid {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package capture
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestHandlerPrefixTraversal(t *testing.T) {
+	root, cleanF := xtest.MustTempDir("", "capture")
+	defer cleanF()
+	dir := filepath.Join(root, "captures")
+	h := NewHandler(dir)
+
+	req := httptest.NewRequest(http.MethodPost, "/capture",
+		strings.NewReader(`{"Prefix": "../../etc/x"}`))
+	rec := httptest.NewRecorder()
+	h(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.False(t, GetStatus().Active)
+	files, err := ioutil.ReadDir(root)
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
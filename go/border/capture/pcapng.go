@@ -0,0 +1,149 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package capture
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"time"
+)
+
+// pcapng block types and option codes, see
+// https://tools.ietf.org/html/draft-tuexen-opsawg-pcapng-02.
+const (
+	blockTypeSectionHeader    uint32 = 0x0A0D0D0A
+	blockTypeInterface        uint32 = 0x00000001
+	blockTypeEnhancedPacket   uint32 = 0x00000006
+	byteOrderMagic            uint32 = 0x1A2B3C4D
+	optionEndOfOpt            uint16 = 0
+	optionComment             uint16 = 1
+	optionIfName              uint16 = 2
+	optionIfTsResol           uint16 = 9
+	linkTypeRaw               uint16 = 101
+	snapLen                   uint32 = 0
+	tsResolNanoseconds        byte   = 9
+	blockHeaderAndTrailerSize        = 12
+)
+
+// ngWriter is a minimal pcapng writer. In contrast to the pcapgo writer, it
+// supports per-packet comments, which are used to record the rpkt debug ID.
+type ngWriter struct {
+	w   *bufio.Writer
+	buf []byte
+}
+
+type ngOption struct {
+	code  uint16
+	value []byte
+}
+
+func newNgWriter(w io.Writer) (*ngWriter, error) {
+	nw := &ngWriter{w: bufio.NewWriter(w)}
+	body := make([]byte, 16)
+	binary.LittleEndian.PutUint32(body[0:4], byteOrderMagic)
+	binary.LittleEndian.PutUint16(body[4:6], 1) // Major version
+	binary.LittleEndian.PutUint16(body[6:8], 0) // Minor version
+	// Section length is unspecified.
+	binary.LittleEndian.PutUint64(body[8:16], 0xFFFFFFFFFFFFFFFF)
+	if err := nw.writeBlock(blockTypeSectionHeader, body, nil, nil); err != nil {
+		return nil, err
+	}
+	return nw, nil
+}
+
+// addInterface writes an interface description block with the given name.
+// Interfaces are numbered in the order they are added, starting at 0.
+func (nw *ngWriter) addInterface(name string) error {
+	body := make([]byte, 8)
+	binary.LittleEndian.PutUint16(body[0:2], linkTypeRaw)
+	binary.LittleEndian.PutUint32(body[4:8], snapLen)
+	opts := []ngOption{
+		{code: optionIfName, value: []byte(name)},
+		{code: optionIfTsResol, value: []byte{tsResolNanoseconds}},
+	}
+	return nw.writeBlock(blockTypeInterface, body, nil, opts)
+}
+
+// writePacket writes an enhanced packet block for the interface with the
+// given index.
+func (nw *ngWriter) writePacket(intf int, ts time.Time, data []byte, comment string) error {
+	body := make([]byte, 20)
+	nanos := uint64(ts.UnixNano())
+	binary.LittleEndian.PutUint32(body[0:4], uint32(intf))
+	binary.LittleEndian.PutUint32(body[4:8], uint32(nanos>>32))
+	binary.LittleEndian.PutUint32(body[8:12], uint32(nanos))
+	binary.LittleEndian.PutUint32(body[12:16], uint32(len(data)))
+	binary.LittleEndian.PutUint32(body[16:20], uint32(len(data)))
+	var opts []ngOption
+	if comment != "" {
+		opts = append(opts, ngOption{code: optionComment, value: []byte(comment)})
+	}
+	return nw.writeBlock(blockTypeEnhancedPacket, body, data, opts)
+}
+
+func (nw *ngWriter) flush() error {
+	return nw.w.Flush()
+}
+
+// writeBlock writes a block consisting of the fixed body, the padded data and
+// the options.
+func (nw *ngWriter) writeBlock(blockType uint32, body, data []byte, opts []ngOption) error {
+	length := blockHeaderAndTrailerSize + len(body) + pad4(len(data))
+	if len(opts) > 0 {
+		for _, o := range opts {
+			length += 4 + pad4(len(o.value))
+		}
+		// End of options.
+		length += 4
+	}
+	nw.buf = nw.buf[:0]
+	nw.buf = appendUint32(nw.buf, blockType)
+	nw.buf = appendUint32(nw.buf, uint32(length))
+	nw.buf = append(nw.buf, body...)
+	nw.buf = appendPadded(nw.buf, data)
+	if len(opts) > 0 {
+		for _, o := range opts {
+			nw.buf = appendUint16(nw.buf, o.code)
+			nw.buf = appendUint16(nw.buf, uint16(len(o.value)))
+			nw.buf = appendPadded(nw.buf, o.value)
+		}
+		nw.buf = appendUint16(nw.buf, optionEndOfOpt)
+		nw.buf = appendUint16(nw.buf, 0)
+	}
+	nw.buf = appendUint32(nw.buf, uint32(length))
+	_, err := nw.w.Write(nw.buf)
+	return err
+}
+
+func pad4(l int) int {
+	return (l + 3) &^ 3
+}
+
+func appendPadded(b, data []byte) []byte {
+	b = append(b, data...)
+	for i := len(data); i < pad4(len(data)); i++ {
+		b = append(b, 0)
+	}
+	return b
+}
+
+func appendUint16(b []byte, v uint16) []byte {
+	return append(b, byte(v), byte(v>>8))
+}
+
+func appendUint32(b []byte, v uint32) []byte {
+	return append(b, byte(v), byte(v>>8), byte(v>>16), byte(v>>24))
+}
@@ -0,0 +1,131 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file contains the HTTP endpoint to drain interfaces.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/scionproto/scion/go/border/ifstate"
+	"github.com/scionproto/scion/go/border/rctx"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// DrainInfo describes a draining interface.
+type DrainInfo struct {
+	IfID     common.IFIDType
+	DrainEnd time.Time
+}
+
+// drainHandler drains interfaces. The interface is selected with the ifid
+// query parameter.
+//
+// GET lists the draining interfaces, POST starts draining the interface for
+// the duration in the optional window query parameter, and DELETE stops
+// draining the interface.
+func drainHandler(w http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		window := cfg.BR.DrainWindow.Duration
+		if raw := req.URL.Query().Get("window"); raw != "" {
+			var err error
+			if window, err = time.ParseDuration(raw); err != nil || window <= 0 {
+				http.Error(w, "invalid window: "+raw, http.StatusBadRequest)
+				return
+			}
+		}
+		if err := handleDrain(req, func(intf *intfParams) error {
+			return ifstate.StartDrain(intf.ifid, intf.ia, time.Now().Add(window))
+		}); err != nil {
+			drainError(w, err)
+			return
+		}
+	case http.MethodDelete:
+		if err := handleDrain(req, func(intf *intfParams) error {
+			ifstate.StopDrain(intf.ifid, intf.ia)
+			return nil
+		}); err != nil {
+			drainError(w, err)
+			return
+		}
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	bytes, err := json.MarshalIndent(drainInfos(), "", "    ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	fmt.Fprint(w, string(bytes)+"\n")
+}
+
+var errUnknownIntf = serrors.New("unknown interface")
+
+type intfParams struct {
+	ifid common.IFIDType
+	ia   addr.IA
+}
+
+// handleDrain parses the interface from the request and calls f with it.
+func handleDrain(req *http.Request, f func(intf *intfParams) error) error {
+	raw := req.URL.Query().Get("ifid")
+	ifid, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil {
+		return serrors.WrapStr("invalid ifid", err, "ifid", raw)
+	}
+	ctx := rctx.Get()
+	if ctx == nil {
+		return errUnknownIntf
+	}
+	intf, ok := ctx.Conf.BR.IFs[common.IFIDType(ifid)]
+	if !ok {
+		return serrors.WithCtx(errUnknownIntf, "ifid", ifid)
+	}
+	return f(&intfParams{ifid: common.IFIDType(ifid), ia: intf.IA})
+}
+
+func drainError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, errUnknownIntf):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, ifstate.ErrNotActive):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	}
+}
+
+func drainInfos() []DrainInfo {
+	infos := []DrainInfo{}
+	for _, state := range ifstate.LoadStates() {
+		if state.Draining {
+			infos = append(infos, DrainInfo{IfID: state.IfID, DrainEnd: state.DrainEnd})
+		}
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].IfID < infos[j].IfID })
+	return infos
+}
@@ -0,0 +1,78 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/border/ifstate"
+	"github.com/scionproto/scion/go/border/rctx"
+	"github.com/scionproto/scion/go/lib/common"
+)
+
+func TestDrainHandler(t *testing.T) {
+	_, ctx := setupTestRouter(t)
+	defer closeAllSocks(ctx)
+	rctx.Set(ctx)
+	defer rctx.Set(nil)
+	defer ifstate.DeleteState(11)
+	defer ifstate.DeleteState(12)
+
+	do := func(method, query string) (int, []DrainInfo) {
+		req := httptest.NewRequest(method, "/drain?"+query, nil)
+		w := httptest.NewRecorder()
+		drainHandler(w, req)
+		if w.Code != http.StatusOK {
+			return w.Code, nil
+		}
+		var infos []DrainInfo
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &infos))
+		return w.Code, infos
+	}
+
+	code, infos := do(http.MethodGet, "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Empty(t, infos)
+
+	start := time.Now()
+	code, infos = do(http.MethodPost, "ifid=11&window=10m")
+	assert.Equal(t, http.StatusOK, code)
+	require.Len(t, infos, 1)
+	assert.Equal(t, common.IFIDType(11), infos[0].IfID)
+	assert.True(t, infos[0].DrainEnd.After(start.Add(9*time.Minute)))
+	state, ok := ifstate.LoadState(11)
+	require.True(t, ok)
+	assert.True(t, state.Active)
+	assert.True(t, state.Draining)
+
+	code, _ = do(http.MethodPost, "ifid=99")
+	assert.Equal(t, http.StatusNotFound, code)
+	code, _ = do(http.MethodPost, "ifid=11&window=-1s")
+	assert.Equal(t, http.StatusBadRequest, code)
+	ifstate.UpdateIfNew(12, nil, ifstate.NewInfo(12, ctx.Conf.BR.IFs[12].IA, false, nil, nil))
+	code, _ = do(http.MethodPost, "ifid=12")
+	assert.Equal(t, http.StatusConflict, code)
+
+	code, infos = do(http.MethodDelete, "ifid=11")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Empty(t, infos)
+}
@@ -0,0 +1,145 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file handles draining of interfaces. Draining is a state on top of an
+// active interface: traffic is still forwarded, but the router has the control
+// service revoke the interface, such that end hosts migrate to other paths
+// before the interface is taken down.
+
+package ifstate
+
+import (
+	"sync/atomic"
+	"time"
+	"unsafe"
+
+	"github.com/scionproto/scion/go/border/internal/metrics"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// ErrNotActive indicates that an inactive interface can not be drained.
+var ErrNotActive = serrors.New("interface is not active")
+
+// StartDrain starts draining the interface until end. If the interface is
+// already draining, the drain window is updated. Interfaces without state are
+// considered active.
+func StartDrain(ifID common.IFIDType, ia addr.IA, end time.Time) error {
+	_, err := update(ifID, ia, func(old *Info) (*Info, error) {
+		if !old.Active {
+			return nil, serrors.WithCtx(ErrNotActive, "ifid", ifID)
+		}
+		info := drainInfo(old)
+		info.DrainEnd = end
+		return info, nil
+	})
+	if err != nil {
+		return err
+	}
+	setDrainingMetric(ifID, ia, true)
+	log.Info("IFState: intf draining", "ifid", ifID, "until", end)
+	return nil
+}
+
+// StopDrain stops draining the interface. The revocation issued during the
+// drain is removed, the interface is active afterwards. The returned bool
+// indicates whether the interface was draining.
+func StopDrain(ifID common.IFIDType, ia addr.IA) bool {
+	var wasDraining bool
+	update(ifID, ia, func(old *Info) (*Info, error) {
+		wasDraining = old.Draining
+		if !wasDraining {
+			return old, nil
+		}
+		return NewInfo(ifID, ia, true, nil, nil), nil
+	})
+	if wasDraining {
+		setDrainingMetric(ifID, ia, false)
+		log.Info("IFState: intf drain stopped", "ifid", ifID)
+	}
+	return wasDraining
+}
+
+// SetDrainRevocation sets the revocation issued for a draining interface.
+// The returned bool indicates whether the interface is still draining.
+func SetDrainRevocation(ifID common.IFIDType, ia addr.IA, srev *path_mgmt.SignedRevInfo,
+	rawSRev common.RawBytes) bool {
+
+	info, _ := update(ifID, ia, func(old *Info) (*Info, error) {
+		if !old.Draining {
+			return old, nil
+		}
+		info := drainInfo(old)
+		info.SRevInfo, info.RawSRev = srev, rawSRev
+		return info, nil
+	})
+	return info != nil && info.Draining
+}
+
+// drainInfo returns a copy of the draining state of old.
+func drainInfo(old *Info) *Info {
+	info := &Info{
+		IfID:     old.IfID,
+		Active:   true,
+		Draining: true,
+		DrainEnd: old.DrainEnd,
+	}
+	if old.Draining {
+		info.SRevInfo, info.RawSRev = old.SRevInfo, old.RawSRev
+	}
+	return info
+}
+
+// update atomically replaces the state info of the interface with the result
+// of f. If there is no state info, f is called with an active state. If f
+// returns an error, the state is not changed.
+func update(ifID common.IFIDType, ia addr.IA, f func(old *Info) (*Info, error)) (*Info, error) {
+	for {
+		s, ok := states.Load(ifID)
+		if !ok {
+			info, err := f(NewInfo(ifID, ia, true, nil, nil))
+			if err != nil {
+				return nil, err
+			}
+			s = &state{info: unsafe.Pointer(info)}
+			if _, loaded := states.LoadOrStore(ifID, s); loaded {
+				continue
+			}
+			return info, nil
+		}
+		old := atomic.LoadPointer(&s.info)
+		info, err := f((*Info)(old))
+		if err != nil {
+			return nil, err
+		}
+		if atomic.CompareAndSwapPointer(&s.info, old, unsafe.Pointer(info)) {
+			return info, nil
+		}
+	}
+}
+
+func setDrainingMetric(ifID common.IFIDType, ia addr.IA, draining bool) {
+	label := metrics.IntfLabels{
+		Intf:    metrics.IntfToLabel(ifID),
+		NeighIA: ia.String(),
+	}
+	var isDraining float64
+	if draining {
+		isDraining = 1
+	}
+	metrics.Control.IFDraining(label).Set(isDraining)
+}
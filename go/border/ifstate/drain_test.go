@@ -0,0 +1,69 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ifstate
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestDrain(t *testing.T) {
+	ia := xtest.MustParseIA("1-ff00:0:111")
+	const ifid common.IFIDType = 1
+	defer DeleteState(ifid)
+	end := time.Now().Add(time.Minute)
+
+	assert.False(t, SetDrainRevocation(ifid, ia, nil, nil), "not draining")
+	require.NoError(t, StartDrain(ifid, ia, end))
+	info, ok := LoadState(ifid)
+	require.True(t, ok)
+	assert.True(t, info.Active)
+	assert.True(t, info.Draining)
+	assert.Equal(t, end, info.DrainEnd)
+
+	srev := &path_mgmt.SignedRevInfo{}
+	assert.True(t, SetDrainRevocation(ifid, ia, srev, common.RawBytes{1}))
+	// Extending the drain window keeps the revocation.
+	require.NoError(t, StartDrain(ifid, ia, end.Add(time.Minute)))
+	info, _ = LoadState(ifid)
+	assert.Equal(t, end.Add(time.Minute), info.DrainEnd)
+	assert.Equal(t, srev, info.SRevInfo)
+
+	assert.True(t, StopDrain(ifid, ia))
+	info, _ = LoadState(ifid)
+	assert.True(t, info.Active)
+	assert.False(t, info.Draining)
+	assert.Nil(t, info.SRevInfo)
+	assert.False(t, StopDrain(ifid, ia))
+}
+
+func TestDrainInactive(t *testing.T) {
+	ia := xtest.MustParseIA("1-ff00:0:111")
+	const ifid common.IFIDType = 2
+	defer DeleteState(ifid)
+	UpdateIfNew(ifid, nil, NewInfo(ifid, ia, false, nil, nil))
+	err := StartDrain(ifid, ia, time.Now().Add(time.Minute))
+	assert.True(t, errors.Is(err, ErrNotActive))
+	info, _ := LoadState(ifid)
+	assert.False(t, info.Draining)
+}
@@ -25,6 +25,7 @@ package ifstate
 import (
 	"sync"
 	"sync/atomic"
+	"time"
 	"unsafe"
 
 	"github.com/scionproto/scion/go/border/internal/metrics"
@@ -51,6 +52,11 @@ func (s *ifStates) Load(key common.IFIDType) (*state, bool) {
 	return val.(*state), loaded
 }
 
+func (s *ifStates) LoadOrStore(key common.IFIDType, val *state) (*state, bool) {
+	actual, loaded := (*sync.Map)(s).LoadOrStore(key, val)
+	return actual.(*state), loaded
+}
+
 func (s *ifStates) Store(key common.IFIDType, val *state) {
 	(*sync.Map)(s).Store(key, val)
 }
@@ -64,8 +70,15 @@ type state struct {
 
 // Info stores state information, as well as the raw revocation info for a given interface.
 type Info struct {
-	IfID     common.IFIDType
-	Active   bool
+	IfID   common.IFIDType
+	Active bool
+	// Draining indicates that the interface is drained. A draining interface
+	// is active and keeps forwarding traffic, but the router proactively
+	// requests revocations for it from the control service until DrainEnd.
+	// SRevInfo and RawSRev hold the latest revocation issued for the drain.
+	Draining bool
+	// DrainEnd is the end of the drain window.
+	DrainEnd time.Time
 	SRevInfo *path_mgmt.SignedRevInfo
 	RawSRev  common.RawBytes
 }
@@ -123,6 +136,13 @@ func Process(ifStates *path_mgmt.IFStateInfos) {
 			continue
 		}
 		oldInfo := (*Info)(atomic.LoadPointer(&s.info))
+		if stateInfo.Active && oldInfo.Draining && time.Now().Before(oldInfo.DrainEnd) {
+			// The beacon service is not aware of the drain, keep draining
+			// until the window ends.
+			stateInfo = oldInfo
+		} else if oldInfo.Draining {
+			setDrainingMetric(ifid, intf.IA, false)
+		}
 		if stateInfo.Active {
 			if !oldInfo.Active {
 				log.Info("IFState: intf activated", "ifid", ifid)
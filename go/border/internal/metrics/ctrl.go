@@ -27,6 +27,10 @@ const (
 	Revocation  = "revocation"
 )
 
+// SCIOND is the SentRevInfoLabels.SVC value for revocations that are sent to
+// a local SCIOND.
+const SCIOND = "sciond"
+
 type ControlLabels struct {
 	// Result is the outcome of processing the packet.
 	Result string
@@ -65,6 +69,7 @@ type control struct {
 	receivedIFStateInfo *prometheus.CounterVec
 	sentIFStateReq      *prometheus.CounterVec
 	ifstate             *prometheus.GaugeVec
+	ifdraining          *prometheus.GaugeVec
 	ifstateTick         prometheus.Counter
 	readRevInfos        *prometheus.CounterVec
 	sentRevInfos        *prometheus.CounterVec
@@ -85,6 +90,8 @@ func newControl() control {
 			ControlLabels{}),
 		ifstate: prom.NewGaugeVecWithLabels(Namespace, sub,
 			"interface_active", "Interface is active.", IntfLabels{}),
+		ifdraining: prom.NewGaugeVecWithLabels(Namespace, sub,
+			"interface_draining", "Interface is draining.", IntfLabels{}),
 		ifstateTick: prom.NewCounter(Namespace, sub,
 			"ifstate_ticks_total", "Total number of IFState requests ticks."),
 		readRevInfos: prom.NewCounterVecWithLabels(Namespace, sub,
@@ -119,6 +126,11 @@ func (c *control) IFState(l IntfLabels) prometheus.Gauge {
 	return c.ifstate.WithLabelValues(l.Values()...)
 }
 
+// IFDraining returns the gauge for the given label set.
+func (c *control) IFDraining(l IntfLabels) prometheus.Gauge {
+	return c.ifdraining.WithLabelValues(l.Values()...)
+}
+
 // IFStateTick returns the counter for the given label set.
 func (c *control) IFStateTick() prometheus.Counter {
 	return c.ifstateTick
@@ -41,6 +41,10 @@ const (
 	ErrParsePayload = "err_parse_payload"
 	// ErrResolveSVC is an error resolving a SVC address.
 	ErrResolveSVC = "err_resolve_svc"
+	// ErrStage is an error in a user-registered processing stage.
+	ErrStage = "err_stage"
+	// DropStage is a packet dropped by a user-registered processing stage.
+	DropStage = "drop_stage"
 )
 
 // Metrics initialization.
@@ -49,6 +53,7 @@ var (
 	Output  = newOutput()
 	Process = newProcess()
 	Control = newControl()
+	Policer = newPolicer()
 )
 
 type IntfLabels struct {
@@ -0,0 +1,74 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/scionproto/scion/go/lib/prom"
+)
+
+// Policer limit values.
+const (
+	LimitInterface    = "interface"
+	LimitSourceIA     = "source_ia"
+	LimitTrafficClass = "traffic_class"
+)
+
+type PolicerLabels struct {
+	// IntfIn is the input SCION interface.
+	IntfIn string
+	// Limit is the kind of limit that was exceeded.
+	Limit string
+	// Action is the action taken for the packet.
+	Action string
+}
+
+// Labels returns the list of labels.
+func (l PolicerLabels) Labels() []string {
+	return []string{"intf_in", "limit", "action"}
+}
+
+// Values returns the label values in the order defined by Labels.
+func (l PolicerLabels) Values() []string {
+	return []string{l.IntfIn, l.Limit, l.Action}
+}
+
+type policer struct {
+	pkts  *prometheus.CounterVec
+	bytes *prometheus.CounterVec
+}
+
+func newPolicer() policer {
+	sub := "policer"
+	return policer{
+		pkts: prom.NewCounterVecWithLabels(Namespace, sub,
+			"exceeded_pkts_total", "Total number of packets exceeding a rate limit.",
+			PolicerLabels{}),
+		bytes: prom.NewCounterVecWithLabels(Namespace, sub,
+			"exceeded_bytes_total", "Total number of bytes exceeding a rate limit.",
+			PolicerLabels{}),
+	}
+}
+
+// Pkts returns the counter for the given label set.
+func (p *policer) Pkts(l PolicerLabels) prometheus.Counter {
+	return p.pkts.WithLabelValues(l.Values()...)
+}
+
+// Bytes returns the counter for the given label set.
+func (p *policer) Bytes(l PolicerLabels) prometheus.Counter {
+	return p.bytes.WithLabelValues(l.Values()...)
+}
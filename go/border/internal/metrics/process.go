@@ -44,9 +44,31 @@ func (l ProcessLabels) Values() []string {
 	return []string{l.Result, l.IntfIn, l.IntfOut}
 }
 
+// StageLabels are the labels for packets handled by user-registered
+// processing stages.
+type StageLabels struct {
+	// Stage is the name of the stage that produced the verdict.
+	Stage string
+	// Verdict is the verdict of the stage.
+	Verdict string
+	// IntfIn is the input SCION interface.
+	IntfIn string
+}
+
+// Labels returns the list of labels.
+func (l StageLabels) Labels() []string {
+	return []string{"stage", "verdict", "intf_in"}
+}
+
+// Values returns the label values in the order defined by Labels.
+func (l StageLabels) Values() []string {
+	return []string{l.Stage, l.Verdict, l.IntfIn}
+}
+
 type process struct {
 	pkts     *prometheus.CounterVec
 	duration *prometheus.CounterVec
+	stages   *prometheus.CounterVec
 }
 
 func newProcess() process {
@@ -56,6 +78,9 @@ func newProcess() process {
 			"pkts_total", "Total number of processed packets.", ProcessLabels{}),
 		duration: prom.NewCounterVecWithLabels(Namespace, sub,
 			"duration_seconds_total", "Total packet processing duration.", IntfLabels{}),
+		stages: prom.NewCounterVecWithLabels(Namespace, sub,
+			"stage_pkts_total", "Total number of packets handled by processing stages.",
+			StageLabels{}),
 	}
 }
 
@@ -68,3 +93,8 @@ func (p *process) Pkts(l ProcessLabels) prometheus.Counter {
 func (p *process) Duration(l IntfLabels) prometheus.Counter {
 	return p.duration.WithLabelValues(l.Values()...)
 }
+
+// Stages returns the counter for the given label set.
+func (p *process) Stages(l StageLabels) prometheus.Counter {
+	return p.stages.WithLabelValues(l.Values()...)
+}
@@ -0,0 +1,203 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file contains the introspection handler, which dumps the current router
+// context and the interface state table as JSON.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/scionproto/scion/go/border/ifstate"
+	"github.com/scionproto/scion/go/border/rctx"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ringbuf"
+)
+
+// RouterInfo is a snapshot of what the router believes about its own state.
+type RouterInfo struct {
+	// ID is the SCION element ID of the router.
+	ID string
+	// IA is the local ISD-AS.
+	IA addr.IA
+	// InternalAddr is the local data-plane address.
+	InternalAddr string
+	// FreePkts is the fill level of the free packet ring-buffer.
+	FreePkts RingInfo
+	// LocSockIn is the Sock receiving packets from the local AS.
+	LocSockIn *rctx.SockInfo
+	// LocSockOut is the Sock sending packets to the local AS.
+	LocSockOut *rctx.SockInfo
+	// Interfaces contains the information for every interface known to the
+	// router, either through the topology or through the interface state table.
+	Interfaces []*IntfInfo
+}
+
+// RingInfo describes the fill level of a ring-buffer.
+type RingInfo struct {
+	Len int
+	Cap int
+}
+
+// IntfInfo is a snapshot of the router state for a single interface.
+type IntfInfo struct {
+	IfID common.IFIDType
+	// InTopo indicates whether the interface is part of the loaded topology.
+	InTopo     bool
+	NeighIA    addr.IA         `json:",omitempty"`
+	RemoteIFID common.IFIDType `json:",omitempty"`
+	LinkType   string          `json:",omitempty"`
+	Local      string          `json:",omitempty"`
+	Remote     string          `json:",omitempty"`
+	MTU        int             `json:",omitempty"`
+	SockIn     *rctx.SockInfo  `json:",omitempty"`
+	SockOut    *rctx.SockInfo  `json:",omitempty"`
+	// State is the interface state as received from the control service. It
+	// is nil if no state has been received for the interface yet.
+	State *IntfStateInfo
+}
+
+// IntfStateInfo is the JSON representation of an ifstate.Info entry.
+type IntfStateInfo struct {
+	Active bool
+	// Draining indicates that the interface is drained until DrainEnd.
+	Draining bool
+	DrainEnd *time.Time `json:",omitempty"`
+	// Revocation is set if the interface state carries a revocation.
+	Revocation *RevInfo `json:",omitempty"`
+}
+
+// RevInfo is the JSON representation of a revocation.
+type RevInfo struct {
+	IA         addr.IA
+	IfID       common.IFIDType
+	LinkType   string
+	Timestamp  time.Time
+	Expiration time.Time
+	Expired    bool
+	// Error is set if the revocation could not be parsed.
+	Error string `json:",omitempty"`
+}
+
+// Introspect returns a snapshot of the current router context and interface
+// state table.
+func (r *Router) Introspect() *RouterInfo {
+	info := &RouterInfo{ID: r.Id}
+	if r.freePkts != nil {
+		info.FreePkts = ringInfo(r.freePkts)
+	}
+	intfs := make(map[common.IFIDType]*IntfInfo)
+	if ctx := rctx.Get(); ctx != nil {
+		info.IA = ctx.Conf.IA
+		info.InternalAddr = udpAddrString(ctx.Conf.BR.InternalAddr)
+		if ctx.LocSockIn != nil {
+			info.LocSockIn = ctx.LocSockIn.Info()
+		}
+		if ctx.LocSockOut != nil {
+			info.LocSockOut = ctx.LocSockOut.Info()
+		}
+		for ifid, intf := range ctx.Conf.BR.IFs {
+			i := &IntfInfo{
+				IfID:       ifid,
+				InTopo:     true,
+				NeighIA:    intf.IA,
+				RemoteIFID: intf.RemoteIFID,
+				LinkType:   intf.LinkType.String(),
+				Local:      udpAddrString(intf.Local),
+				Remote:     udpAddrString(intf.Remote),
+				MTU:        intf.MTU,
+			}
+			if s, ok := ctx.ExtSockIn[ifid]; ok {
+				i.SockIn = s.Info()
+			}
+			if s, ok := ctx.ExtSockOut[ifid]; ok {
+				i.SockOut = s.Info()
+			}
+			intfs[ifid] = i
+		}
+	}
+	now := time.Now()
+	for _, state := range ifstate.LoadStates() {
+		i, ok := intfs[state.IfID]
+		if !ok {
+			i = &IntfInfo{IfID: state.IfID}
+			intfs[state.IfID] = i
+		}
+		i.State = intfStateInfo(state, now)
+	}
+	for _, i := range intfs {
+		info.Interfaces = append(info.Interfaces, i)
+	}
+	sort.Slice(info.Interfaces, func(a, b int) bool {
+		return info.Interfaces[a].IfID < info.Interfaces[b].IfID
+	})
+	return info
+}
+
+// introspectHandler serves the router introspection information as JSON.
+func introspectHandler(w http.ResponseWriter, req *http.Request) {
+	if r == nil {
+		http.Error(w, "router not initialized", http.StatusServiceUnavailable)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	bytes, err := json.MarshalIndent(r.Introspect(), "", "    ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	fmt.Fprint(w, string(bytes)+"\n")
+}
+
+func intfStateInfo(state *ifstate.Info, now time.Time) *IntfStateInfo {
+	info := &IntfStateInfo{Active: state.Active, Draining: state.Draining}
+	if state.Draining {
+		end := state.DrainEnd
+		info.DrainEnd = &end
+	}
+	if state.SRevInfo == nil {
+		return info
+	}
+	info.Revocation = &RevInfo{}
+	revInfo, err := state.SRevInfo.RevInfo()
+	if err != nil {
+		info.Revocation.Error = err.Error()
+		return info
+	}
+	info.Revocation.IA = revInfo.IA()
+	info.Revocation.IfID = revInfo.IfID
+	info.Revocation.LinkType = revInfo.LinkType.String()
+	info.Revocation.Timestamp = revInfo.Timestamp()
+	info.Revocation.Expiration = revInfo.Expiration()
+	info.Revocation.Expired = !now.Before(revInfo.Expiration())
+	return info
+}
+
+func ringInfo(ring *ringbuf.Ring) RingInfo {
+	return RingInfo{Len: ring.Len(), Cap: ring.Cap()}
+}
+
+func udpAddrString(a *net.UDPAddr) string {
+	if a == nil {
+		return ""
+	}
+	return a.String()
+}
@@ -0,0 +1,66 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/border/ifstate"
+	"github.com/scionproto/scion/go/border/rctx"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestIntrospect(t *testing.T) {
+	r, ctx := setupTestRouter(t)
+	defer closeAllSocks(ctx)
+	rctx.Set(ctx)
+	defer rctx.Set(nil)
+	// Interface 12 is in the topology, interface 99 is only known from the
+	// interface state table.
+	ifstate.UpdateIfNew(12, nil, ifstate.NewInfo(12, xtest.MustParseIA("1-ff00:0:112"),
+		false, nil, nil))
+	ifstate.UpdateIfNew(99, nil, ifstate.NewInfo(99, xtest.MustParseIA("1-ff00:0:199"),
+		true, nil, nil))
+	defer ifstate.DeleteState(12)
+	defer ifstate.DeleteState(99)
+
+	info := r.Introspect()
+	assert.Equal(t, ctx.Conf.IA, info.IA)
+	assert.Equal(t, r.freePkts.Cap(), info.FreePkts.Cap)
+	require.NotNil(t, info.LocSockIn)
+	assert.True(t, info.LocSockIn.Running)
+
+	intfs := make(map[common.IFIDType]*IntfInfo)
+	for _, i := range info.Interfaces {
+		intfs[i.IfID] = i
+	}
+	for ifid := range ctx.Conf.BR.IFs {
+		require.Contains(t, intfs, ifid)
+		assert.True(t, intfs[ifid].InTopo)
+		require.NotNil(t, intfs[ifid].SockIn)
+		assert.Equal(t, ifid, intfs[ifid].SockIn.Ifid)
+		assert.True(t, intfs[ifid].SockOut.Running)
+	}
+	require.NotNil(t, intfs[12].State)
+	assert.False(t, intfs[12].State.Active)
+	require.Contains(t, intfs, common.IFIDType(99))
+	assert.False(t, intfs[99].InTopo)
+	assert.True(t, intfs[99].State.Active)
+	assert.Nil(t, intfs[99].SockIn)
+}
@@ -25,6 +25,7 @@ import (
 
 	"golang.org/x/net/ipv4"
 
+	"github.com/scionproto/scion/go/border/capture"
 	"github.com/scionproto/scion/go/border/internal/metrics"
 	"github.com/scionproto/scion/go/border/rctx"
 	"github.com/scionproto/scion/go/border/rpkt"
@@ -262,6 +263,7 @@ func (r *Router) posixOutput(s *rctx.Sock, _, stopped chan struct{}) {
 			}
 			bytes += msg.N
 			outputPktSize.Observe(float64(msg.N))
+			capture.Output(s, epkts[i].(*rpkt.EgressRtrPkt))
 			rp.Release()   // Release inner RtrPkt entry
 			epkts[i] = nil // Clear EgressRtrPkt reference
 		}
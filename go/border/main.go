@@ -31,6 +31,7 @@ import (
 	"github.com/BurntSushi/toml"
 
 	"github.com/scionproto/scion/go/border/brconf"
+	"github.com/scionproto/scion/go/border/capture"
 	"github.com/scionproto/scion/go/border/ifstate"
 	"github.com/scionproto/scion/go/lib/assert"
 	"github.com/scionproto/scion/go/lib/common"
@@ -70,13 +71,16 @@ func realMain() int {
 	defer env.LogAppStopped(common.BR, cfg.General.ID)
 	defer log.HandlePanic()
 	http.HandleFunc("/config", configHandler)
+	http.HandleFunc("/drain", drainHandler)
 	http.HandleFunc("/info", env.InfoHandler)
+	http.HandleFunc("/introspect", introspectHandler)
 	http.HandleFunc("/status", statusHandler)
 	http.HandleFunc("/topology", itopo.TopologyHandler)
 	if err := setup(); err != nil {
 		log.Crit("Setup failed", "err", err)
 		return 1
 	}
+	http.HandleFunc("/capture", capture.NewHandler(cfg.BR.CaptureDir))
 	if err := checkPerms(); err != nil {
 		log.Crit("Permissions checks failed", "err", err)
 		return 1
@@ -159,8 +163,11 @@ func statusHandler(w http.ResponseWriter, r *http.Request) {
 	out := "Interfaces:\n"
 	for _, state := range states {
 		status := "active"
-		if !state.Active {
+		switch {
+		case !state.Active:
 			status = "disabled"
+		case state.Draining:
+			status = "draining"
 		}
 		out += fmt.Sprintf("  %-5v %s\n", state.IfID, status)
 	}
@@ -0,0 +1,357 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package policer implements token-bucket based ingress admission control for
+// the border router. Packets are policed per ingress interface, per source
+// ISD-AS and per traffic class, as configured in brconf.PolicerConf.
+//
+// The policer is run as an rpkt processing stage. The stage is registered once
+// on startup, and the active policer is swapped atomically whenever the
+// configuration is reloaded.
+package policer
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/scionproto/scion/go/border/brconf"
+	"github.com/scionproto/scion/go/border/internal/metrics"
+	"github.com/scionproto/scion/go/border/rpkt"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/scmp"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+const (
+	// StageName is the name of the policer processing stage.
+	StageName = "policer"
+	// maxSrcIABuckets is the maximum number of buckets that are created for
+	// source ISD-ASes without an explicit limit. Once reached, packets from
+	// further source ISD-ASes share a single overflow bucket.
+	maxSrcIABuckets = 4096
+	// sweepInterval is the minimum interval between two sweeps for idle
+	// source ISD-AS buckets.
+	sweepInterval = time.Second
+)
+
+// ErrRateExceeded indicates that a packet exceeded a rate limit.
+var ErrRateExceeded = serrors.New("rate limit exceeded")
+
+// current is the active policer.
+var current atomic.Value
+
+// Set sets the active policer. A nil policer disables policing.
+func Set(p *Policer) {
+	current.Store(p)
+}
+
+// Get returns the active policer, or nil if policing is disabled.
+func Get() *Policer {
+	p, _ := current.Load().(*Policer)
+	return p
+}
+
+// Process is the rpkt.StageFunc that polices the packet with the active
+// policer.
+func Process(rp *rpkt.RtrPkt) (rpkt.Verdict, error) {
+	p := Get()
+	if p == nil {
+		return rpkt.VerdictContinue, nil
+	}
+	return p.Police(rp)
+}
+
+// Policer polices packets according to a brconf.PolicerConf. It is safe for
+// concurrent use. Each bucket has its own lock, such that packets that are
+// policed by different buckets do not contend.
+type Policer struct {
+	cfg *brconf.PolicerConf
+	// now returns the current time. It can be overwritten in tests.
+	now func() time.Time
+
+	// intfs, srcIAs and classes contain the buckets of the explicitly
+	// configured limits. They are not modified after creation, and are read
+	// without locking.
+	intfs   map[common.IFIDType]*bucket
+	srcIAs  map[addr.IA]*bucket
+	classes map[brconf.TrafficClass]*bucket
+	// srcIAOverflow is shared by the source ISD-ASes that do not get their
+	// own bucket, because defaultSrcIAs is full.
+	srcIAOverflow *bucket
+	actionLbl     string
+
+	// mtx protects the buckets that are created on demand for the default
+	// limits.
+	mtx sync.RWMutex
+	// defaultIntfs contains the buckets of the interfaces that are limited
+	// by the default limit.
+	defaultIntfs map[common.IFIDType]*bucket
+	// defaultSrcIAs contains the buckets of the source ISD-ASes that are
+	// limited by the default limit. It holds at most maxDefaultSrcIAs
+	// buckets.
+	defaultSrcIAs    map[addr.IA]*bucket
+	maxDefaultSrcIAs int
+	lastSweep        time.Time
+}
+
+// New creates a new policer for the given configuration.
+func New(cfg *brconf.PolicerConf) *Policer {
+	return newPolicer(cfg, time.Now)
+}
+
+func newPolicer(cfg *brconf.PolicerConf, now func() time.Time) *Policer {
+	p := &Policer{
+		cfg:              cfg,
+		now:              now,
+		intfs:            make(map[common.IFIDType]*bucket),
+		srcIAs:           make(map[addr.IA]*bucket),
+		classes:          make(map[brconf.TrafficClass]*bucket),
+		defaultIntfs:     make(map[common.IFIDType]*bucket),
+		defaultSrcIAs:    make(map[addr.IA]*bucket),
+		maxDefaultSrcIAs: maxSrcIABuckets,
+		actionLbl:        string(cfg.Action),
+	}
+	t := now()
+	if cfg.DefaultSourceIA != nil {
+		p.srcIAOverflow = newBucket(*cfg.DefaultSourceIA, t)
+	}
+	for ifid, l := range cfg.Interfaces {
+		p.intfs[ifid] = newBucket(l, t)
+	}
+	for ia, l := range cfg.SourceIAs {
+		p.srcIAs[ia] = newBucket(l, t)
+	}
+	for tc, l := range cfg.TrafficClasses {
+		p.classes[tc] = newBucket(l, t)
+	}
+	return p
+}
+
+// Config returns the configuration of the policer.
+func (p *Policer) Config() *brconf.PolicerConf {
+	return p.cfg
+}
+
+// Police checks the packet against all applicable limits. The packet is only
+// admitted if it conforms to all of them. Tokens of the limits that were
+// checked before a non-conforming limit was found are not refunded.
+func (p *Policer) Police(rp *rpkt.RtrPkt) (rpkt.Verdict, error) {
+	size := uint64(len(rp.Raw))
+	now := p.now()
+	if b := p.intfBucket(rp.Ingress.IfID, now); b != nil && !b.take(size, now) {
+		return p.exceeded(rp, metrics.LimitInterface, size)
+	}
+	if p.hasSrcIALimits() {
+		srcIA, err := rp.SrcIA()
+		if err != nil {
+			return rpkt.VerdictContinue, err
+		}
+		if b := p.srcIABucket(srcIA, now); b != nil && !b.take(size, now) {
+			return p.exceeded(rp, metrics.LimitSourceIA, size)
+		}
+	}
+	if len(p.classes) > 0 {
+		l4, err := rp.L4Proto()
+		if err != nil {
+			return rpkt.VerdictContinue, err
+		}
+		b := p.classes[brconf.TrafficClassFromL4(l4)]
+		if b != nil && !b.take(size, now) {
+			return p.exceeded(rp, metrics.LimitTrafficClass, size)
+		}
+	}
+	return rpkt.VerdictContinue, nil
+}
+
+func (p *Policer) intfBucket(ifid common.IFIDType, now time.Time) *bucket {
+	if b, ok := p.intfs[ifid]; ok {
+		return b
+	}
+	if p.cfg.DefaultInterface == nil {
+		return nil
+	}
+	p.mtx.RLock()
+	b, ok := p.defaultIntfs[ifid]
+	p.mtx.RUnlock()
+	if ok {
+		return b
+	}
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	// The bucket might have been created concurrently.
+	if b, ok := p.defaultIntfs[ifid]; ok {
+		return b
+	}
+	b = newBucket(*p.cfg.DefaultInterface, now)
+	p.defaultIntfs[ifid] = b
+	return b
+}
+
+func (p *Policer) hasSrcIALimits() bool {
+	return len(p.srcIAs) > 0 || p.cfg.DefaultSourceIA != nil
+}
+
+// srcIABucket returns the bucket of the source ISD-AS. Source ISD-ASes without
+// an explicit limit get their own bucket with the default limit, as long as
+// fewer than maxDefaultSrcIAs such buckets exist. Otherwise, idle buckets are
+// evicted, at most once per sweepInterval. If none can be evicted, the shared
+// overflow bucket is returned.
+func (p *Policer) srcIABucket(ia addr.IA, now time.Time) *bucket {
+	if b, ok := p.srcIAs[ia]; ok {
+		return b
+	}
+	if p.cfg.DefaultSourceIA == nil {
+		return nil
+	}
+	p.mtx.RLock()
+	b, ok := p.defaultSrcIAs[ia]
+	p.mtx.RUnlock()
+	if ok {
+		return b
+	}
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	// The bucket might have been created concurrently.
+	if b, ok := p.defaultSrcIAs[ia]; ok {
+		return b
+	}
+	if len(p.defaultSrcIAs) >= p.maxDefaultSrcIAs {
+		p.sweep(now)
+	}
+	if len(p.defaultSrcIAs) >= p.maxDefaultSrcIAs {
+		return p.srcIAOverflow
+	}
+	b = newBucket(*p.cfg.DefaultSourceIA, now)
+	p.defaultSrcIAs[ia] = b
+	return b
+}
+
+// sweep removes the idle buckets from defaultSrcIAs. Idle buckets are full,
+// i.e., they are equivalent to a new bucket, so no state is lost. The caller
+// must hold the write lock.
+func (p *Policer) sweep(now time.Time) {
+	if now.Sub(p.lastSweep) < sweepInterval {
+		return
+	}
+	p.lastSweep = now
+	for ia, b := range p.defaultSrcIAs {
+		if b.idle(now) {
+			delete(p.defaultSrcIAs, ia)
+		}
+	}
+}
+
+func (p *Policer) exceeded(rp *rpkt.RtrPkt, limit string,
+	size uint64) (rpkt.Verdict, error) {
+
+	l := metrics.PolicerLabels{
+		IntfIn: metrics.IntfToLabel(rp.Ingress.IfID),
+		Limit:  limit,
+		Action: p.actionLbl,
+	}
+	metrics.Policer.Pkts(l).Inc()
+	metrics.Policer.Bytes(l).Add(float64(size))
+	if p.cfg.Action == brconf.PolicerActionSCMP {
+		return rpkt.VerdictDrop, scmp.NewError(scmp.C_Routing, scmp.T_R_AdminDenied, nil,
+			serrors.WithCtx(ErrRateExceeded, "limit", limit))
+	}
+	return rpkt.VerdictDrop, nil
+}
+
+// bucket combines a packet and a byte token bucket.
+type bucket struct {
+	mtx   sync.Mutex
+	pkts  tokenBucket
+	bytes tokenBucket
+}
+
+func newBucket(l brconf.RateLimit, now time.Time) *bucket {
+	return &bucket{
+		pkts:  newTokenBucket(l.PPS, l.PktBurst, now),
+		bytes: newTokenBucket(l.BPS, l.ByteBurst, now),
+	}
+}
+
+// take consumes one packet and size bytes worth of tokens, if both buckets
+// have enough tokens. Otherwise, no tokens are consumed and false is
+// returned.
+func (b *bucket) take(size uint64, now time.Time) bool {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.pkts.refill(now)
+	b.bytes.refill(now)
+	if !b.pkts.has(1) || !b.bytes.has(float64(size)) {
+		return false
+	}
+	b.pkts.consume(1)
+	b.bytes.consume(float64(size))
+	return true
+}
+
+// idle returns whether both buckets are full.
+func (b *bucket) idle(now time.Time) bool {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.pkts.refill(now)
+	b.bytes.refill(now)
+	return b.pkts.full() && b.bytes.full()
+}
+
+// tokenBucket is a classic token bucket. A zero rate disables the bucket,
+// i.e., it always has enough tokens.
+type tokenBucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate, burst uint64, now time.Time) tokenBucket {
+	if burst == 0 {
+		burst = rate
+	}
+	return tokenBucket{
+		rate:   float64(rate),
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   now,
+	}
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	if b.rate == 0 || !now.After(b.last) {
+		return
+	}
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+}
+
+func (b *tokenBucket) has(n float64) bool {
+	return b.rate == 0 || b.tokens >= n
+}
+
+func (b *tokenBucket) full() bool {
+	return b.rate == 0 || b.tokens >= b.burst
+}
+
+func (b *tokenBucket) consume(n float64) {
+	if b.rate != 0 {
+		b.tokens -= n
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policer
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/go/border/brconf"
+	"github.com/scionproto/scion/go/border/rpkt"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/scmp"
+)
+
+func TestPoliceInterface(t *testing.T) {
+	cfg := &brconf.PolicerConf{
+		Action: brconf.PolicerActionDrop,
+		Interfaces: map[common.IFIDType]brconf.RateLimit{
+			1: {PPS: 2},
+			2: {BPS: 100},
+		},
+	}
+	now := time.Unix(1000, 0)
+	p := newPolicer(cfg, func() time.Time { return now })
+
+	pkt := func(ifid common.IFIDType, size int) *rpkt.RtrPkt {
+		rp := rpkt.NewRtrPkt()
+		rp.Raw = rp.Raw[:size]
+		rp.Ingress.IfID = ifid
+		return rp
+	}
+	police := func(rp *rpkt.RtrPkt) rpkt.Verdict {
+		v, err := p.Police(rp)
+		assert.NoError(t, err)
+		return v
+	}
+
+	// Interface 1 admits a burst of 2 packets.
+	assert.Equal(t, rpkt.VerdictContinue, police(pkt(1, 50)))
+	assert.Equal(t, rpkt.VerdictContinue, police(pkt(1, 50)))
+	assert.Equal(t, rpkt.VerdictDrop, police(pkt(1, 50)))
+	// Interface 2 admits 100 bytes.
+	assert.Equal(t, rpkt.VerdictContinue, police(pkt(2, 60)))
+	assert.Equal(t, rpkt.VerdictDrop, police(pkt(2, 60)))
+	assert.Equal(t, rpkt.VerdictContinue, police(pkt(2, 40)))
+	// Interface 3 is not limited.
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, rpkt.VerdictContinue, police(pkt(3, 1000)))
+	}
+	// After half a second, one packet is admitted again on interface 1.
+	now = now.Add(500 * time.Millisecond)
+	assert.Equal(t, rpkt.VerdictContinue, police(pkt(1, 50)))
+	assert.Equal(t, rpkt.VerdictDrop, police(pkt(1, 50)))
+	// Tokens do not accumulate beyond the burst.
+	now = now.Add(time.Hour)
+	assert.Equal(t, rpkt.VerdictContinue, police(pkt(1, 50)))
+	assert.Equal(t, rpkt.VerdictContinue, police(pkt(1, 50)))
+	assert.Equal(t, rpkt.VerdictDrop, police(pkt(1, 50)))
+}
+
+func TestPoliceDefaultInterface(t *testing.T) {
+	cfg := &brconf.PolicerConf{
+		Action:           brconf.PolicerActionSCMP,
+		DefaultInterface: &brconf.RateLimit{PPS: 1},
+	}
+	p := newPolicer(cfg, func() time.Time { return time.Unix(1000, 0) })
+	for _, ifid := range []common.IFIDType{1, 2} {
+		rp := rpkt.NewRtrPkt()
+		rp.Ingress.IfID = ifid
+		v, err := p.Police(rp)
+		assert.NoError(t, err)
+		assert.Equal(t, rpkt.VerdictContinue, v)
+		// Each interface has its own bucket.
+		v, err = p.Police(rp)
+		assert.Equal(t, rpkt.VerdictDrop, v)
+		var scmpErr *scmp.Error
+		assert.True(t, errors.As(err, &scmpErr))
+		assert.True(t, errors.Is(err, ErrRateExceeded))
+	}
+}
+
+func TestPoliceConcurrent(t *testing.T) {
+	cfg := &brconf.PolicerConf{
+		Action:           brconf.PolicerActionDrop,
+		DefaultInterface: &brconf.RateLimit{PPS: 10},
+		DefaultSourceIA:  &brconf.RateLimit{PPS: 10},
+	}
+	p := newPolicer(cfg, func() time.Time { return time.Unix(1000, 0) })
+	var passed int64
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				rp := rpkt.NewRtrPkt()
+				rp.Ingress.IfID = 1
+				if v, _ := p.Police(rp); v == rpkt.VerdictContinue {
+					atomic.AddInt64(&passed, 1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	// All packets share the same buckets, so exactly the burst is admitted.
+	assert.Equal(t, int64(10), passed)
+}
+
+func TestProcess(t *testing.T) {
+	defer Set(nil)
+	rp := rpkt.NewRtrPkt()
+	rp.Ingress.IfID = 1
+	v, err := Process(rp)
+	assert.NoError(t, err)
+	assert.Equal(t, rpkt.VerdictContinue, v)
+
+	Set(New(&brconf.PolicerConf{
+		Action:     brconf.PolicerActionDrop,
+		Interfaces: map[common.IFIDType]brconf.RateLimit{1: {BPS: 1}},
+	}))
+	v, err = Process(rp)
+	assert.NoError(t, err)
+	assert.Equal(t, rpkt.VerdictDrop, v)
+}
+
+func TestSrcIABucketCap(t *testing.T) {
+	cfg := &brconf.PolicerConf{
+		Action:          brconf.PolicerActionDrop,
+		SourceIAs:       map[addr.IA]brconf.RateLimit{{I: 1, A: 1}: {PPS: 1}},
+		DefaultSourceIA: &brconf.RateLimit{PPS: 1},
+	}
+	now := time.Unix(1000, 0)
+	p := newPolicer(cfg, func() time.Time { return now })
+	p.maxDefaultSrcIAs = 2
+	ia := func(as addr.AS) addr.IA { return addr.IA{I: 1, A: as} }
+
+	b2 := p.srcIABucket(ia(2), now)
+	b3 := p.srcIABucket(ia(3), now)
+	assert.True(t, b2 != b3)
+	assert.Same(t, b2, p.srcIABucket(ia(2), now))
+	// Once the cap is reached, further source ISD-ASes share the overflow
+	// bucket.
+	assert.True(t, b2.take(1, now))
+	assert.True(t, b3.take(1, now))
+	b4 := p.srcIABucket(ia(4), now)
+	assert.Same(t, p.srcIAOverflow, b4)
+	assert.Same(t, b4, p.srcIABucket(ia(5), now))
+	assert.Len(t, p.defaultSrcIAs, 2)
+	// Explicitly configured source ISD-ASes do not count towards the cap.
+	assert.Same(t, p.srcIAs[ia(1)], p.srcIABucket(ia(1), now))
+	// Idle buckets are evicted.
+	now = now.Add(time.Second)
+	b6 := p.srcIABucket(ia(6), now)
+	assert.True(t, p.srcIAOverflow != b6)
+	assert.Len(t, p.defaultSrcIAs, 1)
+	assert.Contains(t, p.defaultSrcIAs, ia(6))
+}
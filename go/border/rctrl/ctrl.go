@@ -44,8 +44,11 @@ var (
 	logger   log.Logger
 )
 
-func Control(sRevInfoQ chan rpkt.RawSRevCallbackArgs, dispatcherReconnect bool) {
+func Control(sRevInfoQ chan rpkt.RawSRevCallbackArgs, dispatcherReconnect bool,
+	sciondAddrs []string) {
+
 	var err error
+	drainSCIONDs = sciondAddrs
 	logger = log.New("Part", "Control")
 	ctx := rctx.Get()
 	ia = ctx.Conf.IA
@@ -72,6 +75,10 @@ func Control(sRevInfoQ chan rpkt.RawSRevCallbackArgs, dispatcherReconnect bool)
 		defer log.HandlePanic()
 		revInfoFwd(sRevInfoQ)
 	}()
+	go func() {
+		defer log.HandlePanic()
+		drainRevoke()
+	}()
 	processCtrl()
 }
 
@@ -132,7 +139,7 @@ func processPathMgmtSelf(p *path_mgmt.Pld) error {
 	}
 	switch pld := u.(type) {
 	case *path_mgmt.IFStateInfos:
-		ifstate.Process(pld)
+		processIFStateInfos(pld)
 	default:
 		cl.Result = metrics.ErrInvalidReq
 		metrics.Control.ProcessErrors(cl).Inc()
@@ -140,3 +147,20 @@ func processPathMgmtSelf(p *path_mgmt.Pld) error {
 	}
 	return err
 }
+
+// processIFStateInfos processes the Interface State updates. Infos of active
+// interfaces that carry a revocation are replies to drain requests, they are
+// handled separately.
+func processIFStateInfos(infos *path_mgmt.IFStateInfos) {
+	states := &path_mgmt.IFStateInfos{}
+	for _, info := range infos.Infos {
+		if info.Active && info.SRevInfo != nil {
+			processDrainRev(info)
+			continue
+		}
+		states.Infos = append(states.Infos, info)
+	}
+	if len(states.Infos) > 0 {
+		ifstate.Process(states)
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rctrl
+
+import (
+	"context"
+	"time"
+
+	"github.com/scionproto/scion/go/border/ifstate"
+	"github.com/scionproto/scion/go/border/internal/metrics"
+	"github.com/scionproto/scion/go/border/rctx"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/sciond"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/proto"
+)
+
+const (
+	// drainFreq is how often the state of draining interfaces is checked.
+	drainFreq = time.Second
+	// sciondTimeout is the timeout for notifying a SCIOND about a revocation.
+	sciondTimeout = 2 * time.Second
+)
+
+// drainSCIONDs are the addresses of the SCIONDs that are notified about the
+// revocations of draining interfaces.
+var drainSCIONDs []string
+
+// drainRevoke periodically requests revocations for draining interfaces from
+// the control service, until their drain window ends. The control service
+// signs the revocations with the AS key, stores them and sends them to the
+// path service. The router forwards them to the configured SCIONDs, such that
+// end hosts migrate to other paths while traffic is still forwarded on the
+// interface.
+func drainRevoke() {
+	ticker := time.NewTicker(drainFreq)
+	defer ticker.Stop()
+	for now := range ticker.C {
+		revokeDraining(now)
+	}
+}
+
+func revokeDraining(now time.Time) {
+	topo := rctx.Get().Conf.Topo
+	for _, info := range ifstate.LoadStates() {
+		if !info.Draining {
+			continue
+		}
+		intf, ok := topo.IFInfoMap()[info.IfID]
+		if !ok {
+			ifstate.StopDrain(info.IfID, addr.IA{})
+			continue
+		}
+		if !now.Before(info.DrainEnd) {
+			logger.Info("Drain window elapsed", "ifid", info.IfID)
+			ifstate.StopDrain(info.IfID, intf.IA)
+			continue
+		}
+		if hasValidDrainRev(info, now) {
+			continue
+		}
+		// Until the control service replies, the request is repeated on
+		// every check.
+		if err := genDrainReq(info.IfID); err != nil {
+			logger.Error("Unable to request revocation for draining interface",
+				"ifid", info.IfID, "err", err)
+		}
+	}
+}
+
+// hasValidDrainRev returns whether the revocation of the draining interface
+// is valid for at least half of its TTL.
+func hasValidDrainRev(info *ifstate.Info, now time.Time) bool {
+	if info.SRevInfo == nil {
+		return false
+	}
+	revInfo, err := info.SRevInfo.RevInfo()
+	return err == nil && revInfo.RelativeTTL(now) >= revInfo.TTL()/2
+}
+
+// genDrainReq requests a revocation for the draining interface from the
+// control service.
+func genDrainReq(ifid common.IFIDType) error {
+	cl := metrics.ControlLabels{
+		Result: metrics.ErrProcess,
+	}
+	pld, err := packIFStateReq(&path_mgmt.IFStateReq{IfID: ifid, Drain: true})
+	if err != nil {
+		metrics.Control.SentIFStateReq(cl).Inc()
+		return err
+	}
+	dst := &snet.SVCAddr{IA: ia, SVC: addr.SvcBS}
+	if dst.NextHop, err = rctx.Get().ResolveSVCAny(addr.SvcBS); err != nil {
+		cl.Result = metrics.ErrResolveSVC
+		metrics.Control.SentIFStateReq(cl).Inc()
+		return serrors.WrapStr("resolving SVC BS anycast", err)
+	}
+	if _, err := snetConn.WriteTo(pld, dst); err != nil {
+		cl.Result = metrics.ErrWrite
+		metrics.Control.SentIFStateReq(cl).Inc()
+		return serrors.WrapStr("writing drain request", err, "dst", dst)
+	}
+	logger.Debug("Requested revocation for draining interface", "ifid", ifid, "dst", dst)
+	cl.Result = metrics.Success
+	metrics.Control.SentIFStateReq(cl).Inc()
+	return nil
+}
+
+// processDrainRev handles the revocation issued by the control service for a
+// draining interface. It is stored in the interface state and forwarded to
+// the configured SCIONDs.
+func processDrainRev(info *path_mgmt.IFStateInfo) {
+	ifid := common.IFIDType(info.IfID)
+	intf, ok := rctx.Get().Conf.Topo.IFInfoMap()[ifid]
+	if !ok {
+		logger.Warn("Revocation for unknown draining interface", "ifid", ifid)
+		return
+	}
+	rawSRev, err := proto.PackRoot(info.SRevInfo)
+	if err != nil {
+		logger.Error("Unable to pack revocation for draining interface",
+			"ifid", ifid, "err", err)
+		return
+	}
+	if !ifstate.SetDrainRevocation(ifid, intf.IA, info.SRevInfo, rawSRev) {
+		// The drain was stopped in the meantime.
+		return
+	}
+	logger.Debug("Revoked draining interface", "ifid", ifid)
+	for _, a := range drainSCIONDs {
+		go func(a string) {
+			defer log.HandlePanic()
+			notifySCIOND(info.SRevInfo, a)
+		}(a)
+	}
+}
+
+// notifySCIOND sends the revocation to the SCIOND at the given address.
+func notifySCIOND(srev *path_mgmt.SignedRevInfo, address string) {
+	cl := metrics.SentRevInfoLabels{
+		Result: metrics.ErrWrite,
+		SVC:    metrics.SCIOND,
+	}
+	ctx, cancelF := context.WithTimeout(context.Background(), sciondTimeout)
+	defer cancelF()
+	conn, err := sciond.NewService(address).Connect(ctx)
+	if err != nil {
+		metrics.Control.SentRevInfos(cl).Inc()
+		logger.Error("Connecting to SCIOND", "addr", address, "err", err)
+		return
+	}
+	defer conn.Close(ctx)
+	reply, err := conn.RevNotification(ctx, srev)
+	if err != nil {
+		metrics.Control.SentRevInfos(cl).Inc()
+		logger.Error("Sending RevInfo to SCIOND", "addr", address, "err", err)
+		return
+	}
+	cl.Result = metrics.Success
+	metrics.Control.SentRevInfos(cl).Inc()
+	logger.Debug("Sent RevInfo to SCIOND", "addr", address, "result", reply.Result)
+}
@@ -57,20 +57,10 @@ func genIFStateReq() error {
 	cl := metrics.ControlLabels{
 		Result: metrics.ErrProcess,
 	}
-	cpld, err := ctrl.NewPathMgmtPld(&path_mgmt.IFStateReq{}, nil, nil)
+	pld, err := packIFStateReq(&path_mgmt.IFStateReq{})
 	if err != nil {
 		metrics.Control.SentIFStateReq(cl).Inc()
-		return common.NewBasicError("Generating IFStateReq Ctrl payload", err)
-	}
-	scpld, err := cpld.SignedPld(infra.NullSigner)
-	if err != nil {
-		metrics.Control.SentIFStateReq(cl).Inc()
-		return common.NewBasicError("Generating IFStateReq signed Ctrl payload", err)
-	}
-	pld, err := scpld.PackPld()
-	if err != nil {
-		metrics.Control.SentIFStateReq(cl).Inc()
-		return common.NewBasicError("Writing IFStateReq signed Ctrl payload", err)
+		return err
 	}
 	bsAddrs, err := rctx.Get().ResolveSVCMulti(addr.SvcBS)
 	if err != nil {
@@ -94,3 +84,20 @@ func genIFStateReq() error {
 	}
 	return errors.ToError()
 }
+
+// packIFStateReq packs the Interface State request as signed control payload.
+func packIFStateReq(req *path_mgmt.IFStateReq) (common.RawBytes, error) {
+	cpld, err := ctrl.NewPathMgmtPld(req, nil, nil)
+	if err != nil {
+		return nil, common.NewBasicError("Generating IFStateReq Ctrl payload", err)
+	}
+	scpld, err := cpld.SignedPld(infra.NullSigner)
+	if err != nil {
+		return nil, common.NewBasicError("Generating IFStateReq signed Ctrl payload", err)
+	}
+	pld, err := scpld.PackPld()
+	if err != nil {
+		return nil, common.NewBasicError("Writing IFStateReq signed Ctrl payload", err)
+	}
+	return pld, nil
+}
@@ -15,6 +15,7 @@
 package rctx
 
 import (
+	"sync"
 	"time"
 
 	"github.com/scionproto/scion/go/border/brconf"
@@ -58,8 +59,14 @@ type Sock struct {
 	stop          chan struct{}
 	readerStopped chan struct{}
 	writerStopped chan struct{}
-	running       bool
-	started       bool
+	// mtx serializes Start and Stop.
+	mtx sync.Mutex
+	// stateMtx protects running and started. They are only modified while
+	// holding both mtx and stateMtx, such that they can be read without
+	// waiting for Stop to finish.
+	stateMtx sync.RWMutex
+	running  bool
+	started  bool
 }
 
 func NewSock(ring *ringbuf.Ring, conn conn.Conn, dir rcmn.Dir, ifid common.IFIDType,
@@ -91,6 +98,8 @@ func NewSock(ring *ringbuf.Ring, conn conn.Conn, dir rcmn.Dir, ifid common.IFIDT
 // Start starts the reader/writer goroutines (if any). Does nothing if they
 // have been started already.
 func (s *Sock) Start() {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
 	if !s.running {
 		// Restarting is not permitted because the stop/stopped channels are closed.
 		if assert.On {
@@ -108,8 +117,10 @@ func (s *Sock) Start() {
 				s.Writer(s, s.stop, s.writerStopped)
 			}()
 		}
+		s.stateMtx.Lock()
 		s.running = true
 		s.started = true
+		s.stateMtx.Unlock()
 		log.Info("Sock routines started", "addr", s.Conn.LocalAddr(), "dir", s.Dir,
 			"ifid", s.Ifid, "type", s.Type)
 	}
@@ -118,6 +129,8 @@ func (s *Sock) Start() {
 // Stop stops the running reader/writer goroutines (if any) and waits until the
 // routines are stopped before returning to the caller.
 func (s *Sock) Stop() {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
 	if s.running {
 		log.Debug("Sock routines stopping", "addr", s.Conn.LocalAddr(), "dir", s.Dir,
 			"ifid", s.Ifid, "type", s.Type)
@@ -141,7 +154,9 @@ func (s *Sock) Stop() {
 		if err := s.Conn.Close(); err != nil {
 			log.Error("Error stopping socket", "addr", s.Conn.LocalAddr(), "err", err)
 		}
+		s.stateMtx.Lock()
 		s.running = false
+		s.stateMtx.Unlock()
 		log.Info("Sock routines stopped", "addr", s.Conn.LocalAddr())
 	} else if !s.started {
 		s.Ring.Close()
@@ -153,9 +168,62 @@ func (s *Sock) Stop() {
 }
 
 func (s *Sock) Running() bool {
+	s.stateMtx.RLock()
+	defer s.stateMtx.RUnlock()
 	return s.running
 }
 
 func (s *Sock) Started() bool {
+	s.stateMtx.RLock()
+	defer s.stateMtx.RUnlock()
 	return s.started
 }
+
+// SockInfo is a point-in-time snapshot of a Sock, used for introspection.
+type SockInfo struct {
+	// Ifid is the interface ID associated with the Sock.
+	Ifid common.IFIDType
+	// Dir is the direction of the Sock.
+	Dir string
+	// Type is the type of the socket.
+	Type brconf.SockType
+	// NeighIA is the interface remote IA.
+	NeighIA string `json:",omitempty"`
+	// LocalAddr is the local address of the underlying connection.
+	LocalAddr string
+	// RemoteAddr is the remote address of the underlying connection, if any.
+	RemoteAddr string `json:",omitempty"`
+	// Running indicates whether the reader/writer goroutines are running.
+	Running bool
+	// Started indicates whether the reader/writer goroutines have been started.
+	Started bool
+	// RingLen is the number of entries currently queued in the ring-buffer.
+	RingLen int
+	// RingCap is the capacity of the ring-buffer.
+	RingCap int
+}
+
+// Info returns a snapshot of the Sock state. It does not block while the Sock
+// is being stopped.
+func (s *Sock) Info() *SockInfo {
+	s.stateMtx.RLock()
+	running, started := s.running, s.started
+	s.stateMtx.RUnlock()
+	info := &SockInfo{
+		Ifid:    s.Ifid,
+		Dir:     s.Dir.String(),
+		Type:    s.Type,
+		NeighIA: s.NeighIA,
+		Running: running,
+		Started: started,
+		RingLen: s.Ring.Len(),
+		RingCap: s.Ring.Cap(),
+	}
+	if a := s.Conn.LocalAddr(); a != nil {
+		info.LocalAddr = a.String()
+	}
+	if a := s.Conn.RemoteAddr(); a != nil {
+		info.RemoteAddr = a.String()
+	}
+	return info
+}
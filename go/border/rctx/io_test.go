@@ -0,0 +1,78 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rctx_test
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/border/rcmn"
+	"github.com/scionproto/scion/go/border/rctx"
+	"github.com/scionproto/scion/go/lib/ringbuf"
+	"github.com/scionproto/scion/go/lib/underlay/conn/mock_conn"
+)
+
+func TestSockInfoDuringStop(t *testing.T) {
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+	c := mock_conn.NewMockConn(mctrl)
+	c.EXPECT().LocalAddr().Return(&net.UDPAddr{IP: net.IP{127, 0, 0, 1}, Port: 30041}).
+		AnyTimes()
+	c.EXPECT().RemoteAddr().Return(nil).AnyTimes()
+	c.EXPECT().SetReadDeadline(gomock.Any()).AnyTimes()
+	c.EXPECT().Close()
+
+	// The reader blocks on stopping until it is released, which keeps Stop
+	// from returning.
+	stopping, release := make(chan struct{}), make(chan struct{})
+	reader := func(_ *rctx.Sock, stop, stopped chan struct{}) {
+		<-stop
+		close(stopping)
+		<-release
+		stopped <- struct{}{}
+	}
+	s := rctx.NewSock(ringbuf.New(8, nil, "test"), c, rcmn.DirLocal, 0, "", reader, nil,
+		"test")
+	s.Start()
+
+	stopDone := make(chan struct{})
+	go func() {
+		defer close(stopDone)
+		s.Stop()
+	}()
+	<-stopping
+
+	infoDone := make(chan *rctx.SockInfo)
+	go func() { infoDone <- s.Info() }()
+	select {
+	case info := <-infoDone:
+		assert.True(t, info.Running)
+		assert.True(t, info.Started)
+		assert.Equal(t, "127.0.0.1:30041", info.LocalAddr)
+	case <-time.After(time.Second):
+		t.Fatal("Info blocked while stopping")
+	}
+
+	close(release)
+	<-stopDone
+	info := s.Info()
+	assert.False(t, info.Running)
+	require.True(t, info.Started)
+}
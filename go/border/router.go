@@ -21,6 +21,7 @@ import (
 	"sync"
 
 	"github.com/scionproto/scion/go/border/brconf"
+	"github.com/scionproto/scion/go/border/capture"
 	"github.com/scionproto/scion/go/border/internal/metrics"
 	"github.com/scionproto/scion/go/border/rcmn"
 	"github.com/scionproto/scion/go/border/rctrl"
@@ -68,7 +69,7 @@ func (r *Router) Start() {
 	}()
 	go func() {
 		defer log.HandlePanic()
-		rctrl.Control(r.sRevInfoQ, cfg.General.ReconnectToDispatcher)
+		rctrl.Control(r.sRevInfoQ, cfg.General.ReconnectToDispatcher, cfg.BR.DrainSCIONDs)
 	}()
 }
 
@@ -99,7 +100,7 @@ func (r *Router) handleSock(s *rctx.Sock, stop, stopped chan struct{}) {
 		}
 		for i := 0; i < n; i++ {
 			rp := pkts[i].(*rpkt.RtrPkt)
-			r.processPacket(rp)
+			r.processPacket(s, rp)
 			rp.Release()
 			pkts[i] = nil
 		}
@@ -108,7 +109,8 @@ func (r *Router) handleSock(s *rctx.Sock, stop, stopped chan struct{}) {
 
 // processPacket is the heart of the router's packet handling. It delegates
 // everything from parsing the incoming packet, to routing the outgoing packet.
-func (r *Router) processPacket(rp *rpkt.RtrPkt) {
+// The packet was received on socket s.
+func (r *Router) processPacket(s *rctx.Sock, rp *rpkt.RtrPkt) {
 	if assert.On {
 		assert.Must(rp.DirFrom != rcmn.DirUnset, "DirFrom must be set")
 		assert.Must(rp.Ingress.Dst != nil, "Ingress.Dst must be set")
@@ -127,6 +129,7 @@ func (r *Router) processPacket(rp *rpkt.RtrPkt) {
 	// Assign a pseudorandom ID to the packet, for correlating log entries.
 	rp.Id = log.NewDebugID().String()
 	rp.Logger = log.New("rpkt", rp.Id)
+	capture.Input(s, rp)
 	// XXX(kormat): uncomment for debugging:
 	//rp.Debug("processPacket", "raw", rp.Raw)
 	if err := rp.Parse(); err != nil {
@@ -150,6 +153,10 @@ func (r *Router) processPacket(rp *rpkt.RtrPkt) {
 		metrics.Process.Pkts(l).Inc()
 		return
 	}
+	// Run the user-registered processing stages, if any.
+	if !r.runStages(rp, &l) {
+		return
+	}
 	// Check if the packet needs to be processed locally, and if so register hooks for doing so.
 	rp.NeedsLocalProcessing()
 	// Parse the packet payload, if a previous step has registered a relevant hook for doing so.
@@ -175,3 +182,30 @@ func (r *Router) processPacket(rp *rpkt.RtrPkt) {
 		metrics.Process.Pkts(l).Inc()
 	}
 }
+
+// runStages runs the user-registered processing stages on the packet. It
+// returns false if the packet must not be processed further.
+func (r *Router) runStages(rp *rpkt.RtrPkt, l *metrics.ProcessLabels) bool {
+	verdict, stage, err := rp.RunStages()
+	if stage == "" {
+		return true
+	}
+	sl := metrics.StageLabels{Stage: stage, Verdict: verdict.String(), IntfIn: l.IntfIn}
+	if err != nil {
+		sl.Verdict = metrics.ErrStage
+	}
+	metrics.Process.Stages(sl).Inc()
+	if err != nil {
+		r.handlePktError(rp, err, "Error in processing stage")
+		l.Result = metrics.ErrStage
+		metrics.Process.Pkts(*l).Inc()
+		return false
+	}
+	if verdict == rpkt.VerdictDrop {
+		rp.Debug("Packet dropped by processing stage", "stage", stage)
+		l.Result = metrics.DropStage
+		metrics.Process.Pkts(*l).Inc()
+		return false
+	}
+	return true
+}
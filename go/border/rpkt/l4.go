@@ -68,6 +68,17 @@ func (rp *RtrPkt) L4Hdr(verify bool) (l4.L4Header, error) {
 	return rp.l4, nil
 }
 
+// L4Proto returns the L4 protocol type of the packet, without parsing the L4
+// header itself.
+func (rp *RtrPkt) L4Proto() (common.L4ProtocolType, error) {
+	if rp.idxs.l4 == 0 {
+		if _, err := rp.findL4(); err != nil {
+			return common.L4None, err
+		}
+	}
+	return rp.L4Type, nil
+}
+
 // findL4 tries to find the layer 4 header, if any.
 func (rp *RtrPkt) findL4() (bool, error) {
 	// Start from the next unparsed header, if any.
@@ -102,7 +102,8 @@ func (rp *RtrPkt) validateLocalIF(ifid *common.IFIDType) error {
 	}
 	state, ok := ifstate.LoadState(*ifid)
 	if !ok || state.Active {
-		// Interface is not revoked
+		// Interface is not revoked. Draining interfaces are active, and
+		// still forward traffic.
 		return nil
 	}
 	// Interface is revoked.
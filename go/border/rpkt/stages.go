@@ -0,0 +1,155 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file contains the registry of user-defined processing stages. Stages
+// are run by the router after a packet has been validated, and before it is
+// processed and routed. They allow site-specific policy (e.g. ACLs, policers,
+// sampling) to be compiled into the router without modifying the core
+// forwarding logic.
+
+package rpkt
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// Verdict is the outcome of running a processing stage on a packet.
+type Verdict int
+
+const (
+	// VerdictContinue means the packet is handed to the next stage.
+	VerdictContinue Verdict = iota
+	// VerdictDrop means the packet is silently dropped.
+	VerdictDrop
+	// VerdictForward means that no further stages are run, and the packet
+	// continues with the regular processing and routing.
+	VerdictForward
+)
+
+func (v Verdict) String() string {
+	switch v {
+	case VerdictContinue:
+		return "continue"
+	case VerdictDrop:
+		return "drop"
+	case VerdictForward:
+		return "forward"
+	default:
+		return "unknown"
+	}
+}
+
+// StageFunc is a user-defined processing stage. It must not retain a
+// reference to the packet after returning. If a non-nil error is returned,
+// the packet is handled like any other processing error, i.e., an SCMP error
+// is sent back if the error carries SCMP information.
+type StageFunc func(rp *RtrPkt) (Verdict, error)
+
+// Stage is a registered processing stage.
+type Stage struct {
+	// Name identifies the stage in logs and metrics.
+	Name string
+	// Priority defines the execution order. Stages with lower priority run
+	// first, stages with equal priority run in registration order.
+	Priority int
+	// F is the function run for each packet.
+	F StageFunc
+}
+
+var (
+	ErrStageNameEmpty = serrors.New("stage name must not be empty")
+	ErrStageNilFunc   = serrors.New("stage function must not be nil")
+	ErrStageExists    = serrors.New("stage already registered")
+)
+
+var stages struct {
+	// mtx serializes registrations.
+	mtx sync.Mutex
+	// list holds the sorted []Stage. It is replaced on every registration, so
+	// that the packet processing path can read it without locking.
+	list atomic.Value
+}
+
+// RegisterStage registers a processing stage. Stages should be registered
+// before the router starts processing packets, e.g., in an init function.
+func RegisterStage(s Stage) error {
+	if s.Name == "" {
+		return ErrStageNameEmpty
+	}
+	if s.F == nil {
+		return serrors.WithCtx(ErrStageNilFunc, "name", s.Name)
+	}
+	stages.mtx.Lock()
+	defer stages.mtx.Unlock()
+	old := Stages()
+	for _, o := range old {
+		if o.Name == s.Name {
+			return serrors.WithCtx(ErrStageExists, "name", s.Name)
+		}
+	}
+	list := make([]Stage, 0, len(old)+1)
+	list = append(list, old...)
+	list = append(list, s)
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].Priority < list[j].Priority
+	})
+	stages.list.Store(list)
+	return nil
+}
+
+// UnregisterStage removes the processing stage with the given name. It
+// returns false if no such stage is registered.
+func UnregisterStage(name string) bool {
+	stages.mtx.Lock()
+	defer stages.mtx.Unlock()
+	old := Stages()
+	list := make([]Stage, 0, len(old))
+	for _, o := range old {
+		if o.Name != name {
+			list = append(list, o)
+		}
+	}
+	stages.list.Store(list)
+	return len(list) != len(old)
+}
+
+// Stages returns the registered stages in execution order. The returned
+// slice must not be modified.
+func Stages() []Stage {
+	list, _ := stages.list.Load().([]Stage)
+	return list
+}
+
+// RunStages runs the registered stages on the packet in order. It returns
+// the final verdict, and the name of the stage that produced it. If all
+// stages return VerdictContinue, the name of the last stage is returned. If
+// no stages are registered, VerdictContinue and an empty name are returned.
+func (rp *RtrPkt) RunStages() (Verdict, string, error) {
+	var name string
+	for _, s := range Stages() {
+		name = s.Name
+		v, err := s.F(rp)
+		if err != nil {
+			return v, name, serrors.WrapStr("stage failed", err, "stage", name)
+		}
+		if v != VerdictContinue {
+			return v, name, nil
+		}
+	}
+	return VerdictContinue, name, nil
+}
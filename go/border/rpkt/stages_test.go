@@ -0,0 +1,119 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpkt
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterStage(t *testing.T) {
+	defer resetStages()
+	noop := func(*RtrPkt) (Verdict, error) { return VerdictContinue, nil }
+
+	assert.Error(t, RegisterStage(Stage{F: noop}))
+	assert.Error(t, RegisterStage(Stage{Name: "nil"}))
+	require.NoError(t, RegisterStage(Stage{Name: "b", Priority: 10, F: noop}))
+	require.NoError(t, RegisterStage(Stage{Name: "c", Priority: 10, F: noop}))
+	require.NoError(t, RegisterStage(Stage{Name: "a", Priority: 1, F: noop}))
+	assert.True(t, errors.Is(RegisterStage(Stage{Name: "a", F: noop}), ErrStageExists))
+
+	var names []string
+	for _, s := range Stages() {
+		names = append(names, s.Name)
+	}
+	assert.Equal(t, []string{"a", "b", "c"}, names)
+
+	assert.True(t, UnregisterStage("b"))
+	assert.False(t, UnregisterStage("b"))
+	assert.Len(t, Stages(), 2)
+}
+
+func TestRunStages(t *testing.T) {
+	testErr := errors.New("test")
+	tests := map[string]struct {
+		Verdicts        []Verdict
+		Err             error
+		ExpectedVerdict Verdict
+		ExpectedStage   string
+		ExpectedCalls   int
+	}{
+		"no stages": {
+			ExpectedVerdict: VerdictContinue,
+		},
+		"all continue": {
+			Verdicts:        []Verdict{VerdictContinue, VerdictContinue},
+			ExpectedVerdict: VerdictContinue,
+			ExpectedStage:   "s1",
+			ExpectedCalls:   2,
+		},
+		"drop stops pipeline": {
+			Verdicts:        []Verdict{VerdictDrop, VerdictContinue},
+			ExpectedVerdict: VerdictDrop,
+			ExpectedStage:   "s0",
+			ExpectedCalls:   1,
+		},
+		"forward stops pipeline": {
+			Verdicts:        []Verdict{VerdictContinue, VerdictForward, VerdictDrop},
+			ExpectedVerdict: VerdictForward,
+			ExpectedStage:   "s1",
+			ExpectedCalls:   2,
+		},
+		"error stops pipeline": {
+			Verdicts:        []Verdict{VerdictContinue, VerdictContinue},
+			Err:             testErr,
+			ExpectedVerdict: VerdictContinue,
+			ExpectedStage:   "s0",
+			ExpectedCalls:   1,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer resetStages()
+			var calls int
+			for i, v := range test.Verdicts {
+				v := v
+				s := Stage{
+					Name:     fmt.Sprintf("s%d", i),
+					Priority: i,
+					F: func(*RtrPkt) (Verdict, error) {
+						calls++
+						return v, test.Err
+					},
+				}
+				require.NoError(t, RegisterStage(s))
+			}
+			verdict, stage, err := NewRtrPkt().RunStages()
+			if test.Err != nil {
+				assert.True(t, errors.Is(err, test.Err))
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.ExpectedVerdict, verdict)
+			assert.Equal(t, test.ExpectedStage, stage)
+			assert.Equal(t, test.ExpectedCalls, calls)
+		})
+	}
+}
+
+func resetStages() {
+	for _, s := range Stages() {
+		UnregisterStage(s.Name)
+	}
+}
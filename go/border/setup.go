@@ -23,6 +23,7 @@ import (
 	"github.com/syndtr/gocapability/capability"
 
 	"github.com/scionproto/scion/go/border/brconf"
+	"github.com/scionproto/scion/go/border/policer"
 	"github.com/scionproto/scion/go/border/rctx"
 	"github.com/scionproto/scion/go/border/rpkt"
 	"github.com/scionproto/scion/go/lib/common"
@@ -61,9 +62,13 @@ func (r *Router) setup() error {
 
 	// Configure the rpkt package with the callbacks it needs.
 	rpkt.Init(r.RawSRevCallback)
+	// Register the ingress policer. It is a no-op unless configured.
+	err := rpkt.RegisterStage(rpkt.Stage{Name: policer.StageName, F: policer.Process})
+	if err != nil {
+		return err
+	}
 
 	// Load config.
-	var err error
 	var conf *brconf.BRConf
 	if conf, err = r.loadNewConfig(); err != nil {
 		return err
@@ -178,12 +183,28 @@ func (r *Router) setupNewContext(ctx *rctx.Ctx, tx *itopo.Transaction) error {
 		return err
 	}
 	rctx.Set(ctx)
+	setPolicer(ctx.Conf.Policer)
 	startSocks(ctx)
 	// Tear down sockets for removed interfaces
 	r.teardownNet(ctx, oldCtx, sockConf)
 	return nil
 }
 
+// setPolicer updates the active policer, if the policer configuration has
+// changed. The policer state is kept if the configuration is unchanged, e.g.,
+// on topology updates.
+func setPolicer(cfg *brconf.PolicerConf) {
+	if p := policer.Get(); p != nil && p.Config() == cfg {
+		return
+	}
+	if cfg == nil {
+		policer.Set(nil)
+		return
+	}
+	log.Info("Setting up ingress policer", "action", cfg.Action)
+	policer.Set(policer.New(cfg))
+}
+
 // setupNetAndTopo sets up the net context and set the topology in itopo.
 func (r *Router) setupNetAndTopo(ctx *rctx.Ctx, oldCtx *rctx.Ctx,
 	sockConf brconf.SockConf, tx *itopo.Transaction) error {
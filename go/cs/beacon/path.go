@@ -0,0 +1,81 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beacon
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/pathpol"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+)
+
+var _ pathpol.Path = beaconPath{}
+
+// beaconPath wraps a beacon such that it can be evaluated by path policies.
+// The interfaces are in construction direction, starting at the egress
+// interface of the originating AS and ending at the ingress interface of the
+// local AS.
+type beaconPath struct {
+	intfs []snet.PathInterface
+	key   snet.PathFingerprint
+}
+
+func newBeaconPath(beacon Beacon) (beaconPath, error) {
+	entries := beacon.Segment.ASEntries
+	if len(entries) == 0 {
+		return beaconPath{}, serrors.New("beacon without AS entries")
+	}
+	intfs := make([]snet.PathInterface, 0, 2*len(entries))
+	for i, asEntry := range entries {
+		if len(asEntry.HopEntries) == 0 {
+			return beaconPath{}, serrors.New("AS entry without hop entries",
+				"ia", asEntry.IA())
+		}
+		hopField, err := asEntry.HopEntries[0].HopField()
+		if err != nil {
+			return beaconPath{}, serrors.WrapStr("parsing hop field", err, "ia", asEntry.IA())
+		}
+		if i != 0 {
+			intfs = append(intfs, pathInterface{ia: asEntry.IA(), ifid: hopField.ConsIngress})
+		}
+		intfs = append(intfs, pathInterface{ia: asEntry.IA(), ifid: hopField.ConsEgress})
+	}
+	last := entries[len(entries)-1].HopEntries[0]
+	intfs = append(intfs, pathInterface{ia: last.OutIA(), ifid: last.RemoteOutIF})
+
+	keyParts := make([]string, 0, len(intfs))
+	for _, intf := range intfs {
+		keyParts = append(keyParts, fmt.Sprintf("%s#%d", intf.IA(), intf.ID()))
+	}
+	return beaconPath{
+		intfs: intfs,
+		key:   snet.PathFingerprint(strings.Join(keyParts, " ")),
+	}, nil
+}
+
+func (p beaconPath) Interfaces() []snet.PathInterface  { return p.intfs }
+func (p beaconPath) Fingerprint() snet.PathFingerprint { return p.key }
+
+type pathInterface struct {
+	ia   addr.IA
+	ifid common.IFIDType
+}
+
+func (i pathInterface) IA() addr.IA         { return i.ia }
+func (i pathInterface) ID() common.IFIDType { return i.ifid }
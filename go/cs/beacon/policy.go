@@ -21,6 +21,7 @@ import (
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/pathpol"
 	"github.com/scionproto/scion/go/lib/spath"
 )
 
@@ -80,7 +81,7 @@ func (p *Policies) Validate() error {
 		return common.NewBasicError("Invalid policy type", nil,
 			"expected", DownRegPolicy, "actual", p.DownReg.Type)
 	}
-	return nil
+	return validateSelection(&p.Prop, &p.UpReg, &p.DownReg)
 }
 
 // Filter applies all filters and returns an error if all of them filter the
@@ -118,6 +119,15 @@ func (p *Policies) Usage(beacon Beacon) Usage {
 	return u
 }
 
+func validateSelection(policies ...*Policy) error {
+	for _, p := range policies {
+		if err := p.Selection.Validate(); err != nil {
+			return common.NewBasicError("Invalid selection", err, "type", p.Type)
+		}
+	}
+	return nil
+}
+
 // CorePolicies keeps track of all policies for a core beacon store.
 type CorePolicies struct {
 	// Prop is the propagation policy.
@@ -142,7 +152,7 @@ func (p *CorePolicies) Validate() error {
 		return common.NewBasicError("Invalid policy type", nil,
 			"expected", CoreRegPolicy, "actual", p.CoreReg.Type)
 	}
-	return nil
+	return validateSelection(&p.Prop, &p.CoreReg)
 }
 
 // Filter applies all filters and returns an error if all of them filter the
@@ -186,6 +196,9 @@ type Policy struct {
 	MaxExpTime *spath.ExpTimeType `yaml:"MaxExpTime"`
 	// Filter is the filter applied to segments.
 	Filter Filter `yaml:"Filter"`
+	// Selection configures the algorithm that selects the best segments
+	// from the candidate set.
+	Selection Selection `yaml:"Selection"`
 	// Type is the policy type.
 	Type PolicyType `yaml:"Type"`
 }
@@ -203,6 +216,7 @@ func (p *Policy) InitDefaults() {
 		p.MaxExpTime = &m
 	}
 	p.Filter.InitDefaults()
+	p.Selection.InitDefaults()
 }
 
 func (p *Policy) initDefaults(t PolicyType) error {
@@ -212,7 +226,16 @@ func (p *Policy) initDefaults(t PolicyType) error {
 			"expected", t, "actual", p.Type)
 	}
 	p.Type = t
-	return nil
+	return p.Selection.Validate()
+}
+
+// selectionAlgorithm returns the selection algorithm configured in the
+// policy.
+func (p *Policy) selectionAlgorithm() selectionAlgorithm {
+	if p.Selection.Algorithm == WeightedSelection {
+		return newWeightedAlgo(p.Selection)
+	}
+	return baseAlgo{}
 }
 
 // ParsePolicyYaml parses the policy in yaml format and initializes the default values.
@@ -237,6 +260,87 @@ func LoadPolicyFromYaml(path string, t PolicyType) (*Policy, error) {
 	return ParsePolicyYaml(b, t)
 }
 
+// SelectionAlgorithm is the name of a beacon selection algorithm.
+type SelectionAlgorithm string
+
+const (
+	// BaseSelection selects the shortest beacons and adds the most diverse
+	// beacon.
+	BaseSelection SelectionAlgorithm = "Base"
+	// WeightedSelection selects the beacons with the best weighted score.
+	WeightedSelection SelectionAlgorithm = "Weighted"
+)
+
+// DefaultWeights are the weights of the weighted selection algorithm, if no
+// weight is set.
+var DefaultWeights = Weights{
+	HopCount:      1,
+	Diversity:     1,
+	Expiry:        1,
+	InterfaceCost: 1,
+}
+
+// Selection configures the beacon selection algorithm.
+type Selection struct {
+	// Algorithm is the selection algorithm. (default Base)
+	Algorithm SelectionAlgorithm `yaml:"Algorithm"`
+	// Weights are the weights of the weighted selection algorithm.
+	Weights Weights `yaml:"Weights"`
+	// InterfaceCosts are the costs of the local interfaces that are
+	// considered by the weighted selection algorithm. Interfaces without a
+	// cost have cost 0.
+	InterfaceCosts map[common.IFIDType]float64 `yaml:"InterfaceCosts"`
+}
+
+// InitDefaults initializes the default values for unset fields.
+func (s *Selection) InitDefaults() {
+	if s.Algorithm == "" {
+		s.Algorithm = BaseSelection
+	}
+	if s.Algorithm == WeightedSelection && s.Weights == (Weights{}) {
+		s.Weights = DefaultWeights
+	}
+}
+
+// Validate checks that the algorithm is known, and that weights and costs are
+// not negative.
+func (s *Selection) Validate() error {
+	switch s.Algorithm {
+	case BaseSelection, WeightedSelection:
+	default:
+		return common.NewBasicError("Unknown selection algorithm", nil,
+			"algorithm", s.Algorithm)
+	}
+	if s.Weights.HopCount < 0 || s.Weights.Diversity < 0 || s.Weights.Expiry < 0 ||
+		s.Weights.InterfaceCost < 0 {
+		return common.NewBasicError("Selection weights must not be negative", nil,
+			"weights", s.Weights)
+	}
+	for ifid, cost := range s.InterfaceCosts {
+		if cost < 0 {
+			return common.NewBasicError("Interface cost must not be negative", nil,
+				"ifid", ifid, "cost", cost)
+		}
+	}
+	return nil
+}
+
+// Weights are the weights of the score components in the weighted selection
+// algorithm. Each component is normalized to [0, 1] over the candidate set.
+// Shorter paths, more diverse paths, paths that expire later and paths
+// received on cheaper interfaces are preferred.
+type Weights struct {
+	// HopCount is the weight of the number of AS hops.
+	HopCount float64 `yaml:"HopCount"`
+	// Diversity is the weight of the link diversity against the already
+	// selected beacons.
+	Diversity float64 `yaml:"Diversity"`
+	// Expiry is the weight of the remaining expiry time.
+	Expiry float64 `yaml:"Expiry"`
+	// InterfaceCost is the weight of the ingress interface cost.
+	InterfaceCost float64 `yaml:"InterfaceCost"`
+}
+
 // Filter filters beacons.
 type Filter struct {
 	// MaxHopsLength is the maximum number of hops a segment can have.
@@ -247,6 +351,14 @@ type Filter struct {
 	IsdBlackList []addr.ISD `yaml:"IsdBlackList"`
 	// AllowIsdLoop indicates whether ISD loops should not be filtered.
 	AllowIsdLoop *bool `yaml:"AllowIsdLoop"`
+	// ACL is the path policy ACL that all interfaces of a segment must be
+	// allowed by. The interfaces of a segment include the interface it is
+	// received on in the local AS. The ACL must have a default entry.
+	ACL *pathpol.ACL `yaml:"ACL"`
+	// Sequence is the path policy sequence a segment must match. The
+	// sequence is matched against the segment in construction direction,
+	// starting at the originating AS and ending at the local AS.
+	Sequence *pathpol.Sequence `yaml:"Sequence"`
 }
 
 // InitDefaults initializes the default values for unset fields.
@@ -282,6 +394,27 @@ func (f Filter) Apply(beacon Beacon) error {
 			}
 		}
 	}
+	return f.applyPathPolicy(beacon)
+}
+
+// applyPathPolicy returns an error if the beacon is denied by the ACL or does
+// not match the sequence.
+func (f Filter) applyPathPolicy(beacon Beacon) error {
+	if f.ACL == nil && f.Sequence == nil {
+		return nil
+	}
+	path, err := newBeaconPath(beacon)
+	if err != nil {
+		return err
+	}
+	ps := pathpol.PathSet{path.Fingerprint(): path}
+	if len(f.ACL.Eval(ps)) == 0 {
+		return common.NewBasicError("Denied by ACL", nil, "path", path.Fingerprint())
+	}
+	if len(f.Sequence.Eval(ps)) == 0 {
+		return common.NewBasicError("Sequence not matched", nil, "path", path.Fingerprint(),
+			"sequence", f.Sequence)
+	}
 	return nil
 }
 
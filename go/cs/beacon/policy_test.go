@@ -17,13 +17,18 @@ package beacon_test
 import (
 	"testing"
 
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/scionproto/scion/go/cs/beacon"
 	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/pathpol"
 	"github.com/scionproto/scion/go/lib/spath"
 	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/lib/xtest/graph"
 )
 
 var (
@@ -80,6 +85,29 @@ func TestLoadPolicyFromYaml(t *testing.T) {
 	}
 }
 
+func TestLoadPolicySelection(t *testing.T) {
+	p, err := beacon.LoadPolicyFromYaml("testdata/weightedPolicy.yml", beacon.UpRegPolicy)
+	require.NoError(t, err)
+	expected := beacon.Selection{
+		Algorithm: beacon.WeightedSelection,
+		Weights: beacon.Weights{
+			HopCount:      2,
+			Diversity:     1.5,
+			Expiry:        0.5,
+			InterfaceCost: 1,
+		},
+		InterfaceCosts: map[common.IFIDType]float64{1: 10, 3: 2.5},
+	}
+	assert.Equal(t, expected, p.Selection)
+
+	p, err = beacon.LoadPolicyFromYaml("testdata/typedPolicy.yml", beacon.PropPolicy)
+	require.NoError(t, err)
+	assert.Equal(t, beacon.Selection{Algorithm: beacon.BaseSelection}, p.Selection)
+
+	_, err = beacon.ParsePolicyYaml([]byte("Selection: {Algorithm: Fancy}"), beacon.PropPolicy)
+	assert.Error(t, err)
+}
+
 func TestFilterApply(t *testing.T) {
 	defaultFilter := &beacon.Filter{
 		MaxHopsLength: 2,
@@ -155,6 +183,83 @@ func TestFilterApply(t *testing.T) {
 	}
 }
 
+func TestFilterApplyPathPolicy(t *testing.T) {
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+	g := graph.NewDefaultGraph(mctrl)
+
+	// Both beacons originate in 1-ff00:0:130 and are received in
+	// 1-ff00:0:111 on different interfaces.
+	via120 := testBeaconOrErr(g, graph.If_130_B_120_A, graph.If_120_X_111_B).Beacon
+	direct := testBeaconOrErr(g, graph.If_130_B_111_A).Beacon
+
+	p, err := beacon.LoadPolicyFromYaml("testdata/pathPolicy.yml", beacon.DownRegPolicy)
+	require.NoError(t, err)
+	assert.NoError(t, p.Filter.Apply(via120))
+	assert.Error(t, p.Filter.Apply(direct))
+
+	testCases := map[string]struct {
+		Filter       beacon.Filter
+		Beacon       beacon.Beacon
+		ErrAssertion assert.ErrorAssertionFunc
+	}{
+		"ACL allows ingress interface": {
+			Filter:       beacon.Filter{ACL: p.Filter.ACL},
+			Beacon:       via120,
+			ErrAssertion: assert.NoError,
+		},
+		"ACL denies ingress interface": {
+			Filter:       beacon.Filter{ACL: p.Filter.ACL},
+			Beacon:       direct,
+			ErrAssertion: assert.Error,
+		},
+		"ACL denies transit AS": {
+			Filter:       beacon.Filter{ACL: mustACL(t, "- 1-ff00:0:120", "+")},
+			Beacon:       via120,
+			ErrAssertion: assert.Error,
+		},
+		"Sequence matches": {
+			Filter:       beacon.Filter{Sequence: mustSequence(t, "0 1-ff00:0:120 0")},
+			Beacon:       via120,
+			ErrAssertion: assert.NoError,
+		},
+		"Sequence does not match": {
+			Filter:       beacon.Filter{Sequence: mustSequence(t, "0 1-ff00:0:120 0")},
+			Beacon:       direct,
+			ErrAssertion: assert.Error,
+		},
+		"Sequence matches ingress interface": {
+			Filter:       beacon.Filter{Sequence: mustSequence(t, "0* 1-ff00:0:111#1432")},
+			Beacon:       direct,
+			ErrAssertion: assert.NoError,
+		},
+	}
+	for name, test := range testCases {
+		t.Run(name, func(t *testing.T) {
+			test.Filter.InitDefaults()
+			test.ErrAssertion(t, test.Filter.Apply(test.Beacon))
+		})
+	}
+}
+
+func mustACL(t *testing.T, entries ...string) *pathpol.ACL {
+	var aclEntries []*pathpol.ACLEntry
+	for _, str := range entries {
+		entry := &pathpol.ACLEntry{}
+		require.NoError(t, entry.LoadFromString(str))
+		aclEntries = append(aclEntries, entry)
+	}
+	acl, err := pathpol.NewACL(aclEntries...)
+	require.NoError(t, err)
+	return acl
+}
+
+func mustSequence(t *testing.T, str string) *pathpol.Sequence {
+	seq, err := pathpol.NewSequence(str)
+	require.NoError(t, err)
+	return seq
+}
+
 func TestFilterLoop(t *testing.T) {
 	testCases := []struct {
 		Name         string
@@ -14,7 +14,12 @@
 
 package beacon
 
-import "math"
+import (
+	"math"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/common"
+)
 
 type selectionAlgorithm interface {
 	// SelectAndServe selects the n best beacons from the beacons channel and
@@ -110,6 +115,126 @@ func (baseAlgo) serveMostDiverse(beacons <-chan BeaconOrErr, results chan<- Beac
 	results <- BeaconOrErr{Beacon: first}
 }
 
+// weightedAlgo implements a multi-objective selection algorithm. Each
+// candidate is scored by a weighted combination of its hop count, its link
+// diversity against the already selected beacons, its remaining expiry time
+// and the cost of the interface it was received on. The beacons are selected
+// greedily, i.e., the candidate with the highest score is selected and the
+// diversity of the remaining candidates is updated, until the result set is
+// full.
+type weightedAlgo struct {
+	weights Weights
+	costs   map[common.IFIDType]float64
+	// now returns the current time. It can be overwritten in tests.
+	now func() time.Time
+}
+
+func newWeightedAlgo(s Selection) weightedAlgo {
+	return weightedAlgo{weights: s.Weights, costs: s.InterfaceCosts, now: time.Now}
+}
+
+// candidate is a beacon with its normalized score components.
+type candidate struct {
+	beacon Beacon
+	// hops, expiry and cost are normalized to [0, 1] over all candidates.
+	hops   float64
+	expiry float64
+	cost   float64
+	// diversity is the minimum diversity against the selected beacons,
+	// normalized by the number of links in the beacon.
+	diversity float64
+	selected  bool
+}
+
+// SelectAndServe reads all candidates from the beacons channel, selects the
+// resultSize best ones and serves them on the results channel. Errors are
+// served immediately.
+func (a weightedAlgo) SelectAndServe(beacons <-chan BeaconOrErr, results chan<- BeaconOrErr,
+	resultSize int) {
+
+	var candidates []*candidate
+	for res := range beacons {
+		if res.Err != nil {
+			results <- res
+			continue
+		}
+		candidates = append(candidates, &candidate{beacon: res.Beacon, diversity: 1})
+	}
+	// Select all beacons before serving them, the receiver might modify the
+	// served beacons.
+	for _, c := range a.selectBeacons(candidates, resultSize) {
+		results <- BeaconOrErr{Beacon: c.beacon}
+	}
+}
+
+func (a weightedAlgo) selectBeacons(candidates []*candidate, resultSize int) []*candidate {
+	a.normalize(candidates)
+	var selected []*candidate
+	for len(selected) < resultSize && len(selected) < len(candidates) {
+		var best *candidate
+		bestScore := math.Inf(-1)
+		for _, c := range candidates {
+			if c.selected {
+				continue
+			}
+			// Ties are broken by the order of the candidates.
+			if score := a.score(c); score > bestScore {
+				best, bestScore = c, score
+			}
+		}
+		best.selected = true
+		selected = append(selected, best)
+		for _, c := range candidates {
+			if !c.selected {
+				c.diversity = math.Min(c.diversity, linkDiversity(c.beacon, best.beacon))
+			}
+		}
+	}
+	return selected
+}
+
+func (a weightedAlgo) score(c *candidate) float64 {
+	return a.weights.Diversity*c.diversity + a.weights.Expiry*c.expiry -
+		a.weights.HopCount*c.hops - a.weights.InterfaceCost*c.cost
+}
+
+// normalize sets the hops, expiry and cost of the candidates, normalized by
+// the maximum value over all candidates.
+func (a weightedAlgo) normalize(candidates []*candidate) {
+	now := a.now()
+	var maxHops, maxExpiry, maxCost float64
+	for _, c := range candidates {
+		c.hops = float64(len(c.beacon.Segment.ASEntries))
+		c.expiry = math.Max(0, c.beacon.Segment.MaxExpiry().Sub(now).Seconds())
+		c.cost = a.costs[c.beacon.InIfId]
+		maxHops = math.Max(maxHops, c.hops)
+		maxExpiry = math.Max(maxExpiry, c.expiry)
+		maxCost = math.Max(maxCost, c.cost)
+	}
+	for _, c := range candidates {
+		c.hops = normalized(c.hops, maxHops)
+		c.expiry = normalized(c.expiry, maxExpiry)
+		c.cost = normalized(c.cost, maxCost)
+	}
+}
+
+// linkDiversity returns the fraction of links in b that do not appear in
+// other.
+func linkDiversity(b, other Beacon) float64 {
+	n := len(b.Segment.ASEntries)
+	if n == 0 {
+		return 0
+	}
+	return float64(b.Diversity(other)) / float64(n)
+}
+
+func normalized(v, max float64) float64 {
+	if max == 0 {
+		return 0
+	}
+	return v / max
+}
+
 func max(a, b int) int {
 	if a > b {
 		return a
@@ -52,8 +52,7 @@ func NewBeaconStore(policies Policies, db DB) (*Store, error) {
 	}
 	s := &Store{
 		baseStore: baseStore{
-			db:   db,
-			algo: baseAlgo{},
+			db: db,
 		},
 		policies: policies,
 	}
@@ -96,7 +95,7 @@ func (s *Store) getBeacons(ctx context.Context, policy *Policy) (<-chan BeaconOr
 	go func() {
 		defer log.HandlePanic()
 		defer close(results)
-		s.algo.SelectAndServe(beacons, results, policy.BestSetSize)
+		policy.selectionAlgorithm().SelectAndServe(beacons, results, policy.BestSetSize)
 	}()
 	return results, nil
 }
@@ -131,8 +130,7 @@ func NewCoreBeaconStore(policies CorePolicies, db DB) (*CoreStore, error) {
 	}
 	s := &CoreStore{
 		baseStore: baseStore{
-			db:   db,
-			algo: baseAlgo{},
+			db: db,
 		},
 		policies: policies,
 	}
@@ -183,7 +181,7 @@ func (s *CoreStore) getBeacons(ctx context.Context, policy *Policy) (<-chan Beac
 		go func() {
 			defer log.HandlePanic()
 			defer wg.Done()
-			s.algo.SelectAndServe(beacons, results, policy.BestSetSize)
+			policy.selectionAlgorithm().SelectAndServe(beacons, results, policy.BestSetSize)
 		}()
 	}
 	go func() {
@@ -214,7 +212,6 @@ func (s *CoreStore) MaxExpTime(policyType PolicyType) spath.ExpTimeType {
 type baseStore struct {
 	db     DB
 	usager usager
-	algo   selectionAlgorithm
 }
 
 // PreFilter indicates whether the beacon will be filtered on insert by
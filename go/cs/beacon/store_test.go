@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/scionproto/scion/go/cs/beacon"
@@ -369,6 +370,114 @@ func testCoreStoreSelection(t *testing.T,
 	}
 }
 
+func TestStoreWeightedSelection(t *testing.T) {
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+	g := graph.NewDefaultGraph(mctrl)
+
+	stub := graph.If_210_X_220_X
+	beacons := []beacon.BeaconOrErr{
+		testBeaconOrErr(g, graph.If_130_A_110_X, graph.If_110_X_210_X, stub),
+		// Same beacon as the first beacon.
+		testBeaconOrErr(g, graph.If_130_A_110_X, graph.If_110_X_210_X, stub),
+		// Share the last link between 110 and 210.
+		testBeaconOrErr(g, graph.If_130_B_120_A, graph.If_120_A_110_X, graph.If_110_X_210_X, stub),
+		// Share no link.
+		testBeaconOrErr(g, graph.If_130_B_120_A, graph.If_120_B_220_X, graph.If_220_X_210_X, stub),
+	}
+	// Same as the first beacon, but received on a different interface.
+	otherIntf := beacons[0]
+	otherIntf.Beacon.InIfId = 42
+
+	var tests = map[string]struct {
+		results   []beacon.BeaconOrErr
+		bestSize  int
+		selection beacon.Selection
+		expected  []beacon.BeaconOrErr
+	}{
+		"hop count only": {
+			results:  beacons,
+			bestSize: 2,
+			selection: beacon.Selection{
+				Algorithm: beacon.WeightedSelection,
+				Weights:   beacon.Weights{HopCount: 1},
+			},
+			expected: beacons[:2],
+		},
+		"hop count and diversity": {
+			results:  beacons,
+			bestSize: 2,
+			selection: beacon.Selection{
+				Algorithm: beacon.WeightedSelection,
+				Weights:   beacon.Weights{HopCount: 1, Diversity: 1},
+			},
+			expected: []beacon.BeaconOrErr{beacons[0], beacons[3]},
+		},
+		"default weights": {
+			results:   beacons,
+			bestSize:  3,
+			selection: beacon.Selection{Algorithm: beacon.WeightedSelection},
+			expected:  []beacon.BeaconOrErr{beacons[0], beacons[3], beacons[2]},
+		},
+		"interface cost": {
+			results:  []beacon.BeaconOrErr{beacons[0], otherIntf},
+			bestSize: 1,
+			selection: beacon.Selection{
+				Algorithm:      beacon.WeightedSelection,
+				InterfaceCosts: map[common.IFIDType]float64{beacons[0].Beacon.InIfId: 10},
+			},
+			expected: []beacon.BeaconOrErr{otherIntf},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mctrl := gomock.NewController(t)
+			defer mctrl.Finish()
+			db := mock_beacon.NewMockDB(mctrl)
+			policy := beacon.Policy{BestSetSize: test.bestSize, Selection: test.selection}
+			policies := beacon.Policies{Prop: policy, UpReg: policy, DownReg: policy}
+			store, err := beacon.NewBeaconStore(policies, db)
+			require.NoError(t, err)
+			db.EXPECT().CandidateBeacons(gomock.Any(), gomock.Any(), gomock.Any(),
+				addr.IA{}).DoAndReturn(
+				func(_ ...interface{}) (<-chan beacon.BeaconOrErr, error) {
+					results := make(chan beacon.BeaconOrErr, len(test.results))
+					defer close(results)
+					for _, res := range test.results {
+						results <- res
+					}
+					return results, nil
+				},
+			)
+			res, err := store.BeaconsToPropagate(context.Background())
+			require.NoError(t, err)
+			var selected []beacon.BeaconOrErr
+			for bOrErr := range res {
+				selected = append(selected, bOrErr)
+			}
+			assert.Equal(t, test.expected, selected)
+		})
+	}
+}
+
+func TestNewBeaconStoreInvalidSelection(t *testing.T) {
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+	policies := beacon.Policies{
+		Prop: beacon.Policy{Selection: beacon.Selection{Algorithm: "Unknown"}},
+	}
+	_, err := beacon.NewBeaconStore(policies, mock_beacon.NewMockDB(mctrl))
+	assert.Error(t, err)
+	policies = beacon.Policies{
+		UpReg: beacon.Policy{Selection: beacon.Selection{
+			Algorithm: beacon.WeightedSelection,
+			Weights:   beacon.Weights{HopCount: -1},
+		}},
+	}
+	_, err = beacon.NewBeaconStore(policies, mock_beacon.NewMockDB(mctrl))
+	assert.Error(t, err)
+}
+
 func testBeaconOrErr(g *graph.Graph, desc ...common.IFIDType) beacon.BeaconOrErr {
 	pseg := testBeacon(g, desc)
 	asEntry := pseg.ASEntries[pseg.MaxAEIdx()]
@@ -56,6 +56,18 @@ const (
 	// DefaultQueryInterval is the default interval after which the segment
 	// cache expires.
 	DefaultQueryInterval = 5 * time.Minute
+	// DefaultRenewalCheckInterval is the default interval between checking
+	// whether the AS certificate needs to be renewed.
+	DefaultRenewalCheckInterval = 10 * time.Minute
+	// DefaultRenewalLeadTime is the default time before the expiry of the AS
+	// certificate at which the renewal starts.
+	DefaultRenewalLeadTime = 24 * time.Hour
+	// DefaultRenewalValidity is the default requested validity period of a
+	// renewed AS certificate.
+	DefaultRenewalValidity = 3 * 24 * time.Hour
+	// DefaultRenewalMaxValidity is the default maximum validity period of an AS
+	// certificate issued by an issuing AS.
+	DefaultRenewalMaxValidity = 3 * 24 * time.Hour
 )
 
 // Error values
@@ -79,6 +91,7 @@ type Config struct {
 	PathDB   pathstorage.PathDBConf     `toml:"path_db,omitempty"`
 	BS       BSConfig                   `toml:"beaconing,omitempty"`
 	PS       PSConfig                   `toml:"path,omitempty"`
+	Renewal  RenewalConfig              `toml:"renewal,omitempty"`
 }
 
 // InitDefaults initializes the default values for all parts of the config.
@@ -94,6 +107,7 @@ func (cfg *Config) InitDefaults() {
 		&cfg.PathDB,
 		&cfg.BS,
 		&cfg.PS,
+		&cfg.Renewal,
 	)
 }
 
@@ -109,6 +123,7 @@ func (cfg *Config) Validate() error {
 		&cfg.PathDB,
 		&cfg.BS,
 		&cfg.PS,
+		&cfg.Renewal,
 	)
 }
 
@@ -126,6 +141,7 @@ func (cfg *Config) Sample(dst io.Writer, path config.Path, _ config.CtxMap) {
 		&cfg.PathDB,
 		&cfg.BS,
 		&cfg.PS,
+		&cfg.Renewal,
 	)
 }
 
@@ -247,6 +263,59 @@ func (cfg *PSConfig) ConfigName() string {
 	return "path"
 }
 
+var _ config.Config = (*RenewalConfig)(nil)
+
+// RenewalConfig holds the configuration for the AS certificate renewal.
+type RenewalConfig struct {
+	// CheckInterval is the interval between checking whether the AS
+	// certificate needs to be renewed.
+	CheckInterval util.DurWrap `toml:"check_interval,omitempty"`
+	// LeadTime is the time before the expiry of the AS certificate at which
+	// the renewal starts.
+	LeadTime util.DurWrap `toml:"lead_time,omitempty"`
+	// Validity is the requested validity period of a renewed AS certificate.
+	Validity util.DurWrap `toml:"validity,omitempty"`
+	// MaxValidity is the maximum validity period of an AS certificate issued
+	// by this AS. In a non-issuing AS, this field is ignored.
+	MaxValidity util.DurWrap `toml:"max_validity,omitempty"`
+}
+
+// InitDefaults initializes the default values for the durations that are
+// equal to zero.
+func (cfg *RenewalConfig) InitDefaults() {
+	initDurWrap(&cfg.CheckInterval, DefaultRenewalCheckInterval)
+	initDurWrap(&cfg.LeadTime, DefaultRenewalLeadTime)
+	initDurWrap(&cfg.Validity, DefaultRenewalValidity)
+	initDurWrap(&cfg.MaxValidity, DefaultRenewalMaxValidity)
+}
+
+// Validate validates that all durations are set.
+func (cfg *RenewalConfig) Validate() error {
+	switch {
+	case cfg.CheckInterval.Duration == 0:
+		return serrors.New("check_interval must not be zero")
+	case cfg.LeadTime.Duration == 0:
+		return serrors.New("lead_time must not be zero")
+	case cfg.Validity.Duration == 0:
+		return serrors.New("validity must not be zero")
+	case cfg.MaxValidity.Duration == 0:
+		return serrors.New("max_validity must not be zero")
+	case cfg.LeadTime.Duration >= cfg.Validity.Duration:
+		return serrors.New("lead_time must be smaller than validity")
+	}
+	return nil
+}
+
+// Sample generates a sample for the certificate renewal configuration.
+func (cfg *RenewalConfig) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
+	config.WriteString(dst, renewalSample)
+}
+
+// ConfigName is the toml key for the certificate renewal configuration.
+func (cfg *RenewalConfig) ConfigName() string {
+	return "renewal"
+}
+
 var _ config.Config = (*Policies)(nil)
 
 // Policies contains the file paths of the policies.
@@ -85,6 +85,7 @@ func CheckTestConfig(t *testing.T, cfg *Config, id string) {
 	pathstoragetest.CheckTestPathDBConf(t, &cfg.PathDB, id)
 	CheckTestBSConfig(t, &cfg.BS)
 	CheckTestPSConfig(t, &cfg.PS, id)
+	CheckTestRenewalConfig(t, &cfg.Renewal)
 }
 
 func CheckTestBSConfig(t *testing.T, cfg *BSConfig) {
@@ -112,3 +113,10 @@ func InitTestPSConfig(cfg *PSConfig) {
 func CheckTestPSConfig(t *testing.T, cfg *PSConfig, id string) {
 	assert.Equal(t, DefaultQueryInterval, cfg.QueryInterval.Duration)
 }
+
+func CheckTestRenewalConfig(t *testing.T, cfg *RenewalConfig) {
+	assert.Equal(t, DefaultRenewalCheckInterval, cfg.CheckInterval.Duration)
+	assert.Equal(t, DefaultRenewalLeadTime, cfg.LeadTime.Duration)
+	assert.Equal(t, DefaultRenewalValidity, cfg.Validity.Duration)
+	assert.Equal(t, DefaultRenewalMaxValidity, cfg.MaxValidity.Duration)
+}
@@ -20,3 +20,20 @@ const psSample = `
 # The time after which segments for a destination are refetched. (default 5m)
 query_interval = "5m"
 `
+
+const renewalSample = `
+# The interval between checking whether the AS certificate needs to be
+# renewed. (default 10m)
+check_interval = "10m"
+
+# The time before the expiry of the AS certificate at which the renewal
+# starts. (default 24h)
+lead_time = "24h"
+
+# The requested validity period of a renewed AS certificate. (default 72h)
+validity = "72h"
+
+# The maximum validity period of an AS certificate issued by this AS. In a
+# non-issuing AS, this field is ignored. (default 72h)
+max_validity = "72h"
+`
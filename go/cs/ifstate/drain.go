@@ -0,0 +1,93 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ifstate
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/topology"
+)
+
+var (
+	// ErrUnknownIntf indicates that the drained interface is not in the
+	// topology.
+	ErrUnknownIntf = serrors.New("unknown interface")
+	// ErrNotOwner indicates that the drain request was not sent by the border
+	// router that owns the interface.
+	ErrNotOwner = serrors.New("request not sent by the border router of the interface")
+)
+
+// Drainer issues revocations for interfaces that are drained by their border
+// router. The state of the interfaces is not changed, they stay active and
+// keep forwarding traffic. The revocations make end hosts migrate to other
+// paths before the interfaces are taken down.
+type Drainer struct {
+	Msgr         infra.Messenger
+	Signer       infra.Signer
+	TopoProvider topology.Provider
+	RevInserter  RevInserter
+	RevTTL       time.Duration
+}
+
+// Revoke issues a signed revocation for the draining interface. The request
+// must be sent from the control address of the border router that owns the
+// interface. The revocation is stored and sent to the path service.
+func (d *Drainer) Revoke(ctx context.Context, ifid common.IFIDType,
+	src net.Addr) (*path_mgmt.SignedRevInfo, error) {
+
+	topo := d.TopoProvider.Get()
+	intf, ok := topo.IFInfoMap()[ifid]
+	if !ok {
+		return nil, serrors.WithCtx(ErrUnknownIntf, "ifid", ifid)
+	}
+	if !fromBR(topo, intf, src) {
+		return nil, serrors.WithCtx(ErrNotOwner, "ifid", ifid, "src", src)
+	}
+	srev, err := createSignedRev(topo, d.Signer, ifid, d.RevTTL)
+	if err != nil {
+		return nil, serrors.WrapStr("creating revocation", err, "ifid", ifid)
+	}
+	if err := d.RevInserter.InsertRevocations(ctx, srev); err != nil {
+		log.FromCtx(ctx).Error("[ifstate.Drainer] Failed to insert revocation in store",
+			"ifid", ifid, "err", err)
+		// still continue to try to push it to the PS.
+	}
+	pushRevocationsToPS(ctx, d.Msgr, topo, map[common.IFIDType]*path_mgmt.SignedRevInfo{
+		ifid: srev,
+	})
+	return srev, nil
+}
+
+// fromBR indicates whether src is the control address of the border router
+// that owns the interface.
+func fromBR(topo topology.Topology, intf topology.IFInfo, src net.Addr) bool {
+	a, ok := src.(*snet.UDPAddr)
+	if !ok || a.Host == nil || !a.IA.Equal(topo.IA()) {
+		return false
+	}
+	if intf.CtrlAddrs == nil || intf.CtrlAddrs.SCIONAddress == nil {
+		return false
+	}
+	ctrl := intf.CtrlAddrs.SCIONAddress
+	return ctrl.IP.Equal(a.Host.IP) && ctrl.Port == a.Host.Port
+}
@@ -0,0 +1,153 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ifstate
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/cs/ifstate/mock_ifstate"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/infra/mock_infra"
+	"github.com/scionproto/scion/go/lib/infra/modules/itopo/itopotest"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestDrainerRevoke(t *testing.T) {
+	topoProvider := itopotest.TopoProviderFromFile(t, "testdata/topology.json")
+	pub, priv, err := scrypto.GenKeyPair(scrypto.Ed25519)
+	require.NoError(t, err)
+	// Interface 105 is owned by br1-ff00_0_111-2.
+	owner := &snet.UDPAddr{IA: ia, Host: &net.UDPAddr{IP: net.IP{127, 0, 0, 82}, Port: 31031}}
+	other := &snet.UDPAddr{IA: ia, Host: &net.UDPAddr{IP: net.IP{127, 0, 0, 81}, Port: 31029}}
+
+	newDrainer := func(mctrl *gomock.Controller) (*Drainer, *mock_infra.MockMessenger,
+		*mock_ifstate.MockRevInserter) {
+
+		msgr := mock_infra.NewMockMessenger(mctrl)
+		revInserter := mock_ifstate.NewMockRevInserter(mctrl)
+		return &Drainer{
+			Msgr:         msgr,
+			Signer:       createTestSigner(t, priv),
+			TopoProvider: topoProvider,
+			RevInserter:  revInserter,
+			RevTTL:       ttl,
+		}, msgr, revInserter
+	}
+
+	t.Run("revocation is signed, stored and sent to the PS", func(t *testing.T) {
+		mctrl := gomock.NewController(t)
+		defer mctrl.Finish()
+		d, msgr, revInserter := newDrainer(mctrl)
+		var stored, sent *path_mgmt.SignedRevInfo
+		revInserter.EXPECT().InsertRevocations(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, revs ...*path_mgmt.SignedRevInfo) error {
+				require.Len(t, revs, 1)
+				stored = revs[0]
+				return nil
+			})
+		msgr.EXPECT().SendRev(gomock.Any(), gomock.Any(),
+			&snet.SVCAddr{IA: ia, SVC: addr.SvcPS}, gomock.Any()).DoAndReturn(
+			func(_ context.Context, srev *path_mgmt.SignedRevInfo, _ net.Addr, _ uint64) error {
+				sent = srev
+				return nil
+			})
+		srev, err := d.Revoke(context.Background(), 105, owner)
+		require.NoError(t, err)
+		assert.Equal(t, srev, stored)
+		assert.Equal(t, srev, sent)
+		revInfo, err := srev.VerifiedRevInfo(context.Background(), revVerifier(pub))
+		require.NoError(t, err)
+		assert.Equal(t, uint64(105), uint64(revInfo.IfID))
+		assert.Equal(t, ia.IAInt(), revInfo.RawIsdas)
+		assert.Equal(t, uint32(ttl.Seconds()), revInfo.RawTTL)
+	})
+	t.Run("other border router", func(t *testing.T) {
+		mctrl := gomock.NewController(t)
+		defer mctrl.Finish()
+		d, _, _ := newDrainer(mctrl)
+		_, err := d.Revoke(context.Background(), 105, other)
+		xtest.AssertErrorsIs(t, err, ErrNotOwner)
+	})
+	t.Run("unknown interface", func(t *testing.T) {
+		mctrl := gomock.NewController(t)
+		defer mctrl.Finish()
+		d, _, _ := newDrainer(mctrl)
+		_, err := d.Revoke(context.Background(), 42, owner)
+		xtest.AssertErrorsIs(t, err, ErrUnknownIntf)
+	})
+}
+
+func TestHandlerDrain(t *testing.T) {
+	topoProvider := itopotest.TopoProviderFromFile(t, "testdata/topology.json")
+	_, priv, err := scrypto.GenKeyPair(scrypto.Ed25519)
+	require.NoError(t, err)
+	owner := &snet.UDPAddr{IA: ia, Host: &net.UDPAddr{IP: net.IP{127, 0, 0, 82}, Port: 31031}}
+	intfs := NewInterfaces(topoProvider.Get().IFInfoMap(), Config{})
+	activateAll(intfs)
+
+	t.Run("reply contains the revocation", func(t *testing.T) {
+		mctrl := gomock.NewController(t)
+		defer mctrl.Finish()
+		msgr := mock_infra.NewMockMessenger(mctrl)
+		msgr.EXPECT().SendRev(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+		revInserter := mock_ifstate.NewMockRevInserter(mctrl)
+		revInserter.EXPECT().InsertRevocations(gomock.Any(), gomock.Any())
+		rw := mock_infra.NewMockResponseWriter(mctrl)
+		var reply *path_mgmt.IFStateInfos
+		rw.EXPECT().SendIfStateInfoReply(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, msg *path_mgmt.IFStateInfos) error {
+				reply = msg
+				return nil
+			})
+		h := NewHandler(intfs, &Drainer{
+			Msgr:         msgr,
+			Signer:       createTestSigner(t, priv),
+			TopoProvider: topoProvider,
+			RevInserter:  revInserter,
+			RevTTL:       ttl,
+		})
+		serveCtx := infra.NewContextWithResponseWriter(context.Background(), rw)
+		req := infra.NewRequest(serveCtx, &path_mgmt.IFStateReq{IfID: 105, Drain: true},
+			nil, owner, 0)
+		assert.Equal(t, infra.MetricsResultOk, h.Handle(req))
+		require.Len(t, reply.Infos, 1)
+		assert.Equal(t, uint64(105), uint64(reply.Infos[0].IfID))
+		assert.True(t, reply.Infos[0].Active)
+		assert.NotNil(t, reply.Infos[0].SRevInfo)
+		// The interface state is not changed.
+		assert.Equal(t, Active, intfs.Get(105).State())
+		assert.Nil(t, intfs.Get(105).Revocation())
+	})
+	t.Run("draining not supported", func(t *testing.T) {
+		mctrl := gomock.NewController(t)
+		defer mctrl.Finish()
+		rw := mock_infra.NewMockResponseWriter(mctrl)
+		h := NewHandler(intfs, nil)
+		serveCtx := infra.NewContextWithResponseWriter(context.Background(), rw)
+		req := infra.NewRequest(serveCtx, &path_mgmt.IFStateReq{IfID: 105, Drain: true},
+			nil, owner, 0)
+		assert.Equal(t, infra.MetricsErrInvalid, h.Handle(req))
+	})
+}
@@ -19,18 +19,22 @@ import (
 	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
 	"github.com/scionproto/scion/go/lib/infra"
 	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/serrors"
 )
 
 type handler struct {
 	intfs   *Interfaces
+	drainer *Drainer
 	request *infra.Request
 }
 
-// NewHandler creates interface state request handler.
-func NewHandler(intfs *Interfaces) infra.Handler {
+// NewHandler creates interface state request handler. Drain requests are
+// served by the drainer. If it is nil, drain requests are rejected.
+func NewHandler(intfs *Interfaces, drainer *Drainer) infra.Handler {
 	f := func(r *infra.Request) *infra.HandlerResult {
 		handler := &handler{
 			intfs:   intfs,
+			drainer: drainer,
 			request: r,
 		}
 		return handler.Handle()
@@ -52,7 +56,17 @@ func (h *handler) Handle() *infra.HandlerResult {
 		logger.Error("[IfStateReqHandler] No response writer")
 		return infra.MetricsErrInternal
 	}
-	reply := h.buildIfStateInfo(ifStateReq)
+	var reply *path_mgmt.IFStateInfos
+	if ifStateReq.Drain {
+		var err error
+		if reply, err = h.drain(ifStateReq); err != nil {
+			logger.Info("[IfStateReqHandler] Unable to revoke draining interface",
+				"ifid", ifStateReq.IfID, "src", h.request.Peer, "err", err)
+			return infra.MetricsErrInvalid
+		}
+	} else {
+		reply = h.buildIfStateInfo(ifStateReq)
+	}
 	if err := rw.SendIfStateInfoReply(h.request.Context(), reply); err != nil {
 		logger.Error("[IfStateReqHandler] Failed to send reply", "err", err)
 		return infra.MetricsErrMsger(err)
@@ -74,6 +88,21 @@ func (h *handler) buildIfStateInfo(req *path_mgmt.IFStateReq) *path_mgmt.IFState
 	return &path_mgmt.IFStateInfos{Infos: infos}
 }
 
+// drain issues a revocation for the draining interface. The reply contains
+// the revocation, the interface stays active.
+func (h *handler) drain(req *path_mgmt.IFStateReq) (*path_mgmt.IFStateInfos, error) {
+	if h.drainer == nil {
+		return nil, serrors.New("draining not supported")
+	}
+	srev, err := h.drainer.Revoke(h.request.Context(), req.IfID, h.request.Peer)
+	if err != nil {
+		return nil, err
+	}
+	return &path_mgmt.IFStateInfos{
+		Infos: []*path_mgmt.IFStateInfo{{IfID: req.IfID, Active: true, SRevInfo: srev}},
+	}, nil
+}
+
 func infoFromInterface(ifid common.IFIDType, intf *Interface) *path_mgmt.IFStateInfo {
 	return &path_mgmt.IFStateInfo{
 		IfID:     ifid,
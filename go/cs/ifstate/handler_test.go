@@ -97,7 +97,7 @@ func TestHandler(t *testing.T) {
 					reply = msg
 					return nil
 				})
-			h := NewHandler(interfaces(t, topoProvider, test.expected))
+			h := NewHandler(interfaces(t, topoProvider, test.expected), nil)
 			serveCtx := infra.NewContextWithResponseWriter(context.Background(), rw)
 			req := infra.NewRequest(serveCtx, test.req, nil, nil, 0)
 			handlerRes := h.Handle(req)
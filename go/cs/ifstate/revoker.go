@@ -101,7 +101,8 @@ func (r *Revoker) Run(ctx context.Context) {
 				labelsIssued.State = metrics.RevNew
 				logger.Info("[ifstate.Revoker] interface went down", "ifid", ifid)
 			}
-			srev, err := r.createSignedRev(ifid)
+			srev, err := createSignedRev(r.cfg.TopoProvider.Get(), r.cfg.Signer, ifid,
+				r.cfg.RevConfig.RevTTL)
 			if err != nil {
 				logger.Error("[ifstate.Revoker] Failed to create revocation",
 					"ifid", ifid, "err", err)
@@ -125,7 +126,7 @@ func (r *Revoker) Run(ctx context.Context) {
 			// still continue to try to push it to BR/PS.
 		}
 		r.pushRevocationsToBRs(ctx, revs, wg)
-		r.pushRevocationsToPS(ctx, revs)
+		pushRevocationsToPS(ctx, r.cfg.Msgr, r.cfg.TopoProvider.Get(), revs)
 		wg.Wait()
 	}
 }
@@ -138,16 +139,18 @@ func (r *Revoker) hasValidRevocation(intf *Interface) bool {
 	return false
 }
 
-func (r *Revoker) createSignedRev(ifid common.IFIDType) (*path_mgmt.SignedRevInfo, error) {
+func createSignedRev(topo topology.Topology, signer infra.Signer, ifid common.IFIDType,
+	ttl time.Duration) (*path_mgmt.SignedRevInfo, error) {
+
 	now := util.TimeToSecs(time.Now())
 	revInfo := &path_mgmt.RevInfo{
 		IfID:         ifid,
-		RawIsdas:     r.cfg.TopoProvider.Get().IA().IAInt(),
-		LinkType:     proto.LinkType(r.cfg.TopoProvider.Get().IFInfoMap()[ifid].LinkType),
+		RawIsdas:     topo.IA().IAInt(),
+		LinkType:     proto.LinkType(topo.IFInfoMap()[ifid].LinkType),
 		RawTimestamp: now,
-		RawTTL:       uint32(r.cfg.RevConfig.RevTTL.Seconds()),
+		RawTTL:       uint32(ttl.Seconds()),
 	}
-	return path_mgmt.NewSignedRevInfo(revInfo, r.cfg.Signer)
+	return path_mgmt.NewSignedRevInfo(revInfo, signer)
 }
 
 func (r *Revoker) pushRevocationsToBRs(ctx context.Context,
@@ -165,15 +168,14 @@ func (r *Revoker) pushRevocationsToBRs(ctx context.Context,
 	r.pusher.sendIfStateToAllBRs(ctx, msg, r.cfg.TopoProvider.Get(), wg)
 }
 
-func (r *Revoker) pushRevocationsToPS(ctx context.Context,
+func pushRevocationsToPS(ctx context.Context, msgr infra.Messenger, topo topology.Topology,
 	revs map[common.IFIDType]*path_mgmt.SignedRevInfo) {
 
-	topo := r.cfg.TopoProvider.Get()
 	labels := metrics.SentLabels{Dst: metrics.DstPS}
 
 	a := &snet.SVCAddr{IA: topo.IA(), SVC: addr.SvcPS}
 	for ifid, srev := range revs {
-		if err := r.cfg.Msgr.SendRev(ctx, srev, a, messenger.NextId()); err != nil {
+		if err := msgr.SendRev(ctx, srev, a, messenger.NextId()); err != nil {
 			log.FromCtx(ctx).Error("[ifstate.Revoker] Failed to send revocation to PS",
 				"ifid", ifid, "err", err)
 		}
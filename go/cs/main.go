@@ -42,6 +42,7 @@ import (
 	"github.com/scionproto/scion/go/cs/keepalive"
 	"github.com/scionproto/scion/go/cs/metrics"
 	"github.com/scionproto/scion/go/cs/onehop"
+	"github.com/scionproto/scion/go/cs/reiss"
 	"github.com/scionproto/scion/go/cs/revocation"
 	"github.com/scionproto/scion/go/cs/segreq"
 	"github.com/scionproto/scion/go/cs/segsyncer"
@@ -238,19 +239,35 @@ func realMain() int {
 		log.Crit("Error loading crypto material", "err", err)
 		return 1
 	}
-	gen := trust.SignerGen{
-		IA: topo.IA(),
-		KeyRing: keyconf.LoadingRing{
-			Dir: filepath.Join(cfg.General.ConfigDir, "keys"),
-			IA:  topo.IA(),
-		},
+	keyRing := keyconf.LoadingRing{
+		Dir: filepath.Join(cfg.General.ConfigDir, "keys"),
+		IA:  topo.IA(),
+	}
+	gen := &trust.SignerGen{
+		IA:       topo.IA(),
+		KeyRing:  keyRing,
 		Provider: trustStore,
 	}
-	signer, err := gen.Signer(context.Background())
+	signer, err := reiss.NewSigner(context.Background(), gen)
 	if err != nil {
 		log.Crit("Error initializing signer", "err", err)
 		return 1
 	}
+	issuing, err := inspector.HasAttributes(context.Background(), topo.IA(),
+		infra.ASInspectorOpts{RequiredAttributes: []infra.Attribute{infra.Issuing}})
+	if err != nil {
+		log.Crit("Unable to determine whether local AS is issuing", "err", err)
+		return 1
+	}
+	var chainIssuer reiss.Issuer = reiss.RemoteIssuer{RPC: msgr, Router: trustRouter}
+	if issuing {
+		chainIssuer = reiss.ChainIssuer{
+			IA:          topo.IA(),
+			Provider:    trustStore,
+			KeyRing:     keyRing,
+			MaxValidity: cfg.Renewal.MaxValidity.Duration,
+		}
+	}
 
 	beaconStore, err := loadStore(topo.Core(), topo.IA(), cfg)
 	if err != nil {
@@ -266,7 +283,16 @@ func realMain() int {
 	msgr.AddHandler(infra.TRCRequest, trcReqHandler)
 	msgr.AddHandler(infra.Chain, trustStore.NewChainPushHandler(topo.IA()))
 	msgr.AddHandler(infra.TRC, trustStore.NewTRCPushHandler(topo.IA()))
-	msgr.AddHandler(infra.IfStateReq, ifstate.NewHandler(intfs))
+	if issuing {
+		msgr.AddHandler(infra.ChainIssueRequest, reiss.NewHandler(chainIssuer))
+	}
+	msgr.AddHandler(infra.IfStateReq, ifstate.NewHandler(intfs, &ifstate.Drainer{
+		Msgr:         msgr,
+		Signer:       signer,
+		TopoProvider: itopo.Provider(),
+		RevInserter:  beaconStore,
+		RevTTL:       cfg.BS.RevTTL.Duration,
+	}))
 	msgr.AddHandler(infra.Seg, beaconing.NewHandler(topo.IA(), intfs, beaconStore,
 		trust.NewVerifier(trustStore)))
 	msgr.AddHandler(infra.IfId, keepalive.NewHandler(topo.IA(), intfs,
@@ -339,6 +365,9 @@ func realMain() int {
 		conn:         conn.(*snet.SCIONPacketConn),
 		trustStore:   trustStore,
 		trustDB:      trustDB,
+		keyRing:      keyRing,
+		signer:       signer,
+		chainIssuer:  chainIssuer,
 		store:        beaconStore,
 		allowIsdLoop: *propPolicy.Filter.AllowIsdLoop,
 		pathDB:       pathDB,
@@ -396,6 +425,9 @@ type periodicTasks struct {
 	genMac          func() hash.Hash
 	trustStore      trust.Store
 	trustDB         trust.DB
+	keyRing         trust.KeyRing
+	signer          *reiss.Signer
+	chainIssuer     reiss.Issuer
 	store           beaconstorage.Store
 	pathDB          pathdb.PathDB
 	msgr            infra.Messenger
@@ -462,7 +494,7 @@ func (t *periodicTasks) Start() error {
 		beaconstorage.NewRevocationCleaner(t.store), 5*time.Second, 5*time.Second)
 
 	// t.corePusher = t.startCorePusher()
-	// t.reissuance = t.startReissuance(t.corePusher)
+	t.reissuance = t.startReissuance()
 
 	if itopo.Get().Core() {
 		t.segSyncers, err = segsyncer.StartAll(t.args, t.msgr)
@@ -486,16 +518,11 @@ func (t *periodicTasks) Start() error {
 }
 
 func (t *periodicTasks) startRevoker() (*periodic.Runner, error) {
-	topo := t.topoProvider.Get()
-	signer, err := t.createSigner(topo.IA())
-	if err != nil {
-		return nil, err
-	}
 	r := ifstate.RevokerConf{
 		Intfs:        t.intfs,
 		Msgr:         t.msgr,
 		RevInserter:  t.store,
-		Signer:       signer,
+		Signer:       t.signer,
 		TopoProvider: t.topoProvider,
 		RevConfig: ifstate.RevConfig{
 			RevTTL:     cfg.BS.RevTTL.Duration,
@@ -526,10 +553,6 @@ func (t *periodicTasks) startOriginator(a *net.UDPAddr) (*periodic.Runner, error
 	if !topo.Core() {
 		return nil, nil
 	}
-	signer, err := t.createSigner(topo.IA())
-	if err != nil {
-		return nil, err
-	}
 	s, err := beaconing.OriginatorConf{
 		BeaconSender: &onehop.BeaconSender{
 			Sender: onehop.Sender{
@@ -545,7 +568,7 @@ func (t *periodicTasks) startOriginator(a *net.UDPAddr) (*periodic.Runner, error
 			Intfs:         t.intfs,
 			Mac:           t.genMac(),
 			MTU:           topo.MTU(),
-			Signer:        signer,
+			Signer:        t.signer,
 			GetMaxExpTime: maxExpTimeFactory(t.store, beacon.PropPolicy),
 		},
 		Period: cfg.BS.OriginationInterval.Duration,
@@ -559,10 +582,6 @@ func (t *periodicTasks) startOriginator(a *net.UDPAddr) (*periodic.Runner, error
 
 func (t *periodicTasks) startPropagator(a *net.UDPAddr) (*periodic.Runner, error) {
 	topo := t.topoProvider.Get()
-	signer, err := t.createSigner(topo.IA())
-	if err != nil {
-		return nil, err
-	}
 	p, err := beaconing.PropagatorConf{
 		BeaconProvider: t.store,
 		AllowIsdLoop:   t.allowIsdLoop,
@@ -581,7 +600,7 @@ func (t *periodicTasks) startPropagator(a *net.UDPAddr) (*periodic.Runner, error
 			Intfs:         t.intfs,
 			Mac:           t.genMac(),
 			MTU:           topo.MTU(),
-			Signer:        signer,
+			Signer:        t.signer,
 			GetMaxExpTime: maxExpTimeFactory(t.store, beacon.PropPolicy),
 		},
 		Period: cfg.BS.PropagationInterval.Duration,
@@ -618,10 +637,6 @@ func (t *periodicTasks) startSegRegRunners() (segRegRunners, error) {
 func (t *periodicTasks) startRegistrar(topo topology.Topology, segType proto.PathSegType,
 	policyType beacon.PolicyType) (*periodic.Runner, error) {
 
-	signer, err := t.createSigner(topo.IA())
-	if err != nil {
-		return nil, err
-	}
 	r, err := beaconing.RegistrarConf{
 		Msgr:         t.msgr,
 		SegProvider:  t.store,
@@ -633,7 +648,7 @@ func (t *periodicTasks) startRegistrar(topo topology.Topology, segType proto.Pat
 			Intfs:         t.intfs,
 			Mac:           t.genMac(),
 			MTU:           topo.MTU(),
-			Signer:        signer,
+			Signer:        t.signer,
 			GetMaxExpTime: maxExpTimeFactory(t.store, policyType),
 		},
 	}.New()
@@ -644,18 +659,19 @@ func (t *periodicTasks) startRegistrar(topo topology.Topology, segType proto.Pat
 		cfg.BS.RegistrationInterval.Duration), nil
 }
 
-func (t *periodicTasks) createSigner(ia addr.IA) (infra.Signer, error) {
-	ctx, cancelF := context.WithTimeout(context.Background(), time.Second)
-	defer cancelF()
-	gen := trust.SignerGen{
-		IA:       itopo.Get().IA(),
+func (t *periodicTasks) startReissuance() *periodic.Runner {
+	r := &reiss.Requester{
+		IA:       t.args.IA,
 		Provider: t.trustStore,
-		KeyRing: keyconf.LoadingRing{
-			Dir: filepath.Join(cfg.General.ConfigDir, "keys"),
-			IA:  ia,
-		},
-	}
-	return gen.Signer(ctx)
+		Inserter: t.trustStore,
+		KeyRing:  t.keyRing,
+		Issuer:   t.chainIssuer,
+		LeadTime: cfg.Renewal.LeadTime.Duration,
+		Validity: cfg.Renewal.Validity.Duration,
+		Signer:   t.signer,
+	}
+	return periodic.Start(r, cfg.Renewal.CheckInterval.Duration,
+		cfg.Renewal.CheckInterval.Duration)
 }
 
 func (t *periodicTasks) Kill() {
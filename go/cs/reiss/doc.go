@@ -0,0 +1,28 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package reiss implements the reissuance of AS certificates.
+//
+// The control service of an issuing AS handles certificate chain issuance
+// requests with the ChainIssuer. A renewal request is verified against the
+// currently active certificate chain of the subject, and a new AS certificate
+// is signed with the issuer certificate signing key of the local AS.
+//
+// Every control service runs the Requester, which periodically checks the
+// validity of the local AS certificate. Shortly before it expires, a renewal
+// request is sent to the issuing AS, and the renewed certificate chain is
+// inserted into the trust store. Issuing ASes renew their own AS certificate
+// with the local ChainIssuer. After the renewal, the Signer switches to the
+// renewed certificate chain.
+package reiss
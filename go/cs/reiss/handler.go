@@ -0,0 +1,89 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reiss
+
+import (
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/cert_mgmt"
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/infra/messenger"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/scrypto/cert/renewal"
+	"github.com/scionproto/scion/go/proto"
+)
+
+type handler struct {
+	issuer  Issuer
+	request *infra.Request
+}
+
+// NewHandler creates a certificate chain issuance request handler. The
+// renewal requests are verified and answered by the issuer.
+func NewHandler(issuer Issuer) infra.Handler {
+	f := func(r *infra.Request) *infra.HandlerResult {
+		handler := &handler{
+			issuer:  issuer,
+			request: r,
+		}
+		return handler.Handle()
+	}
+	return infra.HandlerFunc(f)
+}
+
+func (h *handler) Handle() *infra.HandlerResult {
+	ctx := h.request.Context()
+	logger := log.FromCtx(ctx)
+	req, ok := h.request.Message.(*cert_mgmt.ChainIssReq)
+	if !ok {
+		logger.Error("[ChainIssueHandler] Wrong message type",
+			"type", common.TypeOf(h.request.Message))
+		return infra.MetricsErrInternal
+	}
+	logger.Debug("[ChainIssueHandler] Received", "req", req, "peer", h.request.Peer)
+	rw, ok := infra.ResponseWriterFromContext(ctx)
+	if !ok {
+		logger.Error("[ChainIssueHandler] No response writer")
+		return infra.MetricsErrInternal
+	}
+	sendAck := messenger.SendAckHelper(ctx, rw)
+
+	signed, err := renewal.ParseSignedRequest(req.Raw)
+	if err != nil {
+		logger.Info("[ChainIssueHandler] Unable to parse renewal request", "err", err,
+			"peer", h.request.Peer)
+		sendAck(proto.Ack_ErrCode_reject, messenger.AckRejectFailedToParse)
+		return infra.MetricsErrInvalid
+	}
+	chain, err := h.issuer.Issue(ctx, signed)
+	if err != nil {
+		logger.Info("[ChainIssueHandler] Unable to issue certificate chain", "err", err,
+			"peer", h.request.Peer)
+		sendAck(proto.Ack_ErrCode_reject, messenger.AckRejectFailedToVerify)
+		return infra.MetricsErrInvalid
+	}
+	raw, err := chain.MarshalJSON()
+	if err != nil {
+		logger.Error("[ChainIssueHandler] Unable to encode certificate chain", "err", err)
+		return infra.MetricsErrInternal
+	}
+	rep := &cert_mgmt.ChainIssRep{RawChain: raw}
+	if err := rw.SendChainIssueReply(ctx, rep); err != nil {
+		logger.Error("[ChainIssueHandler] Failed to send reply", "err", err)
+		return infra.MetricsErrMsger(err)
+	}
+	logger.Info("[ChainIssueHandler] Issued certificate chain", "chain", rep,
+		"peer", h.request.Peer)
+	return infra.MetricsResultOk
+}
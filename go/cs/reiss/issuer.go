@@ -0,0 +1,244 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reiss
+
+import (
+	"context"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/infra/modules/trust"
+	"github.com/scionproto/scion/go/lib/keyconf"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/scrypto/cert"
+	"github.com/scionproto/scion/go/lib/scrypto/cert/renewal"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/util"
+)
+
+var (
+	// ErrUnexpectedIssuer indicates that the AS certificate of the subject is
+	// not issued by the local AS, or the request is addressed to another
+	// issuer.
+	ErrUnexpectedIssuer = serrors.New("unexpected issuer")
+	// ErrNotIssuing indicates that the local AS does not hold an issuer
+	// certificate.
+	ErrNotIssuing = serrors.New("local AS is not issuing")
+	// ErrInvalidValidity indicates that the requested validity period cannot
+	// be covered by the issuer certificate.
+	ErrInvalidValidity = serrors.New("invalid validity period")
+	// ErrExpiredChain indicates that the current certificate chain of the
+	// subject is not valid at the time of the request.
+	ErrExpiredChain = serrors.New("current certificate chain not valid")
+	// ErrInactiveTRC indicates that the TRC the issuer certificate of the
+	// local AS references is not valid anymore.
+	ErrInactiveTRC = serrors.New("issuing TRC not active")
+)
+
+// Issuer issues certificate chains for renewal requests.
+type Issuer interface {
+	// Issue issues a new certificate chain for the signed renewal request.
+	Issue(ctx context.Context, req renewal.SignedRequest) (cert.Chain, error)
+}
+
+// ChainIssuer issues AS certificates with the issuer certificate signing key
+// of the local AS. Renewal requests are verified against the currently active
+// certificate chain of the subject.
+type ChainIssuer struct {
+	// IA is the ISD-AS of the local issuing AS.
+	IA addr.IA
+	// Provider provides the verified certificate chains and TRCs.
+	Provider trust.CryptoProvider
+	// KeyRing holds the issuer certificate signing key.
+	KeyRing trust.KeyRing
+	// MaxValidity is the maximum validity period of an issued AS certificate.
+	// If it is zero, the requested validity period is only limited by the
+	// issuer certificate.
+	MaxValidity time.Duration
+}
+
+// Issue verifies the renewal request, and issues a new certificate chain. The
+// current certificate chain of the subject must be valid. The encryption key
+// of the current AS certificate is carried over to the new AS certificate.
+func (i ChainIssuer) Issue(ctx context.Context, req renewal.SignedRequest) (cert.Chain, error) {
+	info, err := req.Info()
+	if err != nil {
+		return cert.Chain{}, serrors.WrapStr("unable to decode request info", err)
+	}
+	if !info.Issuer.Equal(i.IA) {
+		return cert.Chain{}, serrors.WithCtx(ErrUnexpectedIssuer, "expected", i.IA,
+			"actual", info.Issuer)
+	}
+	_, current, err := latestChain(ctx, i.Provider, info.Subject)
+	if err != nil {
+		return cert.Chain{}, serrors.WrapStr("unable to get current certificate chain", err,
+			"subject", info.Subject)
+	}
+	if !current.Issuer.IA.Equal(i.IA) {
+		return cert.Chain{}, serrors.WithCtx(ErrUnexpectedIssuer, "expected", i.IA,
+			"current", current.Issuer.IA)
+	}
+	if !current.Validity.Contains(time.Now()) {
+		return cert.Chain{}, serrors.WithCtx(ErrExpiredChain, "subject", info.Subject,
+			"validity", current.Validity)
+	}
+	verified, keys, err := renewal.RequestVerifier{AS: current}.Verify(req)
+	if err != nil {
+		return cert.Chain{}, serrors.WrapStr("unable to verify renewal request", err,
+			"subject", info.Subject)
+	}
+	info = verified
+	local, _, err := latestChain(ctx, i.Provider, i.IA)
+	if err != nil {
+		return cert.Chain{}, serrors.WrapStr("unable to get local certificate chain", err)
+	}
+	issuer, err := i.verifiedIssuer(ctx, local.Issuer)
+	if err != nil {
+		return cert.Chain{}, err
+	}
+	validity, err := i.validity(*info.Validity, *issuer.Validity)
+	if err != nil {
+		return cert.Chain{}, err
+	}
+	if enc, ok := current.Keys[cert.EncryptionKey]; ok {
+		keys[cert.EncryptionKey] = enc
+	}
+	as := &cert.AS{
+		Base: cert.Base{
+			Subject:                    info.Subject,
+			Version:                    info.Version,
+			FormatVersion:              info.FormatVersion,
+			Description:                info.Description,
+			OptionalDistributionPoints: info.OptionalDistributionPoints,
+			Validity:                   &validity,
+			Keys:                       keys,
+		},
+		Issuer: cert.IssuerCertID{
+			IA:                 i.IA,
+			CertificateVersion: issuer.Version,
+		},
+	}
+	if err := as.Validate(); err != nil {
+		return cert.Chain{}, serrors.WrapStr("invalid AS certificate", err)
+	}
+	signed, err := i.sign(as, issuer)
+	if err != nil {
+		return cert.Chain{}, err
+	}
+	v := cert.ASVerifier{Issuer: issuer, AS: as, SignedAS: &signed}
+	if err := v.Verify(); err != nil {
+		return cert.Chain{}, serrors.WrapStr("unable to verify issued AS certificate", err)
+	}
+	return cert.Chain{Issuer: local.Issuer, AS: signed}, nil
+}
+
+// verifiedIssuer decodes the issuer certificate of the local AS, and verifies
+// it against the TRC it references. The TRC must still be valid.
+func (i ChainIssuer) verifiedIssuer(ctx context.Context,
+	signed cert.SignedIssuer) (*cert.Issuer, error) {
+
+	issuer, err := signed.Encoded.Decode()
+	if err != nil {
+		return nil, serrors.WrapStr("unable to decode issuer certificate", err)
+	}
+	if !issuer.Subject.Equal(i.IA) {
+		return nil, serrors.WithCtx(ErrNotIssuing, "issuer", issuer.Subject)
+	}
+	id := trust.TRCID{ISD: i.IA.I, Version: issuer.Issuer.TRCVersion}
+	t, err := i.Provider.GetTRC(ctx, id, infra.TRCOpts{})
+	if err != nil {
+		return nil, serrors.WrapStr("unable to get issuing TRC", err,
+			"version", issuer.Issuer.TRCVersion)
+	}
+	if !t.Validity.Contains(time.Now()) {
+		return nil, serrors.WithCtx(ErrInactiveTRC, "version", t.Version,
+			"validity", t.Validity)
+	}
+	v := cert.IssuerVerifier{TRC: t, Issuer: issuer, SignedIssuer: &signed}
+	if err := v.Verify(); err != nil {
+		return nil, serrors.WrapStr("unable to verify issuer certificate", err)
+	}
+	return issuer, nil
+}
+
+// validity returns the validity period of the issued AS certificate. The
+// requested period is cut to the maximum validity and the validity of the
+// issuer certificate.
+func (i ChainIssuer) validity(requested,
+	issuer scrypto.Validity) (scrypto.Validity, error) {
+
+	v := requested
+	if i.MaxValidity != 0 {
+		if max := v.NotBefore.Add(i.MaxValidity); v.NotAfter.After(max) {
+			v.NotAfter = util.UnixTime{Time: max}
+		}
+	}
+	if v.NotAfter.After(issuer.NotAfter.Time) {
+		v.NotAfter = issuer.NotAfter
+	}
+	if !issuer.Covers(v) || v.Validate() != nil {
+		return scrypto.Validity{}, serrors.WithCtx(ErrInvalidValidity,
+			"requested", requested, "issuer", issuer)
+	}
+	return v, nil
+}
+
+func (i ChainIssuer) sign(as *cert.AS, issuer *cert.Issuer) (cert.SignedAS, error) {
+	meta := issuer.Keys[cert.IssuingKey]
+	key, err := i.KeyRing.PrivateKey(keyconf.IssCertSigningKey, meta.KeyVersion)
+	if err != nil {
+		return cert.SignedAS{}, serrors.WrapStr("unable to load issuing key", err,
+			"version", meta.KeyVersion)
+	}
+	var signed cert.SignedAS
+	if signed.Encoded, err = cert.EncodeAS(as); err != nil {
+		return cert.SignedAS{}, serrors.WrapStr("unable to encode AS certificate", err)
+	}
+	protected := cert.ProtectedAS{
+		Algorithm:          key.Algorithm,
+		IA:                 i.IA,
+		CertificateVersion: issuer.Version,
+	}
+	if signed.EncodedProtected, err = cert.EncodeProtectedAS(protected); err != nil {
+		return cert.SignedAS{}, serrors.WrapStr("unable to encode protected", err)
+	}
+	signed.Signature, err = scrypto.Sign(signed.SigInput(), key.Bytes, key.Algorithm)
+	if err != nil {
+		return cert.SignedAS{}, serrors.WrapStr("unable to sign AS certificate", err)
+	}
+	return signed, nil
+}
+
+// latestChain returns the latest certificate chain of the AS, together with
+// the decoded AS certificate.
+func latestChain(ctx context.Context, provider trust.CryptoProvider,
+	ia addr.IA) (cert.Chain, *cert.AS, error) {
+
+	id := trust.ChainID{IA: ia, Version: scrypto.LatestVer}
+	raw, err := provider.GetRawChain(ctx, id, infra.ChainOpts{})
+	if err != nil {
+		return cert.Chain{}, nil, err
+	}
+	chain, err := cert.ParseChain(raw)
+	if err != nil {
+		return cert.Chain{}, nil, serrors.WrapStr("unable to parse certificate chain", err)
+	}
+	as, err := chain.AS.Encoded.Decode()
+	if err != nil {
+		return cert.Chain{}, nil, serrors.WrapStr("unable to decode AS certificate", err)
+	}
+	return chain, as, nil
+}
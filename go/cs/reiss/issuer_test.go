@@ -0,0 +1,393 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reiss_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/cs/reiss"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/infra/modules/trust"
+	"github.com/scionproto/scion/go/lib/infra/modules/trust/mock_trust"
+	"github.com/scionproto/scion/go/lib/keyconf"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/scrypto/cert"
+	"github.com/scionproto/scion/go/lib/scrypto/cert/renewal"
+	"github.com/scionproto/scion/go/lib/scrypto/trc"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+var (
+	ia110 = xtest.MustParseIA("1-ff00:0:110")
+	ia111 = xtest.MustParseIA("1-ff00:0:111")
+)
+
+func TestChainIssuerIssue(t *testing.T) {
+	pki := newPKI(t, time.Now())
+	tests := map[string]struct {
+		Modify      func(info *renewal.RequestInfo)
+		Signer      keyconf.Key
+		MaxValidity time.Duration
+		Expected    scrypto.Validity
+		ExpectedErr error
+	}{
+		"valid": {
+			Modify:   func(*renewal.RequestInfo) {},
+			Signer:   pki.keys[ia111][keyconf.ASSigningKey],
+			Expected: pki.validity(0, 3*24*time.Hour),
+		},
+		"validity cut to maximum": {
+			Modify:      func(*renewal.RequestInfo) {},
+			Signer:      pki.keys[ia111][keyconf.ASSigningKey],
+			MaxValidity: 24 * time.Hour,
+			Expected:    pki.validity(0, 24*time.Hour),
+		},
+		"validity cut to issuer": {
+			Modify: func(info *renewal.RequestInfo) {
+				*info.Validity = pki.validity(0, 30*24*time.Hour)
+			},
+			Signer:   pki.keys[ia111][keyconf.ASSigningKey],
+			Expected: pki.validity(0, 7*24*time.Hour),
+		},
+		"validity not covered": {
+			Modify: func(info *renewal.RequestInfo) {
+				*info.Validity = pki.validity(-2*time.Hour, 24*time.Hour)
+			},
+			Signer:      pki.keys[ia111][keyconf.ASSigningKey],
+			ExpectedErr: reiss.ErrInvalidValidity,
+		},
+		"wrong issuer": {
+			Modify: func(info *renewal.RequestInfo) {
+				info.Issuer = xtest.MustParseIA("1-ff00:0:120")
+			},
+			Signer:      pki.keys[ia111][keyconf.ASSigningKey],
+			ExpectedErr: reiss.ErrUnexpectedIssuer,
+		},
+		"signed with wrong key": {
+			Modify:      func(*renewal.RequestInfo) {},
+			Signer:      pki.keys[ia111][keyconf.ASRevocationKey],
+			ExpectedErr: renewal.ErrInvalidSignature,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mctrl := gomock.NewController(t)
+			defer mctrl.Finish()
+
+			info := pki.requestInfo()
+			test.Modify(&info)
+			signing := pki.signer(t, ia111, keyconf.ASSigningKey, renewal.SigningKey)
+			revocation := pki.signer(t, ia111, keyconf.ASRevocationKey, renewal.RevocationKey)
+			outer := signing
+			outer.PrivateKey = test.Signer.Bytes
+			req, err := renewal.NewSignedRequest(&info, []renewal.Signer{signing, revocation},
+				outer)
+			require.NoError(t, err)
+
+			issuer := reiss.ChainIssuer{
+				IA:          ia110,
+				Provider:    pki.provider(mctrl),
+				KeyRing:     pki.keyRing(mctrl, ia110),
+				MaxValidity: test.MaxValidity,
+			}
+			chain, err := issuer.Issue(context.Background(), req)
+			if test.ExpectedErr != nil {
+				xtest.AssertErrorsIs(t, err, test.ExpectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, pki.chains[ia110].Issuer, chain.Issuer)
+			as, err := chain.AS.Encoded.Decode()
+			require.NoError(t, err)
+			assert.Equal(t, ia111, as.Subject)
+			assert.Equal(t, scrypto.Version(2), as.Version)
+			assert.Equal(t, test.Expected, *as.Validity)
+			current, err := pki.chains[ia111].AS.Encoded.Decode()
+			require.NoError(t, err)
+			assert.Equal(t, current.Keys, as.Keys)
+		})
+	}
+}
+
+func TestChainIssuerIssueInactive(t *testing.T) {
+	tests := map[string]struct {
+		Modify      func(pki *testPKI)
+		ExpectedErr error
+	}{
+		"expired current chain": {
+			Modify: func(pki *testPKI) {
+				as, err := pki.chains[ia111].AS.Encoded.Decode()
+				require.NoError(t, err)
+				*as.Validity = pki.validity(-2*time.Hour, -time.Hour)
+				issuer, err := pki.chains[ia110].Issuer.Encoded.Decode()
+				require.NoError(t, err)
+				pki.chains[ia111] = cert.Chain{
+					Issuer: pki.chains[ia111].Issuer,
+					AS:     signAS(t, as, issuer, pki.keys[ia110][keyconf.IssCertSigningKey]),
+				}
+			},
+			ExpectedErr: reiss.ErrExpiredChain,
+		},
+		"expired TRC": {
+			Modify: func(pki *testPKI) {
+				validity := pki.validity(-2*time.Hour, -time.Hour)
+				pki.trc.Validity = &validity
+			},
+			ExpectedErr: reiss.ErrInactiveTRC,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mctrl := gomock.NewController(t)
+			defer mctrl.Finish()
+
+			pki := newPKI(t, time.Now())
+			test.Modify(pki)
+			info := pki.requestInfo()
+			signing := pki.signer(t, ia111, keyconf.ASSigningKey, renewal.SigningKey)
+			revocation := pki.signer(t, ia111, keyconf.ASRevocationKey, renewal.RevocationKey)
+			req, err := renewal.NewSignedRequest(&info, []renewal.Signer{signing, revocation},
+				signing)
+			require.NoError(t, err)
+
+			issuer := reiss.ChainIssuer{
+				IA:       ia110,
+				Provider: pki.provider(mctrl),
+				KeyRing:  pki.keyRing(mctrl, ia110),
+			}
+			_, err = issuer.Issue(context.Background(), req)
+			xtest.AssertErrorsIs(t, err, test.ExpectedErr)
+		})
+	}
+}
+
+// testPKI holds the crypto material of an ISD with the issuing AS 110, and
+// the AS 111 that is issued by 110.
+type testPKI struct {
+	now    time.Time
+	trc    *trc.TRC
+	chains map[addr.IA]cert.Chain
+	keys   map[addr.IA]map[keyconf.Usage]keyconf.Key
+}
+
+func newPKI(t *testing.T, now time.Time) *testPKI {
+	p := &testPKI{
+		now:    now.Truncate(time.Second),
+		chains: make(map[addr.IA]cert.Chain),
+		keys:   make(map[addr.IA]map[keyconf.Usage]keyconf.Key),
+	}
+	for _, ia := range []addr.IA{ia110, ia111} {
+		p.keys[ia] = make(map[keyconf.Usage]keyconf.Key)
+		for _, usage := range []keyconf.Usage{keyconf.ASSigningKey, keyconf.ASRevocationKey,
+			keyconf.IssCertSigningKey, keyconf.TRCIssuingGrantKey} {
+			p.keys[ia][usage] = newKey(t, ia, usage, 1)
+		}
+	}
+	grant := p.keys[ia110][keyconf.TRCIssuingGrantKey]
+	validity := p.validity(-time.Hour, 30*24*time.Hour)
+	p.trc = &trc.TRC{
+		ISD:      1,
+		Version:  1,
+		Validity: &validity,
+		PrimaryASes: trc.PrimaryASes{
+			ia110.A: trc.PrimaryAS{
+				Attributes: trc.Attributes{trc.Issuing},
+				Keys: map[trc.KeyType]scrypto.KeyMeta{
+					trc.IssuingGrantKey: pubKeyMeta(t, grant),
+				},
+			},
+		},
+	}
+	issuerValidity := p.validity(-time.Hour, 7*24*time.Hour)
+	issuer := &cert.Issuer{
+		Base: cert.Base{
+			Subject:                    ia110,
+			Version:                    1,
+			FormatVersion:              1,
+			Description:                "issuer certificate",
+			OptionalDistributionPoints: []addr.IA{},
+			Validity:                   &issuerValidity,
+			Keys: map[cert.KeyType]scrypto.KeyMeta{
+				cert.IssuingKey: pubKeyMeta(t, p.keys[ia110][keyconf.IssCertSigningKey]),
+			},
+		},
+		Issuer: cert.IssuerTRC{TRCVersion: 1},
+	}
+	var err error
+	var signedIssuer cert.SignedIssuer
+	signedIssuer.Encoded, err = cert.EncodeIssuer(issuer)
+	require.NoError(t, err)
+	signedIssuer.EncodedProtected, err = cert.EncodeProtectedIssuer(cert.ProtectedIssuer{
+		Algorithm:  grant.Algorithm,
+		TRCVersion: 1,
+	})
+	require.NoError(t, err)
+	signedIssuer.Signature, err = scrypto.Sign(signedIssuer.SigInput(), grant.Bytes,
+		grant.Algorithm)
+	require.NoError(t, err)
+
+	for _, ia := range []addr.IA{ia110, ia111} {
+		asValidity := p.validity(-time.Hour, time.Hour)
+		as := &cert.AS{
+			Base: cert.Base{
+				Subject:                    ia,
+				Version:                    1,
+				FormatVersion:              1,
+				Description:                "AS certificate",
+				OptionalDistributionPoints: []addr.IA{},
+				Validity:                   &asValidity,
+				Keys: map[cert.KeyType]scrypto.KeyMeta{
+					cert.SigningKey:    pubKeyMeta(t, p.keys[ia][keyconf.ASSigningKey]),
+					cert.RevocationKey: pubKeyMeta(t, p.keys[ia][keyconf.ASRevocationKey]),
+					cert.EncryptionKey: {
+						KeyVersion: 1,
+						Algorithm:  scrypto.Curve25519xSalsa20Poly1305,
+						Key:        []byte("encryption key"),
+					},
+				},
+			},
+			Issuer: cert.IssuerCertID{IA: ia110, CertificateVersion: 1},
+		}
+		p.chains[ia] = cert.Chain{Issuer: signedIssuer, AS: signAS(t, as, issuer,
+			p.keys[ia110][keyconf.IssCertSigningKey])}
+	}
+	return p
+}
+
+func (p *testPKI) validity(notBefore, notAfter time.Duration) scrypto.Validity {
+	return scrypto.Validity{
+		NotBefore: util.UnixTime{Time: p.now.Add(notBefore)},
+		NotAfter:  util.UnixTime{Time: p.now.Add(notAfter)},
+	}
+}
+
+func (p *testPKI) requestInfo() renewal.RequestInfo {
+	validity := p.validity(0, 3*24*time.Hour)
+	return renewal.RequestInfo{
+		Subject:                    ia111,
+		Version:                    2,
+		FormatVersion:              1,
+		Description:                "AS certificate",
+		OptionalDistributionPoints: []addr.IA{},
+		Validity:                   &validity,
+		Keys: renewal.Keys{
+			Signing:    renewal.KeyMeta{Key: p.pubKey(ia111, keyconf.ASSigningKey)},
+			Revocation: renewal.KeyMeta{Key: p.pubKey(ia111, keyconf.ASRevocationKey)},
+		},
+		Issuer:      ia110,
+		RequestTime: util.UnixTime{Time: p.now},
+	}
+}
+
+func (p *testPKI) pubKey(ia addr.IA, usage keyconf.Usage) []byte {
+	key := p.keys[ia][usage]
+	pub, err := scrypto.GetPubKey(key.Bytes, key.Algorithm)
+	if err != nil {
+		panic(err)
+	}
+	return pub
+}
+
+func (p *testPKI) signer(t *testing.T, ia addr.IA, usage keyconf.Usage,
+	keyType renewal.KeyType) renewal.Signer {
+
+	key := p.keys[ia][usage]
+	return renewal.Signer{
+		Type:       keyType,
+		KeyVersion: key.Version,
+		Algorithm:  key.Algorithm,
+		PrivateKey: key.Bytes,
+	}
+}
+
+func (p *testPKI) provider(mctrl *gomock.Controller) *mock_trust.MockCryptoProvider {
+	provider := mock_trust.NewMockCryptoProvider(mctrl)
+	provider.EXPECT().GetRawChain(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, id trust.ChainID, _ interface{}) ([]byte, error) {
+			chain, ok := p.chains[id.IA]
+			if !ok {
+				return nil, trust.ErrNotFound
+			}
+			return chain.MarshalJSON()
+		},
+	).AnyTimes()
+	provider.EXPECT().GetTRC(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+		p.trc, nil).AnyTimes()
+	return provider
+}
+
+func (p *testPKI) keyRing(mctrl *gomock.Controller, ia addr.IA) *mock_trust.MockKeyRing {
+	ring := mock_trust.NewMockKeyRing(mctrl)
+	ring.EXPECT().PrivateKey(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(usage keyconf.Usage, version scrypto.KeyVersion) (keyconf.Key, error) {
+			key, ok := p.keys[ia][usage]
+			if !ok || key.Version != version {
+				return keyconf.Key{}, trust.ErrNotFound
+			}
+			return key, nil
+		},
+	).AnyTimes()
+	return ring
+}
+
+func newKey(t *testing.T, ia addr.IA, usage keyconf.Usage,
+	version scrypto.KeyVersion) keyconf.Key {
+
+	_, priv, err := scrypto.GenKeyPair(scrypto.Ed25519)
+	require.NoError(t, err)
+	return keyconf.Key{
+		ID: keyconf.ID{
+			Usage:   usage,
+			IA:      ia,
+			Version: version,
+		},
+		Type:      keyconf.PrivateKey,
+		Algorithm: scrypto.Ed25519,
+		Bytes:     priv,
+	}
+}
+
+func pubKeyMeta(t *testing.T, key keyconf.Key) scrypto.KeyMeta {
+	pub, err := scrypto.GetPubKey(key.Bytes, key.Algorithm)
+	require.NoError(t, err)
+	return scrypto.KeyMeta{
+		KeyVersion: key.Version,
+		Algorithm:  key.Algorithm,
+		Key:        pub,
+	}
+}
+
+func signAS(t *testing.T, as *cert.AS, issuer *cert.Issuer, key keyconf.Key) cert.SignedAS {
+	var err error
+	var signed cert.SignedAS
+	signed.Encoded, err = cert.EncodeAS(as)
+	require.NoError(t, err)
+	signed.EncodedProtected, err = cert.EncodeProtectedAS(cert.ProtectedAS{
+		Algorithm:          key.Algorithm,
+		CertificateVersion: issuer.Version,
+		IA:                 issuer.Subject,
+	})
+	require.NoError(t, err)
+	signed.Signature, err = scrypto.Sign(signed.SigInput(), key.Bytes, key.Algorithm)
+	require.NoError(t, err)
+	return signed
+}
@@ -0,0 +1,276 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reiss
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/ctrl/cert_mgmt"
+	"github.com/scionproto/scion/go/lib/infra/messenger"
+	"github.com/scionproto/scion/go/lib/infra/modules/trust"
+	"github.com/scionproto/scion/go/lib/keyconf"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/scrypto/cert"
+	"github.com/scionproto/scion/go/lib/scrypto/cert/renewal"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/util"
+)
+
+// ErrInvalidReply indicates that the issued certificate chain does not match
+// the renewal request.
+var ErrInvalidReply = serrors.New("issued chain does not match request")
+
+// RPC sends certificate chain issuance requests.
+type RPC interface {
+	RequestChainIssue(ctx context.Context, msg *cert_mgmt.ChainIssReq, a net.Addr,
+		id uint64) (*cert_mgmt.ChainIssRep, error)
+}
+
+// RemoteIssuer requests certificate chains from the control service of the
+// issuing AS.
+type RemoteIssuer struct {
+	RPC    RPC
+	Router snet.Router
+}
+
+// Issue sends the renewal request to the issuer it is addressed to, and
+// returns the issued certificate chain.
+func (i RemoteIssuer) Issue(ctx context.Context, req renewal.SignedRequest) (cert.Chain, error) {
+	info, err := req.Info()
+	if err != nil {
+		return cert.Chain{}, serrors.WrapStr("unable to decode request info", err)
+	}
+	path, err := i.Router.Route(ctx, info.Issuer)
+	if err != nil {
+		return cert.Chain{}, serrors.WrapStr("unable to find path to issuer", err,
+			"issuer", info.Issuer)
+	}
+	a := &snet.SVCAddr{
+		IA:      info.Issuer,
+		Path:    path.Path(),
+		NextHop: path.UnderlayNextHop(),
+		SVC:     addr.SvcCS,
+	}
+	raw, err := json.Marshal(req)
+	if err != nil {
+		return cert.Chain{}, serrors.WrapStr("unable to encode renewal request", err)
+	}
+	rep, err := i.RPC.RequestChainIssue(ctx, &cert_mgmt.ChainIssReq{Raw: raw}, a,
+		messenger.NextId())
+	if err != nil {
+		return cert.Chain{}, serrors.WrapStr("unable to request certificate chain", err,
+			"issuer", a)
+	}
+	chain, err := cert.ParseChain(rep.RawChain)
+	if err != nil {
+		return cert.Chain{}, serrors.WrapStr("unable to parse certificate chain", err)
+	}
+	return chain, nil
+}
+
+// ChainInserter verifies certificate chains and inserts them into the trust
+// store.
+type ChainInserter interface {
+	InsertRawChain(ctx context.Context, raw []byte) error
+}
+
+// Requester renews the AS certificate of the local AS before it expires. The
+// keys of the renewed certificate are taken from the key ring. If the key ring
+// holds a key with the next key version, the key is rolled over. Otherwise,
+// the current key is kept.
+type Requester struct {
+	// IA is the ISD-AS of the local AS.
+	IA addr.IA
+	// Provider provides the current certificate chain of the local AS.
+	Provider trust.CryptoProvider
+	// Inserter inserts the renewed certificate chain.
+	Inserter ChainInserter
+	// KeyRing holds the private AS keys.
+	KeyRing trust.KeyRing
+	// Issuer issues the renewed certificate chain.
+	Issuer Issuer
+	// LeadTime is the time before the expiry of the current AS certificate at
+	// which the renewal starts.
+	LeadTime time.Duration
+	// Validity is the requested validity period of the renewed certificate.
+	Validity time.Duration
+	// Signer is updated after the renewed certificate chain is inserted. It
+	// can be nil.
+	Signer *Signer
+}
+
+// Name returns the tasks name.
+func (r *Requester) Name() string {
+	return "cs_reiss_requester"
+}
+
+// Run renews the AS certificate, if it expires within the lead time.
+func (r *Requester) Run(ctx context.Context) {
+	if err := r.run(ctx); err != nil {
+		log.FromCtx(ctx).Error("[reiss.Requester] Unable to renew certificate chain",
+			"err", err)
+	}
+}
+
+func (r *Requester) run(ctx context.Context) error {
+	logger := log.FromCtx(ctx)
+	_, current, err := latestChain(ctx, r.Provider, r.IA)
+	if err != nil {
+		return serrors.WrapStr("unable to get current certificate chain", err)
+	}
+	now := time.Now()
+	if current.Validity.NotAfter.Sub(now) > r.LeadTime {
+		return nil
+	}
+	logger.Info("[reiss.Requester] Requesting renewed certificate chain",
+		"version", current.Version, "expiration", current.Validity.NotAfter,
+		"issuer", current.Issuer.IA)
+	info, req, err := r.createRequest(current, now)
+	if err != nil {
+		return err
+	}
+	chain, err := r.Issuer.Issue(ctx, req)
+	if err != nil {
+		return err
+	}
+	if err := checkChain(chain, info); err != nil {
+		return err
+	}
+	raw, err := chain.MarshalJSON()
+	if err != nil {
+		return serrors.WrapStr("unable to encode certificate chain", err)
+	}
+	if err := r.Inserter.InsertRawChain(ctx, raw); err != nil {
+		return serrors.WrapStr("unable to insert certificate chain", err)
+	}
+	logger.Info("[reiss.Requester] Inserted renewed certificate chain",
+		"version", info.Version)
+	if r.Signer == nil {
+		return nil
+	}
+	if err := r.Signer.Update(ctx); err != nil {
+		return serrors.WrapStr("unable to update signer", err)
+	}
+	return nil
+}
+
+func (r *Requester) createRequest(current *cert.AS,
+	now time.Time) (renewal.RequestInfo, renewal.SignedRequest, error) {
+
+	signer, err := r.signer(keyconf.ASSigningKey, renewal.SigningKey,
+		current.Keys[cert.SigningKey].KeyVersion)
+	if err != nil {
+		return renewal.RequestInfo{}, renewal.SignedRequest{}, err
+	}
+	pops := []renewal.Signer{r.nextSigner(keyconf.ASSigningKey, renewal.SigningKey, signer)}
+	if meta, ok := current.Keys[cert.RevocationKey]; ok {
+		revocation, err := r.signer(keyconf.ASRevocationKey, renewal.RevocationKey,
+			meta.KeyVersion)
+		if err != nil {
+			return renewal.RequestInfo{}, renewal.SignedRequest{}, err
+		}
+		pops = append(pops, r.nextSigner(keyconf.ASRevocationKey, renewal.RevocationKey,
+			revocation))
+	}
+	now = now.Truncate(time.Second)
+	info := renewal.RequestInfo{
+		Subject:                    r.IA,
+		Version:                    current.Version + 1,
+		FormatVersion:              current.FormatVersion,
+		Description:                current.Description,
+		OptionalDistributionPoints: current.OptionalDistributionPoints,
+		Validity: &scrypto.Validity{
+			NotBefore: util.UnixTime{Time: now},
+			NotAfter:  util.UnixTime{Time: now.Add(r.Validity)},
+		},
+		Issuer:      current.Issuer.IA,
+		RequestTime: util.UnixTime{Time: now},
+	}
+	for _, s := range pops {
+		pub, err := scrypto.GetPubKey(s.PrivateKey, s.Algorithm)
+		if err != nil {
+			return renewal.RequestInfo{}, renewal.SignedRequest{},
+				serrors.WrapStr("unable to compute public key", err, "key_type", s.Type)
+		}
+		switch s.Type {
+		case renewal.SigningKey:
+			info.Keys.Signing = renewal.KeyMeta{Key: pub}
+		case renewal.RevocationKey:
+			info.Keys.Revocation = renewal.KeyMeta{Key: pub}
+		}
+	}
+	req, err := renewal.NewSignedRequest(&info, pops, signer)
+	if err != nil {
+		return renewal.RequestInfo{}, renewal.SignedRequest{}, err
+	}
+	return info, req, nil
+}
+
+func (r *Requester) signer(usage keyconf.Usage, keyType renewal.KeyType,
+	version scrypto.KeyVersion) (renewal.Signer, error) {
+
+	key, err := r.KeyRing.PrivateKey(usage, version)
+	if err != nil {
+		return renewal.Signer{}, serrors.WrapStr("private key not found", err,
+			"usage", usage, "version", version)
+	}
+	s := renewal.Signer{
+		Type:       keyType,
+		KeyVersion: key.Version,
+		Algorithm:  key.Algorithm,
+		PrivateKey: key.Bytes,
+	}
+	return s, nil
+}
+
+// nextSigner returns the signer for the next key version, if the key ring
+// holds it. Otherwise, the current signer is returned.
+func (r *Requester) nextSigner(usage keyconf.Usage, keyType renewal.KeyType,
+	current renewal.Signer) renewal.Signer {
+
+	next, err := r.signer(usage, keyType, current.KeyVersion+1)
+	if err != nil {
+		return current
+	}
+	return next
+}
+
+// checkChain checks that the issued certificate chain authenticates the
+// requested keys for the requested version. The signatures are verified when
+// the chain is inserted into the trust store.
+func checkChain(chain cert.Chain, info renewal.RequestInfo) error {
+	as, err := chain.AS.Encoded.Decode()
+	if err != nil {
+		return serrors.WrapStr("unable to decode issued AS certificate", err)
+	}
+	switch {
+	case !as.Subject.Equal(info.Subject):
+		return serrors.WithCtx(ErrInvalidReply, "subject", as.Subject)
+	case as.Version != info.Version:
+		return serrors.WithCtx(ErrInvalidReply, "version", as.Version)
+	case !bytes.Equal(as.Keys[cert.SigningKey].Key, info.Keys.Signing.Key):
+		return serrors.WithCtx(ErrInvalidReply, "key_type", cert.SigningKey)
+	case !bytes.Equal(as.Keys[cert.RevocationKey].Key, info.Keys.Revocation.Key):
+		return serrors.WithCtx(ErrInvalidReply, "key_type", cert.RevocationKey)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reiss_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/cs/reiss"
+	"github.com/scionproto/scion/go/lib/infra/modules/trust"
+	"github.com/scionproto/scion/go/lib/keyconf"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/scrypto/cert"
+)
+
+func TestRequesterRun(t *testing.T) {
+	tests := map[string]struct {
+		LeadTime     time.Duration
+		NextKeys     bool
+		ExpectRenew  bool
+		ExpectRolled bool
+	}{
+		"not due": {
+			LeadTime: 30 * time.Minute,
+		},
+		"due": {
+			LeadTime:    2 * time.Hour,
+			ExpectRenew: true,
+		},
+		"due with key rollover": {
+			LeadTime:     2 * time.Hour,
+			NextKeys:     true,
+			ExpectRenew:  true,
+			ExpectRolled: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mctrl := gomock.NewController(t)
+			defer mctrl.Finish()
+
+			pki := newPKI(t, time.Now())
+			ring := keyRing{
+				pki.keys[ia111][keyconf.ASSigningKey],
+				pki.keys[ia111][keyconf.ASRevocationKey],
+			}
+			if test.NextKeys {
+				ring = append(ring,
+					newKey(t, ia111, keyconf.ASSigningKey, 2),
+					newKey(t, ia111, keyconf.ASRevocationKey, 2),
+				)
+			}
+			inserter := &chainInserter{}
+			r := reiss.Requester{
+				IA:       ia111,
+				Provider: pki.provider(mctrl),
+				Inserter: inserter,
+				KeyRing:  ring,
+				Issuer: reiss.ChainIssuer{
+					IA:       ia110,
+					Provider: pki.provider(mctrl),
+					KeyRing:  pki.keyRing(mctrl, ia110),
+				},
+				LeadTime: test.LeadTime,
+				Validity: 3 * 24 * time.Hour,
+			}
+			r.Run(context.Background())
+			if !test.ExpectRenew {
+				assert.Empty(t, inserter.raw)
+				return
+			}
+			require.Len(t, inserter.raw, 1)
+			chain, err := cert.ParseChain(inserter.raw[0])
+			require.NoError(t, err)
+			as, err := chain.AS.Encoded.Decode()
+			require.NoError(t, err)
+			assert.Equal(t, ia111, as.Subject)
+			assert.Equal(t, scrypto.Version(2), as.Version)
+
+			expectedVersion := scrypto.KeyVersion(1)
+			if test.ExpectRolled {
+				expectedVersion = 2
+			}
+			for keyType, usage := range map[cert.KeyType]keyconf.Usage{
+				cert.SigningKey:    keyconf.ASSigningKey,
+				cert.RevocationKey: keyconf.ASRevocationKey,
+			} {
+				key, err := ring.PrivateKey(usage, expectedVersion)
+				require.NoError(t, err)
+				assert.Equal(t, pubKeyMeta(t, key).Key, as.Keys[keyType].Key, keyType)
+			}
+		})
+	}
+}
+
+type chainInserter struct {
+	raw [][]byte
+}
+
+func (i *chainInserter) InsertRawChain(_ context.Context, raw []byte) error {
+	i.raw = append(i.raw, raw)
+	return nil
+}
+
+type keyRing []keyconf.Key
+
+func (r keyRing) PrivateKey(usage keyconf.Usage, version scrypto.KeyVersion) (keyconf.Key,
+	error) {
+
+	for _, key := range r {
+		if key.Usage == usage && key.Version == version {
+			return key, nil
+		}
+	}
+	return keyconf.Key{}, trust.ErrNotFound
+}
@@ -0,0 +1,75 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reiss
+
+import (
+	"context"
+	"sync"
+
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/infra/modules/trust"
+	"github.com/scionproto/scion/go/proto"
+)
+
+var _ infra.Signer = (*Signer)(nil)
+
+// SignerGen generates signers based on the latest certificate chain.
+type SignerGen interface {
+	Signer(ctx context.Context) (*trust.Signer, error)
+}
+
+// Signer signs with the latest certificate chain of the local AS. It is
+// updated after the certificate chain has been renewed, such that the control
+// service does not need to be restarted.
+type Signer struct {
+	gen    SignerGen
+	mtx    sync.RWMutex
+	signer infra.Signer
+}
+
+// NewSigner creates a signer based on the signer generated by gen.
+func NewSigner(ctx context.Context, gen SignerGen) (*Signer, error) {
+	s := &Signer{gen: gen}
+	if err := s.Update(ctx); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Update generates a new signer based on the latest certificate chain.
+func (s *Signer) Update(ctx context.Context) error {
+	signer, err := s.gen.Signer(ctx)
+	if err != nil {
+		return err
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.signer = signer
+	return nil
+}
+
+// Sign signs the message.
+func (s *Signer) Sign(msg []byte) (*proto.SignS, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	return s.signer.Sign(msg)
+}
+
+// Meta returns the meta data the signer uses when signing.
+func (s *Signer) Meta() infra.SignerMeta {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	return s.signer.Meta()
+}
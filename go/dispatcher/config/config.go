@@ -51,6 +51,10 @@ type Dispatcher struct {
 	// DeleteSocket specifies whether the dispatcher should delete the
 	// socket file prior to attempting to create a new one.
 	DeleteSocket bool `toml:"delete_socket,omitempty"`
+	// PolicyFile is the JSON file that defines which applications are allowed
+	// to register which addresses. If it is empty, all registrations are
+	// allowed. (default "")
+	PolicyFile string `toml:"policy_file,omitempty"`
 }
 
 func (cfg *Config) InitDefaults() {
@@ -54,4 +54,5 @@ func CheckTestConfig(t *testing.T, cfg *Config, id string) {
 	assert.Equal(t, reliable.DefaultDispSocketFileMode, int(cfg.Dispatcher.SocketFileMode))
 	assert.Equal(t, topology.EndhostPort, cfg.Dispatcher.UnderlayPort)
 	assert.False(t, cfg.Dispatcher.DeleteSocket)
+	assert.Empty(t, cfg.Dispatcher.PolicyFile)
 }
@@ -32,4 +32,9 @@ underlay_port = 30041
 
 # Remove the socket file (if it exists) on start. (default false)
 delete_socket = false
+
+# The JSON file that maps the UIDs and GIDs of applications to the ports and
+# SVC addresses they are allowed to register, and to the number of packets and
+# bytes buffered for them. (default "", all registrations are allowed)
+policy_file = ""
 `
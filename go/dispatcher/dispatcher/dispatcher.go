@@ -6,6 +6,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/scionproto/scion/go/dispatcher/internal/apppolicy"
 	"github.com/scionproto/scion/go/dispatcher/internal/metrics"
 	"github.com/scionproto/scion/go/dispatcher/internal/registration"
 	"github.com/scionproto/scion/go/dispatcher/internal/respool"
@@ -87,11 +88,28 @@ func (as *Server) Serve() error {
 	return <-errChan
 }
 
-// Register creates a new connection.
+// Register creates a new connection with the default limits.
 func (as *Server) Register(ctx context.Context, ia addr.IA, address *net.UDPAddr,
 	svc addr.HostSVC) (net.PacketConn, uint16, error) {
 
-	tableEntry := newTableEntry()
+	return as.RegisterWithOptions(ctx, ia, address, svc,
+		RegOptions{Limits: apppolicy.DefaultLimits})
+}
+
+// RegOptions are the options of a registration.
+type RegOptions struct {
+	// Limits determine how many packets and bytes are buffered for the
+	// connection.
+	Limits apppolicy.Limits
+	// Owner are the credentials of the application, nil if unknown.
+	Owner *apppolicy.Credentials
+}
+
+// RegisterWithOptions creates a new connection with the options.
+func (as *Server) RegisterWithOptions(ctx context.Context, ia addr.IA, address *net.UDPAddr,
+	svc addr.HostSVC, opts RegOptions) (net.PacketConn, uint16, error) {
+
+	tableEntry := newTableEntry(opts.Limits, opts.Owner)
 	ref, err := as.routingTable.Register(ia, address, nil, svc, tableEntry)
 	if err != nil {
 		return nil, 0, err
@@ -104,7 +122,7 @@ func (as *Server) Register(ctx context.Context, ia addr.IA, address *net.UDPAddr
 	}
 	conn := &Conn{
 		conn:         ovConn,
-		ring:         tableEntry.appIngressRing,
+		entry:        tableEntry,
 		regReference: ref,
 	}
 	return conn, uint16(ref.UDPAddr().Port), nil
@@ -119,8 +137,8 @@ func (as *Server) Close() {
 type Conn struct {
 	// conn is used to send packets.
 	conn net.PacketConn
-	// ring is used to retrieve incoming packets.
-	ring *ringbuf.Ring
+	// entry is used to retrieve incoming packets.
+	entry *TableEntry
 	// regReference is the reference to the registration in the routing table.
 	regReference registration.RegReference
 }
@@ -156,18 +174,19 @@ func (ac *Conn) ReadFrom(p []byte) (n int, addr net.Addr, err error) {
 // Read is optimized for the use by ConnHandler (avoids one copy).
 func (ac *Conn) Read() *respool.Packet {
 	entries := make(ringbuf.EntryList, 1)
-	n, _ := ac.ring.Read(entries, true)
+	n, _ := ac.entry.appIngressRing.Read(entries, true)
 	if n < 0 {
 		// Ring was closed because app shut down its data socket.
 		return nil
 	}
 	pkt := entries[0].(*respool.Packet)
+	ac.entry.release(pkt.Len())
 	return pkt
 }
 
 func (ac *Conn) Close() error {
 	ac.regReference.Free()
-	ac.ring.Close()
+	ac.entry.appIngressRing.Close()
 	return nil
 }
 
@@ -0,0 +1,99 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dispatcher
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync/atomic"
+
+	"github.com/scionproto/scion/go/dispatcher/internal/apppolicy"
+	"github.com/scionproto/scion/go/lib/addr"
+)
+
+// RegistrationInfo describes a registration of an application and the packets
+// delivered to it.
+type RegistrationInfo struct {
+	IA     addr.IA
+	Public string
+	// SVC is the SVC address, if any.
+	SVC string `json:",omitempty"`
+	// SCMPIDs are the registered SCMP General IDs.
+	SCMPIDs []uint64 `json:",omitempty"`
+	// Owner are the credentials of the application, if known.
+	Owner *apppolicy.Credentials `json:",omitempty"`
+	// RingSize is the capacity of the ring, and ByteQuota the maximum number of
+	// bytes buffered in it (0 if not limited).
+	RingSize  int
+	ByteQuota int64
+	// Queued and QueuedBytes describe the packets currently in the ring.
+	Queued      int
+	QueuedBytes int64
+	// Delivered is the number of packets put on the ring.
+	Delivered uint64
+	// DroppedRingFull is the number of packets dropped because the ring was
+	// full.
+	DroppedRingFull uint64
+	// DroppedQuota is the number of packets dropped because the byte quota was
+	// exceeded.
+	DroppedQuota uint64
+}
+
+// Registrations returns all registrations, sorted by IA and public address.
+func (as *Server) Registrations() []RegistrationInfo {
+	regs := as.routingTable.Registrations()
+	infos := make([]RegistrationInfo, 0, len(regs))
+	for _, reg := range regs {
+		entry := reg.Value.(*TableEntry)
+		info := RegistrationInfo{
+			IA:              reg.IA,
+			Public:          reg.Public.String(),
+			SCMPIDs:         reg.IDs,
+			Owner:           entry.owner,
+			RingSize:        entry.appIngressRing.Cap(),
+			ByteQuota:       entry.byteQuota,
+			Queued:          entry.appIngressRing.Len(),
+			QueuedBytes:     atomic.LoadInt64(&entry.queuedBytes),
+			Delivered:       atomic.LoadUint64(&entry.delivered),
+			DroppedRingFull: atomic.LoadUint64(&entry.droppedRingFull),
+			DroppedQuota:    atomic.LoadUint64(&entry.droppedQuota),
+		}
+		if reg.SVC != addr.SvcNone {
+			info.SVC = reg.SVC.String()
+		}
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		if infos[i].IA != infos[j].IA {
+			return infos[i].IA.IAInt() < infos[j].IA.IAInt()
+		}
+		return infos[i].Public < infos[j].Public
+	})
+	return infos
+}
+
+// RegistrationsHandler serves the registrations as JSON.
+func (as *Server) RegistrationsHandler(w http.ResponseWriter, r *http.Request) {
+	raw, err := json.MarshalIndent(as.Registrations(), "", "    ")
+	if err != nil {
+		http.Error(w, fmt.Sprintf("unable to encode registrations: %s", err),
+			http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprint(w, string(raw)+"\n")
+}
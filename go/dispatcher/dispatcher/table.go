@@ -16,7 +16,9 @@ package dispatcher
 
 import (
 	"net"
+	"sync/atomic"
 
+	"github.com/scionproto/scion/go/dispatcher/internal/apppolicy"
 	"github.com/scionproto/scion/go/dispatcher/internal/registration"
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/ringbuf"
@@ -24,16 +26,50 @@ import (
 
 type TableEntry struct {
 	appIngressRing *ringbuf.Ring
+	// byteQuota is the maximum number of bytes buffered in the ring, zero
+	// means no limit.
+	byteQuota int64
+	// owner are the credentials of the application, nil if unknown.
+	owner *apppolicy.Credentials
+	// The following fields must be accessed atomically.
+	// queuedBytes is the number of bytes buffered in the ring.
+	queuedBytes int64
+	// delivered is the number of packets put on the ring.
+	delivered uint64
+	// droppedRingFull is the number of packets dropped because the ring was
+	// full.
+	droppedRingFull uint64
+	// droppedQuota is the number of packets dropped because the byte quota
+	// was exceeded.
+	droppedQuota uint64
 }
 
-func newTableEntry() *TableEntry {
+func newTableEntry(limits apppolicy.Limits, owner *apppolicy.Credentials) *TableEntry {
 	// Construct application ingress ring buffer
-	appIngressRing := ringbuf.New(128, nil, "net_to_app_ring")
+	appIngressRing := ringbuf.New(limits.RingSize, nil, "net_to_app_ring")
 	return &TableEntry{
 		appIngressRing: appIngressRing,
+		byteQuota:      int64(limits.ByteQuota),
+		owner:          owner,
 	}
 }
 
+// reserve accounts for n bytes being added to the ring. It returns false if
+// this exceeds the byte quota.
+func (e *TableEntry) reserve(n int) bool {
+	queued := atomic.AddInt64(&e.queuedBytes, int64(n))
+	if e.byteQuota != 0 && queued > e.byteQuota {
+		atomic.AddInt64(&e.queuedBytes, -int64(n))
+		return false
+	}
+	return true
+}
+
+// release accounts for n bytes being removed from the ring.
+func (e *TableEntry) release(n int) {
+	atomic.AddInt64(&e.queuedBytes, -int64(n))
+}
+
 // IATable is a type-safe convenience wrapper around a generic routing table.
 type IATable struct {
 	registration.IATable
@@ -0,0 +1,101 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dispatcher
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/dispatcher/internal/apppolicy"
+	"github.com/scionproto/scion/go/dispatcher/internal/respool"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestTableEntryLimits(t *testing.T) {
+	pktLen := respool.GetPacket().Len()
+	t.Run("ring size", func(t *testing.T) {
+		entry := newTableEntry(apppolicy.Limits{RingSize: 2}, nil)
+		for i := 0; i < 3; i++ {
+			sendPacket(entry, respool.GetPacket())
+		}
+		assert.Equal(t, 2, entry.appIngressRing.Len())
+		assert.Equal(t, int64(2*pktLen), entry.queuedBytes)
+	})
+	t.Run("byte quota", func(t *testing.T) {
+		entry := newTableEntry(apppolicy.Limits{RingSize: 8, ByteQuota: 2 * pktLen}, nil)
+		conn := &Conn{entry: entry}
+		for i := 0; i < 3; i++ {
+			sendPacket(entry, respool.GetPacket())
+		}
+		assert.Equal(t, 2, entry.appIngressRing.Len())
+		// Reading a packet frees up the quota.
+		conn.Read().Free()
+		assert.Equal(t, int64(pktLen), entry.queuedBytes)
+		sendPacket(entry, respool.GetPacket())
+		assert.Equal(t, 2, entry.appIngressRing.Len())
+		assert.Equal(t, int64(2*pktLen), entry.queuedBytes)
+	})
+}
+
+func TestServerRegistrations(t *testing.T) {
+	server := &Server{routingTable: NewIATable(1024, 65535)}
+	ia := xtest.MustParseIA("1-ff00:0:110")
+	owner := &apppolicy.Credentials{PID: 42, UID: 1000, GID: 1000}
+	conn, port, err := server.RegisterWithOptions(context.Background(), ia,
+		&net.UDPAddr{IP: net.IP{192, 0, 2, 1}, Port: 40000}, addr.SvcCS,
+		RegOptions{Limits: apppolicy.Limits{RingSize: 1}, Owner: owner})
+	require.NoError(t, err)
+	require.Equal(t, uint16(40000), port)
+	require.NoError(t, conn.(*Conn).regReference.RegisterID(7))
+	_, _, err = server.Register(context.Background(), ia,
+		&net.UDPAddr{IP: net.IP{192, 0, 2, 1}, Port: 30000}, addr.SvcNone)
+	require.NoError(t, err)
+
+	entry, ok := server.routingTable.LookupPublic(ia, &net.UDPAddr{IP: net.IP{192, 0, 2, 1},
+		Port: 40000})
+	require.True(t, ok)
+	pkt := respool.GetPacket()
+	sendPacket(entry, pkt)
+	sendPacket(entry, respool.GetPacket())
+
+	expected := []RegistrationInfo{
+		{
+			IA:       ia,
+			Public:   "192.0.2.1:30000",
+			RingSize: apppolicy.DefaultRingSize,
+		},
+		{
+			IA:              ia,
+			Public:          "192.0.2.1:40000",
+			SVC:             addr.SvcCS.String(),
+			SCMPIDs:         []uint64{7},
+			Owner:           owner,
+			RingSize:        1,
+			Queued:          1,
+			QueuedBytes:     int64(pkt.Len()),
+			Delivered:       1,
+			DroppedRingFull: 1,
+		},
+	}
+	assert.Equal(t, expected, server.Registrations())
+
+	conn.Close()
+	assert.Len(t, server.Registrations(), 1)
+}
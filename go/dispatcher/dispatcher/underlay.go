@@ -16,6 +16,7 @@ package dispatcher
 
 import (
 	"net"
+	"sync/atomic"
 
 	"github.com/scionproto/scion/go/dispatcher/internal/metrics"
 	"github.com/scionproto/scion/go/dispatcher/internal/respool"
@@ -219,12 +220,22 @@ func (d *SCMPAppDestination) Send(dp *NetToRingDataplane, pkt *respool.Packet) {
 // sendPacket puts pkt on the routing entry's ring buffer, and releases the
 // reference to pkt.
 func sendPacket(routingEntry *TableEntry, pkt *respool.Packet) {
+	if !routingEntry.reserve(pkt.Len()) {
+		atomic.AddUint64(&routingEntry.droppedQuota, 1)
+		metrics.M.AppQuotaDrops().Inc()
+		pkt.Free()
+		return
+	}
 	// Move packet reference to other goroutine.
 	count, _ := routingEntry.appIngressRing.Write(ringbuf.EntryList{pkt}, false)
 	if count <= 0 {
 		// Release buffer if we couldn't transmit it to the other goroutine.
+		atomic.AddUint64(&routingEntry.droppedRingFull, 1)
+		routingEntry.release(pkt.Len())
 		pkt.Free()
+		return
 	}
+	atomic.AddUint64(&routingEntry.delivered, 1)
 }
 
 var _ Destination = (*SCMPHandlerDestination)(nil)
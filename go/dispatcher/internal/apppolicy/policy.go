@@ -0,0 +1,287 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package apppolicy decides which addresses applications are allowed to
+// register with the dispatcher, and with which resource limits.
+//
+// Applications are identified by the credentials of the process connected to
+// the application socket. The policy is a list of rules, each matching a set of
+// UIDs and GIDs. The first matching rule applies, if no rule matches the
+// default rule applies. If there is no default rule, the registration is
+// denied. Example:
+//
+//   {
+//       "Default": {
+//           "Ports": ["31000-32767"]
+//       },
+//       "Rules": [
+//           {
+//               "Name": "control service",
+//               "UIDs": [1001],
+//               "Ports": ["30252", "30254"],
+//               "SVCs": ["CS"],
+//               "RingSize": 1024,
+//               "ByteQuota": 4194304
+//           }
+//       ]
+//   }
+//
+// Requests for an ephemeral port (port 0) are allowed by every rule, the ports
+// only restrict explicitly requested ports.
+package apppolicy
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+const (
+	// DefaultRingSize is the number of packets buffered for a registration,
+	// if the rule does not specify it.
+	DefaultRingSize = 128
+	// MaxRingSize is the maximum number of packets buffered for a
+	// registration.
+	MaxRingSize = 1 << 16
+)
+
+// ErrDenied indicates that the policy does not allow the registration.
+var ErrDenied = serrors.New("registration denied by policy")
+
+// Credentials identify the process connected to the application socket.
+type Credentials struct {
+	PID int32
+	UID uint32
+	GID uint32
+}
+
+// Limits are the resource limits of a registration.
+type Limits struct {
+	// RingSize is the number of packets buffered for the application.
+	RingSize int
+	// ByteQuota is the number of bytes buffered for the application. Zero
+	// means no limit.
+	ByteQuota int
+}
+
+// DefaultLimits are the limits used if there is no policy.
+var DefaultLimits = Limits{RingSize: DefaultRingSize}
+
+// Policy maps application credentials to the allowed registrations. A nil
+// policy allows all registrations with the default limits.
+type Policy struct {
+	// Default applies to applications that are not matched by any rule.
+	Default *Rule `json:",omitempty"`
+	Rules   []Rule
+}
+
+// Load loads the policy from the JSON file.
+func Load(file string) (*Policy, error) {
+	raw, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, serrors.WrapStr("unable to read policy", err, "file", file)
+	}
+	var p Policy
+	if err := json.Unmarshal(raw, &p); err != nil {
+		return nil, serrors.WrapStr("unable to parse policy", err, "file", file)
+	}
+	if err := p.Validate(); err != nil {
+		return nil, serrors.WrapStr("invalid policy", err, "file", file)
+	}
+	return &p, nil
+}
+
+// Validate checks that the policy is valid.
+func (p *Policy) Validate() error {
+	if p.Default != nil {
+		if err := p.Default.Validate(); err != nil {
+			return serrors.WrapStr("invalid default rule", err)
+		}
+	}
+	for i, r := range p.Rules {
+		if len(r.UIDs) == 0 && len(r.GIDs) == 0 {
+			return serrors.New("rule matches no UID or GID", "rule", r.name(i))
+		}
+		if err := r.Validate(); err != nil {
+			return serrors.WrapStr("invalid rule", err, "rule", r.name(i))
+		}
+	}
+	return nil
+}
+
+// Check checks whether the application is allowed to register the port and the
+// SVC address. If it is, the limits of the registration are returned,
+// otherwise an error wrapping ErrDenied is returned.
+func (p *Policy) Check(creds Credentials, port uint16, svc addr.HostSVC) (Limits, error) {
+	if p == nil {
+		return DefaultLimits, nil
+	}
+	r, name := p.match(creds)
+	if r == nil {
+		return Limits{}, serrors.WithCtx(ErrDenied, "uid", creds.UID, "gid", creds.GID,
+			"reason", "no matching rule")
+	}
+	if port != 0 && !r.allowsPort(port) {
+		return Limits{}, serrors.WithCtx(ErrDenied, "uid", creds.UID, "gid", creds.GID,
+			"rule", name, "reason", "port not allowed", "port", port)
+	}
+	if svc != addr.SvcNone && !r.allowsSVC(svc) {
+		return Limits{}, serrors.WithCtx(ErrDenied, "uid", creds.UID, "gid", creds.GID,
+			"rule", name, "reason", "SVC not allowed", "svc", svc)
+	}
+	return r.limits(), nil
+}
+
+func (p *Policy) match(creds Credentials) (*Rule, string) {
+	for i := range p.Rules {
+		if p.Rules[i].matches(creds) {
+			return &p.Rules[i], p.Rules[i].name(i)
+		}
+	}
+	return p.Default, "default"
+}
+
+// Rule describes the registrations allowed for a set of applications.
+type Rule struct {
+	// Name is used to identify the rule in logs.
+	Name string `json:",omitempty"`
+	// UIDs and GIDs are the credentials the rule applies to. The rule matches
+	// if either the UID or the GID of the application is listed.
+	UIDs []uint32 `json:",omitempty"`
+	GIDs []uint32 `json:",omitempty"`
+	// Ports are the ports the applications are allowed to register.
+	Ports []PortRange `json:",omitempty"`
+	// SVCs are the SVC addresses the applications are allowed to register,
+	// e.g., "CS". They match both the anycast and the multicast address.
+	SVCs []string `json:",omitempty"`
+	// RingSize is the number of packets buffered for a registration. If it is
+	// zero, DefaultRingSize is used.
+	RingSize int `json:",omitempty"`
+	// ByteQuota is the number of bytes buffered for a registration. If it is
+	// zero, the number of bytes is not limited.
+	ByteQuota int `json:",omitempty"`
+}
+
+// Validate checks that the rule is valid.
+func (r *Rule) Validate() error {
+	if r.RingSize < 0 || r.RingSize > MaxRingSize {
+		return serrors.New("ring size out of range", "ring_size", r.RingSize,
+			"max", MaxRingSize)
+	}
+	if r.ByteQuota < 0 {
+		return serrors.New("negative byte quota", "byte_quota", r.ByteQuota)
+	}
+	for _, s := range r.SVCs {
+		if addr.HostSVCFromString(s) == addr.SvcNone {
+			return serrors.New("unknown SVC address", "svc", s)
+		}
+	}
+	return nil
+}
+
+func (r *Rule) matches(creds Credentials) bool {
+	for _, uid := range r.UIDs {
+		if uid == creds.UID {
+			return true
+		}
+	}
+	for _, gid := range r.GIDs {
+		if gid == creds.GID {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Rule) allowsPort(port uint16) bool {
+	for _, pr := range r.Ports {
+		if pr.Contains(port) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Rule) allowsSVC(svc addr.HostSVC) bool {
+	for _, s := range r.SVCs {
+		if addr.HostSVCFromString(s).Base() == svc.Base() {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Rule) limits() Limits {
+	l := Limits{RingSize: r.RingSize, ByteQuota: r.ByteQuota}
+	if l.RingSize == 0 {
+		l.RingSize = DefaultRingSize
+	}
+	return l
+}
+
+func (r *Rule) name(i int) string {
+	if r.Name != "" {
+		return r.Name
+	}
+	return fmt.Sprintf("#%d", i)
+}
+
+// PortRange is an inclusive range of ports. It is encoded as "min-max", or as
+// "port" for a single port.
+type PortRange struct {
+	Min uint16
+	Max uint16
+}
+
+// Contains returns whether the port is in the range.
+func (pr PortRange) Contains(port uint16) bool {
+	return pr.Min <= port && port <= pr.Max
+}
+
+func (pr PortRange) String() string {
+	if pr.Min == pr.Max {
+		return strconv.Itoa(int(pr.Min))
+	}
+	return fmt.Sprintf("%d-%d", pr.Min, pr.Max)
+}
+
+// MarshalText encodes the range.
+func (pr PortRange) MarshalText() ([]byte, error) {
+	return []byte(pr.String()), nil
+}
+
+// UnmarshalText decodes the range.
+func (pr *PortRange) UnmarshalText(text []byte) error {
+	parts := strings.SplitN(string(text), "-", 2)
+	min, err := strconv.ParseUint(parts[0], 10, 16)
+	if err != nil {
+		return serrors.WrapStr("invalid port", err, "range", string(text))
+	}
+	max := min
+	if len(parts) == 2 {
+		if max, err = strconv.ParseUint(parts[1], 10, 16); err != nil {
+			return serrors.WrapStr("invalid port", err, "range", string(text))
+		}
+	}
+	if min > max {
+		return serrors.New("invalid port range", "range", string(text))
+	}
+	*pr = PortRange{Min: uint16(min), Max: uint16(max)}
+	return nil
+}
@@ -0,0 +1,227 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apppolicy_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/dispatcher/internal/apppolicy"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestLoad(t *testing.T) {
+	p, err := apppolicy.Load("testdata/policy.json")
+	require.NoError(t, err)
+	expected := &apppolicy.Policy{
+		Default: &apppolicy.Rule{
+			Ports: []apppolicy.PortRange{{Min: 31000, Max: 32767}},
+		},
+		Rules: []apppolicy.Rule{
+			{
+				Name: "control service",
+				UIDs: []uint32{1001},
+				Ports: []apppolicy.PortRange{
+					{Min: 30252, Max: 30252},
+					{Min: 30254, Max: 30254},
+				},
+				SVCs:      []string{"CS"},
+				RingSize:  1024,
+				ByteQuota: 4194304,
+			},
+			{
+				Name:  "gateway",
+				GIDs:  []uint32{2000},
+				Ports: []apppolicy.PortRange{{Min: 30256, Max: 30256}},
+				SVCs:  []string{"SIG"},
+			},
+		},
+	}
+	assert.Equal(t, expected, p)
+
+	_, err = apppolicy.Load("testdata/nonexistent.json")
+	assert.Error(t, err)
+}
+
+func TestPolicyValidate(t *testing.T) {
+	tests := map[string]struct {
+		Policy    apppolicy.Policy
+		Assertion assert.ErrorAssertionFunc
+	}{
+		"empty": {
+			Assertion: assert.NoError,
+		},
+		"rule without credentials": {
+			Policy:    apppolicy.Policy{Rules: []apppolicy.Rule{{Name: "nobody"}}},
+			Assertion: assert.Error,
+		},
+		"ring size too large": {
+			Policy: apppolicy.Policy{Rules: []apppolicy.Rule{
+				{UIDs: []uint32{1}, RingSize: apppolicy.MaxRingSize + 1},
+			}},
+			Assertion: assert.Error,
+		},
+		"negative byte quota": {
+			Policy:    apppolicy.Policy{Default: &apppolicy.Rule{ByteQuota: -1}},
+			Assertion: assert.Error,
+		},
+		"unknown SVC": {
+			Policy: apppolicy.Policy{Rules: []apppolicy.Rule{
+				{UIDs: []uint32{1}, SVCs: []string{"XY"}},
+			}},
+			Assertion: assert.Error,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test.Assertion(t, test.Policy.Validate())
+		})
+	}
+}
+
+func TestPolicyCheck(t *testing.T) {
+	p, err := apppolicy.Load("testdata/policy.json")
+	require.NoError(t, err)
+	cs := apppolicy.Credentials{UID: 1001, GID: 1001}
+	sig := apppolicy.Credentials{UID: 1002, GID: 2000}
+	other := apppolicy.Credentials{UID: 1003, GID: 1003}
+	tests := map[string]struct {
+		Policy      *apppolicy.Policy
+		Credentials apppolicy.Credentials
+		Port        uint16
+		SVC         addr.HostSVC
+		Limits      apppolicy.Limits
+		Denied      bool
+	}{
+		"no policy": {
+			Credentials: other,
+			Port:        30252,
+			SVC:         addr.SvcCS,
+			Limits:      apppolicy.DefaultLimits,
+		},
+		"uid port and SVC allowed": {
+			Policy:      p,
+			Credentials: cs,
+			Port:        30254,
+			SVC:         addr.SvcCS,
+			Limits:      apppolicy.Limits{RingSize: 1024, ByteQuota: 4194304},
+		},
+		"multicast SVC allowed": {
+			Policy:      p,
+			Credentials: cs,
+			Port:        30252,
+			SVC:         addr.SvcCS.Multicast(),
+			Limits:      apppolicy.Limits{RingSize: 1024, ByteQuota: 4194304},
+		},
+		"uid port not allowed": {
+			Policy:      p,
+			Credentials: cs,
+			Port:        30253,
+			SVC:         addr.SvcNone,
+			Denied:      true,
+		},
+		"uid SVC not allowed": {
+			Policy:      p,
+			Credentials: cs,
+			Port:        30252,
+			SVC:         addr.SvcSIG,
+			Denied:      true,
+		},
+		"gid allowed": {
+			Policy:      p,
+			Credentials: sig,
+			Port:        30256,
+			SVC:         addr.SvcSIG,
+			Limits:      apppolicy.DefaultLimits,
+		},
+		"ephemeral port allowed": {
+			Policy:      p,
+			Credentials: sig,
+			SVC:         addr.SvcNone,
+			Limits:      apppolicy.DefaultLimits,
+		},
+		"default port allowed": {
+			Policy:      p,
+			Credentials: other,
+			Port:        31000,
+			SVC:         addr.SvcNone,
+			Limits:      apppolicy.DefaultLimits,
+		},
+		"default hijacking port": {
+			Policy:      p,
+			Credentials: other,
+			Port:        30252,
+			SVC:         addr.SvcNone,
+			Denied:      true,
+		},
+		"default hijacking SVC": {
+			Policy:      p,
+			Credentials: other,
+			Port:        31000,
+			SVC:         addr.SvcCS,
+			Denied:      true,
+		},
+		"no default rule": {
+			Policy:      &apppolicy.Policy{Rules: p.Rules},
+			Credentials: other,
+			SVC:         addr.SvcNone,
+			Denied:      true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			limits, err := test.Policy.Check(test.Credentials, test.Port, test.SVC)
+			if test.Denied {
+				xtest.AssertErrorsIs(t, err, apppolicy.ErrDenied)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.Limits, limits)
+		})
+	}
+}
+
+func TestPortRangeText(t *testing.T) {
+	tests := map[string]struct {
+		Input    string
+		Expected apppolicy.PortRange
+		Invalid  bool
+	}{
+		"single":   {Input: `"30041"`, Expected: apppolicy.PortRange{Min: 30041, Max: 30041}},
+		"range":    {Input: `"1-65535"`, Expected: apppolicy.PortRange{Min: 1, Max: 65535}},
+		"reversed": {Input: `"10-1"`, Invalid: true},
+		"too big":  {Input: `"65536"`, Invalid: true},
+		"garbage":  {Input: `"a-b"`, Invalid: true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var pr apppolicy.PortRange
+			err := json.Unmarshal([]byte(test.Input), &pr)
+			if test.Invalid {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.Expected, pr)
+			raw, err := json.Marshal(pr)
+			require.NoError(t, err)
+			assert.Equal(t, test.Input, string(raw))
+		})
+	}
+}
@@ -101,6 +101,8 @@ type metrics struct {
 	appNotFoundErrors  prometheus.Counter
 	appWriteSVCPkts    *prometheus.CounterVec
 	netReadOverflows   prometheus.Counter
+	appRegDenied       prometheus.Counter
+	appQuotaDrops      prometheus.Counter
 }
 
 func newMetrics() metrics {
@@ -143,6 +145,10 @@ func newMetrics() metrics {
 			"Total SVC packets delivered to applications", SVC{}),
 		netReadOverflows: prom.NewCounter(Namespace, "", "net_read_overflow_pkts_total",
 			"Total ingress packets that were dropped on the OS socket"),
+		appRegDenied: prom.NewCounter(Namespace, "", "app_conn_reg_denied_total",
+			"Application socket registrations denied by the policy."),
+		appQuotaDrops: prom.NewCounter(Namespace, "", "app_quota_drop_pkts_total",
+			"Total packets dropped because the byte quota of the application was exceeded."),
 	}
 }
 
@@ -223,3 +229,11 @@ func (m metrics) AppWriteSVCPkts(labels SVC) prometheus.Counter {
 func (m metrics) NetReadOverflows() prometheus.Counter {
 	return m.netReadOverflows
 }
+
+func (m metrics) AppRegDenied() prometheus.Counter {
+	return m.appRegDenied
+}
+
+func (m metrics) AppQuotaDrops() prometheus.Counter {
+	return m.appQuotaDrops
+}
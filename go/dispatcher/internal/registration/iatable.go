@@ -93,6 +93,24 @@ type IATable interface {
 	// If an entry is found, the returned boolean is set to true. Otherwise, it
 	// is set to false.
 	LookupID(ia addr.IA, id uint64) (interface{}, bool)
+	// Registrations returns all the entries in the table, in no particular
+	// order.
+	Registrations() []Registration
+}
+
+// Registration describes an entry of an IATable.
+type Registration struct {
+	IA     addr.IA
+	Public *net.UDPAddr
+	// Bind is the explicit bind address of the SVC registration, nil if there
+	// is none.
+	Bind net.IP
+	// SVC is the SVC address, SvcNone if there is none.
+	SVC addr.HostSVC
+	// IDs are the registered SCMP General IDs.
+	IDs []uint64
+	// Value is the value associated with the entry.
+	Value interface{}
 }
 
 // NewIATable creates a new UDP/IP port registration table.
@@ -110,6 +128,7 @@ var _ IATable = (*iaTable)(nil)
 type iaTable struct {
 	mtx     sync.RWMutex
 	ia      map[addr.IA]*Table
+	refs    map[*iaTableReference]struct{}
 	minPort int
 	maxPort int
 }
@@ -117,6 +136,7 @@ type iaTable struct {
 func newIATable(minPort, maxPort int) *iaTable {
 	return &iaTable{
 		ia:      make(map[addr.IA]*Table),
+		refs:    make(map[*iaTableReference]struct{}),
 		minPort: minPort,
 		maxPort: maxPort,
 	}
@@ -142,13 +162,16 @@ func (t *iaTable) Register(ia addr.IA, public *net.UDPAddr, bind net.IP, svc add
 	if err != nil {
 		return nil, err
 	}
-	return &iaTableReference{
+	ref := &iaTableReference{
 		table:    t,
 		ia:       ia,
 		entryRef: reference,
+		bind:     bind,
 		svc:      svc,
 		value:    value,
-	}, nil
+	}
+	t.refs[ref] = struct{}{}
+	return ref, nil
 }
 
 func (t *iaTable) LookupPublic(ia addr.IA, public *net.UDPAddr) (interface{}, bool) {
@@ -178,12 +201,31 @@ func (t *iaTable) LookupID(ia addr.IA, id uint64) (interface{}, bool) {
 	return nil, false
 }
 
+func (t *iaTable) Registrations() []Registration {
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+	regs := make([]Registration, 0, len(t.refs))
+	for ref := range t.refs {
+		address := ref.entryRef.UDPAddr()
+		regs = append(regs, Registration{
+			IA:     ref.ia,
+			Public: &net.UDPAddr{IP: address.IP, Port: address.Port, Zone: address.Zone},
+			Bind:   ref.bind,
+			SVC:    ref.svc,
+			IDs:    append([]uint64(nil), ref.entryRef.ids...),
+			Value:  ref.value,
+		})
+	}
+	return regs
+}
+
 var _ RegReference = (*iaTableReference)(nil)
 
 type iaTableReference struct {
 	table    *iaTable
 	ia       addr.IA
 	entryRef *TableReference
+	bind     net.IP
 	svc      addr.HostSVC
 	// value is the main table information associated with this reference
 	value interface{}
@@ -193,6 +235,7 @@ func (r *iaTableReference) Free() {
 	r.table.mtx.Lock()
 	defer r.table.mtx.Unlock()
 	r.entryRef.Free()
+	delete(r.table.refs, r)
 	if r.table.ia[r.ia].Size() == 0 {
 		delete(r.table.ia, r.ia)
 	}
@@ -170,3 +170,27 @@ func TestIATableSCMPExistingRegistration(t *testing.T) {
 		assert.Nil(t, retValue)
 	})
 }
+
+func TestIATableRegistrations(t *testing.T) {
+	table := NewIATable(minPort, maxPort)
+	assert.Empty(t, table.Registrations())
+
+	ref, err := table.Register(ia, public, nil, addr.SvcNone, value)
+	require.NoError(t, err)
+	require.NoError(t, ref.RegisterID(42))
+	svcPublic := &net.UDPAddr{IP: net.IP{192, 0, 2, 2}, Port: 81}
+	bind := net.IP{192, 0, 2, 3}
+	svcRef, err := table.Register(ia, svcPublic, bind, addr.SvcCS, "svc value")
+	require.NoError(t, err)
+
+	expected := []Registration{
+		{IA: ia, Public: public, SVC: addr.SvcNone, IDs: []uint64{42}, Value: value},
+		{IA: ia, Public: svcPublic, Bind: bind, SVC: addr.SvcCS, Value: "svc value"},
+	}
+	assert.ElementsMatch(t, expected, table.Registrations())
+
+	svcRef.Free()
+	assert.ElementsMatch(t, expected[:1], table.Registrations())
+	ref.Free()
+	assert.Empty(t, table.Registrations())
+}
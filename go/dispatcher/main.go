@@ -27,6 +27,7 @@ import (
 	"github.com/BurntSushi/toml"
 
 	"github.com/scionproto/scion/go/dispatcher/config"
+	"github.com/scionproto/scion/go/dispatcher/internal/apppolicy"
 	"github.com/scionproto/scion/go/dispatcher/network"
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/env"
@@ -74,15 +75,25 @@ func realMain() int {
 		return 1
 	}
 
+	var policy *apppolicy.Policy
+	if cfg.Dispatcher.PolicyFile != "" {
+		var err error
+		if policy, err = apppolicy.Load(cfg.Dispatcher.PolicyFile); err != nil {
+			log.Crit("Unable to load registration policy", "err", err)
+			return 1
+		}
+		log.Info("Loaded registration policy", "file", cfg.Dispatcher.PolicyFile)
+	}
+
+	dispatcher := &network.Dispatcher{
+		UnderlaySocket:    fmt.Sprintf(":%d", cfg.Dispatcher.UnderlayPort),
+		ApplicationSocket: cfg.Dispatcher.ApplicationSocket,
+		SocketFileMode:    os.FileMode(cfg.Dispatcher.SocketFileMode),
+		Policy:            policy,
+	}
 	go func() {
 		defer log.HandlePanic()
-		err := RunDispatcher(
-			cfg.Dispatcher.DeleteSocket,
-			cfg.Dispatcher.ApplicationSocket,
-			os.FileMode(cfg.Dispatcher.SocketFileMode),
-			cfg.Dispatcher.UnderlayPort,
-		)
-		if err != nil {
+		if err := RunDispatcher(cfg.Dispatcher.DeleteSocket, dispatcher); err != nil {
 			fatal.Fatal(err)
 		}
 	}()
@@ -90,6 +101,7 @@ func realMain() int {
 	env.SetupEnv(nil)
 	http.HandleFunc("/config", configHandler)
 	http.HandleFunc("/info", env.InfoHandler)
+	http.HandleFunc("/registrations", dispatcher.RegistrationsHandler)
 	cfg.Metrics.StartPrometheus()
 
 	returnCode := waitForTeardown()
@@ -127,20 +139,14 @@ func setupBasic() error {
 	return env.LogAppStarted("Dispatcher", cfg.Dispatcher.ID)
 }
 
-func RunDispatcher(deleteSocketFlag bool, applicationSocket string, socketFileMode os.FileMode,
-	underlayPort int) error {
-
+func RunDispatcher(deleteSocketFlag bool, dispatcher *network.Dispatcher) error {
 	if deleteSocketFlag {
-		if err := deleteSocket(cfg.Dispatcher.ApplicationSocket); err != nil {
+		if err := deleteSocket(dispatcher.ApplicationSocket); err != nil {
 			return err
 		}
 	}
-	dispatcher := &network.Dispatcher{
-		UnderlaySocket:    fmt.Sprintf(":%d", underlayPort),
-		ApplicationSocket: applicationSocket,
-		SocketFileMode:    socketFileMode,
-	}
-	log.Debug("Dispatcher starting", "appSocket", applicationSocket, "underlayPort", underlayPort)
+	log.Debug("Dispatcher starting", "appSocket", dispatcher.ApplicationSocket,
+		"underlaySocket", dispatcher.UnderlaySocket)
 	return dispatcher.ListenAndServe()
 }
 
@@ -17,6 +17,7 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"io/ioutil"
 	"net"
 	"os"
@@ -25,6 +26,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/scionproto/scion/go/dispatcher/network"
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/hpkt"
@@ -420,8 +422,11 @@ func TestDataplaneIntegration(t *testing.T) {
 	settings := InitTestSettings(t)
 
 	go func() {
-		err := RunDispatcher(false, settings.ApplicationSocket, reliable.DefaultDispSocketFileMode,
-			settings.UnderlayPort)
+		err := RunDispatcher(false, &network.Dispatcher{
+			UnderlaySocket:    fmt.Sprintf(":%d", settings.UnderlayPort),
+			ApplicationSocket: settings.ApplicationSocket,
+			SocketFileMode:    reliable.DefaultDispSocketFileMode,
+		})
 		xtest.FailOnErr(t, err, "dispatcher error")
 	}()
 	time.Sleep(defaultWaitDuration)
@@ -20,11 +20,13 @@ import (
 	"net"
 
 	"github.com/scionproto/scion/go/dispatcher/dispatcher"
+	"github.com/scionproto/scion/go/dispatcher/internal/apppolicy"
 	"github.com/scionproto/scion/go/dispatcher/internal/metrics"
 	"github.com/scionproto/scion/go/dispatcher/internal/respool"
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/sock/reliable"
 )
 
@@ -33,6 +35,9 @@ import (
 type AppSocketServer struct {
 	Listener   *reliable.Listener
 	DispServer *dispatcher.Server
+	// Policy decides which registrations are allowed. If it is nil, all
+	// registrations are allowed.
+	Policy *apppolicy.Policy
 }
 
 func (s *AppSocketServer) Serve() error {
@@ -50,6 +55,7 @@ func (s *AppSocketServer) Serve() error {
 func (h *AppSocketServer) Handle(conn net.PacketConn) {
 	ch := &AppConnHandler{
 		Conn:   conn,
+		Policy: h.Policy,
 		Logger: log.Root().New("clientID", fmt.Sprintf("%p", conn)),
 	}
 	go func() {
@@ -63,7 +69,10 @@ type AppConnHandler struct {
 	// Conn is the local socket to which the application is connected.
 	Conn     net.PacketConn
 	DispConn *dispatcher.Conn
-	Logger   log.Logger
+	// Policy decides whether the registration is allowed. If it is nil, all
+	// registrations are allowed.
+	Policy *apppolicy.Policy
+	Logger log.Logger
 }
 
 func (h *AppConnHandler) Handle(appServer *dispatcher.Server) {
@@ -103,9 +112,23 @@ func (h *AppConnHandler) doRegExchange(appServer *dispatcher.Server) (net.Packet
 	if err != nil {
 		return nil, common.NewBasicError("registration message error", nil, "err", err)
 	}
-	appConn, _, err := appServer.Register(nil,
-		regInfo.IA, regInfo.PublicAddress, regInfo.SVCAddress)
+	var owner *apppolicy.Credentials
+	if creds, err := peerCredentials(h.Conn); err != nil {
+		h.Logger.Debug("Unable to get peer credentials", "err", err)
+	} else {
+		owner = &creds
+	}
+	limits, err := h.checkPolicy(owner, regInfo)
+	if err != nil {
+		metrics.M.AppRegDenied().Inc()
+		h.sendRejection(b, err)
+		return nil, common.NewBasicError("registration denied", nil, "err", err)
+	}
+	appConn, _, err := appServer.RegisterWithOptions(nil,
+		regInfo.IA, regInfo.PublicAddress, regInfo.SVCAddress,
+		dispatcher.RegOptions{Limits: limits, Owner: owner})
 	if err != nil {
+		h.sendRejection(b, err)
 		return nil, common.NewBasicError("registration table error", nil, "err", err)
 	}
 	udpAddr := appConn.(*dispatcher.Conn).LocalAddr().(*net.UDPAddr)
@@ -119,6 +142,33 @@ func (h *AppConnHandler) doRegExchange(appServer *dispatcher.Server) (net.Packet
 	return appConn, nil
 }
 
+// checkPolicy checks the registration of the application with the credentials
+// against the policy, and returns the limits of the registration.
+func (h *AppConnHandler) checkPolicy(creds *apppolicy.Credentials,
+	regInfo *reliable.Registration) (apppolicy.Limits, error) {
+
+	if h.Policy == nil {
+		return apppolicy.DefaultLimits, nil
+	}
+	if creds == nil {
+		return apppolicy.Limits{}, serrors.New("unknown peer credentials")
+	}
+	var port uint16
+	if regInfo.PublicAddress != nil {
+		port = uint16(regInfo.PublicAddress.Port)
+	}
+	h.Logger.Debug("Checking registration", "pid", creds.PID, "uid", creds.UID,
+		"gid", creds.GID)
+	return h.Policy.Check(*creds, port, regInfo.SVCAddress)
+}
+
+// sendRejection reports the reason of a failed registration to the client.
+func (h *AppConnHandler) sendRejection(b common.RawBytes, reason error) {
+	if err := h.sendConfirmation(b, &reliable.Confirmation{Error: reason.Error()}); err != nil {
+		h.Logger.Debug("Unable to send rejection", "err", err)
+	}
+}
+
 func (h *AppConnHandler) logRegistration(ia addr.IA, public *net.UDPAddr, bind net.IP,
 	svc addr.HostSVC) {
 
@@ -15,9 +15,12 @@
 package network
 
 import (
+	"net/http"
 	"os"
+	"sync"
 
 	"github.com/scionproto/scion/go/dispatcher/dispatcher"
+	"github.com/scionproto/scion/go/dispatcher/internal/apppolicy"
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/sock/reliable"
@@ -27,6 +30,12 @@ type Dispatcher struct {
 	UnderlaySocket    string
 	ApplicationSocket string
 	SocketFileMode    os.FileMode
+	// Policy decides which registrations are allowed. If it is nil, all
+	// registrations are allowed.
+	Policy *apppolicy.Policy
+
+	mtx    sync.Mutex
+	server *dispatcher.Server
 }
 
 func (d *Dispatcher) ListenAndServe() error {
@@ -35,6 +44,8 @@ func (d *Dispatcher) ListenAndServe() error {
 		return err
 	}
 	defer dispServer.Close()
+	d.setServer(dispServer)
+	defer d.setServer(nil)
 
 	dispServerConn, err := reliable.Listen(d.ApplicationSocket)
 	if err != nil {
@@ -56,9 +67,28 @@ func (d *Dispatcher) ListenAndServe() error {
 		dispServer := &AppSocketServer{
 			Listener:   dispServerConn,
 			DispServer: dispServer,
+			Policy:     d.Policy,
 		}
 		errChan <- dispServer.Serve()
 	}()
 
 	return <-errChan
 }
+
+// RegistrationsHandler serves the registrations of the applications as JSON.
+func (d *Dispatcher) RegistrationsHandler(w http.ResponseWriter, r *http.Request) {
+	d.mtx.Lock()
+	server := d.server
+	d.mtx.Unlock()
+	if server == nil {
+		http.Error(w, "dispatcher not running", http.StatusServiceUnavailable)
+		return
+	}
+	server.RegistrationsHandler(w, r)
+}
+
+func (d *Dispatcher) setServer(server *dispatcher.Server) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	d.server = server
+}
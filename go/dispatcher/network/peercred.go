@@ -0,0 +1,54 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build linux
+
+package network
+
+import (
+	"fmt"
+	"net"
+	"syscall"
+
+	"github.com/scionproto/scion/go/dispatcher/internal/apppolicy"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// peerCredentials returns the credentials of the process connected to the
+// application socket, as reported by SO_PEERCRED.
+func peerCredentials(conn net.PacketConn) (apppolicy.Credentials, error) {
+	sc, ok := conn.(syscall.Conn)
+	if !ok {
+		return apppolicy.Credentials{}, serrors.New("connection does not support credentials",
+			"type", fmt.Sprintf("%T", conn))
+	}
+	rawConn, err := sc.SyscallConn()
+	if err != nil {
+		return apppolicy.Credentials{}, serrors.WrapStr("error accessing raw connection", err)
+	}
+	var ucred *syscall.Ucred
+	var credErr error
+	err = rawConn.Control(func(fd uintptr) {
+		ucred, credErr = syscall.GetsockoptUcred(int(fd), syscall.SOL_SOCKET,
+			syscall.SO_PEERCRED)
+	})
+	if err != nil {
+		return apppolicy.Credentials{}, serrors.WrapStr("RawConn.Control error", err)
+	}
+	if credErr != nil {
+		return apppolicy.Credentials{}, serrors.WrapStr("unable to get peer credentials",
+			credErr)
+	}
+	return apppolicy.Credentials{PID: ucred.Pid, UID: ucred.Uid, GID: ucred.Gid}, nil
+}
@@ -0,0 +1,32 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build !linux
+
+package network
+
+import (
+	"net"
+	"runtime"
+
+	"github.com/scionproto/scion/go/dispatcher/internal/apppolicy"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// peerCredentials is not supported on this platform. SO_PEERCRED is only
+// available on Linux.
+func peerCredentials(conn net.PacketConn) (apppolicy.Credentials, error) {
+	return apppolicy.Credentials{}, serrors.New("peer credentials not supported",
+		"os", runtime.GOOS)
+}
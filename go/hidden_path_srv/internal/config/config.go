@@ -0,0 +1,131 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package config contains the configuration of the hidden path server.
+package config
+
+import (
+	"io"
+	"net"
+
+	"github.com/scionproto/scion/go/lib/config"
+	"github.com/scionproto/scion/go/lib/env"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/pathstorage"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/truststorage"
+)
+
+var _ config.Config = (*Config)(nil)
+
+// Config is the hidden path server configuration.
+type Config struct {
+	General  env.General              `toml:"general,omitempty"`
+	Features env.Features             `toml:"features,omitempty"`
+	Logging  log.Config               `toml:"log,omitempty"`
+	Metrics  env.Metrics              `toml:"metrics,omitempty"`
+	Tracing  env.Tracing              `toml:"tracing,omitempty"`
+	QUIC     env.QUIC                 `toml:"quic,omitempty"`
+	TrustDB  truststorage.TrustDBConf `toml:"trust_db,omitempty"`
+	// PathDB contains the configuration for the PathDB connection. The hidden
+	// path segments are stored in the PathDB.
+	PathDB pathstorage.PathDBConf `toml:"path_db,omitempty"`
+	HPS    HPSConfig              `toml:"hidden_path,omitempty"`
+}
+
+// InitDefaults initializes the default values for all parts of the config.
+func (cfg *Config) InitDefaults() {
+	config.InitAll(
+		&cfg.General,
+		&cfg.Features,
+		&cfg.Logging,
+		&cfg.Metrics,
+		&cfg.Tracing,
+		&cfg.TrustDB,
+		&cfg.PathDB,
+		&cfg.HPS,
+	)
+}
+
+// Validate validates all parts of the config.
+func (cfg *Config) Validate() error {
+	return config.ValidateAll(
+		&cfg.General,
+		&cfg.Features,
+		&cfg.Logging,
+		&cfg.Metrics,
+		&cfg.TrustDB,
+		&cfg.PathDB,
+		&cfg.HPS,
+	)
+}
+
+// Sample generates a sample config file for the hidden path server.
+func (cfg *Config) Sample(dst io.Writer, path config.Path, _ config.CtxMap) {
+	config.WriteSample(dst, path, config.CtxMap{config.ID: idSample},
+		&cfg.General,
+		&cfg.Features,
+		&cfg.Logging,
+		&cfg.Metrics,
+		&cfg.Tracing,
+		&cfg.QUIC,
+		&cfg.TrustDB,
+		&cfg.PathDB,
+		&cfg.HPS,
+	)
+}
+
+// ConfigName is the toml key.
+func (cfg *Config) ConfigName() string {
+	return "hps_config"
+}
+
+var _ config.Config = (*HPSConfig)(nil)
+
+// HPSConfig holds the configuration specific to the hidden path server.
+type HPSConfig struct {
+	// Address is the local address to listen on for SCION messages, and to
+	// send out messages to other nodes.
+	Address string `toml:"address,omitempty"`
+	// GroupConfigFiles contains the file paths of the hidden path group
+	// configurations. Every file contains exactly one group. The files are
+	// reloaded on SIGHUP.
+	GroupConfigFiles []string `toml:"group_config_files,omitempty"`
+}
+
+// InitDefaults does nothing, all values are required.
+func (cfg *HPSConfig) InitDefaults() {
+}
+
+// Validate validates that the address is set.
+func (cfg *HPSConfig) Validate() error {
+	if cfg.Address == "" {
+		return serrors.New("address must be set")
+	}
+	if _, err := net.ResolveUDPAddr("udp", cfg.Address); err != nil {
+		return serrors.WrapStr("invalid address", err, "address", cfg.Address)
+	}
+	return nil
+}
+
+// Sample generates a sample for the hidden path server specific configuration.
+func (cfg *HPSConfig) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
+	config.WriteString(dst, hpsSample)
+}
+
+// ConfigName is the toml key for the hidden path server specific
+// configuration.
+func (cfg *HPSConfig) ConfigName() string {
+	return "hidden_path"
+}
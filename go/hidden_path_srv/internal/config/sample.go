@@ -0,0 +1,28 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+const idSample = "hps-1"
+
+const hpsSample = `
+# Local address to listen on for SCION messages, and to send messages to
+# other nodes. (required)
+address = "127.0.0.1:30270"
+
+# The file paths of the hidden path group configurations. Every file contains
+# the JSON encoding of exactly one group. The files are reloaded on SIGHUP.
+# (default [])
+group_config_files = ["/etc/scion/hps/group.json"]
+`
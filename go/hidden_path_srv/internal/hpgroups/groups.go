@@ -0,0 +1,69 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package hpgroups loads the hidden path group configurations of the hidden
+// path server.
+package hpgroups
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"sort"
+
+	"github.com/scionproto/scion/go/lib/hiddenpath"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// ErrDuplicateGroup indicates that a group is configured more than once.
+var ErrDuplicateGroup = serrors.New("duplicate group")
+
+// Groups contains the hidden path groups indexed by their ID.
+type Groups map[hiddenpath.GroupId]*hiddenpath.Group
+
+// Load loads the hidden path groups from the given files. Every file contains
+// the JSON encoding of exactly one group. Each group must only be configured
+// once.
+func Load(files ...string) (Groups, error) {
+	groups := make(Groups, len(files))
+	for _, file := range files {
+		raw, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, serrors.WrapStr("unable to read group file", err, "file", file)
+		}
+		g := &hiddenpath.Group{}
+		if err := json.Unmarshal(raw, g); err != nil {
+			return nil, serrors.WrapStr("unable to parse group file", err, "file", file)
+		}
+		if _, ok := groups[g.Id]; ok {
+			return nil, serrors.WithCtx(ErrDuplicateGroup, "group", g.Id, "file", file)
+		}
+		groups[g.Id] = g
+	}
+	return groups, nil
+}
+
+// List returns the groups sorted by their ID.
+func (g Groups) List() []*hiddenpath.Group {
+	list := make([]*hiddenpath.Group, 0, len(g))
+	for _, group := range g {
+		list = append(list, group)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Id.OwnerAS != list[j].Id.OwnerAS {
+			return list[i].Id.OwnerAS < list[j].Id.OwnerAS
+		}
+		return list[i].Id.Suffix < list[j].Id.Suffix
+	})
+	return list
+}
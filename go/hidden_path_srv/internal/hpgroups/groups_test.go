@@ -0,0 +1,88 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hpgroups_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/hidden_path_srv/internal/hpgroups"
+	"github.com/scionproto/scion/go/lib/hiddenpath"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+var (
+	id110 = hiddenpath.GroupId{OwnerAS: xtest.MustParseAS("ff00:0:110"), Suffix: 0x69b5}
+	id120 = hiddenpath.GroupId{OwnerAS: xtest.MustParseAS("ff00:0:120"), Suffix: 0x1}
+)
+
+func TestLoad(t *testing.T) {
+	tests := map[string]struct {
+		Files       []string
+		ExpectedIDs []hiddenpath.GroupId
+		ExpectedErr error
+		AssertErr   assert.ErrorAssertionFunc
+	}{
+		"no files": {
+			AssertErr: assert.NoError,
+		},
+		"valid": {
+			Files:       []string{"testdata/group2.json", "testdata/group1.json"},
+			ExpectedIDs: []hiddenpath.GroupId{id110, id120},
+			AssertErr:   assert.NoError,
+		},
+		"duplicate": {
+			Files:       []string{"testdata/group1.json", "testdata/group1.json"},
+			ExpectedErr: hpgroups.ErrDuplicateGroup,
+			AssertErr:   assert.Error,
+		},
+		"invalid group": {
+			Files:     []string{"testdata/group1.json", "testdata/no_writers.json"},
+			AssertErr: assert.Error,
+		},
+		"missing file": {
+			Files:     []string{"testdata/missing.json"},
+			AssertErr: assert.Error,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			groups, err := hpgroups.Load(test.Files...)
+			test.AssertErr(t, err)
+			if test.ExpectedErr != nil {
+				xtest.AssertErrorsIs(t, err, test.ExpectedErr)
+			}
+			if err != nil {
+				return
+			}
+			var ids []hiddenpath.GroupId
+			for _, g := range groups.List() {
+				ids = append(ids, g.Id)
+			}
+			assert.Equal(t, test.ExpectedIDs, ids)
+		})
+	}
+}
+
+func TestGroupsList(t *testing.T) {
+	groups, err := hpgroups.Load("testdata/group1.json", "testdata/group2.json")
+	require.NoError(t, err)
+	list := groups.List()
+	require.Len(t, list, 2)
+	assert.Equal(t, groups[id110], list[0])
+	assert.Equal(t, groups[id120], list[1])
+}
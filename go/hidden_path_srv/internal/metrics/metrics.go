@@ -0,0 +1,75 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics contains the metrics of the hidden path server.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/scionproto/scion/go/lib/prom"
+)
+
+// Namespace is the metrics namespace for the hidden path server.
+const Namespace = "hps"
+
+// Result labels.
+const (
+	Success = prom.Success
+	ErrLoad = "err_load"
+)
+
+var (
+	// Groups is the single-instance struct to get the group metrics.
+	Groups = newGroups()
+)
+
+// ReloadLabels defines the group reload label set.
+type ReloadLabels struct {
+	Result string
+}
+
+// Labels returns the name of the labels in correct order.
+func (l ReloadLabels) Labels() []string {
+	return []string{prom.LabelResult}
+}
+
+// Values returns the values of the label in correct order.
+func (l ReloadLabels) Values() []string {
+	return []string{l.Result}
+}
+
+type groups struct {
+	active  prometheus.Gauge
+	reloads *prometheus.CounterVec
+}
+
+func newGroups() groups {
+	return groups{
+		active: prom.NewGauge(Namespace, "", "groups",
+			"The number of hidden path groups that are currently configured."),
+		reloads: prom.NewCounterVecWithLabels(Namespace, "", "group_reloads_total",
+			"The total number of hidden path group reloads.", ReloadLabels{}),
+	}
+}
+
+// Active returns the prometheus gauge.
+func (g groups) Active() prometheus.Gauge {
+	return g.active
+}
+
+// Reloads returns the prometheus counter.
+func (g groups) Reloads(l ReloadLabels) prometheus.Counter {
+	return g.reloads.WithLabelValues(l.Values()...)
+}
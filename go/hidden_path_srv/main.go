@@ -0,0 +1,298 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// The hidden path server implementation.
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/scionproto/scion/go/hidden_path_srv/internal/config"
+	"github.com/scionproto/scion/go/hidden_path_srv/internal/hiddenpathdb"
+	"github.com/scionproto/scion/go/hidden_path_srv/internal/hiddenpathdb/adapter"
+	"github.com/scionproto/scion/go/hidden_path_srv/internal/hpcfgreq"
+	"github.com/scionproto/scion/go/hidden_path_srv/internal/hpgroups"
+	"github.com/scionproto/scion/go/hidden_path_srv/internal/hpsegreq"
+	"github.com/scionproto/scion/go/hidden_path_srv/internal/metrics"
+	"github.com/scionproto/scion/go/hidden_path_srv/internal/registration"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/env"
+	"github.com/scionproto/scion/go/lib/fatal"
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/infra/infraenv"
+	"github.com/scionproto/scion/go/lib/infra/messenger"
+	"github.com/scionproto/scion/go/lib/infra/modules/itopo"
+	"github.com/scionproto/scion/go/lib/infra/modules/seghandler"
+	"github.com/scionproto/scion/go/lib/infra/modules/trust"
+	"github.com/scionproto/scion/go/lib/infra/modules/trust/trustdbmetrics"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/pathdb"
+	"github.com/scionproto/scion/go/lib/pathstorage"
+	"github.com/scionproto/scion/go/lib/periodic"
+	"github.com/scionproto/scion/go/lib/prom"
+	"github.com/scionproto/scion/go/lib/revcache"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/topology"
+)
+
+var (
+	cfg config.Config
+)
+
+func init() {
+	flag.Usage = env.Usage
+}
+
+func main() {
+	os.Exit(realMain())
+}
+
+func realMain() int {
+	fatal.Init()
+	env.AddFlags()
+	flag.Parse()
+	if v, ok := env.CheckFlags(&cfg); !ok {
+		return v
+	}
+	if err := setupBasic(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer log.Flush()
+	defer env.LogAppStopped("HPS", cfg.General.ID)
+	defer log.HandlePanic()
+	if err := setup(); err != nil {
+		log.Crit("Setup failed", "err", err)
+		return 1
+	}
+	pathDB, revCache, err := pathstorage.NewPathStorage(cfg.PathDB)
+	if err != nil {
+		log.Crit("Unable to initialize path storage", "err", err)
+		return 1
+	}
+	defer revCache.Close()
+	pathDB = pathdb.WithMetrics(string(cfg.PathDB.Backend()), pathDB)
+	defer pathDB.Close()
+
+	tracer, trCloser, err := cfg.Tracing.NewTracer(cfg.General.ID)
+	if err != nil {
+		log.Crit("Unable to create tracer", "err", err)
+		return 1
+	}
+	defer trCloser.Close()
+	opentracing.SetGlobalTracer(tracer)
+
+	public, err := net.ResolveUDPAddr("udp", cfg.HPS.Address)
+	if err != nil {
+		log.Crit("Unable to resolve listening address", "err", err, "addr", cfg.HPS.Address)
+		return 1
+	}
+	topo := itopo.Get()
+	nc := infraenv.NetworkConfig{
+		IA:                    topo.IA(),
+		Public:                public,
+		SVC:                   addr.SvcHPS,
+		ReconnectToDispatcher: cfg.General.ReconnectToDispatcher,
+		QUIC: infraenv.QUIC{
+			Address:  cfg.QUIC.Address,
+			CertFile: cfg.QUIC.CertFile,
+			KeyFile:  cfg.QUIC.KeyFile,
+		},
+		SVCResolutionFraction: cfg.QUIC.ResolutionFraction,
+		SVCRouter:             messenger.NewSVCRouter(itopo.Provider()),
+	}
+	msgr, err := nc.Messenger()
+	if err != nil {
+		log.Crit(infraenv.ErrAppUnableToInitMessenger.Error(), "err", err)
+		return 1
+	}
+	defer msgr.CloseServer()
+
+	trustDB, err := cfg.TrustDB.New()
+	if err != nil {
+		log.Crit("Error initializing trust database", "err", err)
+		return 1
+	}
+	trustDB = trustdbmetrics.WithMetrics(string(cfg.TrustDB.Backend()), trustDB)
+	defer trustDB.Close()
+	inserter := trust.DefaultInserter{
+		BaseInserter: trust.BaseInserter{DB: trustDB},
+	}
+	provider := trust.Provider{
+		DB:       trustDB,
+		Recurser: trust.LocalOnlyRecurser{},
+		Resolver: trust.DefaultResolver{
+			DB:       trustDB,
+			Inserter: inserter,
+			RPC:      trust.DefaultRPC{Msgr: msgr},
+			IA:       topo.IA(),
+		},
+		Router: trust.LocalRouter{IA: topo.IA()},
+	}
+	trustStore := trust.Store{
+		Inspector:      trust.DefaultInspector{Provider: provider},
+		CryptoProvider: provider,
+		Inserter:       inserter,
+		DB:             trustDB,
+	}
+	certsDir := filepath.Join(cfg.General.ConfigDir, "certs")
+	if err = trustStore.LoadCryptoMaterial(context.Background(), certsDir); err != nil {
+		log.Crit("Error loading crypto material", "err", err)
+		return 1
+	}
+	msgr.AddHandler(infra.ChainRequest, trustStore.NewChainReqHandler(topo.IA()))
+	msgr.AddHandler(infra.TRCRequest, trustStore.NewTRCReqHandler(topo.IA()))
+
+	h := &handlers{
+		ia:   topo.IA(),
+		msgr: msgr,
+		db:   adapter.New(pathDB),
+		segHandler: seghandler.Handler{
+			Verifier: &seghandler.DefaultVerifier{Verifier: trust.NewVerifier(trustStore)},
+			Storage:  &seghandler.DefaultStorage{PathDB: pathDB, RevCache: revCache},
+		},
+	}
+	if err := h.load(cfg.HPS.GroupConfigFiles); err != nil {
+		log.Crit("Unable to load hidden path groups", "err", err)
+		return 1
+	}
+	env.SetupEnv(
+		func() {
+			// Keep serving the current groups, if the reload fails.
+			if err := h.load(cfg.HPS.GroupConfigFiles); err != nil {
+				log.Error("Unable to reload hidden path groups", "err", err)
+			}
+		},
+	)
+
+	cleaner := periodic.Start(pathdb.NewCleaner(pathDB, "hps_segments"),
+		300*time.Second, 295*time.Second)
+	defer cleaner.Stop()
+	rcCleaner := periodic.Start(revcache.NewCleaner(revCache, "hps_revocation"),
+		10*time.Second, 10*time.Second)
+	defer rcCleaner.Stop()
+
+	http.HandleFunc("/config", configHandler)
+	http.HandleFunc("/info", env.InfoHandler)
+	http.HandleFunc("/topology", itopo.TopologyHandler)
+	http.HandleFunc("/groups", h.groupsHandler)
+	cfg.Metrics.StartPrometheus()
+	go func() {
+		defer log.HandlePanic()
+		msgr.ListenAndServe()
+	}()
+	select {
+	case <-fatal.ShutdownChan():
+		// Whenever we receive a SIGINT or SIGTERM we exit without an error.
+		return 0
+	case <-fatal.FatalChan():
+		return 1
+	}
+}
+
+// handlers registers the hidden path handlers with the messenger. The handlers
+// are replaced whenever the hidden path groups are reloaded.
+type handlers struct {
+	ia         addr.IA
+	msgr       infra.Messenger
+	db         hiddenpathdb.HiddenPathDB
+	segHandler seghandler.Handler
+
+	mtx    sync.Mutex
+	groups hpgroups.Groups
+}
+
+func (h *handlers) load(files []string) error {
+	groups, err := hpgroups.Load(files...)
+	if err != nil {
+		metrics.Groups.Reloads(metrics.ReloadLabels{Result: metrics.ErrLoad}).Inc()
+		return err
+	}
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	validator := registration.NewDefaultValidator(h.ia, groups)
+	h.msgr.AddHandler(infra.HPSegReg, registration.NewSegRegHandler(validator, h.segHandler))
+	fetcher := hpsegreq.NewDefaultFetcher(
+		&hpsegreq.GroupInfo{LocalIA: h.ia, Groups: groups}, h.msgr, h.db)
+	h.msgr.AddHandler(infra.HPSegRequest, hpsegreq.NewSegReqHandler(fetcher))
+	h.msgr.AddHandler(infra.HPCfgRequest, hpcfgreq.NewHandler(groups.List(), h.ia))
+	h.groups = groups
+	metrics.Groups.Active().Set(float64(len(groups)))
+	metrics.Groups.Reloads(metrics.ReloadLabels{Result: metrics.Success}).Inc()
+	log.Info("Loaded hidden path groups", "groups", len(groups))
+	return nil
+}
+
+func (h *handlers) groupsHandler(w http.ResponseWriter, r *http.Request) {
+	h.mtx.Lock()
+	groups := h.groups.List()
+	h.mtx.Unlock()
+	w.Header().Set("Content-Type", "text/plain")
+	for _, g := range groups {
+		fmt.Fprintf(w, "%s version=%d owner=%s writers=%v readers=%v registries=%v\n",
+			g.Id, g.Version, g.Owner, g.Writers, g.Readers, g.Registries)
+	}
+}
+
+func setupBasic() error {
+	md, err := toml.DecodeFile(env.ConfigFile(), &cfg)
+	if err != nil {
+		return serrors.WrapStr("Failed to load config", err, "file", env.ConfigFile())
+	}
+	if len(md.Undecoded()) > 0 {
+		return serrors.New("Failed to load config: undecoded keys", "undecoded", md.Undecoded())
+	}
+	cfg.InitDefaults()
+	if err := log.Setup(cfg.Logging); err != nil {
+		return serrors.WrapStr("Failed to initialize logging", err)
+	}
+	prom.ExportElementID(cfg.General.ID)
+	return env.LogAppStarted("HPS", cfg.General.ID)
+}
+
+func setup() error {
+	if err := cfg.Validate(); err != nil {
+		return common.NewBasicError("unable to validate config", err)
+	}
+	topo, err := topology.FromJSONFile(cfg.General.Topology())
+	if err != nil {
+		return common.NewBasicError("unable to load topology", err)
+	}
+	itopo.Init(&itopo.Config{})
+	if err := itopo.Update(topo); err != nil {
+		return common.NewBasicError("unable to set initial static topology", err)
+	}
+	infraenv.InitInfraEnvironment(cfg.General.Topology())
+	return nil
+}
+
+func configHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain")
+	var buf bytes.Buffer
+	toml.NewEncoder(&buf).Encode(cfg)
+	fmt.Fprint(w, buf.String())
+}
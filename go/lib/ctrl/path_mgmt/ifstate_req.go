@@ -28,6 +28,9 @@ var _ proto.Cerealizable = (*IFStateReq)(nil)
 
 type IFStateReq struct {
 	IfID common.IFIDType
+	// Drain requests a revocation for the draining interface IfID. The
+	// interface keeps its state, the revocation is returned in the reply.
+	Drain bool
 }
 
 func (i *IFStateReq) ProtoId() proto.ProtoIdType {
@@ -35,5 +38,5 @@ func (i *IFStateReq) ProtoId() proto.ProtoIdType {
 }
 
 func (i *IFStateReq) String() string {
-	return fmt.Sprintf("IfID: %v", i.IfID)
+	return fmt.Sprintf("IfID: %v Drain: %v", i.IfID, i.Drain)
 }
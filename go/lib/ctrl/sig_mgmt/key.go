@@ -0,0 +1,78 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sig_mgmt
+
+import (
+	"fmt"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/proto"
+)
+
+var _ proto.Cerealizable = (*KeyAnnounce)(nil)
+
+// KeyAnnounce announces the key that the sending SIG uses to encrypt the
+// frames of a session in an epoch. The key is encrypted with the encryption
+// keys of the certificate chains of the sending and the receiving AS.
+type KeyAnnounce struct {
+	// Addr is the control address the KeyAck is sent to.
+	Addr            *Addr
+	Session         SessionType
+	Epoch           uint16
+	SrcChainVersion scrypto.Version
+	DstChainVersion scrypto.Version
+	Nonce           common.RawBytes
+	Key             common.RawBytes
+}
+
+func (k *KeyAnnounce) ProtoId() proto.ProtoIdType {
+	return proto.SIGKeyAnnounce_TypeID
+}
+
+func (k *KeyAnnounce) Write(b common.RawBytes) (int, error) {
+	return proto.WriteRoot(k, b)
+}
+
+func (k *KeyAnnounce) String() string {
+	return fmt.Sprintf("%s Session: %s Epoch: %d SrcChainVersion: %d DstChainVersion: %d",
+		k.Addr, k.Session, k.Epoch, k.SrcChainVersion, k.DstChainVersion)
+}
+
+var _ proto.Cerealizable = (*KeyAck)(nil)
+
+// KeyAck acknowledges that the key of a session epoch has been received.
+type KeyAck struct {
+	Session SessionType
+	Epoch   uint16
+	// Mac authenticates the session and epoch with the acknowledged key.
+	Mac common.RawBytes
+}
+
+func NewKeyAck(s SessionType, epoch uint16, mac common.RawBytes) *KeyAck {
+	return &KeyAck{Session: s, Epoch: epoch, Mac: mac}
+}
+
+func (k *KeyAck) ProtoId() proto.ProtoIdType {
+	return proto.SIGKeyAck_TypeID
+}
+
+func (k *KeyAck) Write(b common.RawBytes) (int, error) {
+	return proto.WriteRoot(k, b)
+}
+
+func (k *KeyAck) String() string {
+	return fmt.Sprintf("Session: %s Epoch: %d", k.Session, k.Epoch)
+}
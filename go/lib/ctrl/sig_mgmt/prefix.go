@@ -0,0 +1,134 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sig_mgmt
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/proto"
+)
+
+var _ proto.Cerealizable = (*PrefixAnnounce)(nil)
+
+// PrefixAnnounce announces the complete set of prefixes that are reachable
+// through the sending SIG. Prefixes that were part of an earlier announcement
+// but are missing from a newer one are withdrawn.
+type PrefixAnnounce struct {
+	// Version is increased by the sender whenever the set of prefixes changes.
+	Version uint64
+	// Lifetime is the number of seconds the prefixes are valid for, unless
+	// they are announced again.
+	Lifetime uint32
+	Prefixes []*Prefix
+}
+
+// NewPrefixAnnounce creates an announcement for the networks.
+func NewPrefixAnnounce(version uint64, lifetime time.Duration,
+	nets []*net.IPNet) *PrefixAnnounce {
+
+	p := &PrefixAnnounce{
+		Version:  version,
+		Lifetime: uint32(lifetime / time.Second),
+		Prefixes: make([]*Prefix, 0, len(nets)),
+	}
+	for _, n := range nets {
+		p.Prefixes = append(p.Prefixes, NewPrefix(n))
+	}
+	return p
+}
+
+// LifetimeDuration returns the lifetime of the announcement.
+func (p *PrefixAnnounce) LifetimeDuration() time.Duration {
+	return time.Duration(p.Lifetime) * time.Second
+}
+
+// Nets returns the announced networks. An error is returned if any of the
+// prefixes is malformed.
+func (p *PrefixAnnounce) Nets() ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, 0, len(p.Prefixes))
+	for _, prefix := range p.Prefixes {
+		n, err := prefix.IPNet()
+		if err != nil {
+			return nil, err
+		}
+		nets = append(nets, n)
+	}
+	return nets, nil
+}
+
+func (p *PrefixAnnounce) ProtoId() proto.ProtoIdType {
+	return proto.SIGPrefixAnnounce_TypeID
+}
+
+func (p *PrefixAnnounce) Write(b common.RawBytes) (int, error) {
+	return proto.WriteRoot(p, b)
+}
+
+func (p *PrefixAnnounce) String() string {
+	prefixes := make([]string, 0, len(p.Prefixes))
+	for _, prefix := range p.Prefixes {
+		prefixes = append(prefixes, prefix.String())
+	}
+	return fmt.Sprintf("Version: %d Lifetime: %ds Prefixes: [%s]", p.Version, p.Lifetime,
+		strings.Join(prefixes, " "))
+}
+
+var _ proto.Cerealizable = (*Prefix)(nil)
+
+// Prefix is an IPv4 or IPv6 network prefix.
+type Prefix struct {
+	IP     common.RawBytes `capnp:"ip"`
+	Length uint8
+}
+
+// NewPrefix creates a prefix from the network. IPv4 networks are encoded with
+// a 4 byte address.
+func NewPrefix(n *net.IPNet) *Prefix {
+	ip := n.IP.To4()
+	if ip == nil {
+		ip = n.IP.To16()
+	}
+	ones, _ := n.Mask.Size()
+	return &Prefix{IP: common.RawBytes(ip), Length: uint8(ones)}
+}
+
+// IPNet returns the network of the prefix. An error is returned if the
+// address has an invalid length, or the prefix length exceeds the address
+// length.
+func (p *Prefix) IPNet() (*net.IPNet, error) {
+	bits := len(p.IP) * 8
+	if bits != 8*net.IPv4len && bits != 8*net.IPv6len {
+		return nil, common.NewBasicError("Invalid prefix address length", nil,
+			"len", len(p.IP))
+	}
+	if int(p.Length) > bits {
+		return nil, common.NewBasicError("Invalid prefix length", nil,
+			"length", p.Length, "bits", bits)
+	}
+	mask := net.CIDRMask(int(p.Length), bits)
+	return &net.IPNet{IP: net.IP(p.IP).Mask(mask), Mask: mask}, nil
+}
+
+func (p *Prefix) ProtoId() proto.ProtoIdType {
+	return proto.SIGPrefix_TypeID
+}
+
+func (p *Prefix) String() string {
+	return fmt.Sprintf("%s/%d", net.IP(p.IP), p.Length)
+}
@@ -0,0 +1,119 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sig_mgmt_test
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/ctrl"
+	"github.com/scionproto/scion/go/lib/ctrl/sig_mgmt"
+	"github.com/scionproto/scion/go/proto"
+)
+
+func TestPrefixIPNetInvalid(t *testing.T) {
+	testCases := map[string]*sig_mgmt.Prefix{
+		"invalid address length": {IP: []byte{192, 0, 2}, Length: 8},
+		"IPv4 length too long":   {IP: []byte{192, 0, 2, 0}, Length: 33},
+		"IPv6 length too long":   {IP: make([]byte, net.IPv6len), Length: 129},
+	}
+	for name, prefix := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := prefix.IPNet()
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestPrefixAnnounceSerialize(t *testing.T) {
+	testCases := map[string]struct {
+		Nets      []*net.IPNet
+		IPLengths []int
+	}{
+		"IPv4": {
+			Nets: []*net.IPNet{
+				mustParseCIDR(t, "192.0.2.0/24"),
+				mustParseCIDR(t, "198.51.100.128/25"),
+			},
+			IPLengths: []int{net.IPv4len, net.IPv4len},
+		},
+		"IPv6": {
+			Nets: []*net.IPNet{
+				mustParseCIDR(t, "2001:db8:a0b:12f0::/64"),
+				mustParseCIDR(t, "2001:db8::/32"),
+			},
+			IPLengths: []int{net.IPv6len, net.IPv6len},
+		},
+		"IPv4 and IPv6": {
+			Nets: []*net.IPNet{
+				mustParseCIDR(t, "192.0.2.0/24"),
+				mustParseCIDR(t, "2001:db8:a0b:12f0::/64"),
+			},
+			IPLengths: []int{net.IPv4len, net.IPv6len},
+		},
+		"empty": {
+			Nets:      []*net.IPNet{},
+			IPLengths: []int{},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ann := sig_mgmt.NewPrefixAnnounce(42, 10*time.Minute, tc.Nets)
+			require.Len(t, ann.Prefixes, len(tc.IPLengths))
+			for i, prefix := range ann.Prefixes {
+				assert.Len(t, prefix.IP, tc.IPLengths[i])
+			}
+			decoded := roundTrip(t, ann)
+			assert.Equal(t, ann, decoded)
+			assert.Equal(t, uint64(42), decoded.Version)
+			assert.Equal(t, 10*time.Minute, decoded.LifetimeDuration())
+			nets, err := decoded.Nets()
+			require.NoError(t, err)
+			assert.Equal(t, tc.Nets, nets)
+		})
+	}
+}
+
+// roundTrip encodes the announcement in a control payload and decodes it
+// again.
+func roundTrip(t *testing.T, ann *sig_mgmt.PrefixAnnounce) *sig_mgmt.PrefixAnnounce {
+	sigPld, err := sig_mgmt.NewPld(1, ann)
+	require.NoError(t, err)
+	pld, err := ctrl.NewPld(sigPld, nil)
+	require.NoError(t, err)
+	raw, err := proto.PackRoot(pld)
+	require.NoError(t, err)
+	decodedPld, err := ctrl.NewPldFromRaw(raw)
+	require.NoError(t, err)
+	u, err := decodedPld.Union()
+	require.NoError(t, err)
+	decodedSIGPld, ok := u.(*sig_mgmt.Pld)
+	require.True(t, ok, "unexpected payload type %T", u)
+	u, err = decodedSIGPld.Union()
+	require.NoError(t, err)
+	decoded, ok := u.(*sig_mgmt.PrefixAnnounce)
+	require.True(t, ok, "unexpected payload type %T", u)
+	return decoded
+}
+
+func mustParseCIDR(t *testing.T, s string) *net.IPNet {
+	_, n, err := net.ParseCIDR(s)
+	require.NoError(t, err)
+	return n
+}
@@ -16,6 +16,7 @@ package pathmgr
 
 import (
 	"context"
+	"reflect"
 	"sync"
 	"time"
 
@@ -64,7 +65,9 @@ type DefaultPollingPolicy struct {
 
 func (pp *DefaultPollingPolicy) UpdateState(availablePaths spathmeta.AppPathSet) {
 	parameters := pp.getPollingParams(availablePaths)
-	if parameters != pp.params {
+	// The flags contain slices, and are thus not comparable with the
+	// equality operator.
+	if !reflect.DeepEqual(parameters, pp.params) {
 		pp.params = parameters
 		pp.runner.Stop()
 
@@ -64,6 +64,25 @@ func (a *ACL) UnmarshalJSON(b []byte) error {
 	return json.Unmarshal(b, &a.Entries)
 }
 
+func (a *ACL) MarshalYAML() (interface{}, error) {
+	return a.Entries, nil
+}
+
+// UnmarshalYAML unmarshals the ACL from a list of ACL entries. In contrast to
+// UnmarshalJSON, it returns an error if the ACL does not have a default entry.
+func (a *ACL) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var entries []*ACLEntry
+	if err := unmarshal(&entries); err != nil {
+		return err
+	}
+	acl, err := NewACL(entries...)
+	if err != nil {
+		return err
+	}
+	*a = *acl
+	return nil
+}
+
 func (a *ACL) evalPath(path Path) ACLAction {
 	for i, iface := range path.Interfaces() {
 		if a.evalInterface(iface, i%2 != 0) == Deny {
@@ -74,9 +93,16 @@ func (a *ACL) evalPath(path Path) ACLAction {
 }
 
 func (a *ACL) evalInterface(iface snet.PathInterface, ingress bool) ACLAction {
-	for _, aclEntry := range a.Entries {
+	_, aclEntry := a.matchInterface(iface, ingress)
+	return aclEntry.Action
+}
+
+// matchInterface returns the first entry that matches the interface, and its
+// index.
+func (a *ACL) matchInterface(iface snet.PathInterface, ingress bool) (int, *ACLEntry) {
+	for i, aclEntry := range a.Entries {
 		if aclEntry.Rule == nil || aclEntry.Rule.pathIFMatch(iface, ingress) {
-			return aclEntry.Action
+			return i, aclEntry
 		}
 	}
 	panic("Default ACL action missing")
@@ -128,6 +154,18 @@ func (ae *ACLEntry) UnmarshalJSON(b []byte) error {
 	return ae.LoadFromString(str)
 }
 
+func (ae *ACLEntry) MarshalYAML() (interface{}, error) {
+	return ae.String(), nil
+}
+
+func (ae *ACLEntry) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var str string
+	if err := unmarshal(&str); err != nil {
+		return err
+	}
+	return ae.LoadFromString(str)
+}
+
 func getAction(symbol string) (ACLAction, error) {
 	if symbol == allowSymbol {
 		return true, nil
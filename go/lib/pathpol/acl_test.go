@@ -19,6 +19,8 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	yaml "gopkg.in/yaml.v2"
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
@@ -60,6 +62,25 @@ func TestNewACL(t *testing.T) {
 	}
 }
 
+func TestACLYamlConversion(t *testing.T) {
+	acl, err := NewACL(
+		&ACLEntry{Action: Allow, Rule: mustHopPredicate(t, "1-ff00:0:110#2")},
+		&ACLEntry{Action: Deny, Rule: mustHopPredicate(t, "1-ff00:0:110#0")},
+		&ACLEntry{Action: Allow},
+	)
+	require.NoError(t, err)
+	raw, err := yaml.Marshal(acl)
+	require.NoError(t, err)
+	assert.Equal(t, "- + 1-ff00:0:110#2\n- '- 1-ff00:0:110#0'\n- +\n", string(raw))
+	var parsed ACL
+	require.NoError(t, yaml.Unmarshal(raw, &parsed))
+	assert.Equal(t, acl, &parsed)
+
+	err = yaml.Unmarshal([]byte(`["+ 1-ff00:0:110#2"]`), &parsed)
+	xtest.AssertErrorsIs(t, err, ErrNoDefault)
+	assert.Error(t, yaml.Unmarshal([]byte(`["* 1-ff00:0:110#2", "+"]`), &parsed))
+}
+
 func TestACLEntryLoadFromString(t *testing.T) {
 	tests := map[string]struct {
 		String         string
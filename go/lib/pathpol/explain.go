@@ -0,0 +1,216 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathpol
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/snet"
+)
+
+// Explanation describes how a policy evaluated a path.
+type Explanation struct {
+	// Selected indicates whether the path is part of the filtered path set.
+	Selected bool `json:"selected"`
+	// Reason is a human readable reason for the decision.
+	Reason string `json:"reason"`
+	// ACL contains the ACL entries that matched the interfaces of the path,
+	// in the order they were evaluated. Evaluation stops at the first
+	// interface that is denied. It is empty if the policy has no ACL.
+	ACL []ACLMatch `json:"acl,omitempty"`
+	// SequenceMatched indicates whether the sequence matched the path. It is
+	// nil if the sequence was not evaluated.
+	SequenceMatched *bool `json:"sequence_matched,omitempty"`
+	// FailedAttributes lists the path attributes that the path does not
+	// satisfy, e.g., "lat".
+	FailedAttributes []string `json:"failed_attributes,omitempty"`
+	// Option is the index of the option that selected the path. It is nil if
+	// the options were not evaluated, or no option selected the path.
+	Option *int `json:"option,omitempty"`
+	// Options contains the explanation of every option for this path. It is
+	// empty if the options were not evaluated.
+	Options []*Explanation `json:"options,omitempty"`
+}
+
+// ACLMatch is the ACL entry that matched an interface of a path.
+type ACLMatch struct {
+	Interface string    `json:"interface"`
+	Index     int       `json:"index"`
+	Entry     *ACLEntry `json:"entry"`
+}
+
+// Explain evaluates the policy for every path, and returns an explanation
+// keyed by the path fingerprint. The decisions are the same as the ones taken
+// by Filter.
+func (p *Policy) Explain(paths PathSet) map[snet.PathFingerprint]*Explanation {
+	return p.ExplainOpt(paths, FilterOptions{})
+}
+
+// ExplainOpt is the same as Explain, but uses the given filter options.
+func (p *Policy) ExplainOpt(paths PathSet,
+	opts FilterOptions) map[snet.PathFingerprint]*Explanation {
+
+	result := make(map[snet.PathFingerprint]*Explanation, len(paths))
+	if p == nil {
+		for key := range paths {
+			result[key] = &Explanation{Selected: true, Reason: "no policy"}
+		}
+		return result
+	}
+	now := time.Now()
+	remaining := make(PathSet)
+	for key, path := range paths {
+		e := &Explanation{}
+		result[key] = e
+		if p.explainPath(e, path, opts, now) {
+			remaining[key] = path
+		}
+	}
+	if len(p.Options) == 0 {
+		for key := range remaining {
+			result[key].Selected = true
+			result[key].Reason = "matched"
+		}
+		return result
+	}
+	p.explainOptions(result, remaining, opts)
+	return result
+}
+
+// explainPath evaluates the ACL, the sequence and the attributes of the
+// policy for the path, and returns whether the path passed all of them.
+func (p *Policy) explainPath(e *Explanation, path Path, opts FilterOptions,
+	now time.Time) bool {
+
+	if p.ACL != nil && len(p.ACL.Entries) > 0 {
+		e.ACL = p.ACL.explainPath(path)
+		if len(e.ACL) > 0 && e.ACL[len(e.ACL)-1].Entry.Action == Deny {
+			last := e.ACL[len(e.ACL)-1]
+			e.Reason = fmt.Sprintf("interface %s denied by acl[%d] %q",
+				last.Interface, last.Index, last.Entry)
+			return false
+		}
+	}
+	if p.Sequence != nil && !opts.IgnoreSequence && p.Sequence.String() != "" {
+		matched := len(p.Sequence.Eval(PathSet{path.Fingerprint(): path})) > 0
+		e.SequenceMatched = &matched
+		if !matched {
+			e.Reason = fmt.Sprintf("sequence %q not matched", p.Sequence)
+			return false
+		}
+	}
+	if p.hasAttributes() {
+		e.FailedAttributes = p.failedAttributes(computeMetrics(path, opts.LinkMetadata), now)
+		if len(e.FailedAttributes) > 0 {
+			e.Reason = fmt.Sprintf("attributes %v not satisfied", e.FailedAttributes)
+			return false
+		}
+	}
+	return true
+}
+
+// explainOptions explains the options for the paths that passed the top-level
+// policy. It mirrors evalOptions.
+func (p *Policy) explainOptions(result map[snet.PathFingerprint]*Explanation,
+	remaining PathSet, opts FilterOptions) {
+
+	subs := make([]map[snet.PathFingerprint]*Explanation, len(p.Options))
+	for i, option := range p.Options {
+		subs[i] = option.Policy.ExplainOpt(remaining, opts)
+	}
+	// Determine the options that are evaluated, the same way as evalOptions
+	// does. Options with a lower weight than a matching option are skipped.
+	evaluated := len(p.Options)
+	currWeight := p.Options[0].Weight
+	matched := false
+	for i, option := range p.Options {
+		if currWeight > option.Weight && matched {
+			evaluated = i
+			break
+		}
+		currWeight = option.Weight
+		for _, e := range subs[i] {
+			if e.Selected {
+				matched = true
+				break
+			}
+		}
+	}
+	for key := range remaining {
+		e := result[key]
+		for i := range p.Options {
+			sub := subs[i][key]
+			e.Options = append(e.Options, sub)
+			if e.Option == nil && i < evaluated && sub.Selected {
+				idx := i
+				e.Option = &idx
+			}
+		}
+		switch {
+		case e.Option != nil:
+			e.Selected = true
+			e.Reason = fmt.Sprintf("selected by option %d with weight %d",
+				*e.Option, p.Options[*e.Option].Weight)
+		case !matched:
+			e.Reason = "no option matched any path"
+		default:
+			e.Reason = fmt.Sprintf("not matched by any option with weight >= %d",
+				p.Options[evaluated-1].Weight)
+		}
+	}
+}
+
+// failedAttributes returns the names of the attributes the path metrics do not
+// satisfy.
+func (p *Policy) failedAttributes(m pathMetrics, now time.Time) []string {
+	attributes := []struct {
+		name   string
+		policy *Policy
+	}{
+		{name: "mtu", policy: &Policy{MTU: p.MTU}},
+		{name: "exp", policy: &Policy{Expiry: p.Expiry}},
+		{name: "hops", policy: &Policy{Hops: p.Hops}},
+		{name: "lat", policy: &Policy{Latency: p.Latency}},
+		{name: "bw", policy: &Policy{Bandwidth: p.Bandwidth}},
+		{name: "avoid_geo", policy: &Policy{AvoidGeo: p.AvoidGeo}},
+	}
+	var failed []string
+	for _, attr := range attributes {
+		if attr.policy.hasAttributes() && !attr.policy.satisfies(m, now) {
+			failed = append(failed, attr.name)
+		}
+	}
+	return failed
+}
+
+// explainPath returns the ACL entries that matched the interfaces of the path,
+// up to and including the first entry that denies an interface. It mirrors
+// evalPath.
+func (a *ACL) explainPath(path Path) []ACLMatch {
+	var matches []ACLMatch
+	for i, iface := range path.Interfaces() {
+		idx, entry := a.matchInterface(iface, i%2 != 0)
+		matches = append(matches, ACLMatch{
+			Interface: fmt.Sprintf("%s#%d", iface.IA(), iface.ID()),
+			Index:     idx,
+			Entry:     entry,
+		})
+		if entry.Action == Deny {
+			break
+		}
+	}
+	return matches
+}
@@ -0,0 +1,154 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathpol
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestExplain(t *testing.T) {
+	src := xtest.MustParseIA("1-ff00:0:110")
+	core := xtest.MustParseIA("1-ff00:0:120")
+	dst := xtest.MustParseIA("1-ff00:0:111")
+	viaCore := &metaPath{
+		testPath: testPath{
+			interfaces: []snet.PathInterface{
+				testPathIntf{ia: src, ifid: 1}, testPathIntf{ia: core, ifid: 1},
+				testPathIntf{ia: core, ifid: 2}, testPathIntf{ia: dst, ifid: 1},
+			},
+			key: "via_core",
+		},
+		mtu: 1472,
+	}
+	direct := &metaPath{
+		testPath: testPath{
+			interfaces: []snet.PathInterface{
+				testPathIntf{ia: src, ifid: 2}, testPathIntf{ia: dst, ifid: 2},
+			},
+			key: "direct",
+		},
+		mtu: 1280,
+	}
+	paths := PathSet{viaCore.key: viaCore, direct.key: direct}
+
+	t.Run("acl", func(t *testing.T) {
+		policy := &Policy{ACL: &ACL{Entries: []*ACLEntry{
+			{Action: Deny, Rule: mustHopPredicate(t, "1-ff00:0:120")},
+			allowEntry,
+		}}}
+		expl := policy.Explain(paths)
+		require.Len(t, expl, 2)
+		assert.True(t, expl["direct"].Selected)
+		assert.Len(t, expl["direct"].ACL, 2)
+		e := expl["via_core"]
+		assert.False(t, e.Selected)
+		require.Len(t, e.ACL, 2)
+		assert.Equal(t, ACLMatch{Interface: "1-ff00:0:120#1", Index: 0,
+			Entry: policy.ACL.Entries[0]}, e.ACL[1])
+		assert.Equal(t, `interface 1-ff00:0:120#1 denied by acl[0] "- 1-ff00:0:120#0"`,
+			e.Reason)
+	})
+	t.Run("sequence", func(t *testing.T) {
+		policy := &Policy{Sequence: newSequence(t, "1-ff00:0:110 1-ff00:0:111")}
+		expl := policy.Explain(paths)
+		assert.True(t, *expl["direct"].SequenceMatched)
+		assert.False(t, *expl["via_core"].SequenceMatched)
+		assert.False(t, expl["via_core"].Selected)
+		expl = policy.ExplainOpt(paths, FilterOptions{IgnoreSequence: true})
+		assert.Nil(t, expl["via_core"].SequenceMatched)
+		assert.True(t, expl["via_core"].Selected)
+	})
+	t.Run("attributes", func(t *testing.T) {
+		policy := &Policy{
+			MTU:    &IntPredicate{Op: CmpGE, Value: 1400},
+			Hops:   &IntPredicate{Op: CmpLE, Value: 1},
+			Expiry: &DurationPredicate{Op: CmpGE, Value: time.Minute},
+		}
+		expl := policy.Explain(paths)
+		assert.Equal(t, []string{"mtu", "exp"}, expl["direct"].FailedAttributes)
+		assert.Equal(t, []string{"exp", "hops"}, expl["via_core"].FailedAttributes)
+	})
+	t.Run("options", func(t *testing.T) {
+		policy := NewPolicy("", nil, nil, []Option{
+			{Weight: 2, Policy: &ExtPolicy{Policy: &Policy{
+				MTU: &IntPredicate{Op: CmpGT, Value: 1500},
+			}}},
+			{Weight: 1, Policy: &ExtPolicy{Policy: &Policy{
+				MTU: &IntPredicate{Op: CmpGT, Value: 1400},
+			}}},
+			{Weight: 0, Policy: &ExtPolicy{Policy: &Policy{}}},
+		})
+		expl := policy.Explain(paths)
+		e := expl["via_core"]
+		assert.True(t, e.Selected)
+		require.NotNil(t, e.Option)
+		assert.Equal(t, 1, *e.Option)
+		assert.Len(t, e.Options, 3)
+		assert.False(t, e.Options[0].Selected)
+		e = expl["direct"]
+		assert.False(t, e.Selected)
+		assert.Nil(t, e.Option)
+		// The last option matches, but is not evaluated.
+		assert.True(t, e.Options[2].Selected)
+		assert.Equal(t, "not matched by any option with weight >= 1", e.Reason)
+	})
+}
+
+func TestExplainConsistentWithFilter(t *testing.T) {
+	policies := map[string]*Policy{
+		"nil": nil,
+		"acl": {ACL: &ACL{Entries: []*ACLEntry{
+			{Action: Deny, Rule: mustHopPredicate(t, "1-ff00:0:130")},
+			allowEntry,
+		}}},
+		"sequence": {Sequence: newSequence(t, "0+ 1-ff00:0:111 0+")},
+		"options": NewPolicy("", nil, nil, []Option{
+			{Weight: 3, Policy: &ExtPolicy{Policy: &Policy{ACL: &ACL{Entries: []*ACLEntry{
+				{Action: Deny, Rule: mustHopPredicate(t, "1-ff00:0:120")},
+				allowEntry,
+			}}}}},
+			{Weight: 1, Policy: &ExtPolicy{Policy: &Policy{
+				Sequence: newSequence(t, "0+ 1-ff00:0:120 0+"),
+			}}},
+			{Weight: 1, Policy: &ExtPolicy{Policy: &Policy{
+				Sequence: newSequence(t, "0+ 2-ff00:0:210 0+"),
+			}}},
+		}),
+	}
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	pp := NewPathProvider(ctrl)
+	paths := pp.GetPaths(xtest.MustParseIA("1-ff00:0:110"), xtest.MustParseIA("2-ff00:0:220"))
+	for name, policy := range policies {
+		t.Run(name, func(t *testing.T) {
+			filtered := policy.Filter(paths)
+			expl := policy.Explain(paths)
+			require.Len(t, expl, len(paths))
+			for key, e := range expl {
+				_, ok := filtered[key]
+				assert.Equal(t, ok, e.Selected, "path %s: %s", key, e.Reason)
+				assert.NotEmpty(t, e.Reason)
+			}
+		})
+	}
+}
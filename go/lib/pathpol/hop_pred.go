@@ -138,6 +138,20 @@ func (hp *HopPredicate) UnmarshalJSON(b []byte) error {
 	return err
 }
 
+func (hp *HopPredicate) MarshalYAML() (interface{}, error) {
+	return hp.String(), nil
+}
+
+func (hp *HopPredicate) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var str string
+	if err := unmarshal(&str); err != nil {
+		return err
+	}
+	nhp, err := HopPredicateFromString(str)
+	*hp = *nhp
+	return err
+}
+
 func parseIfID(str string) (common.IFIDType, error) {
 	ifid, err := strconv.ParseUint(str, 10, 64)
 	if err != nil {
@@ -0,0 +1,378 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathpol
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// Severity is the severity of a diagnostic.
+type Severity string
+
+const (
+	// SeverityError indicates that the policy is invalid and can not be
+	// evaluated as intended.
+	SeverityError Severity = "error"
+	// SeverityWarning indicates that the policy is valid, but likely does not
+	// do what the author intended.
+	SeverityWarning Severity = "warning"
+)
+
+// Diagnostic is a problem found when linting a policy.
+type Diagnostic struct {
+	Severity Severity `json:"severity"`
+	// Policy is the name of the policy the problem was found in.
+	Policy string `json:"policy"`
+	// Location is the location of the problem within the policy, e.g.,
+	// "acl[2]" or "options[1]".
+	Location string `json:"location,omitempty"`
+	Message  string `json:"message"`
+}
+
+func (d Diagnostic) String() string {
+	if d.Location == "" {
+		return fmt.Sprintf("%s: %s: %s", d.Severity, d.Policy, d.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s: %s", d.Severity, d.Policy, d.Location, d.Message)
+}
+
+// Diagnostics is a list of diagnostics.
+type Diagnostics []Diagnostic
+
+// HasErrors returns whether any of the diagnostics is an error.
+func (d Diagnostics) HasErrors() bool {
+	for _, diag := range d {
+		if diag.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate lints the policies and returns an error if any of them is invalid.
+// Warnings are ignored.
+func (m PolicyMap) Validate() error {
+	var errs []string
+	for _, d := range m.Lint() {
+		if d.Severity == SeverityError {
+			errs = append(errs, d.String())
+		}
+	}
+	if len(errs) > 0 {
+		return serrors.New("invalid policies", "errors", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// Lint checks the policies for problems. It detects:
+//   - references to policies that do not exist,
+//   - circular extensions,
+//   - ACLs without a default entry,
+//   - ACL entries shadowed by a preceding catch-all entry,
+//   - options that are never evaluated, because an option with a higher weight
+//     matches all paths,
+//   - sequences that require a hop denied by the ACL.
+//
+// The diagnostics are sorted by policy name.
+func (m PolicyMap) Lint() Diagnostics {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	l := linter{policies: m, cyclic: make(map[string]bool)}
+	for _, name := range names {
+		l.lintCycles(name, nil)
+	}
+	for _, name := range names {
+		l.lintPolicy(name, m[name])
+	}
+	return l.diags
+}
+
+type linter struct {
+	policies PolicyMap
+	// cyclic contains the policies that are part of a reported cycle.
+	cyclic map[string]bool
+	diags  Diagnostics
+}
+
+func (l *linter) add(sev Severity, policy, location, format string, args ...interface{}) {
+	l.diags = append(l.diags, Diagnostic{
+		Severity: sev,
+		Policy:   policy,
+		Location: location,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// lintCycles reports circular extensions reachable from the named policy.
+// Each cycle is reported once, on the policy with the smallest name that is
+// part of it.
+func (l *linter) lintCycles(name string, chain []string) {
+	for i, n := range chain {
+		if n != name {
+			continue
+		}
+		cycle := chain[i:]
+		if l.cyclic[name] {
+			return
+		}
+		for _, c := range cycle {
+			l.cyclic[c] = true
+		}
+		first := 0
+		for j := range cycle {
+			if cycle[j] < cycle[first] {
+				first = j
+			}
+		}
+		rotated := append(append([]string{}, cycle[first:]...), cycle[:first]...)
+		l.add(SeverityError, rotated[0], "extends", "circular extension: %s",
+			strings.Join(append(rotated, rotated[0]), " -> "))
+		return
+	}
+	ext, ok := l.policies[name]
+	if !ok || ext == nil {
+		return
+	}
+	chain = append(chain, name)
+	for _, e := range ext.Extends {
+		l.lintCycles(e, chain)
+	}
+}
+
+func (l *linter) lintPolicy(name string, ext *ExtPolicy) {
+	if ext == nil {
+		l.add(SeverityError, name, "", "empty policy")
+		return
+	}
+	l.lintExtends(name, "extends", ext.Extends)
+	if ext.Policy == nil {
+		return
+	}
+	l.lintACL(name, "acl", ext.ACL)
+	l.lintOptions(name, "options", ext.Options)
+	if l.cyclic[name] {
+		// The effective policy can not be computed for circular policies.
+		return
+	}
+	var extended []*ExtPolicy
+	for n, p := range l.policies {
+		if p != nil {
+			extended = append(extended, named(n, p))
+		}
+	}
+	effective, err := PolicyFromExtPolicy(named(name, ext), extended)
+	if err != nil {
+		// Missing references are reported by lintExtends.
+		return
+	}
+	l.lintSequence(name, effective)
+}
+
+// named returns a copy of the policy with the given name. The policy names are
+// not part of the JSON representation, the keys of the map are authoritative.
+func named(name string, ext *ExtPolicy) *ExtPolicy {
+	p := &ExtPolicy{Extends: ext.Extends, Policy: &Policy{}}
+	if ext.Policy != nil {
+		*p.Policy = *ext.Policy
+	}
+	p.Name = name
+	return p
+}
+
+func (l *linter) lintExtends(name, location string, extends []string) {
+	for i, e := range extends {
+		if _, ok := l.policies[e]; !ok {
+			l.add(SeverityError, name, fmt.Sprintf("%s[%d]", location, i),
+				"extended policy %q does not exist", e)
+		}
+	}
+}
+
+func (l *linter) lintACL(name, location string, acl *ACL) {
+	if acl == nil {
+		return
+	}
+	catchAll := -1
+	for i, entry := range acl.Entries {
+		if catchAll >= 0 {
+			l.add(SeverityWarning, name, fmt.Sprintf("%s[%d]", location, i),
+				"entry %q is shadowed by catch-all entry %s[%d]", entry, location, catchAll)
+			continue
+		}
+		if entry.Rule.matchesAll() {
+			catchAll = i
+		}
+	}
+	if catchAll < 0 {
+		l.add(SeverityError, name, location, "ACL does not end with a default entry")
+	}
+}
+
+func (l *linter) lintOptions(name, location string, options []Option) {
+	type indexed struct {
+		Option
+		idx int
+	}
+	var sorted []indexed
+	for i, o := range options {
+		loc := fmt.Sprintf("%s[%d]", location, i)
+		if o.Policy == nil {
+			l.add(SeverityError, name, loc, "option has no policy")
+			continue
+		}
+		l.lintExtends(name, loc+".extends", o.Policy.Extends)
+		if o.Policy.Policy != nil {
+			l.lintACL(name, loc+".acl", o.Policy.ACL)
+			l.lintOptions(name, loc+".options", o.Policy.Options)
+		}
+		sorted = append(sorted, indexed{Option: o, idx: i})
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Weight > sorted[j].Weight
+	})
+	// Options with a lower weight than an option that matches all paths are
+	// never evaluated.
+	for i, o := range sorted {
+		if !o.Policy.matchesAll() {
+			continue
+		}
+		for _, shadowed := range sorted[i+1:] {
+			if shadowed.Weight < o.Weight {
+				l.add(SeverityWarning, name, fmt.Sprintf("%s[%d]", location, shadowed.idx),
+					"option is unreachable, option %s[%d] with higher weight matches all paths",
+					location, o.idx)
+			}
+		}
+		return
+	}
+}
+
+// matchesAll returns whether the extending policy trivially matches all paths.
+func (p *ExtPolicy) matchesAll() bool {
+	if len(p.Extends) > 0 {
+		return false
+	}
+	if p.Policy == nil {
+		return true
+	}
+	if p.Sequence != nil && p.Sequence.String() != "" {
+		return false
+	}
+	if len(p.Options) > 0 || p.hasAttributes() {
+		return false
+	}
+	if p.ACL == nil || len(p.ACL.Entries) == 0 {
+		return true
+	}
+	first := p.ACL.Entries[0]
+	return first.Action == Allow && first.Rule.matchesAll()
+}
+
+// lintSequence reports hops that the sequence requires, but the ACL denies.
+// Only sequences without alternatives and groups are checked.
+func (l *linter) lintSequence(name string, p *Policy) {
+	if p.Sequence == nil || p.ACL == nil {
+		return
+	}
+	seq := p.Sequence.String()
+	if strings.ContainsAny(seq, "|()") {
+		return
+	}
+	for _, token := range strings.Fields(seq) {
+		if strings.HasSuffix(token, "?") || strings.HasSuffix(token, "*") {
+			continue
+		}
+		hp, err := HopPredicateFromString(strings.TrimSuffix(token, "+"))
+		if err != nil {
+			continue
+		}
+		for i, entry := range p.ACL.Entries {
+			if !entry.Rule.overlaps(hp) {
+				continue
+			}
+			if entry.Action == Deny && entry.Rule.covers(hp) {
+				l.add(SeverityError, name, "sequence",
+					"sequence requires hop %q, which is denied by acl[%d] %q",
+					token, i, entry)
+			}
+			break
+		}
+	}
+}
+
+// covers returns whether every interface matched by other is also matched by
+// the hop predicate. A nil hop predicate matches all interfaces.
+func (hp *HopPredicate) covers(other *HopPredicate) bool {
+	if hp == nil {
+		return true
+	}
+	if hp.ISD != 0 && hp.ISD != other.ISD {
+		return false
+	}
+	if hp.AS != 0 && hp.AS != other.AS {
+		return false
+	}
+	if hp.ifIDsWildcard() {
+		return true
+	}
+	if len(hp.IfIDs) != len(other.IfIDs) {
+		return false
+	}
+	for i := range hp.IfIDs {
+		if hp.IfIDs[i] != 0 && hp.IfIDs[i] != other.IfIDs[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// overlaps returns whether there is an interface that is matched by both hop
+// predicates. It is conservative, i.e., it may report an overlap for
+// predicates with different interfaces.
+func (hp *HopPredicate) overlaps(other *HopPredicate) bool {
+	if hp == nil {
+		return true
+	}
+	if hp.ISD != 0 && other.ISD != 0 && hp.ISD != other.ISD {
+		return false
+	}
+	if hp.AS != 0 && other.AS != 0 && hp.AS != other.AS {
+		return false
+	}
+	if len(hp.IfIDs) == len(other.IfIDs) {
+		for i := range hp.IfIDs {
+			if hp.IfIDs[i] != 0 && other.IfIDs[i] != 0 && hp.IfIDs[i] != other.IfIDs[i] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (hp *HopPredicate) ifIDsWildcard() bool {
+	for _, ifid := range hp.IfIDs {
+		if ifid != 0 {
+			return false
+		}
+	}
+	return true
+}
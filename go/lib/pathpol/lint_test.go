@@ -0,0 +1,132 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathpol
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLint(t *testing.T) {
+	tests := map[string]struct {
+		Policies string
+		Expected []string
+	}{
+		"valid": {
+			Policies: `{
+				"base": {"acl": ["- 1-ff00:0:133", "+"]},
+				"top": {"extends": ["base"], "sequence": "1-ff00:0:110 0* 1-ff00:0:111"}
+			}`,
+		},
+		"missing reference": {
+			Policies: `{
+				"top": {"extends": ["base"]},
+				"opt": {"options": [{"policy": {"extends": ["other"]}}]}
+			}`,
+			Expected: []string{
+				`error: opt: options[0].extends[0]: extended policy "other" does not exist`,
+				`error: top: extends[0]: extended policy "base" does not exist`,
+			},
+		},
+		"cycle": {
+			Policies: `{
+				"c": {"extends": ["a"]},
+				"a": {"extends": ["b"]},
+				"b": {"extends": ["a"]},
+				"self": {"extends": ["self"]}
+			}`,
+			Expected: []string{
+				"error: a: extends: circular extension: a -> b -> a",
+				"error: self: extends: circular extension: self -> self",
+			},
+		},
+		"shadowed acl entries": {
+			Policies: `{
+				"acl": {"acl": ["- 1", "+", "- 2", "-"]}
+			}`,
+			Expected: []string{
+				`warning: acl: acl[2]: entry "- 2-0#0" is shadowed by catch-all entry acl[1]`,
+				`warning: acl: acl[3]: entry "-" is shadowed by catch-all entry acl[1]`,
+			},
+		},
+		"missing default": {
+			Policies: `{
+				"acl": {"acl": ["- 1"]}
+			}`,
+			Expected: []string{
+				"error: acl: acl: ACL does not end with a default entry",
+			},
+		},
+		"unreachable options": {
+			Policies: `{
+				"opt": {"options": [
+					{"weight": 1, "policy": {"acl": ["- 1", "+"]}},
+					{"weight": 2, "policy": {"acl": ["+"]}},
+					{"weight": 2, "policy": {"acl": ["- 2", "+"]}},
+					{"weight": 0, "policy": {}}
+				]}
+			}`,
+			Expected: []string{
+				"warning: opt: options[0]: option is unreachable, " +
+					"option options[1] with higher weight matches all paths",
+				"warning: opt: options[3]: option is unreachable, " +
+					"option options[1] with higher weight matches all paths",
+			},
+		},
+		"contradictory sequence": {
+			Policies: `{
+				"base": {"acl": ["- 1-ff00:0:133", "+"]},
+				"top": {"extends": ["base"], "sequence": "1-ff00:0:110 1-ff00:0:133+ 0*"},
+				"optional": {"extends": ["base"], "sequence": "1-ff00:0:110 1-ff00:0:133?"},
+				"allowed": {
+					"acl": ["+ 1-ff00:0:133#1", "- 1-ff00:0:133", "+"],
+					"sequence": "1-ff00:0:110 1-ff00:0:133"
+				}
+			}`,
+			Expected: []string{
+				`error: top: sequence: sequence requires hop "1-ff00:0:133+", ` +
+					`which is denied by acl[0] "- 1-ff00:0:133#0"`,
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var policies PolicyMap
+			require.NoError(t, json.Unmarshal([]byte(test.Policies), &policies))
+			var diags []string
+			for _, d := range policies.Lint() {
+				diags = append(diags, d.String())
+			}
+			assert.Equal(t, test.Expected, diags)
+			hasErrors := false
+			for _, d := range test.Expected {
+				hasErrors = hasErrors || d[:5] == "error"
+			}
+			assert.Equal(t, hasErrors, policies.Validate() != nil)
+		})
+	}
+}
+
+func TestCircularExtension(t *testing.T) {
+	extended := []*ExtPolicy{
+		{Extends: []string{"b"}, Policy: &Policy{Name: "a"}},
+		{Extends: []string{"a"}, Policy: &Policy{Name: "b"}},
+	}
+	_, err := PolicyFromExtPolicy(&ExtPolicy{Extends: []string{"a"}}, extended)
+	assert.Error(t, err)
+}
@@ -0,0 +1,373 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathpol
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/util"
+)
+
+// LinkInfo is the static metadata of an inter-AS link, as seen from one of
+// its interfaces.
+type LinkInfo struct {
+	// Latency is the latency of the link. Zero means unknown.
+	Latency util.DurWrap
+	// Bandwidth is the bandwidth of the link in Kbit/s. Zero means unknown.
+	Bandwidth uint64
+	// Geo is the location of the interface, if known.
+	Geo *GeoCoordinates `json:",omitempty"`
+}
+
+// GeoCoordinates is the geographic location of an interface.
+type GeoCoordinates struct {
+	Latitude  float32
+	Longitude float32
+	Address   string `json:",omitempty"`
+}
+
+// LinkMetadata provides static metadata about inter-AS links.
+type LinkMetadata interface {
+	// Link returns the metadata of the link attached to the interface ifid of
+	// AS ia.
+	Link(ia addr.IA, ifid common.IFIDType) (LinkInfo, bool)
+}
+
+// LinkMetadataPath is implemented by paths that carry their own link metadata,
+// e.g., the paths returned by SCIOND if it is configured with link metadata.
+type LinkMetadataPath interface {
+	LinkMetadata() LinkMetadata
+}
+
+// StaticLinkMetadata is link metadata from a local file. It is keyed by the
+// interface in the ISD-AS#IFID format, e.g., "1-ff00:0:110#1".
+type StaticLinkMetadata map[string]LinkInfo
+
+// LoadLinkMetadata loads static link metadata from a JSON file.
+func LoadLinkMetadata(file string) (StaticLinkMetadata, error) {
+	raw, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, serrors.WrapStr("unable to read link metadata", err, "file", file)
+	}
+	var parsed map[string]LinkInfo
+	if err := json.Unmarshal(raw, &parsed); err != nil {
+		return nil, serrors.WrapStr("unable to parse link metadata", err, "file", file)
+	}
+	// Normalize the keys, such that lookups do not depend on the formatting
+	// in the file.
+	md := make(StaticLinkMetadata, len(parsed))
+	for key, info := range parsed {
+		ia, ifid, err := parseIntfKey(key)
+		if err != nil {
+			return nil, serrors.WrapStr("invalid link metadata key", err,
+				"file", file, "key", key)
+		}
+		md[intfKey(ia, ifid)] = info
+	}
+	return md, nil
+}
+
+// Link returns the metadata of the given interface.
+func (m StaticLinkMetadata) Link(ia addr.IA, ifid common.IFIDType) (LinkInfo, bool) {
+	info, ok := m[intfKey(ia, ifid)]
+	return info, ok
+}
+
+func intfKey(ia addr.IA, ifid common.IFIDType) string {
+	return fmt.Sprintf("%s#%d", ia, ifid)
+}
+
+func parseIntfKey(key string) (addr.IA, common.IFIDType, error) {
+	parts := strings.Split(key, "#")
+	if len(parts) != 2 {
+		return addr.IA{}, 0, serrors.New("key must be of the form ISD-AS#IFID")
+	}
+	ia, err := addr.IAFromString(parts[0])
+	if err != nil {
+		return addr.IA{}, 0, err
+	}
+	ifid, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return addr.IA{}, 0, err
+	}
+	return ia, common.IFIDType(ifid), nil
+}
+
+// GeoRegion is a rectangular region, given as latitude and longitude ranges in
+// degrees.
+type GeoRegion struct {
+	MinLatitude  float32 `json:"min_lat"`
+	MaxLatitude  float32 `json:"max_lat"`
+	MinLongitude float32 `json:"min_long"`
+	MaxLongitude float32 `json:"max_long"`
+}
+
+// Contains returns whether the coordinates are within the region.
+func (r GeoRegion) Contains(c GeoCoordinates) bool {
+	return c.Latitude >= r.MinLatitude && c.Latitude <= r.MaxLatitude &&
+		c.Longitude >= r.MinLongitude && c.Longitude <= r.MaxLongitude
+}
+
+// CmpOp is a comparison operator of a path attribute predicate.
+type CmpOp string
+
+const (
+	CmpLT CmpOp = "<"
+	CmpLE CmpOp = "<="
+	CmpEQ CmpOp = "="
+	CmpGE CmpOp = ">="
+	CmpGT CmpOp = ">"
+)
+
+// parseCmp splits a predicate of the form "<op><value>" into operator and
+// value.
+func parseCmp(s string) (CmpOp, string, error) {
+	s = strings.TrimSpace(s)
+	// Two character operators must be checked first.
+	for _, op := range []CmpOp{CmpLE, CmpGE, CmpLT, CmpGT, CmpEQ} {
+		if strings.HasPrefix(s, string(op)) {
+			return op, strings.TrimSpace(s[len(op):]), nil
+		}
+	}
+	return "", "", serrors.New("missing comparison operator", "predicate", s)
+}
+
+func (op CmpOp) eval(cmp int) bool {
+	switch op {
+	case CmpLT:
+		return cmp < 0
+	case CmpLE:
+		return cmp <= 0
+	case CmpEQ:
+		return cmp == 0
+	case CmpGE:
+		return cmp >= 0
+	case CmpGT:
+		return cmp > 0
+	}
+	return false
+}
+
+// IntPredicate compares an integer path attribute to a value, e.g., ">=1400".
+type IntPredicate struct {
+	Op    CmpOp
+	Value uint64
+}
+
+// Eval returns whether v satisfies the predicate.
+func (p *IntPredicate) Eval(v uint64) bool {
+	switch {
+	case v < p.Value:
+		return p.Op.eval(-1)
+	case v > p.Value:
+		return p.Op.eval(1)
+	}
+	return p.Op.eval(0)
+}
+
+func (p IntPredicate) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("%s%d", p.Op, p.Value)), nil
+}
+
+func (p *IntPredicate) UnmarshalText(b []byte) error {
+	op, val, err := parseCmp(string(b))
+	if err != nil {
+		return err
+	}
+	v, err := strconv.ParseUint(val, 10, 64)
+	if err != nil {
+		return serrors.WrapStr("invalid predicate value", err, "predicate", string(b))
+	}
+	p.Op, p.Value = op, v
+	return nil
+}
+
+// DurationPredicate compares a duration path attribute to a value, e.g.,
+// "<=100ms".
+type DurationPredicate struct {
+	Op    CmpOp
+	Value time.Duration
+}
+
+// Eval returns whether d satisfies the predicate.
+func (p *DurationPredicate) Eval(d time.Duration) bool {
+	switch {
+	case d < p.Value:
+		return p.Op.eval(-1)
+	case d > p.Value:
+		return p.Op.eval(1)
+	}
+	return p.Op.eval(0)
+}
+
+func (p DurationPredicate) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("%s%s", p.Op, util.FmtDuration(p.Value))), nil
+}
+
+func (p *DurationPredicate) UnmarshalText(b []byte) error {
+	op, val, err := parseCmp(string(b))
+	if err != nil {
+		return err
+	}
+	d, err := util.ParseDuration(val)
+	if err != nil {
+		return serrors.WrapStr("invalid predicate value", err, "predicate", string(b))
+	}
+	p.Op, p.Value = op, d
+	return nil
+}
+
+// pathMetrics are the metrics of a path that policies can filter and order
+// on. Unknown metrics are represented by their zero value, or by a false known
+// flag where zero is a valid value.
+type pathMetrics struct {
+	mtu    uint16
+	expiry time.Time
+	// hops is the number of AS hops, i.e., the number of inter-AS links.
+	hops           int
+	latency        time.Duration
+	latencyKnown   bool
+	bandwidth      uint64
+	bandwidthKnown bool
+	locations      []GeoCoordinates
+	geoKnown       bool
+}
+
+// metricsPath is implemented by paths that know their MTU and expiry, e.g.,
+// snet.Path.
+type metricsPath interface {
+	MTU() uint16
+	Expiry() time.Time
+}
+
+// computeMetrics computes the metrics of the path. Link metadata is looked up
+// in md first, and in the metadata carried by the path second. The latency
+// and bandwidth are only known, if they are known for every link on the path.
+func computeMetrics(path Path, md LinkMetadata) pathMetrics {
+	var m pathMetrics
+	if mp, ok := path.(metricsPath); ok {
+		m.mtu = mp.MTU()
+		m.expiry = mp.Expiry()
+	}
+	var pathMD LinkMetadata
+	if lp, ok := path.(LinkMetadataPath); ok {
+		pathMD = lp.LinkMetadata()
+	}
+	lookup := func(ia addr.IA, ifid common.IFIDType) (LinkInfo, bool) {
+		if md != nil {
+			if info, ok := md.Link(ia, ifid); ok {
+				return info, true
+			}
+		}
+		if pathMD != nil {
+			return pathMD.Link(ia, ifid)
+		}
+		return LinkInfo{}, false
+	}
+	intfs := path.Interfaces()
+	m.hops = len(intfs) / 2
+	m.latencyKnown, m.bandwidthKnown, m.geoKnown = true, true, true
+	var infos []LinkInfo
+	for _, intf := range intfs {
+		info, _ := lookup(intf.IA(), intf.ID())
+		infos = append(infos, info)
+		if info.Geo == nil {
+			m.geoKnown = false
+			continue
+		}
+		m.locations = append(m.locations, *info.Geo)
+	}
+	// Interfaces come in pairs, each pair is one inter-AS link. The metadata
+	// of either end of the link is used.
+	for i := 0; i+1 < len(infos); i += 2 {
+		lat := infos[i].Latency.Duration
+		if lat == 0 {
+			lat = infos[i+1].Latency.Duration
+		}
+		if lat == 0 {
+			m.latencyKnown = false
+		}
+		m.latency += lat
+		bw := infos[i].Bandwidth
+		if bw == 0 {
+			bw = infos[i+1].Bandwidth
+		}
+		if bw == 0 {
+			m.bandwidthKnown = false
+		} else if m.bandwidth == 0 || bw < m.bandwidth {
+			m.bandwidth = bw
+		}
+	}
+	return m
+}
+
+// satisfies returns whether the path metrics satisfy all attribute predicates
+// of the policy. A predicate on an unknown metric is not satisfied.
+func (p *Policy) satisfies(m pathMetrics, now time.Time) bool {
+	if p.MTU != nil && (m.mtu == 0 || !p.MTU.Eval(uint64(m.mtu))) {
+		return false
+	}
+	if p.Expiry != nil && (m.expiry.IsZero() || !p.Expiry.Eval(m.expiry.Sub(now))) {
+		return false
+	}
+	if p.Hops != nil && !p.Hops.Eval(uint64(m.hops)) {
+		return false
+	}
+	if p.Latency != nil && (!m.latencyKnown || !p.Latency.Eval(m.latency)) {
+		return false
+	}
+	if p.Bandwidth != nil && (!m.bandwidthKnown || !p.Bandwidth.Eval(m.bandwidth)) {
+		return false
+	}
+	if len(p.AvoidGeo) > 0 {
+		if !m.geoKnown {
+			return false
+		}
+		for _, loc := range m.locations {
+			for _, region := range p.AvoidGeo {
+				if region.Contains(loc) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// hasAttributes returns whether the policy has any attribute predicates.
+func (p *Policy) hasAttributes() bool {
+	return p.MTU != nil || p.Expiry != nil || p.Hops != nil || p.Latency != nil ||
+		p.Bandwidth != nil || len(p.AvoidGeo) > 0
+}
+
+// evalAttributes filters the paths by the attribute predicates of the policy.
+func (p *Policy) evalAttributes(inputSet PathSet, opts FilterOptions) PathSet {
+	now := time.Now()
+	resultSet := make(PathSet)
+	for key, path := range inputSet {
+		if p.satisfies(computeMetrics(path, opts.LinkMetadata), now) {
+			resultSet[key] = path
+		}
+	}
+	return resultSet
+}
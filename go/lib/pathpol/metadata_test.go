@@ -0,0 +1,289 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathpol
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestPredicateText(t *testing.T) {
+	tests := map[string]struct {
+		Input   string
+		Pred    interface{}
+		Output  string
+		Invalid bool
+	}{
+		"int ge":          {Input: ">=1400", Pred: &IntPredicate{}, Output: ">=1400"},
+		"int spaces":      {Input: " < 3 ", Pred: &IntPredicate{}, Output: "<3"},
+		"int eq":          {Input: "=3", Pred: &IntPredicate{}, Output: "=3"},
+		"int no op":       {Input: "3", Pred: &IntPredicate{}, Invalid: true},
+		"int negative":    {Input: ">-3", Pred: &IntPredicate{}, Invalid: true},
+		"duration le":     {Input: "<=100ms", Pred: &DurationPredicate{}, Output: "<=100ms"},
+		"duration gt":     {Input: ">1h", Pred: &DurationPredicate{}, Output: ">1h"},
+		"duration no op":  {Input: "100ms", Pred: &DurationPredicate{}, Invalid: true},
+		"duration no val": {Input: "<=", Pred: &DurationPredicate{}, Invalid: true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			type textPred interface {
+				UnmarshalText([]byte) error
+				MarshalText() ([]byte, error)
+			}
+			p := test.Pred.(textPred)
+			err := p.UnmarshalText([]byte(test.Input))
+			if test.Invalid {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			out, err := p.MarshalText()
+			require.NoError(t, err)
+			assert.Equal(t, test.Output, string(out))
+		})
+	}
+}
+
+func TestPredicateEval(t *testing.T) {
+	lt := &IntPredicate{Op: CmpLT, Value: 3}
+	assert.True(t, lt.Eval(2))
+	assert.False(t, lt.Eval(3))
+	ge := &IntPredicate{Op: CmpGE, Value: 3}
+	assert.True(t, ge.Eval(3))
+	assert.False(t, ge.Eval(2))
+	le := &DurationPredicate{Op: CmpLE, Value: 100 * time.Millisecond}
+	assert.True(t, le.Eval(100*time.Millisecond))
+	assert.False(t, le.Eval(101*time.Millisecond))
+}
+
+func TestPolicyAttributes(t *testing.T) {
+	src := xtest.MustParseIA("1-ff00:0:110")
+	core := xtest.MustParseIA("1-ff00:0:120")
+	dst := xtest.MustParseIA("1-ff00:0:111")
+	zurich := &GeoCoordinates{Latitude: 47.37, Longitude: 8.54}
+	md := StaticLinkMetadata{
+		"1-ff00:0:110#1": {Latency: dur(10 * time.Millisecond), Bandwidth: 1000, Geo: zurich},
+		"1-ff00:0:120#1": {Geo: zurich},
+		"1-ff00:0:120#2": {Latency: dur(80 * time.Millisecond), Bandwidth: 100, Geo: zurich},
+		"1-ff00:0:111#1": {Geo: zurich},
+		"1-ff00:0:110#2": {Latency: dur(50 * time.Millisecond), Bandwidth: 10000},
+	}
+	// The long path has 2 hops, a latency of 90ms and a bandwidth of 100.
+	long := &metaPath{
+		testPath: testPath{
+			interfaces: []snet.PathInterface{
+				testPathIntf{ia: src, ifid: 1}, testPathIntf{ia: core, ifid: 1},
+				testPathIntf{ia: core, ifid: 2}, testPathIntf{ia: dst, ifid: 1},
+			},
+			key: "long",
+		},
+		mtu:    1472,
+		expiry: time.Now().Add(time.Hour),
+	}
+	// The short path has 1 hop, a latency of 50ms, a bandwidth of 10000 and
+	// no geo information.
+	short := &metaPath{
+		testPath: testPath{
+			interfaces: []snet.PathInterface{
+				testPathIntf{ia: src, ifid: 2}, testPathIntf{ia: dst, ifid: 2},
+			},
+			key: "short",
+		},
+		mtu:    1280,
+		expiry: time.Now().Add(10 * time.Minute),
+	}
+	paths := PathSet{long.key: long, short.key: short}
+
+	tests := map[string]struct {
+		Policy   *Policy
+		Metadata LinkMetadata
+		Expected []snet.PathFingerprint
+	}{
+		"mtu": {
+			Policy:   &Policy{MTU: &IntPredicate{Op: CmpGE, Value: 1400}},
+			Expected: []snet.PathFingerprint{"long"},
+		},
+		"expiry": {
+			Policy:   &Policy{Expiry: &DurationPredicate{Op: CmpGT, Value: 30 * time.Minute}},
+			Expected: []snet.PathFingerprint{"long"},
+		},
+		"hops": {
+			Policy:   &Policy{Hops: &IntPredicate{Op: CmpLE, Value: 1}},
+			Expected: []snet.PathFingerprint{"short"},
+		},
+		"latency": {
+			Policy:   &Policy{Latency: &DurationPredicate{Op: CmpLE, Value: 60 * time.Millisecond}},
+			Metadata: md,
+			Expected: []snet.PathFingerprint{"short"},
+		},
+		"latency unknown": {
+			Policy: &Policy{Latency: &DurationPredicate{Op: CmpLE, Value: time.Second}},
+		},
+		"bandwidth": {
+			Policy:   &Policy{Bandwidth: &IntPredicate{Op: CmpGE, Value: 100}},
+			Metadata: md,
+			Expected: []snet.PathFingerprint{"long", "short"},
+		},
+		"avoid geo": {
+			Policy: &Policy{AvoidGeo: []GeoRegion{
+				{MinLatitude: 0, MaxLatitude: 10, MinLongitude: 0, MaxLongitude: 10},
+			}},
+			Metadata: md,
+			Expected: []snet.PathFingerprint{"long"},
+		},
+		"avoid geo match": {
+			Policy: &Policy{AvoidGeo: []GeoRegion{
+				{MinLatitude: 45, MaxLatitude: 48, MinLongitude: 5, MaxLongitude: 11},
+			}},
+			Metadata: md,
+		},
+		"attributes in options": {
+			Policy: NewPolicy("", nil, nil, []Option{
+				{
+					Weight: 1,
+					Policy: &ExtPolicy{Policy: &Policy{
+						Latency: &DurationPredicate{Op: CmpLT, Value: 10 * time.Millisecond},
+					}},
+				},
+				{
+					Policy: &ExtPolicy{Policy: &Policy{
+						MTU: &IntPredicate{Op: CmpLT, Value: 1400},
+					}},
+				},
+			}),
+			Metadata: md,
+			Expected: []snet.PathFingerprint{"short"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := test.Policy.FilterOpt(paths, FilterOptions{LinkMetadata: test.Metadata})
+			var fps []snet.PathFingerprint
+			for fp := range result {
+				fps = append(fps, fp)
+			}
+			assert.ElementsMatch(t, test.Expected, fps)
+		})
+	}
+
+	t.Run("path metadata", func(t *testing.T) {
+		policy := &Policy{Latency: &DurationPredicate{Op: CmpLE, Value: 60 * time.Millisecond}}
+		withMD := &metaPath{testPath: short.testPath, md: md}
+		result := policy.Filter(PathSet{withMD.key: withMD})
+		assert.Len(t, result, 1)
+	})
+}
+
+func TestLoadLinkMetadata(t *testing.T) {
+	dir, cleanF := xtest.MustTempDir("", "pathpol")
+	defer cleanF()
+	write := func(content string) string {
+		file := filepath.Join(dir, "metadata.json")
+		require.NoError(t, ioutil.WriteFile(file, []byte(content), 0644))
+		return file
+	}
+
+	md, err := LoadLinkMetadata(write(`{
+		"1-ff00:0:0110#1": {"Latency": "10ms", "Bandwidth": 1000,
+			"Geo": {"Latitude": 47.37, "Longitude": 8.54, "Address": "Zurich"}}
+	}`))
+	require.NoError(t, err)
+	info, ok := md.Link(xtest.MustParseIA("1-ff00:0:110"), 1)
+	require.True(t, ok)
+	assert.Equal(t, 10*time.Millisecond, info.Latency.Duration)
+	assert.Equal(t, uint64(1000), info.Bandwidth)
+	assert.Equal(t, "Zurich", info.Geo.Address)
+	_, ok = md.Link(xtest.MustParseIA("1-ff00:0:110"), 2)
+	assert.False(t, ok)
+
+	_, err = LoadLinkMetadata(write(`{"1-ff00:0:110": {"Latency": "10ms"}}`))
+	assert.Error(t, err)
+	_, err = LoadLinkMetadata(filepath.Join(dir, "nonexistent.json"))
+	assert.Error(t, err)
+}
+
+func TestPolicyAttributesJson(t *testing.T) {
+	// Latency at most 100ms, avoid ISD 3.
+	raw := `{
+		"acl": ["- 3", "+"],
+		"lat": "<=100ms",
+		"mtu": ">=1280",
+		"avoid_geo": [{"min_lat": 45, "max_lat": 48, "min_long": 5, "max_long": 11}],
+		"order": ["lat", "hops"]
+	}`
+	var policy Policy
+	require.NoError(t, json.Unmarshal([]byte(raw), &policy))
+	assert.Equal(t, &DurationPredicate{Op: CmpLE, Value: 100 * time.Millisecond},
+		policy.Latency)
+	assert.Equal(t, &IntPredicate{Op: CmpGE, Value: 1280}, policy.MTU)
+	assert.Equal(t, []OrderKey{OrderLatency, OrderHops}, policy.Order)
+	assert.Len(t, policy.AvoidGeo, 1)
+	jsonPol, err := json.Marshal(&policy)
+	require.NoError(t, err)
+	var other Policy
+	require.NoError(t, json.Unmarshal(jsonPol, &other))
+	assert.Equal(t, policy, other)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"order": ["cost"]}`), &policy))
+	assert.Error(t, json.Unmarshal([]byte(`{"lat": "100ms"}`), &policy))
+}
+
+func TestExtendsAttributes(t *testing.T) {
+	extPolicy := &ExtPolicy{
+		Extends: []string{"latency"},
+		Policy:  &Policy{Hops: &IntPredicate{Op: CmpLE, Value: 3}},
+	}
+	extended := []*ExtPolicy{
+		{
+			Policy: &Policy{
+				Name:    "latency",
+				Latency: &DurationPredicate{Op: CmpLE, Value: 100 * time.Millisecond},
+				Hops:    &IntPredicate{Op: CmpLE, Value: 5},
+				Order:   []OrderKey{OrderLatency},
+			},
+		},
+	}
+	pol, err := PolicyFromExtPolicy(extPolicy, extended)
+	require.NoError(t, err)
+	assert.Equal(t, &IntPredicate{Op: CmpLE, Value: 3}, pol.Hops)
+	assert.Equal(t, &DurationPredicate{Op: CmpLE, Value: 100 * time.Millisecond}, pol.Latency)
+	assert.Equal(t, []OrderKey{OrderLatency}, pol.Order)
+}
+
+func dur(d time.Duration) util.DurWrap {
+	return util.DurWrap{Duration: d}
+}
+
+// metaPath is a test path with MTU, expiry and optional link metadata.
+type metaPath struct {
+	testPath
+	mtu    uint16
+	expiry time.Time
+	md     LinkMetadata
+}
+
+func (p *metaPath) MTU() uint16                { return p.mtu }
+func (p *metaPath) Expiry() time.Time          { return p.expiry }
+func (p *metaPath) LinkMetadata() LinkMetadata { return p.md }
@@ -0,0 +1,139 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathpol
+
+import (
+	"sort"
+
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// OrderKey is a path attribute paths can be ordered by.
+type OrderKey string
+
+const (
+	// OrderLatency prefers paths with lower latency.
+	OrderLatency OrderKey = "lat"
+	// OrderBandwidth prefers paths with higher bandwidth.
+	OrderBandwidth OrderKey = "bw"
+	// OrderHops prefers paths with fewer hops.
+	OrderHops OrderKey = "hops"
+	// OrderMTU prefers paths with higher MTU.
+	OrderMTU OrderKey = "mtu"
+	// OrderExpiry prefers paths that expire later.
+	OrderExpiry OrderKey = "exp"
+)
+
+func (k *OrderKey) UnmarshalText(b []byte) error {
+	switch key := OrderKey(b); key {
+	case OrderLatency, OrderBandwidth, OrderHops, OrderMTU, OrderExpiry:
+		*k = key
+		return nil
+	default:
+		return serrors.New("unknown order key", "key", string(b))
+	}
+}
+
+// Sort returns the paths ordered according to the order clauses of the
+// policy. Paths for which an attribute is unknown are ordered after the paths
+// for which it is known. Ties are broken by the path fingerprint, such that
+// the result is deterministic.
+func (p *Policy) Sort(paths PathSet) []Path {
+	return p.SortOpt(paths, FilterOptions{})
+}
+
+// SortOpt is the same as Sort, but uses the link metadata of the given
+// options.
+func (p *Policy) SortOpt(paths PathSet, opts FilterOptions) []Path {
+	var order []OrderKey
+	if p != nil {
+		order = p.Order
+	}
+	type entry struct {
+		path    Path
+		metrics pathMetrics
+	}
+	entries := make([]entry, 0, len(paths))
+	for _, path := range paths {
+		e := entry{path: path}
+		if len(order) > 0 {
+			e.metrics = computeMetrics(path, opts.LinkMetadata)
+		}
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		for _, key := range order {
+			if c := compareMetrics(key, entries[i].metrics, entries[j].metrics); c != 0 {
+				return c < 0
+			}
+		}
+		return entries[i].path.Fingerprint() < entries[j].path.Fingerprint()
+	})
+	result := make([]Path, 0, len(entries))
+	for _, e := range entries {
+		result = append(result, e.path)
+	}
+	return result
+}
+
+// compareMetrics returns a negative value if a is preferred over b for the
+// given key, a positive value if b is preferred, and 0 otherwise.
+func compareMetrics(key OrderKey, a, b pathMetrics) int {
+	switch key {
+	case OrderLatency:
+		if c := compareKnown(a.latencyKnown, b.latencyKnown); c != 0 {
+			return c
+		}
+		return compareInt(int64(a.latency), int64(b.latency))
+	case OrderBandwidth:
+		if c := compareKnown(a.bandwidthKnown, b.bandwidthKnown); c != 0 {
+			return c
+		}
+		return compareInt(int64(b.bandwidth), int64(a.bandwidth))
+	case OrderHops:
+		return compareInt(int64(a.hops), int64(b.hops))
+	case OrderMTU:
+		if c := compareKnown(a.mtu != 0, b.mtu != 0); c != 0 {
+			return c
+		}
+		return compareInt(int64(b.mtu), int64(a.mtu))
+	case OrderExpiry:
+		if c := compareKnown(!a.expiry.IsZero(), !b.expiry.IsZero()); c != 0 {
+			return c
+		}
+		return compareInt(b.expiry.UnixNano(), a.expiry.UnixNano())
+	}
+	return 0
+}
+
+func compareKnown(a, b bool) int {
+	switch {
+	case a && !b:
+		return -1
+	case !a && b:
+		return 1
+	}
+	return 0
+}
+
+func compareInt(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,99 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathpol
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestSort(t *testing.T) {
+	ia := xtest.MustParseIA("1-ff00:0:110")
+	md := StaticLinkMetadata{
+		"1-ff00:0:110#1": {Latency: dur(10 * time.Millisecond), Bandwidth: 100},
+		"1-ff00:0:110#2": {Latency: dur(10 * time.Millisecond), Bandwidth: 1000},
+		"1-ff00:0:110#3": {Latency: dur(50 * time.Millisecond), Bandwidth: 1000},
+	}
+	now := time.Now()
+	paths := PathSet{}
+	add := func(key snet.PathFingerprint, hops int, mtu uint16, exp time.Duration,
+		ifids ...int) {
+
+		var intfs []snet.PathInterface
+		for _, ifid := range ifids {
+			intfs = append(intfs, testPathIntf{ia: ia, ifid: common.IFIDType(ifid)})
+		}
+		for len(intfs) < 2*hops {
+			intfs = append(intfs, testPathIntf{ia: addr.IA{}, ifid: 0})
+		}
+		paths[key] = &metaPath{
+			testPath: testPath{interfaces: intfs, key: key},
+			mtu:      mtu,
+			expiry:   now.Add(exp),
+		}
+	}
+	// a: 10ms, 1 hop, bw 100. b: 20ms, 2 hops, bw 1000. c: 50ms, 1 hop.
+	// d: unknown latency.
+	add("a", 1, 1280, time.Hour, 1, 1)
+	add("b", 2, 1472, time.Minute, 2, 2, 2, 2)
+	add("c", 1, 1400, 2*time.Hour, 3, 3)
+	add("d", 1, 0, 0)
+
+	tests := map[string]struct {
+		Order    []OrderKey
+		Expected []snet.PathFingerprint
+	}{
+		"no order": {
+			Expected: []snet.PathFingerprint{"a", "b", "c", "d"},
+		},
+		"latency then hops": {
+			Order:    []OrderKey{OrderLatency, OrderHops},
+			Expected: []snet.PathFingerprint{"a", "b", "c", "d"},
+		},
+		"hops then latency": {
+			Order:    []OrderKey{OrderHops, OrderLatency},
+			Expected: []snet.PathFingerprint{"a", "c", "d", "b"},
+		},
+		"bandwidth": {
+			Order:    []OrderKey{OrderBandwidth},
+			Expected: []snet.PathFingerprint{"b", "c", "a", "d"},
+		},
+		"mtu": {
+			Order:    []OrderKey{OrderMTU},
+			Expected: []snet.PathFingerprint{"b", "c", "a", "d"},
+		},
+		"expiry": {
+			Order:    []OrderKey{OrderExpiry},
+			Expected: []snet.PathFingerprint{"c", "a", "b", "d"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			policy := &Policy{Order: test.Order}
+			var fps []snet.PathFingerprint
+			for _, path := range policy.SortOpt(paths, FilterOptions{LinkMetadata: md}) {
+				fps = append(fps, path.Fingerprint())
+			}
+			assert.Equal(t, test.Expected, fps)
+		})
+	}
+}
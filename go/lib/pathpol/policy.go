@@ -14,17 +14,25 @@
 // limitations under the License.
 
 // Package pathpol implements path policies, documentation in doc/PathPolicy.md
-// Currently implemented: ACL, Sequence, Extends and Options.
+// Currently implemented: ACL, Sequence, Extends, Options, path attribute
+// predicates (MTU, expiry, hops, latency, bandwidth, geo) and ordering.
 //
 // A policy has an Act() method that takes an AppPathSet and returns a filtered AppPathSet
 package pathpol
 
 import (
+	"encoding/json"
+	"io/ioutil"
 	"sort"
+	"strings"
 
 	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/serrors"
 )
 
+// ErrPolicyNotFound indicates that a policy is not part of the policy map.
+var ErrPolicyNotFound = serrors.New("policy not found")
+
 // ExtPolicy is an extending policy, it may have a list of policies it extends
 type ExtPolicy struct {
 	Extends []string `json:"extends,omitempty"`
@@ -36,10 +44,46 @@ type ExtPolicy struct {
 // guaranteed to yield an object that is identical to the initial one.
 type PolicyMap map[string]*ExtPolicy
 
+// LoadPolicyMap loads named policies from a JSON file and validates them.
+func LoadPolicyMap(file string) (PolicyMap, error) {
+	raw, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, serrors.WrapStr("unable to read policy file", err, "file", file)
+	}
+	var policies PolicyMap
+	if err := json.Unmarshal(raw, &policies); err != nil {
+		return nil, serrors.WrapStr("unable to parse policy file", err, "file", file)
+	}
+	if err := policies.Validate(); err != nil {
+		return nil, serrors.WithCtx(err, "file", file)
+	}
+	return policies, nil
+}
+
+// Resolve returns the policy with the given name. The policies it extends are
+// looked up in the map and applied.
+func (m PolicyMap) Resolve(name string) (*Policy, error) {
+	ext, ok := m[name]
+	if !ok || ext == nil {
+		return nil, serrors.WithCtx(ErrPolicyNotFound, "policy", name)
+	}
+	all := make([]*ExtPolicy, 0, len(m))
+	for n, p := range m {
+		if p != nil {
+			all = append(all, named(n, p))
+		}
+	}
+	return PolicyFromExtPolicy(named(name, ext), all)
+}
+
 // FilterOptions contains options for filtering.
 type FilterOptions struct {
 	// IgnoreSequence can be used to ignore the sequence part of policies.
 	IgnoreSequence bool
+	// LinkMetadata is the static link metadata used to evaluate the latency,
+	// bandwidth and geo attributes. It takes precedence over the metadata
+	// carried by the paths themselves.
+	LinkMetadata LinkMetadata
 }
 
 // Policy is a compiled path policy object, all extended policies have been merged.
@@ -48,18 +92,36 @@ type Policy struct {
 	ACL      *ACL      `json:"acl,omitempty"`
 	Sequence *Sequence `json:"sequence,omitempty"`
 	Options  []Option  `json:"options,omitempty"`
+	// MTU restricts the path MTU.
+	MTU *IntPredicate `json:"mtu,omitempty"`
+	// Expiry restricts the remaining lifetime of the path.
+	Expiry *DurationPredicate `json:"exp,omitempty"`
+	// Hops restricts the number of AS hops.
+	Hops *IntPredicate `json:"hops,omitempty"`
+	// Latency restricts the sum of the static link latencies.
+	Latency *DurationPredicate `json:"lat,omitempty"`
+	// Bandwidth restricts the minimum static link bandwidth in Kbit/s.
+	Bandwidth *IntPredicate `json:"bw,omitempty"`
+	// AvoidGeo lists regions the path must not traverse.
+	AvoidGeo []GeoRegion `json:"avoid_geo,omitempty"`
+	// Order defines the order of preference used by Sort.
+	Order []OrderKey `json:"order,omitempty"`
 }
 
 // NewPolicy creates a Policy and sorts its Options
 func NewPolicy(name string, acl *ACL, sequence *Sequence, options []Option) *Policy {
 	policy := &Policy{Name: name, ACL: acl, Sequence: sequence, Options: options}
-	// Sort Options by weight, descending
-	sort.Slice(policy.Options, func(i, j int) bool {
-		return policy.Options[i].Weight > policy.Options[j].Weight
-	})
+	policy.sortOptions()
 	return policy
 }
 
+// sortOptions sorts the Options by weight, descending.
+func (p *Policy) sortOptions() {
+	sort.SliceStable(p.Options, func(i, j int) bool {
+		return p.Options[i].Weight > p.Options[j].Weight
+	})
+}
+
 // Filter filters the path set according to the policy.
 func (p *Policy) Filter(paths PathSet) PathSet {
 	return p.FilterOpt(paths, FilterOptions{})
@@ -75,6 +137,9 @@ func (p *Policy) FilterOpt(paths PathSet, opts FilterOptions) PathSet {
 	if p.Sequence != nil && !opts.IgnoreSequence {
 		resultSet = p.Sequence.Eval(resultSet)
 	}
+	if p.hasAttributes() {
+		resultSet = p.evalAttributes(resultSet, opts)
+	}
 	// Filter on sub policies
 	if len(p.Options) > 0 {
 		resultSet = p.evalOptions(resultSet, opts)
@@ -82,14 +147,55 @@ func (p *Policy) FilterOpt(paths PathSet, opts FilterOptions) PathSet {
 	return resultSet
 }
 
-// PolicyFromExtPolicy creates a Policy from an extending Policy and the extended policies
+// PolicyFromExtPolicy creates a Policy from an extending Policy and the extended
+// policies. The Options of the resulting policy and of its nested policies are
+// sorted by weight, as policies loaded from JSON keep the order of the file.
 func PolicyFromExtPolicy(extPolicy *ExtPolicy, extended []*ExtPolicy) (*Policy, error) {
-	policy := extPolicy.Policy
-	if policy == nil {
-		policy = &Policy{}
+	policy, err := policyFromExtPolicy(extPolicy, extended, nil)
+	if err != nil {
+		return nil, err
+	}
+	policy.Options = sortedOptions(policy.Options)
+	return policy, nil
+}
+
+// sortedOptions returns the options sorted by weight, descending. The options
+// of nested policies are sorted as well. The options and nested policies are
+// copied, such that the loaded policies are not modified.
+func sortedOptions(options []Option) []Option {
+	if len(options) == 0 {
+		return options
+	}
+	sorted := make([]Option, 0, len(options))
+	for _, option := range options {
+		if option.Policy != nil && option.Policy.Policy != nil &&
+			len(option.Policy.Options) > 0 {
+
+			nested := *option.Policy.Policy
+			nested.Options = sortedOptions(nested.Options)
+			option.Policy = &ExtPolicy{Extends: option.Policy.Extends, Policy: &nested}
+		}
+		sorted = append(sorted, option)
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Weight > sorted[j].Weight
+	})
+	return sorted
+}
+
+// policyFromExtPolicy creates a Policy from an extending Policy. The chain
+// contains the names of the policies that are currently being resolved, it is
+// used to detect circular extensions.
+func policyFromExtPolicy(extPolicy *ExtPolicy, extended []*ExtPolicy,
+	chain []string) (*Policy, error) {
+
+	policy := &Policy{}
+	if extPolicy.Policy != nil {
+		// Copy the policy, such that the extended policies are not modified.
+		*policy = *extPolicy.Policy
 	}
 	// Apply all extended policies
-	if err := policy.applyExtended(extPolicy.Extends, extended); err != nil {
+	if err := policy.applyExtended(extPolicy.Extends, extended, chain); err != nil {
 		return nil, err
 	}
 	return policy, nil
@@ -97,8 +203,16 @@ func PolicyFromExtPolicy(extPolicy *ExtPolicy, extended []*ExtPolicy) (*Policy,
 
 // applyExtended adds attributes of extended policies to the extending policy if they are not
 // already set
-func (p *Policy) applyExtended(extends []string, exPolicies []*ExtPolicy) error {
-	// TODO(worxli): Prevent circular policies.
+func (p *Policy) applyExtended(extends []string, exPolicies []*ExtPolicy, chain []string) error {
+	if p.Name != "" {
+		for _, name := range chain {
+			if name == p.Name {
+				return common.NewBasicError("Circular policy extension", nil,
+					"chain", strings.Join(append(chain, p.Name), " -> "))
+			}
+		}
+		chain = append(chain, p.Name)
+	}
 	// traverse in reverse s.t. last entry of the list has precedence
 	for i := len(extends) - 1; i >= 0; i-- {
 		var policy *Policy
@@ -106,7 +220,7 @@ func (p *Policy) applyExtended(extends []string, exPolicies []*ExtPolicy) error
 		for _, exPol := range exPolicies {
 			if exPol.Name == extends[i] {
 				var err error
-				if policy, err = PolicyFromExtPolicy(exPol, exPolicies); err != nil {
+				if policy, err = policyFromExtPolicy(exPol, exPolicies, chain); err != nil {
 					return err
 				}
 			}
@@ -127,10 +241,38 @@ func (p *Policy) applyExtended(extends []string, exPolicies []*ExtPolicy) error
 		if p.Sequence == nil {
 			p.Sequence = policy.Sequence
 		}
+		// Replace attributes
+		p.applyExtendedAttributes(policy)
 	}
 	return nil
 }
 
+// applyExtendedAttributes sets the path attributes and the order of the
+// extended policy if they are not already set.
+func (p *Policy) applyExtendedAttributes(policy *Policy) {
+	if p.MTU == nil {
+		p.MTU = policy.MTU
+	}
+	if p.Expiry == nil {
+		p.Expiry = policy.Expiry
+	}
+	if p.Hops == nil {
+		p.Hops = policy.Hops
+	}
+	if p.Latency == nil {
+		p.Latency = policy.Latency
+	}
+	if p.Bandwidth == nil {
+		p.Bandwidth = policy.Bandwidth
+	}
+	if len(p.AvoidGeo) == 0 {
+		p.AvoidGeo = policy.AvoidGeo
+	}
+	if len(p.Order) == 0 {
+		p.Order = policy.Order
+	}
+}
+
 // evalOptions evaluates the options of a policy and returns the pathSet that matches the option
 // with the highest weight
 func (p *Policy) evalOptions(inputSet PathSet, opts FilterOptions) PathSet {
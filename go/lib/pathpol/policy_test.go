@@ -586,6 +586,73 @@ func TestFilterOpt(t *testing.T) {
 	}
 }
 
+func TestPolicyMapResolve(t *testing.T) {
+	policies := PolicyMap{
+		"base": &ExtPolicy{
+			Policy: &Policy{
+				Hops:  &IntPredicate{Op: CmpLE, Value: 3},
+				Order: []OrderKey{OrderHops},
+			},
+		},
+		"mtu": &ExtPolicy{
+			Extends: []string{"base"},
+			Policy: &Policy{
+				MTU: &IntPredicate{Op: CmpGE, Value: 1400},
+			},
+		},
+	}
+	t.Run("extended attributes are applied", func(t *testing.T) {
+		pol, err := policies.Resolve("mtu")
+		require.NoError(t, err)
+		assert.Equal(t, "mtu", pol.Name)
+		assert.Equal(t, &IntPredicate{Op: CmpGE, Value: 1400}, pol.MTU)
+		assert.Equal(t, &IntPredicate{Op: CmpLE, Value: 3}, pol.Hops)
+		assert.Equal(t, []OrderKey{OrderHops}, pol.Order)
+		// The map itself is not modified.
+		assert.Nil(t, policies["mtu"].Hops)
+		assert.Empty(t, policies["mtu"].Name)
+	})
+	t.Run("unknown policy", func(t *testing.T) {
+		_, err := policies.Resolve("unknown")
+		xtest.AssertErrorsIs(t, err, ErrPolicyNotFound)
+	})
+}
+
+func TestPolicyMapResolveOptionsOrder(t *testing.T) {
+	raw := `{
+		"base": {"options": [
+			{"weight": 1, "policy": {"hops": "<=2"}},
+			{"weight": 3, "policy": {"hops": "<=4"}},
+			{"weight": 2, "policy": {"options": [
+				{"weight": 1, "policy": {"hops": "<=1"}},
+				{"weight": 2, "policy": {"hops": "<=3"}}
+			]}}
+		]},
+		"ext": {"extends": ["base"]}
+	}`
+	var policies PolicyMap
+	require.NoError(t, json.Unmarshal([]byte(raw), &policies))
+	weights := func(options []Option) []int {
+		var w []int
+		for _, o := range options {
+			w = append(w, o.Weight)
+		}
+		return w
+	}
+	for _, name := range []string{"base", "ext"} {
+		t.Run(name, func(t *testing.T) {
+			pol, err := policies.Resolve(name)
+			require.NoError(t, err)
+			assert.Equal(t, []int{3, 2, 1}, weights(pol.Options))
+			// The options of nested policies are sorted as well.
+			assert.Equal(t, []int{2, 1}, weights(pol.Options[1].Policy.Options))
+		})
+	}
+	// The map itself is not modified.
+	assert.Equal(t, []int{1, 3, 2}, weights(policies["base"].Options))
+	assert.Equal(t, []int{1, 2}, weights(policies["base"].Options[2].Policy.Options))
+}
+
 func TestPolicyJsonConversion(t *testing.T) {
 	policy := NewPolicy("", nil, nil, []Option{
 		{
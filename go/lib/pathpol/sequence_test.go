@@ -20,6 +20,8 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	yaml "gopkg.in/yaml.v2"
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/xtest"
@@ -41,6 +43,18 @@ func TestNewSequence(t *testing.T) {
 	}
 }
 
+func TestSequenceYamlConversion(t *testing.T) {
+	var s struct {
+		Sequence *Sequence `yaml:"Sequence"`
+	}
+	require.NoError(t, yaml.Unmarshal([]byte(`Sequence: "1-ff00:0:133#0 0*"`), &s))
+	assert.Equal(t, "1-ff00:0:133#0 0*", s.Sequence.String())
+	raw, err := yaml.Marshal(s)
+	require.NoError(t, err)
+	assert.Equal(t, "Sequence: 1-ff00:0:133#0 0*\n", string(raw))
+	assert.Error(t, yaml.Unmarshal([]byte(`Sequence: "1#0"`), &s))
+}
+
 func TestSequenceEval(t *testing.T) {
 	tests := map[string]struct {
 		Seq        *Sequence
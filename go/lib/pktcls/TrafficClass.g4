@@ -4,6 +4,7 @@ WHITESPACE: [ \r\n\t]+ -> skip;
 DIGITS: '0' | [1-9] [0-9]*;
 HEX_DIGITS: ('a' .. 'f' | 'A' .. 'F' | [0-9])+;
 NET: DIGITS '.' DIGITS '.' DIGITS '.' DIGITS '/' DIGITS;
+NET6: ('a' .. 'f' | 'A' .. 'F' | [0-9] | ':')+ '/' DIGITS;
 
 ANY: 'ANY' | 'any';
 ALL: 'ALL' | 'all';
@@ -13,11 +14,21 @@ SRC: 'SRC' | 'src';
 DST: 'DST' | 'dst';
 DSCP: 'DSCP' | 'dscp';
 TOS: 'TOS' | 'tos';
+TC: 'TC' | 'tc';
+FLOW: 'FLOW' | 'flow';
+PROTO: 'PROTO' | 'proto';
+SPORT: 'SPORT' | 'sport';
+DPORT: 'DPORT' | 'dport';
 
-matchSrc: SRC '=' NET;
-matchDst: DST '=' NET;
+matchSrc: SRC '=' (NET | NET6);
+matchDst: DST '=' (NET | NET6);
 matchDSCP: DSCP '=0x' (HEX_DIGITS | DIGITS);
 matchTOS: TOS '=0x' (HEX_DIGITS | DIGITS);
+matchTC: TC '=0x' (HEX_DIGITS | DIGITS);
+matchFlowLabel: FLOW '=0x' (HEX_DIGITS | DIGITS);
+matchProto: PROTO '=' DIGITS;
+matchSrcPort: SPORT '=' DIGITS ('-' DIGITS)?;
+matchDstPort: DPORT '=' DIGITS ('-' DIGITS)?;
 
 condCls: 'cls=' DIGITS;
 condAny: ANY '(' cond (',' cond)* ')';
@@ -25,6 +36,7 @@ condAll: ALL '(' cond (',' cond)* ')';
 condNot: NOT '(' cond ')';
 condBool: BOOL '=' ('true' | 'false');
 
-condIPv4: matchSrc | matchDst | matchDSCP | matchTOS;
-cond: condAll | condAny | condNot | condIPv4 | condCls | condBool;
+condIP: matchSrc | matchDst | matchDSCP | matchTOS | matchTC | matchFlowLabel | matchProto;
+condPort: matchSrcPort | matchDstPort;
+cond: condAll | condAny | condNot | condIP | condPort | condCls | condBool;
 trafficClass: cond EOF;
@@ -74,6 +74,45 @@ func TestClassMapMarshalUnMarshal(t *testing.T) {
 				),
 			},
 		},
+		{
+			Name:     "IPv6 and transport",
+			FileName: "class_3",
+			Classes: pktcls.ClassMap{
+				"web over IPv6": pktcls.NewClass(
+					"web over IPv6",
+					pktcls.NewCondAllOf(
+						pktcls.NewCondIPv6(&pktcls.IPv6MatchSource{
+							Net: &net.IPNet{
+								IP:   net.ParseIP("2001:db8::"),
+								Mask: net.CIDRMask(32, 128),
+							},
+						}),
+						pktcls.NewCondIPv6(&pktcls.IPv6MatchDestination{
+							Net: &net.IPNet{
+								IP:   net.ParseIP("2001:db8:1::"),
+								Mask: net.CIDRMask(48, 128),
+							},
+						}),
+						pktcls.NewCondTransport(&pktcls.MatchProtocol{Protocol: 6}),
+						pktcls.NewCondTransport(&pktcls.MatchDestinationPort{
+							MinPort: 443,
+							MaxPort: 443,
+						}),
+					),
+				),
+				"bulk": pktcls.NewClass(
+					"bulk",
+					pktcls.NewCondAnyOf(
+						pktcls.NewCondIPv6(&pktcls.IPv6MatchTrafficClass{TrafficClass: 0x20}),
+						pktcls.NewCondIPv6(&pktcls.IPv6MatchFlowLabel{FlowLabel: 0xbeef}),
+						pktcls.NewCondTransport(&pktcls.MatchSourcePort{
+							MinPort: 6000,
+							MaxPort: 6100,
+						}),
+					),
+				),
+			},
+		},
 		{
 			Name:     "nil ClassMap stays nil",
 			FileName: "class_2",
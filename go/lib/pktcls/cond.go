@@ -216,6 +216,93 @@ func (c *CondIPv4) UnmarshalJSON(b []byte) error {
 	return err
 }
 
+var _ Cond = (*CondIPv6)(nil)
+
+// CondIPv6 conditions return true if the embedded IPv6 predicate returns true.
+type CondIPv6 struct {
+	Predicate IPv6Predicate
+}
+
+func NewCondIPv6(p IPv6Predicate) *CondIPv6 {
+	return &CondIPv6{Predicate: p}
+}
+
+func (c *CondIPv6) Eval(v interface{}) bool {
+	if v == nil {
+		return false
+	}
+	pkt := v.(*Packet)
+	// Protect against typed nils
+	if pkt == nil {
+		return false
+	}
+	parsedPkt, ok := pkt.parsedPkt.Layer(layers.LayerTypeIPv6).(*layers.IPv6)
+	if !ok || parsedPkt == nil {
+		return false
+	}
+	return c.Predicate.Eval(parsedPkt)
+}
+
+func (c *CondIPv6) Type() string {
+	return TypeCondIPv6
+}
+
+func (c *CondIPv6) String() string {
+	return c.Predicate.String()
+}
+
+func (c *CondIPv6) MarshalJSON() ([]byte, error) {
+	return marshalInterface(c.Predicate)
+}
+
+func (c *CondIPv6) UnmarshalJSON(b []byte) error {
+	var err error
+	c.Predicate, err = unmarshalIPv6Predicate(b)
+	return err
+}
+
+var _ Cond = (*CondTransport)(nil)
+
+// CondTransport conditions return true if the embedded transport predicate
+// returns true. They apply to both IPv4 and IPv6 packets.
+type CondTransport struct {
+	Predicate TransportPredicate
+}
+
+func NewCondTransport(p TransportPredicate) *CondTransport {
+	return &CondTransport{Predicate: p}
+}
+
+func (c *CondTransport) Eval(v interface{}) bool {
+	if v == nil {
+		return false
+	}
+	pkt := v.(*Packet)
+	// Protect against typed nils
+	if pkt == nil || pkt.parsedPkt == nil {
+		return false
+	}
+	return c.Predicate.Eval(pkt.parsedPkt)
+}
+
+func (c *CondTransport) Type() string {
+	return TypeCondTransport
+}
+
+func (c *CondTransport) String() string {
+	return c.Predicate.String()
+}
+
+func (c *CondTransport) MarshalJSON() ([]byte, error) {
+	return marshalInterface(c.Predicate)
+}
+
+func (c *CondTransport) UnmarshalJSON(b []byte) error {
+	var err error
+	c.Predicate, err = unmarshalTransportPredicate(b)
+	return err
+}
+
 const typeCondClass = "CondClass"
 
 // CondClass conditions return true if the embedded traffic class returns true
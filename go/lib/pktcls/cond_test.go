@@ -147,6 +147,91 @@ func TestIPCond(t *testing.T) {
 			),
 			ExpEval: false,
 		},
+		{
+			Name: "Match IPv6 source and traffic class",
+			Cond: pktcls.NewCondAllOf(
+				pktcls.NewCondIPv6(
+					&pktcls.IPv6MatchSource{
+						Net: &net.IPNet{
+							IP:   net.ParseIP("2001:db8::"),
+							Mask: net.CIDRMask(32, 128),
+						},
+					},
+				),
+				pktcls.NewCondIPv6(&pktcls.IPv6MatchTrafficClass{TrafficClass: 0xb8}),
+				pktcls.NewCondIPv6(&pktcls.IPv6MatchFlowLabel{FlowLabel: 0x12345}),
+			),
+			Packet: newTestPacketLayers(
+				newTestIPv6(layers.IPProtocolNoNextHeader),
+				gopacket.Payload([]byte{1, 1, 1, 1}),
+			),
+			ExpEval: true,
+		},
+		{
+			Name: "IPv4 predicate does not match IPv6 packet",
+			Cond: pktcls.NewCondIPv4(
+				&pktcls.IPv4MatchSource{
+					Net: &net.IPNet{
+						IP:   net.IP{0, 0, 0, 0},
+						Mask: net.IPv4Mask(0, 0, 0, 0),
+					},
+				},
+			),
+			Packet: newTestPacketLayers(
+				newTestIPv6(layers.IPProtocolNoNextHeader),
+				gopacket.Payload([]byte{1, 1, 1, 1}),
+			),
+			ExpEval: false,
+		},
+		{
+			Name: "Match IPv4 UDP protocol and ports",
+			Cond: pktcls.NewCondAllOf(
+				pktcls.NewCondTransport(&pktcls.MatchProtocol{Protocol: 17}),
+				pktcls.NewCondTransport(&pktcls.MatchSourcePort{MinPort: 53, MaxPort: 53}),
+				pktcls.NewCondTransport(
+					&pktcls.MatchDestinationPort{MinPort: 1000, MaxPort: 2000},
+				),
+			),
+			Packet: newTestPacketLayers(
+				&layers.IPv4{
+					Version:  4,
+					TTL:      64,
+					Protocol: layers.IPProtocolUDP,
+					SrcIP:    net.IP{192, 168, 1, 1},
+					DstIP:    net.IP{10, 0, 0, 2},
+				},
+				&layers.UDP{SrcPort: 53, DstPort: 1500},
+				gopacket.Payload([]byte{1, 1, 1, 1}),
+			),
+			ExpEval: true,
+		},
+		{
+			Name: "Match IPv6 TCP destination port outside range",
+			Cond: pktcls.NewCondAllOf(
+				pktcls.NewCondTransport(&pktcls.MatchProtocol{Protocol: 6}),
+				pktcls.NewCondTransport(
+					&pktcls.MatchDestinationPort{MinPort: 1000, MaxPort: 2000},
+				),
+			),
+			Packet: newTestPacketLayers(
+				newTestIPv6(layers.IPProtocolTCP),
+				&layers.TCP{SrcPort: 40000, DstPort: 443, DataOffset: 5},
+				gopacket.Payload([]byte{1, 1, 1, 1}),
+			),
+			ExpEval: false,
+		},
+		{
+			Name: "Match protocol behind IPv6 extension header",
+			Cond: pktcls.NewCondTransport(&pktcls.MatchProtocol{Protocol: 17}),
+			Packet: newTestPacketLayers(
+				newTestIPv6(layers.IPProtocolIPv6Fragment),
+				// Fragment header: next header UDP, offset 0, last fragment.
+				gopacket.Payload([]byte{17, 0, 0, 0, 0, 0, 0, 1}),
+				&layers.UDP{SrcPort: 53, DstPort: 1500},
+				gopacket.Payload([]byte{1, 1, 1, 1}),
+			),
+			ExpEval: true,
+		},
 	}
 
 	for _, test := range testCases {
@@ -158,6 +243,7 @@ func TestIPCond(t *testing.T) {
 
 func TestStringer(t *testing.T) {
 	_, net, _ := net.ParseCIDR("12.12.12.0/26")
+	_, net6 := mustParseCIDR(t, "2001:db8::/32")
 	tests := map[string]struct {
 		Cond pktcls.Cond
 		Str  string
@@ -183,6 +269,19 @@ func TestStringer(t *testing.T) {
 				},
 			},
 		},
+		"ALL IPv6 proto ports": {
+			Str: "all(src=2001:db8::/32,tc=0xb8,flow=0x1,proto=6,sport=53,dport=1000-2000)",
+			Cond: pktcls.CondAllOf{
+				pktcls.NewCondIPv6(&pktcls.IPv6MatchSource{Net: net6}),
+				pktcls.NewCondIPv6(&pktcls.IPv6MatchTrafficClass{TrafficClass: uint8(0xb8)}),
+				pktcls.NewCondIPv6(&pktcls.IPv6MatchFlowLabel{FlowLabel: uint32(0x1)}),
+				pktcls.NewCondTransport(&pktcls.MatchProtocol{Protocol: 6}),
+				pktcls.NewCondTransport(&pktcls.MatchSourcePort{MinPort: 53, MaxPort: 53}),
+				pktcls.NewCondTransport(
+					&pktcls.MatchDestinationPort{MinPort: 1000, MaxPort: 2000},
+				),
+			},
+		},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -205,3 +304,25 @@ func newTestPacket(ipv4 *layers.IPv4, pld []byte) *pktcls.Packet {
 	)
 	return pktcls.NewPacket(buf.Bytes())
 }
+
+func newTestPacketLayers(l ...gopacket.SerializableLayer) *pktcls.Packet {
+	buf := gopacket.NewSerializeBuffer()
+	gopacket.SerializeLayers(
+		buf,
+		gopacket.SerializeOptions{FixLengths: true},
+		l...,
+	)
+	return pktcls.NewPacket(buf.Bytes())
+}
+
+func newTestIPv6(next layers.IPProtocol) *layers.IPv6 {
+	return &layers.IPv6{
+		Version:      6,
+		TrafficClass: 0xb8,
+		FlowLabel:    0x12345,
+		NextHeader:   next,
+		HopLimit:     64,
+		SrcIP:        net.ParseIP("2001:db8::1"),
+		DstIP:        net.ParseIP("2001:db8:1::1"),
+	}
+}
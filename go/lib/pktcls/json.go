@@ -50,6 +50,17 @@ const (
 	TypeIPv4MatchDestination = "MatchDestination"
 	TypeIPv4MatchToS         = "MatchToS"
 	TypeIPv4MatchDSCP        = "MatchDSCP"
+
+	TypeCondIPv6              = "CondIPv6"
+	TypeIPv6MatchSource       = "IPv6MatchSource"
+	TypeIPv6MatchDestination  = "IPv6MatchDestination"
+	TypeIPv6MatchTrafficClass = "IPv6MatchTrafficClass"
+	TypeIPv6MatchFlowLabel    = "IPv6MatchFlowLabel"
+
+	TypeCondTransport        = "CondTransport"
+	TypeMatchProtocol        = "MatchProtocol"
+	TypeMatchSourcePort      = "MatchSourcePort"
+	TypeMatchDestinationPort = "MatchDestinationPort"
 )
 
 // generic container for marshaling custom data
@@ -112,6 +123,42 @@ func unmarshalInterface(b []byte) (Typer, error) {
 			var p IPv4MatchDSCP
 			err := json.Unmarshal(*v, &p)
 			return &p, err
+		case TypeCondIPv6:
+			var c CondIPv6
+			err := json.Unmarshal(*v, &c)
+			return &c, err
+		case TypeIPv6MatchSource:
+			var p IPv6MatchSource
+			err := json.Unmarshal(*v, &p)
+			return &p, err
+		case TypeIPv6MatchDestination:
+			var p IPv6MatchDestination
+			err := json.Unmarshal(*v, &p)
+			return &p, err
+		case TypeIPv6MatchTrafficClass:
+			var p IPv6MatchTrafficClass
+			err := json.Unmarshal(*v, &p)
+			return &p, err
+		case TypeIPv6MatchFlowLabel:
+			var p IPv6MatchFlowLabel
+			err := json.Unmarshal(*v, &p)
+			return &p, err
+		case TypeCondTransport:
+			var c CondTransport
+			err := json.Unmarshal(*v, &c)
+			return &c, err
+		case TypeMatchProtocol:
+			var p MatchProtocol
+			err := json.Unmarshal(*v, &p)
+			return &p, err
+		case TypeMatchSourcePort:
+			var p MatchSourcePort
+			err := json.Unmarshal(*v, &p)
+			return &p, err
+		case TypeMatchDestinationPort:
+			var p MatchDestinationPort
+			err := json.Unmarshal(*v, &p)
+			return &p, err
 		default:
 			return nil, common.NewBasicError("Unknown type", nil, "type", k)
 		}
@@ -145,6 +192,32 @@ func unmarshalPredicate(b []byte) (IPv4Predicate, error) {
 	return p, nil
 }
 
+// unmarshalIPv6Predicate extracts an IPv6Predicate from a JSON encoding
+func unmarshalIPv6Predicate(b []byte) (IPv6Predicate, error) {
+	t, err := unmarshalInterface(b)
+	if err != nil {
+		return nil, err
+	}
+	p, ok := t.(IPv6Predicate)
+	if !ok {
+		return nil, serrors.New("Unable to extract IPv6Predicate from interface")
+	}
+	return p, nil
+}
+
+// unmarshalTransportPredicate extracts a TransportPredicate from a JSON encoding
+func unmarshalTransportPredicate(b []byte) (TransportPredicate, error) {
+	t, err := unmarshalInterface(b)
+	if err != nil {
+		return nil, err
+	}
+	p, ok := t.(TransportPredicate)
+	if !ok {
+		return nil, serrors.New("Unable to extract TransportPredicate from interface")
+	}
+	return p, nil
+}
+
 // Special case slices because we only need them for Conds
 
 func marshalCondSlice(conds []Cond) ([]byte, error) {
@@ -17,6 +17,7 @@ package pktcls
 import (
 	"net"
 	"strconv"
+	"strings"
 
 	"github.com/antlr/antlr4/runtime/Go/antlr"
 
@@ -69,6 +70,12 @@ func (l *classListener) EnterTrafficClass(ctx *traffic_class.TrafficClassContext
 
 func (l *classListener) EnterMatchDst(ctx *traffic_class.MatchDstContext) {
 	// Push Selector as Predicate on stack and update the number of Conds on the stack
+	if ctx.NET6() != nil {
+		mdst := &IPv6MatchDestination{}
+		mdst.Net = l.parseIPv6Net(ctx.GetStop().GetText())
+		l.pushCond(NewCondIPv6(mdst))
+		return
+	}
 	var err error
 	mdst := &IPv4MatchDestination{}
 	_, mdst.Net, err = net.ParseCIDR(ctx.GetStop().GetText())
@@ -80,6 +87,12 @@ func (l *classListener) EnterMatchDst(ctx *traffic_class.MatchDstContext) {
 
 func (l *classListener) EnterMatchSrc(ctx *traffic_class.MatchSrcContext) {
 	// Push Selector as Predicate on stack and update the number of Conds on the stack
+	if ctx.NET6() != nil {
+		msrc := &IPv6MatchSource{}
+		msrc.Net = l.parseIPv6Net(ctx.GetStop().GetText())
+		l.pushCond(NewCondIPv6(msrc))
+		return
+	}
 	var err error
 	msrc := &IPv4MatchSource{}
 	_, msrc.Net, err = net.ParseCIDR(ctx.GetStop().GetText())
@@ -111,6 +124,55 @@ func (l *classListener) EnterMatchTOS(ctx *traffic_class.MatchTOSContext) {
 	l.pushCond(NewCondIPv4(mtos))
 }
 
+func (l *classListener) EnterMatchTC(ctx *traffic_class.MatchTCContext) {
+	// Push Selector as Predicate on stack and update the number of Conds on the stack
+	mtc := &IPv6MatchTrafficClass{}
+	tc, err := strconv.ParseUint(ctx.GetStop().GetText(), 16, 8)
+	if err != nil {
+		l.err = common.NewBasicError("TC parsing failed!", err, "tc", ctx.GetStop().GetText())
+	}
+	mtc.TrafficClass = uint8(tc)
+	l.pushCond(NewCondIPv6(mtc))
+}
+
+func (l *classListener) EnterMatchFlowLabel(ctx *traffic_class.MatchFlowLabelContext) {
+	// Push Selector as Predicate on stack and update the number of Conds on the stack
+	mflow := &IPv6MatchFlowLabel{}
+	flow, err := strconv.ParseUint(ctx.GetStop().GetText(), 16, 20)
+	if err != nil {
+		l.err = common.NewBasicError("Flow label parsing failed!", err,
+			"flow", ctx.GetStop().GetText())
+	}
+	mflow.FlowLabel = uint32(flow)
+	l.pushCond(NewCondIPv6(mflow))
+}
+
+func (l *classListener) EnterMatchProto(ctx *traffic_class.MatchProtoContext) {
+	// Push Selector as Predicate on stack and update the number of Conds on the stack
+	mproto := &MatchProtocol{}
+	proto, err := strconv.ParseUint(ctx.GetStop().GetText(), 10, 8)
+	if err != nil {
+		l.err = common.NewBasicError("Protocol parsing failed!", err,
+			"proto", ctx.GetStop().GetText())
+	}
+	mproto.Protocol = uint8(proto)
+	l.pushCond(NewCondTransport(mproto))
+}
+
+func (l *classListener) EnterMatchSrcPort(ctx *traffic_class.MatchSrcPortContext) {
+	// Push Selector as Predicate on stack and update the number of Conds on the stack
+	msport := &MatchSourcePort{}
+	msport.MinPort, msport.MaxPort = l.parsePorts(ctx.AllDIGITS())
+	l.pushCond(NewCondTransport(msport))
+}
+
+func (l *classListener) EnterMatchDstPort(ctx *traffic_class.MatchDstPortContext) {
+	// Push Selector as Predicate on stack and update the number of Conds on the stack
+	mdport := &MatchDestinationPort{}
+	mdport.MinPort, mdport.MaxPort = l.parsePorts(ctx.AllDIGITS())
+	l.pushCond(NewCondTransport(mdport))
+}
+
 func (l *classListener) EnterCondCls(ctx *traffic_class.CondClsContext) {
 	l.pushCond(CondClass{TrafficClass: ctx.GetStop().GetText()})
 }
@@ -153,6 +215,27 @@ func (l *classListener) EnterCondBool(ctx *traffic_class.CondBoolContext) {
 	l.pushCond(CondBool(bool))
 }
 
+func (l *classListener) parseIPv6Net(cidr string) *net.IPNet {
+	network, err := parseIPv6Net(cidr)
+	if err != nil {
+		l.err = common.NewBasicError("CIDR parsing failed!", err, "cidr", cidr)
+	}
+	return network
+}
+
+// parsePorts parses the port or the port range given by the DIGITS tokens.
+func (l *classListener) parsePorts(nodes []antlr.TerminalNode) (uint16, uint16) {
+	texts := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		texts = append(texts, node.GetText())
+	}
+	min, max, err := parsePortRange(strings.Join(texts, "-"))
+	if err != nil {
+		l.err = common.NewBasicError("Port parsing failed!", err)
+	}
+	return min, max
+}
+
 // ValidateTrafficClass validates the structure of the class param
 func ValidateTrafficClass(class string) error {
 	p := buildTrafficClassParser(class)
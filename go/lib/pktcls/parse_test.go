@@ -19,6 +19,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/scionproto/scion/go/lib/pktcls"
 )
@@ -109,6 +110,76 @@ func TestTrafficClassValidation(t *testing.T) {
 			Class: "ANY(dscp=0x2,ALL(dst=12.12.12.0/24,dscp=0x2, NOT(src=2.2.2.0/28)))",
 			Valid: true,
 		},
+		{
+			Name:  "src IPv6Cond",
+			Class: "src=2001:db8::/32",
+			Valid: true,
+		},
+		{
+			Name:  "dst IPv6Cond",
+			Class: "dst=2001:DB8:a::/48",
+			Valid: true,
+		},
+		{
+			Name:  "bad dst IPv6Cond",
+			Class: "dst=2001:db8::",
+			Valid: false,
+		},
+		{
+			Name:  "bad dst IPv6Cond mask",
+			Class: "dst=2001:db8::/129",
+			Valid: false,
+		},
+		{
+			Name:  "tc IPv6Cond",
+			Class: "tc=0xb8",
+			Valid: true,
+		},
+		{
+			Name:  "flow IPv6Cond",
+			Class: "flow=0x12345",
+			Valid: true,
+		},
+		{
+			Name:  "bad flow IPv6Cond",
+			Class: "flow=0x123456",
+			Valid: false,
+		},
+		{
+			Name:  "proto TransportCond",
+			Class: "proto=17",
+			Valid: true,
+		},
+		{
+			Name:  "bad proto TransportCond",
+			Class: "proto=256",
+			Valid: false,
+		},
+		{
+			Name:  "sport TransportCond",
+			Class: "sport=53",
+			Valid: true,
+		},
+		{
+			Name:  "dport range TransportCond",
+			Class: "dport=1000-2000",
+			Valid: true,
+		},
+		{
+			Name:  "bad dport range TransportCond",
+			Class: "dport=2000-1000",
+			Valid: false,
+		},
+		{
+			Name:  "bad dport TransportCond",
+			Class: "dport=65536",
+			Valid: false,
+		},
+		{
+			Name:  "ALL IPv6 proto ports",
+			Class: "ALL(src=2001:db8::/32,proto=6,ANY(dport=80,dport=443),NOT(sport=0-1023))",
+			Valid: true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -125,6 +196,7 @@ func TestTrafficClassValidation(t *testing.T) {
 
 func TestTrafficClassTree(t *testing.T) {
 	_, net, _ := net.ParseCIDR("12.12.12.0/26")
+	_, net6 := mustParseCIDR(t, "2001:db8::/32")
 	testCases := []struct {
 		Name  string
 		Class string
@@ -205,6 +277,55 @@ func TestTrafficClassTree(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name:  "src IPv6Cond",
+			Class: "src=2001:db8::/32",
+			Tree: pktcls.NewCondIPv6(
+				&pktcls.IPv6MatchSource{Net: net6},
+			),
+		},
+		{
+			Name:  "dst IPv6Cond",
+			Class: "dst=2001:db8::/32",
+			Tree: pktcls.NewCondIPv6(
+				&pktcls.IPv6MatchDestination{Net: net6},
+			),
+		},
+		{
+			Name:  "tc IPv6Cond",
+			Class: "tc=0xb8",
+			Tree: pktcls.NewCondIPv6(
+				&pktcls.IPv6MatchTrafficClass{TrafficClass: uint8(0xb8)},
+			),
+		},
+		{
+			Name:  "flow IPv6Cond",
+			Class: "flow=0x12345",
+			Tree: pktcls.NewCondIPv6(
+				&pktcls.IPv6MatchFlowLabel{FlowLabel: uint32(0x12345)},
+			),
+		},
+		{
+			Name:  "proto TransportCond",
+			Class: "proto=17",
+			Tree: pktcls.NewCondTransport(
+				&pktcls.MatchProtocol{Protocol: 17},
+			),
+		},
+		{
+			Name:  "sport TransportCond",
+			Class: "sport=53",
+			Tree: pktcls.NewCondTransport(
+				&pktcls.MatchSourcePort{MinPort: 53, MaxPort: 53},
+			),
+		},
+		{
+			Name:  "dport range TransportCond",
+			Class: "dport=1000-2000",
+			Tree: pktcls.NewCondTransport(
+				&pktcls.MatchDestinationPort{MinPort: 1000, MaxPort: 2000},
+			),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -215,3 +336,9 @@ func TestTrafficClassTree(t *testing.T) {
 		})
 	}
 }
+
+func mustParseCIDR(t *testing.T, cidr string) (net.IP, *net.IPNet) {
+	ip, network, err := net.ParseCIDR(cidr)
+	require.NoError(t, err)
+	return ip, network
+}
@@ -0,0 +1,229 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pktcls
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+
+	"github.com/scionproto/scion/go/lib/common"
+)
+
+// TransportPredicate describes a single test on the transport protocol of an
+// IP packet. The predicates are independent of the IP version.
+type TransportPredicate interface {
+	// Eval returns true if the decoded packet matched the predicate
+	Eval(gopacket.Packet) bool
+	Typer
+	fmt.Stringer
+}
+
+var _ TransportPredicate = (*MatchProtocol)(nil)
+
+// MatchProtocol checks whether the upper layer protocol of the packet matches.
+// For IPv6, the extension header chain is skipped.
+type MatchProtocol struct {
+	Protocol uint8
+}
+
+func (m *MatchProtocol) Type() string {
+	return TypeMatchProtocol
+}
+
+func (m *MatchProtocol) Eval(p gopacket.Packet) bool {
+	proto, ok := upperLayerProtocol(p)
+	return ok && m.Protocol == uint8(proto)
+}
+
+func (m *MatchProtocol) String() string {
+	return fmt.Sprintf("proto=%d", m.Protocol)
+}
+
+func (m *MatchProtocol) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		jsonContainer{
+			"Protocol": strconv.Itoa(int(m.Protocol)),
+		},
+	)
+}
+
+func (m *MatchProtocol) UnmarshalJSON(b []byte) error {
+	i, err := unmarshalUintField(b, TypeMatchProtocol, "Protocol", 8)
+	if err != nil {
+		return err
+	}
+	m.Protocol = uint8(i)
+	return nil
+}
+
+var _ TransportPredicate = (*MatchSourcePort)(nil)
+
+// MatchSourcePort checks whether the TCP or UDP source port is contained in the
+// inclusive range [MinPort, MaxPort]. Packets that are neither TCP nor UDP never
+// match.
+type MatchSourcePort struct {
+	MinPort uint16
+	MaxPort uint16
+}
+
+func (m *MatchSourcePort) Type() string {
+	return TypeMatchSourcePort
+}
+
+func (m *MatchSourcePort) Eval(p gopacket.Packet) bool {
+	src, _, ok := transportPorts(p)
+	return ok && m.MinPort <= src && src <= m.MaxPort
+}
+
+func (m *MatchSourcePort) String() string {
+	return fmt.Sprintf("sport=%s", formatPortRange(m.MinPort, m.MaxPort))
+}
+
+func (m *MatchSourcePort) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		jsonContainer{
+			"Ports": formatPortRange(m.MinPort, m.MaxPort),
+		},
+	)
+}
+
+func (m *MatchSourcePort) UnmarshalJSON(b []byte) error {
+	s, err := unmarshalStringField(b, TypeMatchSourcePort, "Ports")
+	if err != nil {
+		return err
+	}
+	m.MinPort, m.MaxPort, err = parsePortRange(s)
+	return err
+}
+
+var _ TransportPredicate = (*MatchDestinationPort)(nil)
+
+// MatchDestinationPort checks whether the TCP or UDP destination port is
+// contained in the inclusive range [MinPort, MaxPort]. Packets that are neither
+// TCP nor UDP never match.
+type MatchDestinationPort struct {
+	MinPort uint16
+	MaxPort uint16
+}
+
+func (m *MatchDestinationPort) Type() string {
+	return TypeMatchDestinationPort
+}
+
+func (m *MatchDestinationPort) Eval(p gopacket.Packet) bool {
+	_, dst, ok := transportPorts(p)
+	return ok && m.MinPort <= dst && dst <= m.MaxPort
+}
+
+func (m *MatchDestinationPort) String() string {
+	return fmt.Sprintf("dport=%s", formatPortRange(m.MinPort, m.MaxPort))
+}
+
+func (m *MatchDestinationPort) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		jsonContainer{
+			"Ports": formatPortRange(m.MinPort, m.MaxPort),
+		},
+	)
+}
+
+func (m *MatchDestinationPort) UnmarshalJSON(b []byte) error {
+	s, err := unmarshalStringField(b, TypeMatchDestinationPort, "Ports")
+	if err != nil {
+		return err
+	}
+	m.MinPort, m.MaxPort, err = parsePortRange(s)
+	return err
+}
+
+// upperLayerProtocol returns the protocol carried by the first IP header of the
+// packet. IPv6 extension headers are skipped.
+func upperLayerProtocol(p gopacket.Packet) (layers.IPProtocol, bool) {
+	var proto layers.IPProtocol
+	found := false
+	for _, l := range p.Layers() {
+		switch h := l.(type) {
+		case *layers.IPv4:
+			if found {
+				return proto, true
+			}
+			return h.Protocol, true
+		case *layers.IPv6:
+			if found {
+				return proto, true
+			}
+			proto, found = h.NextHeader, true
+			if h.HopByHop != nil {
+				proto = h.HopByHop.NextHeader
+			}
+		case *layers.IPv6Routing:
+			proto = h.NextHeader
+		case *layers.IPv6Destination:
+			proto = h.NextHeader
+		case *layers.IPv6Fragment:
+			proto = h.NextHeader
+		default:
+			if found {
+				return proto, true
+			}
+		}
+	}
+	return proto, found
+}
+
+// transportPorts returns the source and destination ports of TCP and UDP
+// packets.
+func transportPorts(p gopacket.Packet) (uint16, uint16, bool) {
+	switch t := p.TransportLayer().(type) {
+	case *layers.TCP:
+		return uint16(t.SrcPort), uint16(t.DstPort), true
+	case *layers.UDP:
+		return uint16(t.SrcPort), uint16(t.DstPort), true
+	default:
+		return 0, 0, false
+	}
+}
+
+func formatPortRange(min, max uint16) string {
+	if min == max {
+		return strconv.Itoa(int(min))
+	}
+	return fmt.Sprintf("%d-%d", min, max)
+}
+
+// parsePortRange parses a single port ("80") or an inclusive port range
+// ("1000-2000").
+func parsePortRange(s string) (uint16, uint16, error) {
+	parts := strings.SplitN(s, "-", 2)
+	min, err := strconv.ParseUint(parts[0], 10, 16)
+	if err != nil {
+		return 0, 0, common.NewBasicError("Unable to parse port", err, "ports", s)
+	}
+	max := min
+	if len(parts) == 2 {
+		if max, err = strconv.ParseUint(parts[1], 10, 16); err != nil {
+			return 0, 0, common.NewBasicError("Unable to parse port", err, "ports", s)
+		}
+	}
+	if min > max {
+		return 0, 0, common.NewBasicError("Invalid port range", nil, "ports", s)
+	}
+	return uint16(min), uint16(max), nil
+}
@@ -44,6 +44,36 @@ func (s *BaseTrafficClassListener) EnterMatchTOS(ctx *MatchTOSContext) {}
 // ExitMatchTOS is called when production matchTOS is exited.
 func (s *BaseTrafficClassListener) ExitMatchTOS(ctx *MatchTOSContext) {}
 
+// EnterMatchTC is called when production matchTC is entered.
+func (s *BaseTrafficClassListener) EnterMatchTC(ctx *MatchTCContext) {}
+
+// ExitMatchTC is called when production matchTC is exited.
+func (s *BaseTrafficClassListener) ExitMatchTC(ctx *MatchTCContext) {}
+
+// EnterMatchFlowLabel is called when production matchFlowLabel is entered.
+func (s *BaseTrafficClassListener) EnterMatchFlowLabel(ctx *MatchFlowLabelContext) {}
+
+// ExitMatchFlowLabel is called when production matchFlowLabel is exited.
+func (s *BaseTrafficClassListener) ExitMatchFlowLabel(ctx *MatchFlowLabelContext) {}
+
+// EnterMatchProto is called when production matchProto is entered.
+func (s *BaseTrafficClassListener) EnterMatchProto(ctx *MatchProtoContext) {}
+
+// ExitMatchProto is called when production matchProto is exited.
+func (s *BaseTrafficClassListener) ExitMatchProto(ctx *MatchProtoContext) {}
+
+// EnterMatchSrcPort is called when production matchSrcPort is entered.
+func (s *BaseTrafficClassListener) EnterMatchSrcPort(ctx *MatchSrcPortContext) {}
+
+// ExitMatchSrcPort is called when production matchSrcPort is exited.
+func (s *BaseTrafficClassListener) ExitMatchSrcPort(ctx *MatchSrcPortContext) {}
+
+// EnterMatchDstPort is called when production matchDstPort is entered.
+func (s *BaseTrafficClassListener) EnterMatchDstPort(ctx *MatchDstPortContext) {}
+
+// ExitMatchDstPort is called when production matchDstPort is exited.
+func (s *BaseTrafficClassListener) ExitMatchDstPort(ctx *MatchDstPortContext) {}
+
 // EnterCondCls is called when production condCls is entered.
 func (s *BaseTrafficClassListener) EnterCondCls(ctx *CondClsContext) {}
 
@@ -74,11 +104,17 @@ func (s *BaseTrafficClassListener) EnterCondBool(ctx *CondBoolContext) {}
 // ExitCondBool is called when production condBool is exited.
 func (s *BaseTrafficClassListener) ExitCondBool(ctx *CondBoolContext) {}
 
-// EnterCondIPv4 is called when production condIPv4 is entered.
-func (s *BaseTrafficClassListener) EnterCondIPv4(ctx *CondIPv4Context) {}
+// EnterCondIP is called when production condIP is entered.
+func (s *BaseTrafficClassListener) EnterCondIP(ctx *CondIPContext) {}
+
+// ExitCondIP is called when production condIP is exited.
+func (s *BaseTrafficClassListener) ExitCondIP(ctx *CondIPContext) {}
+
+// EnterCondPort is called when production condPort is entered.
+func (s *BaseTrafficClassListener) EnterCondPort(ctx *CondPortContext) {}
 
-// ExitCondIPv4 is called when production condIPv4 is exited.
-func (s *BaseTrafficClassListener) ExitCondIPv4(ctx *CondIPv4Context) {}
+// ExitCondPort is called when production condPort is exited.
+func (s *BaseTrafficClassListener) ExitCondPort(ctx *CondPortContext) {}
 
 // EnterCond is called when production cond is entered.
 func (s *BaseTrafficClassListener) EnterCond(ctx *CondContext) {}
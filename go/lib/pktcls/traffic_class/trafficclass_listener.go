@@ -19,6 +19,21 @@ type TrafficClassListener interface {
 	// EnterMatchTOS is called when entering the matchTOS production.
 	EnterMatchTOS(c *MatchTOSContext)
 
+	// EnterMatchTC is called when entering the matchTC production.
+	EnterMatchTC(c *MatchTCContext)
+
+	// EnterMatchFlowLabel is called when entering the matchFlowLabel production.
+	EnterMatchFlowLabel(c *MatchFlowLabelContext)
+
+	// EnterMatchProto is called when entering the matchProto production.
+	EnterMatchProto(c *MatchProtoContext)
+
+	// EnterMatchSrcPort is called when entering the matchSrcPort production.
+	EnterMatchSrcPort(c *MatchSrcPortContext)
+
+	// EnterMatchDstPort is called when entering the matchDstPort production.
+	EnterMatchDstPort(c *MatchDstPortContext)
+
 	// EnterCondCls is called when entering the condCls production.
 	EnterCondCls(c *CondClsContext)
 
@@ -34,8 +49,11 @@ type TrafficClassListener interface {
 	// EnterCondBool is called when entering the condBool production.
 	EnterCondBool(c *CondBoolContext)
 
-	// EnterCondIPv4 is called when entering the condIPv4 production.
-	EnterCondIPv4(c *CondIPv4Context)
+	// EnterCondIP is called when entering the condIP production.
+	EnterCondIP(c *CondIPContext)
+
+	// EnterCondPort is called when entering the condPort production.
+	EnterCondPort(c *CondPortContext)
 
 	// EnterCond is called when entering the cond production.
 	EnterCond(c *CondContext)
@@ -55,6 +73,21 @@ type TrafficClassListener interface {
 	// ExitMatchTOS is called when exiting the matchTOS production.
 	ExitMatchTOS(c *MatchTOSContext)
 
+	// ExitMatchTC is called when exiting the matchTC production.
+	ExitMatchTC(c *MatchTCContext)
+
+	// ExitMatchFlowLabel is called when exiting the matchFlowLabel production.
+	ExitMatchFlowLabel(c *MatchFlowLabelContext)
+
+	// ExitMatchProto is called when exiting the matchProto production.
+	ExitMatchProto(c *MatchProtoContext)
+
+	// ExitMatchSrcPort is called when exiting the matchSrcPort production.
+	ExitMatchSrcPort(c *MatchSrcPortContext)
+
+	// ExitMatchDstPort is called when exiting the matchDstPort production.
+	ExitMatchDstPort(c *MatchDstPortContext)
+
 	// ExitCondCls is called when exiting the condCls production.
 	ExitCondCls(c *CondClsContext)
 
@@ -70,8 +103,11 @@ type TrafficClassListener interface {
 	// ExitCondBool is called when exiting the condBool production.
 	ExitCondBool(c *CondBoolContext)
 
-	// ExitCondIPv4 is called when exiting the condIPv4 production.
-	ExitCondIPv4(c *CondIPv4Context)
+	// ExitCondIP is called when exiting the condIP production.
+	ExitCondIP(c *CondIPContext)
+
+	// ExitCondPort is called when exiting the condPort production.
+	ExitCondPort(c *CondPortContext)
 
 	// ExitCond is called when exiting the cond production.
 	ExitCond(c *CondContext)
@@ -15,58 +15,81 @@ var _ = reflect.Copy
 var _ = strconv.Itoa
 
 var parserATN = []uint16{
-	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 3, 22, 96, 4,
-	2, 9, 2, 4, 3, 9, 3, 4, 4, 9, 4, 4, 5, 9, 5, 4, 6, 9, 6, 4, 7, 9, 7, 4,
-	8, 9, 8, 4, 9, 9, 9, 4, 10, 9, 10, 4, 11, 9, 11, 4, 12, 9, 12, 4, 13, 9,
-	13, 3, 2, 3, 2, 3, 2, 3, 2, 3, 3, 3, 3, 3, 3, 3, 3, 3, 4, 3, 4, 3, 4, 3,
-	4, 3, 5, 3, 5, 3, 5, 3, 5, 3, 6, 3, 6, 3, 6, 3, 7, 3, 7, 3, 7, 3, 7, 3,
-	7, 7, 7, 51, 10, 7, 12, 7, 14, 7, 54, 11, 7, 3, 7, 3, 7, 3, 8, 3, 8, 3,
-	8, 3, 8, 3, 8, 7, 8, 63, 10, 8, 12, 8, 14, 8, 66, 11, 8, 3, 8, 3, 8, 3,
-	9, 3, 9, 3, 9, 3, 9, 3, 9, 3, 10, 3, 10, 3, 10, 3, 10, 3, 11, 3, 11, 3,
-	11, 3, 11, 5, 11, 83, 10, 11, 3, 12, 3, 12, 3, 12, 3, 12, 3, 12, 3, 12,
-	5, 12, 91, 10, 12, 3, 13, 3, 13, 3, 13, 3, 13, 2, 2, 14, 2, 4, 6, 8, 10,
-	12, 14, 16, 18, 20, 22, 24, 2, 4, 3, 2, 12, 13, 3, 2, 9, 10, 2, 93, 2,
-	26, 3, 2, 2, 2, 4, 30, 3, 2, 2, 2, 6, 34, 3, 2, 2, 2, 8, 38, 3, 2, 2, 2,
-	10, 42, 3, 2, 2, 2, 12, 45, 3, 2, 2, 2, 14, 57, 3, 2, 2, 2, 16, 69, 3,
-	2, 2, 2, 18, 74, 3, 2, 2, 2, 20, 82, 3, 2, 2, 2, 22, 90, 3, 2, 2, 2, 24,
-	92, 3, 2, 2, 2, 26, 27, 7, 19, 2, 2, 27, 28, 7, 3, 2, 2, 28, 29, 7, 14,
-	2, 2, 29, 3, 3, 2, 2, 2, 30, 31, 7, 20, 2, 2, 31, 32, 7, 3, 2, 2, 32, 33,
-	7, 14, 2, 2, 33, 5, 3, 2, 2, 2, 34, 35, 7, 21, 2, 2, 35, 36, 7, 4, 2, 2,
-	36, 37, 9, 2, 2, 2, 37, 7, 3, 2, 2, 2, 38, 39, 7, 22, 2, 2, 39, 40, 7,
-	4, 2, 2, 40, 41, 9, 2, 2, 2, 41, 9, 3, 2, 2, 2, 42, 43, 7, 5, 2, 2, 43,
-	44, 7, 12, 2, 2, 44, 11, 3, 2, 2, 2, 45, 46, 7, 15, 2, 2, 46, 47, 7, 6,
-	2, 2, 47, 52, 5, 22, 12, 2, 48, 49, 7, 7, 2, 2, 49, 51, 5, 22, 12, 2, 50,
-	48, 3, 2, 2, 2, 51, 54, 3, 2, 2, 2, 52, 50, 3, 2, 2, 2, 52, 53, 3, 2, 2,
-	2, 53, 55, 3, 2, 2, 2, 54, 52, 3, 2, 2, 2, 55, 56, 7, 8, 2, 2, 56, 13,
-	3, 2, 2, 2, 57, 58, 7, 16, 2, 2, 58, 59, 7, 6, 2, 2, 59, 64, 5, 22, 12,
-	2, 60, 61, 7, 7, 2, 2, 61, 63, 5, 22, 12, 2, 62, 60, 3, 2, 2, 2, 63, 66,
-	3, 2, 2, 2, 64, 62, 3, 2, 2, 2, 64, 65, 3, 2, 2, 2, 65, 67, 3, 2, 2, 2,
-	66, 64, 3, 2, 2, 2, 67, 68, 7, 8, 2, 2, 68, 15, 3, 2, 2, 2, 69, 70, 7,
-	17, 2, 2, 70, 71, 7, 6, 2, 2, 71, 72, 5, 22, 12, 2, 72, 73, 7, 8, 2, 2,
-	73, 17, 3, 2, 2, 2, 74, 75, 7, 18, 2, 2, 75, 76, 7, 3, 2, 2, 76, 77, 9,
-	3, 2, 2, 77, 19, 3, 2, 2, 2, 78, 83, 5, 2, 2, 2, 79, 83, 5, 4, 3, 2, 80,
-	83, 5, 6, 4, 2, 81, 83, 5, 8, 5, 2, 82, 78, 3, 2, 2, 2, 82, 79, 3, 2, 2,
-	2, 82, 80, 3, 2, 2, 2, 82, 81, 3, 2, 2, 2, 83, 21, 3, 2, 2, 2, 84, 91,
-	5, 14, 8, 2, 85, 91, 5, 12, 7, 2, 86, 91, 5, 16, 9, 2, 87, 91, 5, 20, 11,
-	2, 88, 91, 5, 10, 6, 2, 89, 91, 5, 18, 10, 2, 90, 84, 3, 2, 2, 2, 90, 85,
-	3, 2, 2, 2, 90, 86, 3, 2, 2, 2, 90, 87, 3, 2, 2, 2, 90, 88, 3, 2, 2, 2,
-	90, 89, 3, 2, 2, 2, 91, 23, 3, 2, 2, 2, 92, 93, 5, 22, 12, 2, 93, 94, 7,
-	2, 2, 3, 94, 25, 3, 2, 2, 2, 6, 52, 64, 82, 90,
+	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 3, 29, 142,
+	4, 2, 9, 2, 4, 3, 9, 3, 4, 4, 9, 4, 4, 5, 9, 5, 4, 6, 9, 6, 4, 7, 9, 7,
+	4, 8, 9, 8, 4, 9, 9, 9, 4, 10, 9, 10, 4, 11, 9, 11, 4, 12, 9, 12, 4, 13,
+	9, 13, 4, 14, 9, 14, 4, 15, 9, 15, 4, 16, 9, 16, 4, 17, 9, 17, 4, 18, 9,
+	18, 4, 19, 9, 19, 3, 2, 3, 2, 3, 2, 3, 2, 3, 3, 3, 3, 3, 3, 3, 3, 3, 4,
+	3, 4, 3, 4, 3, 4, 3, 5, 3, 5, 3, 5, 3, 5, 3, 6, 3, 6, 3, 6, 3, 6, 3, 7,
+	3, 7, 3, 7, 3, 7, 3, 8, 3, 8, 3, 8, 3, 8, 3, 9, 3, 9, 3, 9, 3, 9, 3, 9,
+	5, 9, 72, 10, 9, 3, 10, 3, 10, 3, 10, 3, 10, 3, 10, 5, 10, 79, 10, 10,
+	3, 11, 3, 11, 3, 11, 3, 12, 3, 12, 3, 12, 3, 12, 3, 12, 7, 12, 89, 10,
+	12, 12, 12, 14, 12, 92, 11, 12, 3, 12, 3, 12, 3, 13, 3, 13, 3, 13, 3, 13,
+	3, 13, 7, 13, 101, 10, 13, 12, 13, 14, 13, 104, 11, 13, 3, 13, 3, 13, 3,
+	14, 3, 14, 3, 14, 3, 14, 3, 14, 3, 15, 3, 15, 3, 15, 3, 15, 3, 16, 3, 16,
+	3, 16, 3, 16, 3, 16, 3, 16, 3, 16, 5, 16, 124, 10, 16, 3, 17, 3, 17, 5,
+	17, 128, 10, 17, 3, 18, 3, 18, 3, 18, 3, 18, 3, 18, 3, 18, 3, 18, 5, 18,
+	137, 10, 18, 3, 19, 3, 19, 3, 19, 3, 19, 2, 2, 20, 2, 4, 6, 8, 10, 12,
+	14, 16, 18, 20, 22, 24, 26, 28, 30, 32, 34, 36, 2, 5, 3, 2, 15, 16, 3,
+	2, 13, 14, 3, 2, 10, 11, 2, 140, 2, 38, 3, 2, 2, 2, 4, 42, 3, 2, 2, 2,
+	6, 46, 3, 2, 2, 2, 8, 50, 3, 2, 2, 2, 10, 54, 3, 2, 2, 2, 12, 58, 3, 2,
+	2, 2, 14, 62, 3, 2, 2, 2, 16, 66, 3, 2, 2, 2, 18, 73, 3, 2, 2, 2, 20, 80,
+	3, 2, 2, 2, 22, 83, 3, 2, 2, 2, 24, 95, 3, 2, 2, 2, 26, 107, 3, 2, 2, 2,
+	28, 112, 3, 2, 2, 2, 30, 123, 3, 2, 2, 2, 32, 127, 3, 2, 2, 2, 34, 136,
+	3, 2, 2, 2, 36, 138, 3, 2, 2, 2, 38, 39, 7, 21, 2, 2, 39, 40, 7, 3, 2,
+	2, 40, 41, 9, 2, 2, 2, 41, 3, 3, 2, 2, 2, 42, 43, 7, 22, 2, 2, 43, 44,
+	7, 3, 2, 2, 44, 45, 9, 2, 2, 2, 45, 5, 3, 2, 2, 2, 46, 47, 7, 23, 2, 2,
+	47, 48, 7, 4, 2, 2, 48, 49, 9, 3, 2, 2, 49, 7, 3, 2, 2, 2, 50, 51, 7, 24,
+	2, 2, 51, 52, 7, 4, 2, 2, 52, 53, 9, 3, 2, 2, 53, 9, 3, 2, 2, 2, 54, 55,
+	7, 25, 2, 2, 55, 56, 7, 4, 2, 2, 56, 57, 9, 3, 2, 2, 57, 11, 3, 2, 2, 2,
+	58, 59, 7, 26, 2, 2, 59, 60, 7, 4, 2, 2, 60, 61, 9, 3, 2, 2, 61, 13, 3,
+	2, 2, 2, 62, 63, 7, 27, 2, 2, 63, 64, 7, 3, 2, 2, 64, 65, 7, 13, 2, 2,
+	65, 15, 3, 2, 2, 2, 66, 67, 7, 28, 2, 2, 67, 68, 7, 3, 2, 2, 68, 71, 7,
+	13, 2, 2, 69, 70, 7, 5, 2, 2, 70, 72, 7, 13, 2, 2, 71, 69, 3, 2, 2, 2,
+	71, 72, 3, 2, 2, 2, 72, 17, 3, 2, 2, 2, 73, 74, 7, 29, 2, 2, 74, 75, 7,
+	3, 2, 2, 75, 78, 7, 13, 2, 2, 76, 77, 7, 5, 2, 2, 77, 79, 7, 13, 2, 2,
+	78, 76, 3, 2, 2, 2, 78, 79, 3, 2, 2, 2, 79, 19, 3, 2, 2, 2, 80, 81, 7,
+	6, 2, 2, 81, 82, 7, 13, 2, 2, 82, 21, 3, 2, 2, 2, 83, 84, 7, 17, 2, 2,
+	84, 85, 7, 7, 2, 2, 85, 90, 5, 34, 18, 2, 86, 87, 7, 8, 2, 2, 87, 89, 5,
+	34, 18, 2, 88, 86, 3, 2, 2, 2, 89, 92, 3, 2, 2, 2, 90, 88, 3, 2, 2, 2,
+	90, 91, 3, 2, 2, 2, 91, 93, 3, 2, 2, 2, 92, 90, 3, 2, 2, 2, 93, 94, 7,
+	9, 2, 2, 94, 23, 3, 2, 2, 2, 95, 96, 7, 18, 2, 2, 96, 97, 7, 7, 2, 2, 97,
+	102, 5, 34, 18, 2, 98, 99, 7, 8, 2, 2, 99, 101, 5, 34, 18, 2, 100, 98,
+	3, 2, 2, 2, 101, 104, 3, 2, 2, 2, 102, 100, 3, 2, 2, 2, 102, 103, 3, 2,
+	2, 2, 103, 105, 3, 2, 2, 2, 104, 102, 3, 2, 2, 2, 105, 106, 7, 9, 2, 2,
+	106, 25, 3, 2, 2, 2, 107, 108, 7, 19, 2, 2, 108, 109, 7, 7, 2, 2, 109,
+	110, 5, 34, 18, 2, 110, 111, 7, 9, 2, 2, 111, 27, 3, 2, 2, 2, 112, 113,
+	7, 20, 2, 2, 113, 114, 7, 3, 2, 2, 114, 115, 9, 4, 2, 2, 115, 29, 3, 2,
+	2, 2, 116, 124, 5, 2, 2, 2, 117, 124, 5, 4, 3, 2, 118, 124, 5, 6, 4, 2,
+	119, 124, 5, 8, 5, 2, 120, 124, 5, 10, 6, 2, 121, 124, 5, 12, 7, 2, 122,
+	124, 5, 14, 8, 2, 123, 116, 3, 2, 2, 2, 123, 117, 3, 2, 2, 2, 123, 118,
+	3, 2, 2, 2, 123, 119, 3, 2, 2, 2, 123, 120, 3, 2, 2, 2, 123, 121, 3, 2,
+	2, 2, 123, 122, 3, 2, 2, 2, 124, 31, 3, 2, 2, 2, 125, 128, 5, 16, 9, 2,
+	126, 128, 5, 18, 10, 2, 127, 125, 3, 2, 2, 2, 127, 126, 3, 2, 2, 2, 128,
+	33, 3, 2, 2, 2, 129, 137, 5, 24, 13, 2, 130, 137, 5, 22, 12, 2, 131, 137,
+	5, 26, 14, 2, 132, 137, 5, 30, 16, 2, 133, 137, 5, 32, 17, 2, 134, 137,
+	5, 20, 11, 2, 135, 137, 5, 28, 15, 2, 136, 129, 3, 2, 2, 2, 136, 130, 3,
+	2, 2, 2, 136, 131, 3, 2, 2, 2, 136, 132, 3, 2, 2, 2, 136, 133, 3, 2, 2,
+	2, 136, 134, 3, 2, 2, 2, 136, 135, 3, 2, 2, 2, 137, 35, 3, 2, 2, 2, 138,
+	139, 5, 34, 18, 2, 139, 140, 7, 2, 2, 3, 140, 37, 3, 2, 2, 2, 9, 71, 78,
+	90, 102, 123, 127, 136,
 }
 var deserializer = antlr.NewATNDeserializer(nil)
 var deserializedATN = deserializer.DeserializeFromUInt16(parserATN)
 
 var literalNames = []string{
-	"", "'='", "'=0x'", "'cls='", "'('", "','", "')'", "'true'", "'false'",
+	"", "'='", "'=0x'", "'-'", "'cls='", "'('", "','", "')'", "'true'", "'false'",
 }
 var symbolicNames = []string{
-	"", "", "", "", "", "", "", "", "", "WHITESPACE", "DIGITS", "HEX_DIGITS",
-	"NET", "ANY", "ALL", "NOT", "BOOL", "SRC", "DST", "DSCP", "TOS",
+	"", "", "", "", "", "", "", "", "", "", "WHITESPACE", "DIGITS", "HEX_DIGITS",
+	"NET", "NET6", "ANY", "ALL", "NOT", "BOOL", "SRC", "DST", "DSCP", "TOS",
+	"TC", "FLOW", "PROTO", "SPORT", "DPORT",
 }
 
 var ruleNames = []string{
-	"matchSrc", "matchDst", "matchDSCP", "matchTOS", "condCls", "condAny",
-	"condAll", "condNot", "condBool", "condIPv4", "cond", "trafficClass",
+	"matchSrc", "matchDst", "matchDSCP", "matchTOS", "matchTC", "matchFlowLabel",
+	"matchProto", "matchSrcPort", "matchDstPort", "condCls", "condAny", "condAll",
+	"condNot", "condBool", "condIP", "condPort", "cond", "trafficClass",
 }
 var decisionToDFA = make([]*antlr.DFA, len(deserializedATN.DecisionToState))
 
@@ -106,34 +129,47 @@ const (
 	TrafficClassParserT__5       = 6
 	TrafficClassParserT__6       = 7
 	TrafficClassParserT__7       = 8
-	TrafficClassParserWHITESPACE = 9
-	TrafficClassParserDIGITS     = 10
-	TrafficClassParserHEX_DIGITS = 11
-	TrafficClassParserNET        = 12
-	TrafficClassParserANY        = 13
-	TrafficClassParserALL        = 14
-	TrafficClassParserNOT        = 15
-	TrafficClassParserBOOL       = 16
-	TrafficClassParserSRC        = 17
-	TrafficClassParserDST        = 18
-	TrafficClassParserDSCP       = 19
-	TrafficClassParserTOS        = 20
+	TrafficClassParserT__8       = 9
+	TrafficClassParserWHITESPACE = 10
+	TrafficClassParserDIGITS     = 11
+	TrafficClassParserHEX_DIGITS = 12
+	TrafficClassParserNET        = 13
+	TrafficClassParserNET6       = 14
+	TrafficClassParserANY        = 15
+	TrafficClassParserALL        = 16
+	TrafficClassParserNOT        = 17
+	TrafficClassParserBOOL       = 18
+	TrafficClassParserSRC        = 19
+	TrafficClassParserDST        = 20
+	TrafficClassParserDSCP       = 21
+	TrafficClassParserTOS        = 22
+	TrafficClassParserTC         = 23
+	TrafficClassParserFLOW       = 24
+	TrafficClassParserPROTO      = 25
+	TrafficClassParserSPORT      = 26
+	TrafficClassParserDPORT      = 27
 )
 
 // TrafficClassParser rules.
 const (
-	TrafficClassParserRULE_matchSrc     = 0
-	TrafficClassParserRULE_matchDst     = 1
-	TrafficClassParserRULE_matchDSCP    = 2
-	TrafficClassParserRULE_matchTOS     = 3
-	TrafficClassParserRULE_condCls      = 4
-	TrafficClassParserRULE_condAny      = 5
-	TrafficClassParserRULE_condAll      = 6
-	TrafficClassParserRULE_condNot      = 7
-	TrafficClassParserRULE_condBool     = 8
-	TrafficClassParserRULE_condIPv4     = 9
-	TrafficClassParserRULE_cond         = 10
-	TrafficClassParserRULE_trafficClass = 11
+	TrafficClassParserRULE_matchSrc       = 0
+	TrafficClassParserRULE_matchDst       = 1
+	TrafficClassParserRULE_matchDSCP      = 2
+	TrafficClassParserRULE_matchTOS       = 3
+	TrafficClassParserRULE_matchTC        = 4
+	TrafficClassParserRULE_matchFlowLabel = 5
+	TrafficClassParserRULE_matchProto     = 6
+	TrafficClassParserRULE_matchSrcPort   = 7
+	TrafficClassParserRULE_matchDstPort   = 8
+	TrafficClassParserRULE_condCls        = 9
+	TrafficClassParserRULE_condAny        = 10
+	TrafficClassParserRULE_condAll        = 11
+	TrafficClassParserRULE_condNot        = 12
+	TrafficClassParserRULE_condBool       = 13
+	TrafficClassParserRULE_condIP         = 14
+	TrafficClassParserRULE_condPort       = 15
+	TrafficClassParserRULE_cond           = 16
+	TrafficClassParserRULE_trafficClass   = 17
 )
 
 // IMatchSrcContext is an interface to support dynamic dispatch.
@@ -184,6 +220,10 @@ func (s *MatchSrcContext) NET() antlr.TerminalNode {
 	return s.GetToken(TrafficClassParserNET, 0)
 }
 
+func (s *MatchSrcContext) NET6() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserNET6, 0)
+}
+
 func (s *MatchSrcContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -207,6 +247,7 @@ func (s *MatchSrcContext) ExitRule(listener antlr.ParseTreeListener) {
 func (p *TrafficClassParser) MatchSrc() (localctx IMatchSrcContext) {
 	localctx = NewMatchSrcContext(p, p.GetParserRuleContext(), p.GetState())
 	p.EnterRule(localctx, 0, TrafficClassParserRULE_matchSrc)
+	var _la int
 
 	defer func() {
 		p.ExitRule()
@@ -226,16 +267,23 @@ func (p *TrafficClassParser) MatchSrc() (localctx IMatchSrcContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(24)
+		p.SetState(36)
 		p.Match(TrafficClassParserSRC)
 	}
 	{
-		p.SetState(25)
+		p.SetState(37)
 		p.Match(TrafficClassParserT__0)
 	}
 	{
-		p.SetState(26)
-		p.Match(TrafficClassParserNET)
+		p.SetState(38)
+		_la = p.GetTokenStream().LA(1)
+
+		if !(_la == TrafficClassParserNET || _la == TrafficClassParserNET6) {
+			p.GetErrorHandler().RecoverInline(p)
+		} else {
+			p.GetErrorHandler().ReportMatch(p)
+			p.Consume()
+		}
 	}
 
 	return localctx
@@ -289,6 +337,10 @@ func (s *MatchDstContext) NET() antlr.TerminalNode {
 	return s.GetToken(TrafficClassParserNET, 0)
 }
 
+func (s *MatchDstContext) NET6() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserNET6, 0)
+}
+
 func (s *MatchDstContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -312,6 +364,7 @@ func (s *MatchDstContext) ExitRule(listener antlr.ParseTreeListener) {
 func (p *TrafficClassParser) MatchDst() (localctx IMatchDstContext) {
 	localctx = NewMatchDstContext(p, p.GetParserRuleContext(), p.GetState())
 	p.EnterRule(localctx, 2, TrafficClassParserRULE_matchDst)
+	var _la int
 
 	defer func() {
 		p.ExitRule()
@@ -331,16 +384,23 @@ func (p *TrafficClassParser) MatchDst() (localctx IMatchDstContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(28)
+		p.SetState(40)
 		p.Match(TrafficClassParserDST)
 	}
 	{
-		p.SetState(29)
+		p.SetState(41)
 		p.Match(TrafficClassParserT__0)
 	}
 	{
-		p.SetState(30)
-		p.Match(TrafficClassParserNET)
+		p.SetState(42)
+		_la = p.GetTokenStream().LA(1)
+
+		if !(_la == TrafficClassParserNET || _la == TrafficClassParserNET6) {
+			p.GetErrorHandler().RecoverInline(p)
+		} else {
+			p.GetErrorHandler().ReportMatch(p)
+			p.Consume()
+		}
 	}
 
 	return localctx
@@ -353,74 +413,647 @@ type IMatchDSCPContext interface {
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// IsMatchDSCPContext differentiates from other interfaces.
-	IsMatchDSCPContext()
+	// IsMatchDSCPContext differentiates from other interfaces.
+	IsMatchDSCPContext()
+}
+
+type MatchDSCPContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyMatchDSCPContext() *MatchDSCPContext {
+	var p = new(MatchDSCPContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TrafficClassParserRULE_matchDSCP
+	return p
+}
+
+func (*MatchDSCPContext) IsMatchDSCPContext() {}
+
+func NewMatchDSCPContext(parser antlr.Parser, parent antlr.ParserRuleContext,
+	invokingState int) *MatchDSCPContext {
+
+	var p = new(MatchDSCPContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TrafficClassParserRULE_matchDSCP
+
+	return p
+}
+
+func (s *MatchDSCPContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *MatchDSCPContext) DSCP() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserDSCP, 0)
+}
+
+func (s *MatchDSCPContext) HEX_DIGITS() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserHEX_DIGITS, 0)
+}
+
+func (s *MatchDSCPContext) DIGITS() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserDIGITS, 0)
+}
+
+func (s *MatchDSCPContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *MatchDSCPContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *MatchDSCPContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.EnterMatchDSCP(s)
+	}
+}
+
+func (s *MatchDSCPContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.ExitMatchDSCP(s)
+	}
+}
+
+func (p *TrafficClassParser) MatchDSCP() (localctx IMatchDSCPContext) {
+	localctx = NewMatchDSCPContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 4, TrafficClassParserRULE_matchDSCP)
+	var _la int
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(44)
+		p.Match(TrafficClassParserDSCP)
+	}
+	{
+		p.SetState(45)
+		p.Match(TrafficClassParserT__1)
+	}
+	{
+		p.SetState(46)
+		_la = p.GetTokenStream().LA(1)
+
+		if !(_la == TrafficClassParserDIGITS || _la == TrafficClassParserHEX_DIGITS) {
+			p.GetErrorHandler().RecoverInline(p)
+		} else {
+			p.GetErrorHandler().ReportMatch(p)
+			p.Consume()
+		}
+	}
+
+	return localctx
+}
+
+// IMatchTOSContext is an interface to support dynamic dispatch.
+type IMatchTOSContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsMatchTOSContext differentiates from other interfaces.
+	IsMatchTOSContext()
+}
+
+type MatchTOSContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyMatchTOSContext() *MatchTOSContext {
+	var p = new(MatchTOSContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TrafficClassParserRULE_matchTOS
+	return p
+}
+
+func (*MatchTOSContext) IsMatchTOSContext() {}
+
+func NewMatchTOSContext(parser antlr.Parser, parent antlr.ParserRuleContext,
+	invokingState int) *MatchTOSContext {
+
+	var p = new(MatchTOSContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TrafficClassParserRULE_matchTOS
+
+	return p
+}
+
+func (s *MatchTOSContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *MatchTOSContext) TOS() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserTOS, 0)
+}
+
+func (s *MatchTOSContext) HEX_DIGITS() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserHEX_DIGITS, 0)
+}
+
+func (s *MatchTOSContext) DIGITS() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserDIGITS, 0)
+}
+
+func (s *MatchTOSContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *MatchTOSContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *MatchTOSContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.EnterMatchTOS(s)
+	}
+}
+
+func (s *MatchTOSContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.ExitMatchTOS(s)
+	}
+}
+
+func (p *TrafficClassParser) MatchTOS() (localctx IMatchTOSContext) {
+	localctx = NewMatchTOSContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 6, TrafficClassParserRULE_matchTOS)
+	var _la int
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(48)
+		p.Match(TrafficClassParserTOS)
+	}
+	{
+		p.SetState(49)
+		p.Match(TrafficClassParserT__1)
+	}
+	{
+		p.SetState(50)
+		_la = p.GetTokenStream().LA(1)
+
+		if !(_la == TrafficClassParserDIGITS || _la == TrafficClassParserHEX_DIGITS) {
+			p.GetErrorHandler().RecoverInline(p)
+		} else {
+			p.GetErrorHandler().ReportMatch(p)
+			p.Consume()
+		}
+	}
+
+	return localctx
+}
+
+// IMatchTCContext is an interface to support dynamic dispatch.
+type IMatchTCContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsMatchTCContext differentiates from other interfaces.
+	IsMatchTCContext()
+}
+
+type MatchTCContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyMatchTCContext() *MatchTCContext {
+	var p = new(MatchTCContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TrafficClassParserRULE_matchTC
+	return p
+}
+
+func (*MatchTCContext) IsMatchTCContext() {}
+
+func NewMatchTCContext(parser antlr.Parser, parent antlr.ParserRuleContext,
+	invokingState int) *MatchTCContext {
+
+	var p = new(MatchTCContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TrafficClassParserRULE_matchTC
+
+	return p
+}
+
+func (s *MatchTCContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *MatchTCContext) TC() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserTC, 0)
+}
+
+func (s *MatchTCContext) HEX_DIGITS() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserHEX_DIGITS, 0)
+}
+
+func (s *MatchTCContext) DIGITS() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserDIGITS, 0)
+}
+
+func (s *MatchTCContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *MatchTCContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *MatchTCContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.EnterMatchTC(s)
+	}
+}
+
+func (s *MatchTCContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.ExitMatchTC(s)
+	}
+}
+
+func (p *TrafficClassParser) MatchTC() (localctx IMatchTCContext) {
+	localctx = NewMatchTCContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 8, TrafficClassParserRULE_matchTC)
+	var _la int
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(52)
+		p.Match(TrafficClassParserTC)
+	}
+	{
+		p.SetState(53)
+		p.Match(TrafficClassParserT__1)
+	}
+	{
+		p.SetState(54)
+		_la = p.GetTokenStream().LA(1)
+
+		if !(_la == TrafficClassParserDIGITS || _la == TrafficClassParserHEX_DIGITS) {
+			p.GetErrorHandler().RecoverInline(p)
+		} else {
+			p.GetErrorHandler().ReportMatch(p)
+			p.Consume()
+		}
+	}
+
+	return localctx
+}
+
+// IMatchFlowLabelContext is an interface to support dynamic dispatch.
+type IMatchFlowLabelContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsMatchFlowLabelContext differentiates from other interfaces.
+	IsMatchFlowLabelContext()
+}
+
+type MatchFlowLabelContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyMatchFlowLabelContext() *MatchFlowLabelContext {
+	var p = new(MatchFlowLabelContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TrafficClassParserRULE_matchFlowLabel
+	return p
+}
+
+func (*MatchFlowLabelContext) IsMatchFlowLabelContext() {}
+
+func NewMatchFlowLabelContext(parser antlr.Parser, parent antlr.ParserRuleContext,
+	invokingState int) *MatchFlowLabelContext {
+
+	var p = new(MatchFlowLabelContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TrafficClassParserRULE_matchFlowLabel
+
+	return p
+}
+
+func (s *MatchFlowLabelContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *MatchFlowLabelContext) FLOW() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserFLOW, 0)
+}
+
+func (s *MatchFlowLabelContext) HEX_DIGITS() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserHEX_DIGITS, 0)
+}
+
+func (s *MatchFlowLabelContext) DIGITS() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserDIGITS, 0)
+}
+
+func (s *MatchFlowLabelContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *MatchFlowLabelContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *MatchFlowLabelContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.EnterMatchFlowLabel(s)
+	}
+}
+
+func (s *MatchFlowLabelContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.ExitMatchFlowLabel(s)
+	}
+}
+
+func (p *TrafficClassParser) MatchFlowLabel() (localctx IMatchFlowLabelContext) {
+	localctx = NewMatchFlowLabelContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 10, TrafficClassParserRULE_matchFlowLabel)
+	var _la int
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(56)
+		p.Match(TrafficClassParserFLOW)
+	}
+	{
+		p.SetState(57)
+		p.Match(TrafficClassParserT__1)
+	}
+	{
+		p.SetState(58)
+		_la = p.GetTokenStream().LA(1)
+
+		if !(_la == TrafficClassParserDIGITS || _la == TrafficClassParserHEX_DIGITS) {
+			p.GetErrorHandler().RecoverInline(p)
+		} else {
+			p.GetErrorHandler().ReportMatch(p)
+			p.Consume()
+		}
+	}
+
+	return localctx
+}
+
+// IMatchProtoContext is an interface to support dynamic dispatch.
+type IMatchProtoContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsMatchProtoContext differentiates from other interfaces.
+	IsMatchProtoContext()
+}
+
+type MatchProtoContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyMatchProtoContext() *MatchProtoContext {
+	var p = new(MatchProtoContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TrafficClassParserRULE_matchProto
+	return p
+}
+
+func (*MatchProtoContext) IsMatchProtoContext() {}
+
+func NewMatchProtoContext(parser antlr.Parser, parent antlr.ParserRuleContext,
+	invokingState int) *MatchProtoContext {
+
+	var p = new(MatchProtoContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TrafficClassParserRULE_matchProto
+
+	return p
+}
+
+func (s *MatchProtoContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *MatchProtoContext) PROTO() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserPROTO, 0)
+}
+
+func (s *MatchProtoContext) DIGITS() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserDIGITS, 0)
+}
+
+func (s *MatchProtoContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *MatchProtoContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *MatchProtoContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.EnterMatchProto(s)
+	}
+}
+
+func (s *MatchProtoContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.ExitMatchProto(s)
+	}
+}
+
+func (p *TrafficClassParser) MatchProto() (localctx IMatchProtoContext) {
+	localctx = NewMatchProtoContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 12, TrafficClassParserRULE_matchProto)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(60)
+		p.Match(TrafficClassParserPROTO)
+	}
+	{
+		p.SetState(61)
+		p.Match(TrafficClassParserT__0)
+	}
+	{
+		p.SetState(62)
+		p.Match(TrafficClassParserDIGITS)
+	}
+
+	return localctx
+}
+
+// IMatchSrcPortContext is an interface to support dynamic dispatch.
+type IMatchSrcPortContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsMatchSrcPortContext differentiates from other interfaces.
+	IsMatchSrcPortContext()
 }
 
-type MatchDSCPContext struct {
+type MatchSrcPortContext struct {
 	*antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyMatchDSCPContext() *MatchDSCPContext {
-	var p = new(MatchDSCPContext)
+func NewEmptyMatchSrcPortContext() *MatchSrcPortContext {
+	var p = new(MatchSrcPortContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = TrafficClassParserRULE_matchDSCP
+	p.RuleIndex = TrafficClassParserRULE_matchSrcPort
 	return p
 }
 
-func (*MatchDSCPContext) IsMatchDSCPContext() {}
+func (*MatchSrcPortContext) IsMatchSrcPortContext() {}
 
-func NewMatchDSCPContext(parser antlr.Parser, parent antlr.ParserRuleContext,
-	invokingState int) *MatchDSCPContext {
+func NewMatchSrcPortContext(parser antlr.Parser, parent antlr.ParserRuleContext,
+	invokingState int) *MatchSrcPortContext {
 
-	var p = new(MatchDSCPContext)
+	var p = new(MatchSrcPortContext)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = TrafficClassParserRULE_matchDSCP
+	p.RuleIndex = TrafficClassParserRULE_matchSrcPort
 
 	return p
 }
 
-func (s *MatchDSCPContext) GetParser() antlr.Parser { return s.parser }
+func (s *MatchSrcPortContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *MatchDSCPContext) DSCP() antlr.TerminalNode {
-	return s.GetToken(TrafficClassParserDSCP, 0)
+func (s *MatchSrcPortContext) SPORT() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserSPORT, 0)
 }
 
-func (s *MatchDSCPContext) HEX_DIGITS() antlr.TerminalNode {
-	return s.GetToken(TrafficClassParserHEX_DIGITS, 0)
+func (s *MatchSrcPortContext) AllDIGITS() []antlr.TerminalNode {
+	return s.GetTokens(TrafficClassParserDIGITS)
 }
 
-func (s *MatchDSCPContext) DIGITS() antlr.TerminalNode {
-	return s.GetToken(TrafficClassParserDIGITS, 0)
+func (s *MatchSrcPortContext) DIGITS(i int) antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserDIGITS, i)
 }
 
-func (s *MatchDSCPContext) GetRuleContext() antlr.RuleContext {
+func (s *MatchSrcPortContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *MatchDSCPContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *MatchSrcPortContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *MatchDSCPContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *MatchSrcPortContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TrafficClassListener); ok {
-		listenerT.EnterMatchDSCP(s)
+		listenerT.EnterMatchSrcPort(s)
 	}
 }
 
-func (s *MatchDSCPContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *MatchSrcPortContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TrafficClassListener); ok {
-		listenerT.ExitMatchDSCP(s)
+		listenerT.ExitMatchSrcPort(s)
 	}
 }
 
-func (p *TrafficClassParser) MatchDSCP() (localctx IMatchDSCPContext) {
-	localctx = NewMatchDSCPContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 4, TrafficClassParserRULE_matchDSCP)
+func (p *TrafficClassParser) MatchSrcPort() (localctx IMatchSrcPortContext) {
+	localctx = NewMatchSrcPortContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 14, TrafficClassParserRULE_matchSrcPort)
 	var _la int
 
 	defer func() {
@@ -441,103 +1074,111 @@ func (p *TrafficClassParser) MatchDSCP() (localctx IMatchDSCPContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(32)
-		p.Match(TrafficClassParserDSCP)
+		p.SetState(64)
+		p.Match(TrafficClassParserSPORT)
 	}
 	{
-		p.SetState(33)
-		p.Match(TrafficClassParserT__1)
+		p.SetState(65)
+		p.Match(TrafficClassParserT__0)
 	}
 	{
-		p.SetState(34)
-		_la = p.GetTokenStream().LA(1)
+		p.SetState(66)
+		p.Match(TrafficClassParserDIGITS)
+	}
+	p.SetState(69)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
 
-		if !(_la == TrafficClassParserDIGITS || _la == TrafficClassParserHEX_DIGITS) {
-			p.GetErrorHandler().RecoverInline(p)
-		} else {
-			p.GetErrorHandler().ReportMatch(p)
-			p.Consume()
+	if _la == TrafficClassParserT__2 {
+		{
+			p.SetState(67)
+			p.Match(TrafficClassParserT__2)
+		}
+		{
+			p.SetState(68)
+			p.Match(TrafficClassParserDIGITS)
 		}
+
 	}
 
 	return localctx
 }
 
-// IMatchTOSContext is an interface to support dynamic dispatch.
-type IMatchTOSContext interface {
+// IMatchDstPortContext is an interface to support dynamic dispatch.
+type IMatchDstPortContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// IsMatchTOSContext differentiates from other interfaces.
-	IsMatchTOSContext()
+	// IsMatchDstPortContext differentiates from other interfaces.
+	IsMatchDstPortContext()
 }
 
-type MatchTOSContext struct {
+type MatchDstPortContext struct {
 	*antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyMatchTOSContext() *MatchTOSContext {
-	var p = new(MatchTOSContext)
+func NewEmptyMatchDstPortContext() *MatchDstPortContext {
+	var p = new(MatchDstPortContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = TrafficClassParserRULE_matchTOS
+	p.RuleIndex = TrafficClassParserRULE_matchDstPort
 	return p
 }
 
-func (*MatchTOSContext) IsMatchTOSContext() {}
+func (*MatchDstPortContext) IsMatchDstPortContext() {}
 
-func NewMatchTOSContext(parser antlr.Parser, parent antlr.ParserRuleContext,
-	invokingState int) *MatchTOSContext {
+func NewMatchDstPortContext(parser antlr.Parser, parent antlr.ParserRuleContext,
+	invokingState int) *MatchDstPortContext {
 
-	var p = new(MatchTOSContext)
+	var p = new(MatchDstPortContext)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = TrafficClassParserRULE_matchTOS
+	p.RuleIndex = TrafficClassParserRULE_matchDstPort
 
 	return p
 }
 
-func (s *MatchTOSContext) GetParser() antlr.Parser { return s.parser }
+func (s *MatchDstPortContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *MatchTOSContext) TOS() antlr.TerminalNode {
-	return s.GetToken(TrafficClassParserTOS, 0)
+func (s *MatchDstPortContext) DPORT() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserDPORT, 0)
 }
 
-func (s *MatchTOSContext) HEX_DIGITS() antlr.TerminalNode {
-	return s.GetToken(TrafficClassParserHEX_DIGITS, 0)
+func (s *MatchDstPortContext) AllDIGITS() []antlr.TerminalNode {
+	return s.GetTokens(TrafficClassParserDIGITS)
 }
 
-func (s *MatchTOSContext) DIGITS() antlr.TerminalNode {
-	return s.GetToken(TrafficClassParserDIGITS, 0)
+func (s *MatchDstPortContext) DIGITS(i int) antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserDIGITS, i)
 }
 
-func (s *MatchTOSContext) GetRuleContext() antlr.RuleContext {
+func (s *MatchDstPortContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *MatchTOSContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *MatchDstPortContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *MatchTOSContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *MatchDstPortContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TrafficClassListener); ok {
-		listenerT.EnterMatchTOS(s)
+		listenerT.EnterMatchDstPort(s)
 	}
 }
 
-func (s *MatchTOSContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *MatchDstPortContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TrafficClassListener); ok {
-		listenerT.ExitMatchTOS(s)
+		listenerT.ExitMatchDstPort(s)
 	}
 }
 
-func (p *TrafficClassParser) MatchTOS() (localctx IMatchTOSContext) {
-	localctx = NewMatchTOSContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 6, TrafficClassParserRULE_matchTOS)
+func (p *TrafficClassParser) MatchDstPort() (localctx IMatchDstPortContext) {
+	localctx = NewMatchDstPortContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 16, TrafficClassParserRULE_matchDstPort)
 	var _la int
 
 	defer func() {
@@ -558,23 +1199,31 @@ func (p *TrafficClassParser) MatchTOS() (localctx IMatchTOSContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(36)
-		p.Match(TrafficClassParserTOS)
+		p.SetState(71)
+		p.Match(TrafficClassParserDPORT)
 	}
 	{
-		p.SetState(37)
-		p.Match(TrafficClassParserT__1)
+		p.SetState(72)
+		p.Match(TrafficClassParserT__0)
 	}
 	{
-		p.SetState(38)
-		_la = p.GetTokenStream().LA(1)
+		p.SetState(73)
+		p.Match(TrafficClassParserDIGITS)
+	}
+	p.SetState(76)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
 
-		if !(_la == TrafficClassParserDIGITS || _la == TrafficClassParserHEX_DIGITS) {
-			p.GetErrorHandler().RecoverInline(p)
-		} else {
-			p.GetErrorHandler().ReportMatch(p)
-			p.Consume()
+	if _la == TrafficClassParserT__2 {
+		{
+			p.SetState(74)
+			p.Match(TrafficClassParserT__2)
+		}
+		{
+			p.SetState(75)
+			p.Match(TrafficClassParserDIGITS)
 		}
+
 	}
 
 	return localctx
@@ -646,7 +1295,7 @@ func (s *CondClsContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *TrafficClassParser) CondCls() (localctx ICondClsContext) {
 	localctx = NewCondClsContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 8, TrafficClassParserRULE_condCls)
+	p.EnterRule(localctx, 18, TrafficClassParserRULE_condCls)
 
 	defer func() {
 		p.ExitRule()
@@ -666,11 +1315,11 @@ func (p *TrafficClassParser) CondCls() (localctx ICondClsContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(40)
-		p.Match(TrafficClassParserT__2)
+		p.SetState(78)
+		p.Match(TrafficClassParserT__3)
 	}
 	{
-		p.SetState(41)
+		p.SetState(79)
 		p.Match(TrafficClassParserDIGITS)
 	}
 
@@ -766,7 +1415,7 @@ func (s *CondAnyContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *TrafficClassParser) CondAny() (localctx ICondAnyContext) {
 	localctx = NewCondAnyContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 10, TrafficClassParserRULE_condAny)
+	p.EnterRule(localctx, 20, TrafficClassParserRULE_condAny)
 	var _la int
 
 	defer func() {
@@ -787,38 +1436,38 @@ func (p *TrafficClassParser) CondAny() (localctx ICondAnyContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(43)
+		p.SetState(81)
 		p.Match(TrafficClassParserANY)
 	}
 	{
-		p.SetState(44)
-		p.Match(TrafficClassParserT__3)
+		p.SetState(82)
+		p.Match(TrafficClassParserT__4)
 	}
 	{
-		p.SetState(45)
+		p.SetState(83)
 		p.Cond()
 	}
-	p.SetState(50)
+	p.SetState(88)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
-	for _la == TrafficClassParserT__4 {
+	for _la == TrafficClassParserT__5 {
 		{
-			p.SetState(46)
-			p.Match(TrafficClassParserT__4)
+			p.SetState(84)
+			p.Match(TrafficClassParserT__5)
 		}
 		{
-			p.SetState(47)
+			p.SetState(85)
 			p.Cond()
 		}
 
-		p.SetState(52)
+		p.SetState(90)
 		p.GetErrorHandler().Sync(p)
 		_la = p.GetTokenStream().LA(1)
 	}
 	{
-		p.SetState(53)
-		p.Match(TrafficClassParserT__5)
+		p.SetState(91)
+		p.Match(TrafficClassParserT__6)
 	}
 
 	return localctx
@@ -913,7 +1562,7 @@ func (s *CondAllContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *TrafficClassParser) CondAll() (localctx ICondAllContext) {
 	localctx = NewCondAllContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 12, TrafficClassParserRULE_condAll)
+	p.EnterRule(localctx, 22, TrafficClassParserRULE_condAll)
 	var _la int
 
 	defer func() {
@@ -934,38 +1583,38 @@ func (p *TrafficClassParser) CondAll() (localctx ICondAllContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(55)
+		p.SetState(93)
 		p.Match(TrafficClassParserALL)
 	}
 	{
-		p.SetState(56)
-		p.Match(TrafficClassParserT__3)
+		p.SetState(94)
+		p.Match(TrafficClassParserT__4)
 	}
 	{
-		p.SetState(57)
+		p.SetState(95)
 		p.Cond()
 	}
-	p.SetState(62)
+	p.SetState(100)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
-	for _la == TrafficClassParserT__4 {
+	for _la == TrafficClassParserT__5 {
 		{
-			p.SetState(58)
-			p.Match(TrafficClassParserT__4)
+			p.SetState(96)
+			p.Match(TrafficClassParserT__5)
 		}
 		{
-			p.SetState(59)
+			p.SetState(97)
 			p.Cond()
 		}
 
-		p.SetState(64)
+		p.SetState(102)
 		p.GetErrorHandler().Sync(p)
 		_la = p.GetTokenStream().LA(1)
 	}
 	{
-		p.SetState(65)
-		p.Match(TrafficClassParserT__5)
+		p.SetState(103)
+		p.Match(TrafficClassParserT__6)
 	}
 
 	return localctx
@@ -1047,7 +1696,7 @@ func (s *CondNotContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *TrafficClassParser) CondNot() (localctx ICondNotContext) {
 	localctx = NewCondNotContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 14, TrafficClassParserRULE_condNot)
+	p.EnterRule(localctx, 24, TrafficClassParserRULE_condNot)
 
 	defer func() {
 		p.ExitRule()
@@ -1067,20 +1716,20 @@ func (p *TrafficClassParser) CondNot() (localctx ICondNotContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(67)
+		p.SetState(105)
 		p.Match(TrafficClassParserNOT)
 	}
 	{
-		p.SetState(68)
-		p.Match(TrafficClassParserT__3)
+		p.SetState(106)
+		p.Match(TrafficClassParserT__4)
 	}
 	{
-		p.SetState(69)
+		p.SetState(107)
 		p.Cond()
 	}
 	{
-		p.SetState(70)
-		p.Match(TrafficClassParserT__5)
+		p.SetState(108)
+		p.Match(TrafficClassParserT__6)
 	}
 
 	return localctx
@@ -1152,7 +1801,7 @@ func (s *CondBoolContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *TrafficClassParser) CondBool() (localctx ICondBoolContext) {
 	localctx = NewCondBoolContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 16, TrafficClassParserRULE_condBool)
+	p.EnterRule(localctx, 26, TrafficClassParserRULE_condBool)
 	var _la int
 
 	defer func() {
@@ -1173,18 +1822,18 @@ func (p *TrafficClassParser) CondBool() (localctx ICondBoolContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(72)
+		p.SetState(110)
 		p.Match(TrafficClassParserBOOL)
 	}
 	{
-		p.SetState(73)
+		p.SetState(111)
 		p.Match(TrafficClassParserT__0)
 	}
 	{
-		p.SetState(74)
+		p.SetState(112)
 		_la = p.GetTokenStream().LA(1)
 
-		if !(_la == TrafficClassParserT__6 || _la == TrafficClassParserT__7) {
+		if !(_la == TrafficClassParserT__7 || _la == TrafficClassParserT__8) {
 			p.GetErrorHandler().RecoverInline(p)
 		} else {
 			p.GetErrorHandler().ReportMatch(p)
@@ -1195,47 +1844,47 @@ func (p *TrafficClassParser) CondBool() (localctx ICondBoolContext) {
 	return localctx
 }
 
-// ICondIPv4Context is an interface to support dynamic dispatch.
-type ICondIPv4Context interface {
+// ICondIPContext is an interface to support dynamic dispatch.
+type ICondIPContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// IsCondIPv4Context differentiates from other interfaces.
-	IsCondIPv4Context()
+	// IsCondIPContext differentiates from other interfaces.
+	IsCondIPContext()
 }
 
-type CondIPv4Context struct {
+type CondIPContext struct {
 	*antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyCondIPv4Context() *CondIPv4Context {
-	var p = new(CondIPv4Context)
+func NewEmptyCondIPContext() *CondIPContext {
+	var p = new(CondIPContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = TrafficClassParserRULE_condIPv4
+	p.RuleIndex = TrafficClassParserRULE_condIP
 	return p
 }
 
-func (*CondIPv4Context) IsCondIPv4Context() {}
+func (*CondIPContext) IsCondIPContext() {}
 
-func NewCondIPv4Context(parser antlr.Parser, parent antlr.ParserRuleContext,
-	invokingState int) *CondIPv4Context {
+func NewCondIPContext(parser antlr.Parser, parent antlr.ParserRuleContext,
+	invokingState int) *CondIPContext {
 
-	var p = new(CondIPv4Context)
+	var p = new(CondIPContext)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = TrafficClassParserRULE_condIPv4
+	p.RuleIndex = TrafficClassParserRULE_condIP
 
 	return p
 }
 
-func (s *CondIPv4Context) GetParser() antlr.Parser { return s.parser }
+func (s *CondIPContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *CondIPv4Context) MatchSrc() IMatchSrcContext {
+func (s *CondIPContext) MatchSrc() IMatchSrcContext {
 	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMatchSrcContext)(nil)).Elem(), 0)
 
 	if t == nil {
@@ -1245,7 +1894,7 @@ func (s *CondIPv4Context) MatchSrc() IMatchSrcContext {
 	return t.(IMatchSrcContext)
 }
 
-func (s *CondIPv4Context) MatchDst() IMatchDstContext {
+func (s *CondIPContext) MatchDst() IMatchDstContext {
 	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMatchDstContext)(nil)).Elem(), 0)
 
 	if t == nil {
@@ -1255,7 +1904,7 @@ func (s *CondIPv4Context) MatchDst() IMatchDstContext {
 	return t.(IMatchDstContext)
 }
 
-func (s *CondIPv4Context) MatchDSCP() IMatchDSCPContext {
+func (s *CondIPContext) MatchDSCP() IMatchDSCPContext {
 	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMatchDSCPContext)(nil)).Elem(), 0)
 
 	if t == nil {
@@ -1265,7 +1914,7 @@ func (s *CondIPv4Context) MatchDSCP() IMatchDSCPContext {
 	return t.(IMatchDSCPContext)
 }
 
-func (s *CondIPv4Context) MatchTOS() IMatchTOSContext {
+func (s *CondIPContext) MatchTOS() IMatchTOSContext {
 	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMatchTOSContext)(nil)).Elem(), 0)
 
 	if t == nil {
@@ -1275,29 +1924,59 @@ func (s *CondIPv4Context) MatchTOS() IMatchTOSContext {
 	return t.(IMatchTOSContext)
 }
 
-func (s *CondIPv4Context) GetRuleContext() antlr.RuleContext {
+func (s *CondIPContext) MatchTC() IMatchTCContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMatchTCContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMatchTCContext)
+}
+
+func (s *CondIPContext) MatchFlowLabel() IMatchFlowLabelContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMatchFlowLabelContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMatchFlowLabelContext)
+}
+
+func (s *CondIPContext) MatchProto() IMatchProtoContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMatchProtoContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMatchProtoContext)
+}
+
+func (s *CondIPContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *CondIPv4Context) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *CondIPContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *CondIPv4Context) EnterRule(listener antlr.ParseTreeListener) {
+func (s *CondIPContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TrafficClassListener); ok {
-		listenerT.EnterCondIPv4(s)
+		listenerT.EnterCondIP(s)
 	}
 }
 
-func (s *CondIPv4Context) ExitRule(listener antlr.ParseTreeListener) {
+func (s *CondIPContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TrafficClassListener); ok {
-		listenerT.ExitCondIPv4(s)
+		listenerT.ExitCondIP(s)
 	}
 }
 
-func (p *TrafficClassParser) CondIPv4() (localctx ICondIPv4Context) {
-	localctx = NewCondIPv4Context(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 18, TrafficClassParserRULE_condIPv4)
+func (p *TrafficClassParser) CondIP() (localctx ICondIPContext) {
+	localctx = NewCondIPContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 28, TrafficClassParserRULE_condIP)
 
 	defer func() {
 		p.ExitRule()
@@ -1315,38 +1994,184 @@ func (p *TrafficClassParser) CondIPv4() (localctx ICondIPv4Context) {
 		}
 	}()
 
-	p.SetState(80)
+	p.SetState(121)
 	p.GetErrorHandler().Sync(p)
 
 	switch p.GetTokenStream().LA(1) {
 	case TrafficClassParserSRC:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(76)
+			p.SetState(114)
 			p.MatchSrc()
 		}
 
 	case TrafficClassParserDST:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(77)
+			p.SetState(115)
 			p.MatchDst()
 		}
 
 	case TrafficClassParserDSCP:
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(78)
+			p.SetState(116)
 			p.MatchDSCP()
 		}
 
 	case TrafficClassParserTOS:
 		p.EnterOuterAlt(localctx, 4)
 		{
-			p.SetState(79)
+			p.SetState(117)
 			p.MatchTOS()
 		}
 
+	case TrafficClassParserTC:
+		p.EnterOuterAlt(localctx, 5)
+		{
+			p.SetState(118)
+			p.MatchTC()
+		}
+
+	case TrafficClassParserFLOW:
+		p.EnterOuterAlt(localctx, 6)
+		{
+			p.SetState(119)
+			p.MatchFlowLabel()
+		}
+
+	case TrafficClassParserPROTO:
+		p.EnterOuterAlt(localctx, 7)
+		{
+			p.SetState(120)
+			p.MatchProto()
+		}
+
+	default:
+		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+	}
+
+	return localctx
+}
+
+// ICondPortContext is an interface to support dynamic dispatch.
+type ICondPortContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsCondPortContext differentiates from other interfaces.
+	IsCondPortContext()
+}
+
+type CondPortContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyCondPortContext() *CondPortContext {
+	var p = new(CondPortContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TrafficClassParserRULE_condPort
+	return p
+}
+
+func (*CondPortContext) IsCondPortContext() {}
+
+func NewCondPortContext(parser antlr.Parser, parent antlr.ParserRuleContext,
+	invokingState int) *CondPortContext {
+
+	var p = new(CondPortContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TrafficClassParserRULE_condPort
+
+	return p
+}
+
+func (s *CondPortContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *CondPortContext) MatchSrcPort() IMatchSrcPortContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMatchSrcPortContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMatchSrcPortContext)
+}
+
+func (s *CondPortContext) MatchDstPort() IMatchDstPortContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMatchDstPortContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMatchDstPortContext)
+}
+
+func (s *CondPortContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *CondPortContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *CondPortContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.EnterCondPort(s)
+	}
+}
+
+func (s *CondPortContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.ExitCondPort(s)
+	}
+}
+
+func (p *TrafficClassParser) CondPort() (localctx ICondPortContext) {
+	localctx = NewCondPortContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 30, TrafficClassParserRULE_condPort)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.SetState(125)
+	p.GetErrorHandler().Sync(p)
+
+	switch p.GetTokenStream().LA(1) {
+	case TrafficClassParserSPORT:
+		p.EnterOuterAlt(localctx, 1)
+		{
+			p.SetState(123)
+			p.MatchSrcPort()
+		}
+
+	case TrafficClassParserDPORT:
+		p.EnterOuterAlt(localctx, 2)
+		{
+			p.SetState(124)
+			p.MatchDstPort()
+		}
+
 	default:
 		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 	}
@@ -1424,14 +2249,24 @@ func (s *CondContext) CondNot() ICondNotContext {
 	return t.(ICondNotContext)
 }
 
-func (s *CondContext) CondIPv4() ICondIPv4Context {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*ICondIPv4Context)(nil)).Elem(), 0)
+func (s *CondContext) CondIP() ICondIPContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*ICondIPContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ICondIPContext)
+}
+
+func (s *CondContext) CondPort() ICondPortContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*ICondPortContext)(nil)).Elem(), 0)
 
 	if t == nil {
 		return nil
 	}
 
-	return t.(ICondIPv4Context)
+	return t.(ICondPortContext)
 }
 
 func (s *CondContext) CondCls() ICondClsContext {
@@ -1476,7 +2311,7 @@ func (s *CondContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *TrafficClassParser) Cond() (localctx ICondContext) {
 	localctx = NewCondContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 20, TrafficClassParserRULE_cond)
+	p.EnterRule(localctx, 32, TrafficClassParserRULE_cond)
 
 	defer func() {
 		p.ExitRule()
@@ -1494,51 +2329,59 @@ func (p *TrafficClassParser) Cond() (localctx ICondContext) {
 		}
 	}()
 
-	p.SetState(88)
+	p.SetState(134)
 	p.GetErrorHandler().Sync(p)
 
 	switch p.GetTokenStream().LA(1) {
 	case TrafficClassParserALL:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(82)
+			p.SetState(127)
 			p.CondAll()
 		}
 
 	case TrafficClassParserANY:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(83)
+			p.SetState(128)
 			p.CondAny()
 		}
 
 	case TrafficClassParserNOT:
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(84)
+			p.SetState(129)
 			p.CondNot()
 		}
 
 	case TrafficClassParserSRC, TrafficClassParserDST, TrafficClassParserDSCP,
-		TrafficClassParserTOS:
+		TrafficClassParserTOS, TrafficClassParserTC, TrafficClassParserFLOW,
+		TrafficClassParserPROTO:
 
 		p.EnterOuterAlt(localctx, 4)
 		{
-			p.SetState(85)
-			p.CondIPv4()
+			p.SetState(130)
+			p.CondIP()
 		}
 
-	case TrafficClassParserT__2:
+	case TrafficClassParserSPORT, TrafficClassParserDPORT:
 		p.EnterOuterAlt(localctx, 5)
 		{
-			p.SetState(86)
+			p.SetState(131)
+			p.CondPort()
+		}
+
+	case TrafficClassParserT__3:
+		p.EnterOuterAlt(localctx, 6)
+		{
+			p.SetState(132)
 			p.CondCls()
 		}
 
 	case TrafficClassParserBOOL:
-		p.EnterOuterAlt(localctx, 6)
+		p.EnterOuterAlt(localctx, 7)
 		{
-			p.SetState(87)
+			p.SetState(133)
 			p.CondBool()
 		}
 
@@ -1625,7 +2468,7 @@ func (s *TrafficClassContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *TrafficClassParser) TrafficClass() (localctx ITrafficClassContext) {
 	localctx = NewTrafficClassContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 22, TrafficClassParserRULE_trafficClass)
+	p.EnterRule(localctx, 34, TrafficClassParserRULE_trafficClass)
 
 	defer func() {
 		p.ExitRule()
@@ -1645,11 +2488,11 @@ func (p *TrafficClassParser) TrafficClass() (localctx ITrafficClassContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(90)
+		p.SetState(136)
 		p.Cond()
 	}
 	{
-		p.SetState(91)
+		p.SetState(137)
 		p.Match(TrafficClassParserEOF)
 	}
 
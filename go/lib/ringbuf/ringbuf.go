@@ -146,6 +146,18 @@ func (r *Ring) Close() {
 	r.readableC.Broadcast()
 }
 
+// Len returns the number of entries that are currently readable.
+func (r *Ring) Len() int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.readable
+}
+
+// Cap returns the capacity of the ring buffer.
+func (r *Ring) Cap() int {
+	return len(r.entries)
+}
+
 func (r *Ring) write(entries EntryList) {
 	n := copy(r.entries[r.writeIndex:], entries)
 	r.writeIndex += n
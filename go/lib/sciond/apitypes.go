@@ -26,6 +26,7 @@ import (
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/pathpol"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/snet"
 	"github.com/scionproto/scion/go/lib/spath"
@@ -42,6 +43,24 @@ func (q Querier) Query(ctx context.Context, dst addr.IA) ([]snet.Path, error) {
 	return q.Connector.Paths(ctx, dst, q.IA, PathReqFlags{PathCount: q.MaxPaths})
 }
 
+// PathSubscribeFlags configures a path subscription.
+type PathSubscribeFlags struct {
+	// PathCount is the maximum number of paths per update. If zero, all paths
+	// are returned.
+	PathCount uint16
+	// ExpiryLead is the time before the expiration of a path at which SCIOND
+	// pushes an update. If zero, SCIOND uses its default.
+	ExpiryLead time.Duration
+}
+
+// PathEvent is a path update pushed by SCIOND to a path subscription. Paths
+// contains the complete set of current paths to the destination.
+type PathEvent struct {
+	Reason    PathUpdateReason
+	ErrorCode PathErrorCode
+	Paths     []snet.Path
+}
+
 // RevHandler is an adapter for sciond connector to implement snet.RevocationHandler.
 type RevHandler struct {
 	Connector Connector
@@ -104,6 +123,10 @@ type Path struct {
 	mtu        uint16
 	expiry     time.Time
 	dst        addr.IA
+	health     *PathHealth
+	// links contains the link metadata of the interfaces, in the same order.
+	// It is empty, if the metadata is unknown.
+	links []PathLinkInfo
 }
 
 func pathReplyToPaths(pathReply *PathReply, dst addr.IA) ([]snet.Path, error) {
@@ -112,7 +135,7 @@ func pathReplyToPaths(pathReply *PathReply, dst addr.IA) ([]snet.Path, error) {
 	}
 	paths := make([]snet.Path, 0, len(pathReply.Entries))
 	for _, pe := range pathReply.Entries {
-		p, err := pathReplyEntryToPath(pe, dst)
+		p, err := PathReplyEntryToPath(pe, dst)
 		if err != nil {
 			return nil, serrors.WrapStr("invalid path received", err)
 		}
@@ -121,10 +144,13 @@ func pathReplyToPaths(pathReply *PathReply, dst addr.IA) ([]snet.Path, error) {
 	return paths, nil
 }
 
-func pathReplyEntryToPath(pe PathReplyEntry, dst addr.IA) (Path, error) {
+// PathReplyEntryToPath converts the path reply entry to a path to the
+// destination.
+func PathReplyEntryToPath(pe PathReplyEntry, dst addr.IA) (Path, error) {
 	if len(pe.Path.Interfaces) == 0 {
 		return Path{
-			dst: dst,
+			dst:    dst,
+			health: pe.Health.Copy(),
 		}, nil
 	}
 	sp := spath.New(pe.Path.FwdPath)
@@ -138,10 +164,14 @@ func pathReplyEntryToPath(pe PathReplyEntry, dst addr.IA) (Path, error) {
 		spath:      sp,
 		mtu:        pe.Path.Mtu,
 		expiry:     pe.Path.Expiry(),
+		health:     pe.Health.Copy(),
 	}
 	for _, intf := range pe.Path.Interfaces {
 		p.interfaces = append(p.interfaces, pathInterface{ia: intf.IA(), id: intf.ID()})
 	}
+	if len(pe.LinkInfo) == len(pe.Path.Interfaces) {
+		p.links = append(p.links, pe.LinkInfo...)
+	}
 	return p, nil
 }
 
@@ -201,6 +231,21 @@ func (p Path) Expiry() time.Time {
 	return p.expiry
 }
 
+// Health returns the health of the path as observed by SCIOND. It is nil, if
+// SCIOND does not probe paths.
+func (p Path) Health() *PathHealth {
+	return p.health
+}
+
+// LinkMetadata returns the static link metadata of the path as provided by
+// SCIOND. It is nil, if SCIOND has no link metadata.
+func (p Path) LinkMetadata() pathpol.LinkMetadata {
+	if len(p.links) == 0 {
+		return nil
+	}
+	return pathLinkMetadata(p)
+}
+
 func (p Path) Copy() snet.Path {
 	return Path{
 		interfaces: append(p.interfaces[:0:0], p.interfaces...),
@@ -208,7 +253,21 @@ func (p Path) Copy() snet.Path {
 		spath:      p.Path(),            // creates copy
 		mtu:        p.mtu,
 		expiry:     p.expiry,
+		health:     p.health.Copy(),
+		links:      append(p.links[:0:0], p.links...),
+	}
+}
+
+// pathLinkMetadata provides the link metadata of the interfaces of a path.
+type pathLinkMetadata Path
+
+func (m pathLinkMetadata) Link(ia addr.IA, ifid common.IFIDType) (pathpol.LinkInfo, bool) {
+	for i, intf := range m.interfaces {
+		if intf.ia.Equal(ia) && intf.id == ifid {
+			return m.links[i].LinkInfo(), true
+		}
 	}
+	return pathpol.LinkInfo{}, false
 }
 
 func (p Path) String() string {
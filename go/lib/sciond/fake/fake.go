@@ -187,6 +187,12 @@ func (c connector) Paths(_ context.Context, _, _ addr.IA,
 	return c.adapter(entry.Paths[:intMax]), nil
 }
 
+func (c connector) SubscribePaths(_ context.Context, _, _ addr.IA,
+	_ sciond.PathSubscribeFlags) (sciond.PathSubscription, error) {
+
+	panic("not implemented")
+}
+
 func (c connector) adapter(paths []*Path) []snet.Path {
 	var snetPaths []snet.Path
 	for _, path := range paths {
@@ -26,6 +26,7 @@ const (
 
 	subsystemConn       = "conn"
 	subsystemPath       = "path"
+	subsystemPathSub    = "path_subscription"
 	subsystemASInfo     = "as_info"
 	subsystemIFInfo     = "if_info"
 	subsystemSVCInfo    = "service_info"
@@ -57,6 +58,8 @@ func (l resultLabel) Values() []string {
 var (
 	// PathRequests contains metrics for path requests.
 	PathRequests = newPathRequest()
+	// PathSubscriptions contains metrics for path subscriptions.
+	PathSubscriptions = newPathSubscription()
 	// Revocations contains metrics for revocations.
 	Revocations = newRevocation()
 	// ASInfos contains metrics for AS info requests.
@@ -93,6 +96,13 @@ func newPathRequest() Request {
 	}
 }
 
+func newPathSubscription() Request {
+	return Request{
+		count: prom.NewCounterVecWithLabels(Namespace, subsystemPathSub, "requests_total",
+			"The amount of Path subscription requests sent.", resultLabel{}),
+	}
+}
+
 func newRevocation() Request {
 	return Request{
 		count: prom.NewCounterVecWithLabels(Namespace, subsystemRevocation, "requests_total",
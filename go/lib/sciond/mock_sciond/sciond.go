@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/scionproto/scion/go/lib/sciond (interfaces: Service,Connector)
+// Source: github.com/scionproto/scion/go/lib/sciond (interfaces: Service,Connector,PathSubscription)
 
 // Package mock_sciond is a generated GoMock package.
 package mock_sciond
@@ -196,3 +196,70 @@ func (mr *MockConnectorMockRecorder) SVCInfo(arg0, arg1 interface{}) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SVCInfo", reflect.TypeOf((*MockConnector)(nil).SVCInfo), arg0, arg1)
 }
+
+// SubscribePaths mocks base method
+func (m *MockConnector) SubscribePaths(arg0 context.Context, arg1, arg2 addr.IA, arg3 sciond.PathSubscribeFlags) (sciond.PathSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribePaths", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(sciond.PathSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubscribePaths indicates an expected call of SubscribePaths
+func (mr *MockConnectorMockRecorder) SubscribePaths(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribePaths", reflect.TypeOf((*MockConnector)(nil).SubscribePaths), arg0, arg1, arg2, arg3)
+}
+
+// MockPathSubscription is a mock of PathSubscription interface
+type MockPathSubscription struct {
+	ctrl     *gomock.Controller
+	recorder *MockPathSubscriptionMockRecorder
+}
+
+// MockPathSubscriptionMockRecorder is the mock recorder for MockPathSubscription
+type MockPathSubscriptionMockRecorder struct {
+	mock *MockPathSubscription
+}
+
+// NewMockPathSubscription creates a new mock instance
+func NewMockPathSubscription(ctrl *gomock.Controller) *MockPathSubscription {
+	mock := &MockPathSubscription{ctrl: ctrl}
+	mock.recorder = &MockPathSubscriptionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockPathSubscription) EXPECT() *MockPathSubscriptionMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method
+func (m *MockPathSubscription) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close
+func (mr *MockPathSubscriptionMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockPathSubscription)(nil).Close))
+}
+
+// Next mocks base method
+func (m *MockPathSubscription) Next() (*sciond.PathEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Next")
+	ret0, _ := ret[0].(*sciond.PathEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Next indicates an expected call of Next
+func (mr *MockPathSubscriptionMockRecorder) Next() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Next", reflect.TypeOf((*MockPathSubscription)(nil).Next))
+}
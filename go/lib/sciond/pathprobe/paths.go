@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"net"
 	"sync"
+	"time"
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
@@ -50,13 +51,15 @@ const (
 type Status struct {
 	Status         StatusName
 	AdditionalInfo string
+	// RTT is the round trip time of the probe. It is only set if the path is
+	// alive.
+	RTT time.Duration
 }
 
 // Predefined path status
 var (
 	unknown = Status{Status: StatusUnknown}
 	timeout = Status{Status: StatusTimeout}
-	alive   = Status{Status: StatusAlive}
 )
 
 func (s Status) String() string {
@@ -109,7 +112,7 @@ func (p Prober) GetStatuses(ctx context.Context,
 	// is going to reply with SCMP error. Receiving the error means that
 	// the path is alive.
 	pathStatuses := make(map[string]Status, len(paths))
-	scmpH := &scmpHandler{statuses: pathStatuses}
+	scmpH := &scmpHandler{statuses: pathStatuses, sent: make(map[string]time.Time, len(paths))}
 	network := snet.NewCustomNetworkWithPR(p.LocalIA,
 		&snet.DefaultPacketDispatcherService{
 			Dispatcher:  reliable.NewDispatcher(""),
@@ -125,6 +128,7 @@ func (p Prober) GetStatuses(ctx context.Context,
 	var sendErrors common.MultiError
 	for _, path := range paths {
 		scmpH.setStatus(PathKey(path), timeout)
+		scmpH.setSent(PathKey(path), time.Now())
 		if err := p.send(snetConn, path); err != nil {
 			sendErrors = append(sendErrors, err)
 		}
@@ -182,6 +186,8 @@ var errSCMP = errors.New("scmp: other")
 type scmpHandler struct {
 	mtx      sync.Mutex
 	statuses map[string]Status
+	// sent contains the time at which the probe was sent on the path.
+	sent map[string]time.Time
 }
 
 func (h *scmpHandler) Handle(pkt *snet.Packet) error {
@@ -192,7 +198,7 @@ func (h *scmpHandler) Handle(pkt *snet.Packet) error {
 			return err
 		}
 		if hdr.Class == scmp.C_Routing && hdr.Type == scmp.T_R_BadHost {
-			h.setStatus(path, alive)
+			h.setAlive(path, time.Now())
 			return errBadHost
 		}
 		h.setStatus(path, Status{Status: StatusSCMP, AdditionalInfo: hdr.String()})
@@ -214,3 +220,19 @@ func (h *scmpHandler) setStatus(path string, status Status) {
 	defer h.mtx.Unlock()
 	h.statuses[path] = status
 }
+
+func (h *scmpHandler) setSent(path string, t time.Time) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	h.sent[path] = t
+}
+
+func (h *scmpHandler) setAlive(path string, t time.Time) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	status := Status{Status: StatusAlive}
+	if sent, ok := h.sent[path]; ok {
+		status.RTT = t.Sub(sent)
+	}
+	h.statuses[path] = status
+}
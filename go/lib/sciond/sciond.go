@@ -31,6 +31,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"time"
 
 	capnp "zombiezen.com/go/capnproto2"
 	"zombiezen.com/go/capnproto2/pogs"
@@ -38,6 +39,7 @@ import (
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/sciond/internal/metrics"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/snet"
@@ -85,6 +87,13 @@ type Connector interface {
 	LocalIA(ctx context.Context) (addr.IA, error)
 	// Paths requests from SCIOND a set of end to end paths between the source and destination.
 	Paths(ctx context.Context, dst, src addr.IA, f PathReqFlags) ([]snet.Path, error)
+	// SubscribePaths subscribes to the set of end to end paths between the
+	// source and destination. SCIOND pushes the current set of paths, and an
+	// update whenever new paths appear, a path is revoked or a path nears its
+	// expiration. The subscription ends when ctx is done or when it is
+	// closed.
+	SubscribePaths(ctx context.Context, dst, src addr.IA,
+		f PathSubscribeFlags) (PathSubscription, error)
 	// ASInfo requests from SCIOND information about AS ia.
 	ASInfo(ctx context.Context, ia addr.IA) (*ASInfoReply, error)
 	// IFInfo requests from SCIOND addresses and ports of interfaces. Slice
@@ -105,6 +114,14 @@ type Connector interface {
 	Close(ctx context.Context) error
 }
 
+// PathSubscription is a subscription to path changes in SCIOND.
+type PathSubscription interface {
+	// Next blocks until SCIOND pushes the next path update and returns it.
+	Next() (*PathEvent, error)
+	// Close terminates the subscription.
+	Close() error
+}
+
 type conn struct {
 	address string
 }
@@ -185,6 +202,77 @@ func (c *conn) Paths(ctx context.Context, dst, src addr.IA,
 	return pathReplyToPaths(reply.PathReply, dst)
 }
 
+func (c *conn) SubscribePaths(ctx context.Context, dst, src addr.IA,
+	f PathSubscribeFlags) (PathSubscription, error) {
+
+	conn, err := c.connect(ctx)
+	if err != nil {
+		metrics.PathSubscriptions.Inc(errorToPrometheusLabel(err))
+		return nil, serrors.Wrap(ErrUnableToConnect, err)
+	}
+	err = Send(
+		&Pld{
+			TraceId: tracing.IDFromCtx(ctx),
+			Which:   proto.SCIONDMsg_Which_pathSubscribeReq,
+			PathSubscribeReq: &PathSubscribeReq{
+				Dst:        dst.IAInt(),
+				Src:        src.IAInt(),
+				MaxPaths:   f.PathCount,
+				ExpiryLead: uint32(f.ExpiryLead / time.Second),
+			},
+		},
+		conn,
+	)
+	if err != nil {
+		conn.Close()
+		metrics.PathSubscriptions.Inc(errorToPrometheusLabel(err))
+		return nil, serrors.WrapStr("[sciond-API] Failed to subscribe to Paths", err)
+	}
+	metrics.PathSubscriptions.Inc(metrics.OkSuccess)
+	ctx, cancelF := context.WithCancel(ctx)
+	go func() {
+		defer log.HandlePanic()
+		<-ctx.Done()
+		conn.Close()
+	}()
+	return &pathSubscription{conn: conn, dst: dst, cancelF: cancelF}, nil
+}
+
+type pathSubscription struct {
+	conn    net.Conn
+	dst     addr.IA
+	cancelF context.CancelFunc
+}
+
+func (s *pathSubscription) Next() (*PathEvent, error) {
+	pld, err := receive(s.conn)
+	if err != nil {
+		return nil, serrors.WrapStr("receive path update failed", err)
+	}
+	if pld.Which != proto.SCIONDMsg_Which_pathUpdate || pld.PathUpdate == nil {
+		return nil, serrors.New("unexpected message", "type", pld.Which)
+	}
+	update := pld.PathUpdate
+	event := &PathEvent{
+		Reason:    update.Reason,
+		ErrorCode: update.ErrorCode,
+		Paths:     make([]snet.Path, 0, len(update.Entries)),
+	}
+	for _, pe := range update.Entries {
+		p, err := PathReplyEntryToPath(pe, s.dst)
+		if err != nil {
+			return nil, serrors.WrapStr("invalid path received", err)
+		}
+		event.Paths = append(event.Paths, p)
+	}
+	return event, nil
+}
+
+func (s *pathSubscription) Close() error {
+	s.cancelF()
+	return s.conn.Close()
+}
+
 func (c *conn) LocalIA(ctx context.Context) (addr.IA, error) {
 	asInfo, err := c.ASInfo(ctx, addr.IA{})
 	if err != nil {
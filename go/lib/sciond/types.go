@@ -16,6 +16,8 @@
 package sciond
 
 import (
+	"crypto/sha256"
+	"encoding/binary"
 	"fmt"
 	"strings"
 	"time"
@@ -24,6 +26,8 @@ import (
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
 	"github.com/scionproto/scion/go/lib/hostinfo"
+	"github.com/scionproto/scion/go/lib/pathpol"
+	"github.com/scionproto/scion/go/lib/snet"
 	"github.com/scionproto/scion/go/lib/util"
 	"github.com/scionproto/scion/go/proto"
 )
@@ -37,6 +41,7 @@ const (
 	ErrorInternal
 	ErrorBadSrcIA
 	ErrorBadDstIA
+	ErrorBadPolicy
 )
 
 func (c PathErrorCode) String() string {
@@ -53,6 +58,8 @@ func (c PathErrorCode) String() string {
 		return "Bad source ISD/AS"
 	case ErrorBadDstIA:
 		return "Bad destination ISD/AS"
+	case ErrorBadPolicy:
+		return "Bad path policy"
 	default:
 		return fmt.Sprintf("Unknown error (%v)", uint16(c))
 	}
@@ -74,6 +81,8 @@ type Pld struct {
 	IfInfoReply        *IFInfoReply
 	ServiceInfoRequest *ServiceInfoRequest
 	ServiceInfoReply   *ServiceInfoReply
+	PathSubscribeReq   *PathSubscribeReq
+	PathUpdate         *PathUpdate
 }
 
 func NewPldFromRaw(b common.RawBytes) (*Pld, error) {
@@ -118,6 +127,10 @@ func (p *Pld) union() (interface{}, error) {
 		return p.ServiceInfoRequest, nil
 	case proto.SCIONDMsg_Which_serviceInfoReply:
 		return p.ServiceInfoReply, nil
+	case proto.SCIONDMsg_Which_pathSubscribeReq:
+		return p.PathSubscribeReq, nil
+	case proto.SCIONDMsg_Which_pathUpdate:
+		return p.PathUpdate, nil
 	}
 	return nil, common.NewBasicError("Unsupported SCIOND union type", nil, "type", p.Which)
 }
@@ -149,6 +162,19 @@ type PathReqFlags struct {
 	PathCount uint16 `capnp:"-"`
 	Refresh   bool
 	Hidden    bool
+	// PolicyName selects a path policy that is configured in SCIOND.
+	PolicyName string
+	// Policy is a JSON encoded path policy. It takes precedence over
+	// PolicyName.
+	Policy []byte
+	// Order lists the keys the paths are ordered by. It takes precedence over
+	// the order of the policy.
+	Order []pathpol.OrderKey
+}
+
+func (f PathReqFlags) String() string {
+	return fmt.Sprintf("{PathCount:%d Refresh:%t Hidden:%t PolicyName:%s Policy:%s Order:%v}",
+		f.PathCount, f.Refresh, f.Hidden, f.PolicyName, f.Policy, f.Order)
 }
 
 type PathReply struct {
@@ -164,9 +190,78 @@ func (r *PathReply) String() string {
 	return fmt.Sprintf("ErrorCode=%v\n  %v", r.ErrorCode, strings.Join(strEntries, "\n  "))
 }
 
+// PathSubscribeReq subscribes to the paths between the source and destination.
+// SCIOND keeps the connection open and pushes a PathUpdate whenever the set of
+// paths changes.
+type PathSubscribeReq struct {
+	Dst      addr.IAInt
+	Src      addr.IAInt
+	MaxPaths uint16
+	// ExpiryLead is the number of seconds before the expiration of a path at
+	// which SCIOND pushes an update. If zero, SCIOND uses its default.
+	ExpiryLead uint32
+}
+
+func (r *PathSubscribeReq) String() string {
+	return fmt.Sprintf("%v -> %v, maxPaths=%d, expiryLead=%ds",
+		r.Src, r.Dst, r.MaxPaths, r.ExpiryLead)
+}
+
+type PathUpdateReason uint16
+
+const (
+	// PathUpdateInitial is the reason of the first update of a subscription.
+	PathUpdateInitial PathUpdateReason = iota
+	// PathUpdateNewPaths indicates that new paths to the destination appeared.
+	PathUpdateNewPaths
+	// PathUpdateRevoked indicates that at least one path was revoked.
+	PathUpdateRevoked
+	// PathUpdateExpiring indicates that at least one path nears its expiration.
+	PathUpdateExpiring
+)
+
+func (r PathUpdateReason) String() string {
+	switch r {
+	case PathUpdateInitial:
+		return "initial"
+	case PathUpdateNewPaths:
+		return "new paths"
+	case PathUpdateRevoked:
+		return "revoked"
+	case PathUpdateExpiring:
+		return "expiring"
+	default:
+		return fmt.Sprintf("Unknown path update reason (%d)", uint16(r))
+	}
+}
+
+// PathUpdate is pushed by SCIOND to path subscribers. It always contains the
+// complete set of current paths.
+type PathUpdate struct {
+	Reason    PathUpdateReason
+	ErrorCode PathErrorCode
+	Entries   []PathReplyEntry
+}
+
+func (u *PathUpdate) String() string {
+	strEntries := make([]string, len(u.Entries))
+	for i := range u.Entries {
+		strEntries[i] = u.Entries[i].String()
+	}
+	return fmt.Sprintf("Reason=%v ErrorCode=%v\n  %v", u.Reason, u.ErrorCode,
+		strings.Join(strEntries, "\n  "))
+}
+
 type PathReplyEntry struct {
 	Path     *FwdPathMeta
 	HostInfo hostinfo.Host
+	// Health contains the probing statistics of the path. It is nil, if SCIOND
+	// does not probe the paths it hands out.
+	Health *PathHealth
+	// LinkInfo contains the static link metadata of the path interfaces, in
+	// the same order as the interfaces. It is empty, if SCIOND has no link
+	// metadata.
+	LinkInfo []PathLinkInfo
 }
 
 func (e *PathReplyEntry) Copy() *PathReplyEntry {
@@ -176,11 +271,119 @@ func (e *PathReplyEntry) Copy() *PathReplyEntry {
 	return &PathReplyEntry{
 		Path:     e.Path.Copy(),
 		HostInfo: *e.HostInfo.Copy(),
+		Health:   e.Health.Copy(),
+		LinkInfo: append(e.LinkInfo[:0:0], e.LinkInfo...),
 	}
 }
 
 func (e *PathReplyEntry) String() string {
-	return fmt.Sprintf("%v NextHop=%v", e.Path, &e.HostInfo)
+	if e.Health == nil {
+		return fmt.Sprintf("%v NextHop=%v", e.Path, &e.HostInfo)
+	}
+	return fmt.Sprintf("%v NextHop=%v Health=%v", e.Path, &e.HostInfo, e.Health)
+}
+
+// PathLiveness describes whether a path is known to forward packets.
+type PathLiveness uint8
+
+const (
+	// PathLivenessUnknown indicates that the path has not been probed yet.
+	PathLivenessUnknown PathLiveness = iota
+	// PathLivenessAlive indicates that the last probe on the path succeeded.
+	PathLivenessAlive
+	// PathLivenessDead indicates that the recent probes on the path failed.
+	PathLivenessDead
+)
+
+func (l PathLiveness) String() string {
+	switch l {
+	case PathLivenessUnknown:
+		return "unknown"
+	case PathLivenessAlive:
+		return "alive"
+	case PathLivenessDead:
+		return "dead"
+	default:
+		return fmt.Sprintf("Unknown path liveness (%d)", uint8(l))
+	}
+}
+
+// PathHealth contains the statistics SCIOND gathered by probing a path.
+type PathHealth struct {
+	State PathLiveness
+	// Rtt is the average round trip time of the successful probes in
+	// microseconds.
+	Rtt uint32
+	// Loss is the fraction of the probes in the history that were lost.
+	Loss float32
+	// Probes is the number of probes in the history.
+	Probes uint16
+	// LastProbe is the time of the last probe in seconds since epoch.
+	LastProbe uint32
+}
+
+// RTT returns the average round trip time.
+func (h *PathHealth) RTT() time.Duration {
+	return time.Duration(h.Rtt) * time.Microsecond
+}
+
+func (h *PathHealth) Copy() *PathHealth {
+	if h == nil {
+		return nil
+	}
+	c := *h
+	return &c
+}
+
+func (h *PathHealth) String() string {
+	return fmt.Sprintf("%s RTT=%v Loss=%.2f Probes=%d LastProbe=%s", h.State, h.RTT(),
+		h.Loss, h.Probes, util.TimeToCompact(util.SecsToTime(h.LastProbe)))
+}
+
+// PathLinkInfo is the static metadata of the link attached to an interface of
+// a path.
+type PathLinkInfo struct {
+	// Latency is the latency of the link in microseconds. Zero means unknown.
+	Latency uint32
+	// Bandwidth is the bandwidth of the link in Kbit/s. Zero means unknown.
+	Bandwidth uint64
+	// HasGeo indicates whether the location of the interface is known.
+	HasGeo    bool
+	Latitude  float32
+	Longitude float32
+	Address   string
+}
+
+// NewPathLinkInfo converts the link metadata of the path policy package.
+func NewPathLinkInfo(info pathpol.LinkInfo) PathLinkInfo {
+	l := PathLinkInfo{
+		Latency:   uint32(info.Latency.Duration / time.Microsecond),
+		Bandwidth: info.Bandwidth,
+	}
+	if info.Geo != nil {
+		l.HasGeo = true
+		l.Latitude = info.Geo.Latitude
+		l.Longitude = info.Geo.Longitude
+		l.Address = info.Geo.Address
+	}
+	return l
+}
+
+// LinkInfo converts the link metadata to the format of the path policy
+// package.
+func (l PathLinkInfo) LinkInfo() pathpol.LinkInfo {
+	info := pathpol.LinkInfo{
+		Latency:   util.DurWrap{Duration: time.Duration(l.Latency) * time.Microsecond},
+		Bandwidth: l.Bandwidth,
+	}
+	if l.HasGeo {
+		info.Geo = &pathpol.GeoCoordinates{
+			Latitude:  l.Latitude,
+			Longitude: l.Longitude,
+			Address:   l.Address,
+		}
+	}
+	return info
 }
 
 type FwdPathMeta struct {
@@ -210,6 +413,20 @@ func (fpm *FwdPathMeta) Expiry() time.Time {
 	return util.SecsToTime(fpm.ExpTime)
 }
 
+// Fingerprint returns the fingerprint of the path. It matches the fingerprint
+// of the snet.Path that is created from the path.
+func (fpm *FwdPathMeta) Fingerprint() snet.PathFingerprint {
+	if len(fpm.Interfaces) == 0 {
+		return ""
+	}
+	h := sha256.New()
+	for _, intf := range fpm.Interfaces {
+		binary.Write(h, common.Order, intf.IA().IAInt())
+		binary.Write(h, common.Order, intf.ID())
+	}
+	return snet.PathFingerprint(h.Sum(nil))
+}
+
 func (fpm *FwdPathMeta) Copy() *FwdPathMeta {
 	if fpm == nil {
 		return nil
@@ -133,6 +133,16 @@ func ParseSignedRequest(raw []byte) (SignedRequest, error) {
 	return signed, nil
 }
 
+// Info decodes and returns the request info. The signatures are not verified,
+// use the RequestVerifier to get the verified request info.
+func (s SignedRequest) Info() (RequestInfo, error) {
+	req, err := s.Encoded.Decode()
+	if err != nil {
+		return RequestInfo{}, err
+	}
+	return req.Encoded.Decode()
+}
+
 // EncodedRequest is the base64Url encoded marshaled renewal request.
 type EncodedRequest string
 
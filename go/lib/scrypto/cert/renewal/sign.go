@@ -0,0 +1,82 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package renewal
+
+import (
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// Signer holds a private key that is used to sign a renewal request.
+type Signer struct {
+	// Type is the type of the key.
+	Type KeyType
+	// KeyVersion is the version of the key.
+	KeyVersion scrypto.KeyVersion
+	// Algorithm is the signing algorithm of the key.
+	Algorithm string
+	// PrivateKey is the raw private key.
+	PrivateKey []byte
+}
+
+func (s Signer) sign(payload string) (EncodedProtected, scrypto.JWSignature, error) {
+	protected, err := EncodeProtected(Protected{
+		Algorithm:  s.Algorithm,
+		KeyType:    s.Type,
+		KeyVersion: s.KeyVersion,
+	})
+	if err != nil {
+		return "", nil, serrors.WrapStr("unable to encode protected", err)
+	}
+	input := scrypto.JWSignatureInput(string(protected), payload)
+	sig, err := scrypto.Sign(input, s.PrivateKey, s.Algorithm)
+	if err != nil {
+		return "", nil, serrors.WrapStr("unable to sign", err, "key_type", s.Type)
+	}
+	return protected, sig, nil
+}
+
+// NewSignedRequest creates a signed renewal request. The request carries a
+// proof of possession for every private key in pops. The outer signature is
+// created by the signer, which holds the signing key that is authenticated by
+// the currently active AS certificate of the subject.
+func NewSignedRequest(info *RequestInfo, pops []Signer, signer Signer) (SignedRequest, error) {
+	encInfo, err := EncodeRequestInfo(info)
+	if err != nil {
+		return SignedRequest{}, serrors.WrapStr("unable to encode request info", err)
+	}
+	req := Request{Encoded: encInfo}
+	for _, s := range pops {
+		protected, sig, err := s.sign(string(encInfo))
+		if err != nil {
+			return SignedRequest{}, serrors.WrapStr("unable to create proof of possession", err)
+		}
+		req.POPs = append(req.POPs, POP{Protected: protected, Signature: sig})
+	}
+	encReq, err := EncodeRequest(&req)
+	if err != nil {
+		return SignedRequest{}, serrors.WrapStr("unable to encode request", err)
+	}
+	protected, sig, err := signer.sign(string(encReq))
+	if err != nil {
+		return SignedRequest{}, err
+	}
+	signed := SignedRequest{
+		Encoded:          encReq,
+		EncodedProtected: protected,
+		Signature:        sig,
+	}
+	return signed, nil
+}
@@ -0,0 +1,156 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package renewal
+
+import (
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/scrypto/cert"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+var (
+	// ErrInvalidProtected indicates an invalid protected meta.
+	ErrInvalidProtected = serrors.New("invalid protected meta")
+	// ErrInvalidSignature indicates that a signature does not verify.
+	ErrInvalidSignature = serrors.New("invalid signature")
+	// ErrUnexpectedSubject indicates that the request subject does not match
+	// the subject of the current certificate.
+	ErrUnexpectedSubject = serrors.New("unexpected subject")
+	// ErrInvalidVersion indicates that the requested version is not newer
+	// than the version of the current certificate.
+	ErrInvalidVersion = serrors.New("invalid version")
+	// ErrValidityNotSet indicates that the requested validity is not set.
+	ErrValidityNotSet = serrors.New("validity not set")
+	// ErrMissingPOP indicates that a proof of possession is missing.
+	ErrMissingPOP = serrors.New("missing proof of possession")
+	// ErrUnexpectedPOP indicates a proof of possession for a key that is not
+	// part of the request, or multiple proofs for the same key.
+	ErrUnexpectedPOP = serrors.New("unexpected proof of possession")
+)
+
+// RequestVerifier verifies signed renewal requests against the currently
+// active AS certificate of the subject. The caller must ensure that the AS
+// certificate is verified.
+type RequestVerifier struct {
+	AS *cert.AS
+}
+
+// Verify verifies the signed renewal request. The outer signature must be
+// created with the signing key of the current AS certificate, and every key in
+// the request must be accompanied by a proof of possession. It returns the
+// verified request info, and the meta data of all keys in the request.
+func (v RequestVerifier) Verify(signed SignedRequest) (RequestInfo,
+	map[cert.KeyType]scrypto.KeyMeta, error) {
+
+	if err := v.verifySignature(signed); err != nil {
+		return RequestInfo{}, nil, err
+	}
+	req, err := signed.Encoded.Decode()
+	if err != nil {
+		return RequestInfo{}, nil, serrors.WrapStr("unable to decode request", err)
+	}
+	info, err := req.Encoded.Decode()
+	if err != nil {
+		return RequestInfo{}, nil, serrors.WrapStr("unable to decode request info", err)
+	}
+	if err := v.checkInfo(info); err != nil {
+		return RequestInfo{}, nil, err
+	}
+	keys, err := verifyPOPs(req, info)
+	if err != nil {
+		return RequestInfo{}, nil, err
+	}
+	return info, keys, nil
+}
+
+func (v RequestVerifier) verifySignature(signed SignedRequest) error {
+	p, err := signed.EncodedProtected.Decode()
+	if err != nil {
+		return serrors.WrapStr("unable to decode protected", err)
+	}
+	meta := v.AS.Keys[cert.SigningKey]
+	if p.KeyType != SigningKey || p.KeyVersion != meta.KeyVersion ||
+		p.Algorithm != meta.Algorithm {
+
+		return serrors.WithCtx(ErrInvalidProtected, "key_type", p.KeyType,
+			"key_version", p.KeyVersion, "algorithm", p.Algorithm,
+			"expected_key_version", meta.KeyVersion, "expected_algorithm", meta.Algorithm)
+	}
+	if err := scrypto.Verify(signed.SigInput(), signed.Signature, meta.Key,
+		meta.Algorithm); err != nil {
+
+		return serrors.Wrap(ErrInvalidSignature, err)
+	}
+	return nil
+}
+
+func (v RequestVerifier) checkInfo(info RequestInfo) error {
+	if !info.Subject.Equal(v.AS.Subject) {
+		return serrors.WithCtx(ErrUnexpectedSubject, "expected", v.AS.Subject,
+			"actual", info.Subject)
+	}
+	if info.Version <= v.AS.Version {
+		return serrors.WithCtx(ErrInvalidVersion, "current", v.AS.Version,
+			"requested", info.Version)
+	}
+	if info.Validity == nil {
+		return ErrValidityNotSet
+	}
+	return nil
+}
+
+// verifyPOPs verifies that there is exactly one valid proof of possession for
+// every key in the request. The signing key is mandatory, the revocation key
+// is optional.
+func verifyPOPs(req Request, info RequestInfo) (map[cert.KeyType]scrypto.KeyMeta, error) {
+	requested := map[KeyType]KeyMeta{SigningKey: info.Keys.Signing}
+	if len(info.Keys.Revocation.Key) != 0 {
+		requested[RevocationKey] = info.Keys.Revocation
+	}
+	keys := make(map[cert.KeyType]scrypto.KeyMeta)
+	for _, pop := range req.POPs {
+		p, err := pop.Protected.Decode()
+		if err != nil {
+			return nil, serrors.WrapStr("unable to decode proof of possession", err)
+		}
+		key, ok := requested[p.KeyType]
+		if !ok {
+			return nil, serrors.WithCtx(ErrUnexpectedPOP, "key_type", p.KeyType)
+		}
+		delete(requested, p.KeyType)
+		if err := scrypto.Verify(pop.SigInput(req.Encoded), pop.Signature, key.Key,
+			p.Algorithm); err != nil {
+
+			return nil, serrors.Wrap(ErrInvalidSignature, err, "key_type", p.KeyType)
+		}
+		keys[certKeyType(p.KeyType)] = scrypto.KeyMeta{
+			KeyVersion: p.KeyVersion,
+			Algorithm:  p.Algorithm,
+			Key:        key.Key,
+		}
+	}
+	for keyType := range requested {
+		return nil, serrors.WithCtx(ErrMissingPOP, "key_type", keyType)
+	}
+	return keys, nil
+}
+
+// certKeyType returns the certificate key type for a validated key type.
+func certKeyType(t KeyType) cert.KeyType {
+	if t == RevocationKey {
+		return cert.RevocationKey
+	}
+	return cert.SigningKey
+}
@@ -0,0 +1,168 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package renewal_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/scrypto/cert"
+	"github.com/scionproto/scion/go/lib/scrypto/cert/renewal"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestRequestVerifierVerify(t *testing.T) {
+	current := newSigner(t, renewal.SigningKey, 1)
+	signing := newSigner(t, renewal.SigningKey, 2)
+	revocation := newSigner(t, renewal.RevocationKey, 1)
+	as := &cert.AS{
+		Base: cert.Base{
+			Subject: xtest.MustParseIA("1-ff00:0:111"),
+			Version: 1,
+			Keys: map[cert.KeyType]scrypto.KeyMeta{
+				cert.SigningKey: pubKeyMeta(t, current),
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		Modify      func(info *renewal.RequestInfo)
+		POPs        []renewal.Signer
+		Signer      renewal.Signer
+		ExpectedErr error
+	}{
+		"valid": {
+			Modify: func(*renewal.RequestInfo) {},
+			POPs:   []renewal.Signer{signing, revocation},
+			Signer: current,
+		},
+		"valid without revocation key": {
+			Modify: func(info *renewal.RequestInfo) {
+				info.Keys.Revocation = renewal.KeyMeta{}
+			},
+			POPs:   []renewal.Signer{signing},
+			Signer: current,
+		},
+		"signed with new key": {
+			Modify:      func(*renewal.RequestInfo) {},
+			POPs:        []renewal.Signer{signing, revocation},
+			Signer:      signing,
+			ExpectedErr: renewal.ErrInvalidProtected,
+		},
+		"signed with wrong key": {
+			Modify:      func(*renewal.RequestInfo) {},
+			POPs:        []renewal.Signer{signing, revocation},
+			Signer:      newSigner(t, renewal.SigningKey, 1),
+			ExpectedErr: renewal.ErrInvalidSignature,
+		},
+		"wrong subject": {
+			Modify: func(info *renewal.RequestInfo) {
+				info.Subject = xtest.MustParseIA("1-ff00:0:112")
+			},
+			POPs:        []renewal.Signer{signing, revocation},
+			Signer:      current,
+			ExpectedErr: renewal.ErrUnexpectedSubject,
+		},
+		"version not increased": {
+			Modify: func(info *renewal.RequestInfo) {
+				info.Version = 1
+			},
+			POPs:        []renewal.Signer{signing, revocation},
+			Signer:      current,
+			ExpectedErr: renewal.ErrInvalidVersion,
+		},
+		"missing POP": {
+			Modify:      func(*renewal.RequestInfo) {},
+			POPs:        []renewal.Signer{signing},
+			Signer:      current,
+			ExpectedErr: renewal.ErrMissingPOP,
+		},
+		"duplicate POP": {
+			Modify:      func(*renewal.RequestInfo) {},
+			POPs:        []renewal.Signer{signing, signing, revocation},
+			Signer:      current,
+			ExpectedErr: renewal.ErrUnexpectedPOP,
+		},
+		"POP with wrong key": {
+			Modify:      func(*renewal.RequestInfo) {},
+			POPs:        []renewal.Signer{current, revocation},
+			Signer:      current,
+			ExpectedErr: renewal.ErrInvalidSignature,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			info := newRequestInfo(time.Now())
+			info.Keys = renewal.Keys{
+				Signing:    renewal.KeyMeta{Key: pubKeyMeta(t, signing).Key},
+				Revocation: renewal.KeyMeta{Key: pubKeyMeta(t, revocation).Key},
+			}
+			test.Modify(&info)
+			signed, err := renewal.NewSignedRequest(&info, test.POPs, test.Signer)
+			require.NoError(t, err)
+
+			verified, keys, err := renewal.RequestVerifier{AS: as}.Verify(signed)
+			if test.ExpectedErr != nil {
+				xtest.AssertErrorsIs(t, err, test.ExpectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, info, verified)
+			assert.Equal(t, pubKeyMeta(t, signing), keys[cert.SigningKey])
+			if len(info.Keys.Revocation.Key) != 0 {
+				assert.Equal(t, pubKeyMeta(t, revocation), keys[cert.RevocationKey])
+			} else {
+				assert.NotContains(t, keys, cert.RevocationKey)
+			}
+		})
+	}
+}
+
+func TestSignedRequestInfo(t *testing.T) {
+	info := newRequestInfo(time.Now())
+	signer := newSigner(t, renewal.SigningKey, 1)
+	signed, err := renewal.NewSignedRequest(&info, []renewal.Signer{signer}, signer)
+	require.NoError(t, err)
+	decoded, err := signed.Info()
+	require.NoError(t, err)
+	assert.Equal(t, info, decoded)
+}
+
+func newSigner(t *testing.T, keyType renewal.KeyType,
+	version scrypto.KeyVersion) renewal.Signer {
+
+	_, priv, err := scrypto.GenKeyPair(scrypto.Ed25519)
+	require.NoError(t, err)
+	return renewal.Signer{
+		Type:       keyType,
+		KeyVersion: version,
+		Algorithm:  scrypto.Ed25519,
+		PrivateKey: priv,
+	}
+}
+
+func pubKeyMeta(t *testing.T, s renewal.Signer) scrypto.KeyMeta {
+	pub, err := scrypto.GetPubKey(s.PrivateKey, s.Algorithm)
+	require.NoError(t, err)
+	return scrypto.KeyMeta{
+		KeyVersion: s.KeyVersion,
+		Algorithm:  s.Algorithm,
+		Key:        pub,
+	}
+}
@@ -66,13 +66,19 @@ var (
 type dispRegistry struct {
 	sync.RWMutex
 	PollReqC RegPldChan
-	pollRep  map[RegPollKey]RegPldChan
+	// PrefixAnnounceC receives the prefix announcements of remote SIGs.
+	PrefixAnnounceC RegPldChan
+	// KeyAnnounceC receives the frame key announcements of remote SIGs.
+	KeyAnnounceC RegPldChan
+	pollRep      map[RegPollKey]RegPldChan
 }
 
 func newDispReg() *dispRegistry {
 	return &dispRegistry{
-		PollReqC: make(RegPldChan, 16),
-		pollRep:  make(map[RegPollKey]RegPldChan),
+		PollReqC:        make(RegPldChan, 16),
+		PrefixAnnounceC: make(RegPldChan, 16),
+		KeyAnnounceC:    make(RegPldChan, 16),
+		pollRep:         make(map[RegPollKey]RegPldChan),
 	}
 }
 
@@ -124,6 +130,28 @@ func (dm *dispRegistry) sigCtrl(pld *sig_mgmt.Pld, addr *snet.UDPAddr) {
 			return
 		}
 		entry <- regPld
+	case *sig_mgmt.PrefixAnnounce:
+		select {
+		case dm.PrefixAnnounceC <- &RegPld{Id: msgId, P: pld, Addr: addr}:
+		default:
+			// Announcements are repeated periodically, dropping one is harmless.
+			log.Warn("Dropping SIG PrefixAnnounce, queue full", "src", addr)
+		}
+	case *sig_mgmt.KeyAnnounce:
+		select {
+		case dm.KeyAnnounceC <- &RegPld{Id: msgId, P: pld, Addr: addr}:
+		default:
+			// Keys are announced until they are acknowledged.
+			log.Warn("Dropping SIG KeyAnnounce, queue full", "src", addr)
+		}
+	case *sig_mgmt.KeyAck:
+		// Acknowledgements are handled by the session that announced the key.
+		entry, ok := dm.pollRep[MkRegPollKey(addr.IA, pld.Session, msgId)]
+		if !ok {
+			log.Warn("Unexpected SIG KeyAck received", "src", addr, "pld", pld)
+			return
+		}
+		entry <- &RegPld{Id: msgId, P: pld, Addr: addr}
 	default:
 		log.Error("Unsupported ctrl payload type", "type", common.TypeOf(pld), "src", addr)
 	}
@@ -20,14 +20,24 @@ package sigjson
 import (
 	"encoding/json"
 	"io/ioutil"
+	"net"
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/sig_mgmt"
+	"github.com/scionproto/scion/go/lib/pathpol"
+	"github.com/scionproto/scion/go/lib/pktcls"
 )
 
 // Cfg is a direct Go representation of the JSON file format.
 type Cfg struct {
-	ASes          map[addr.IA]*ASEntry
+	ASes map[addr.IA]*ASEntry
+	// Classes are the traffic classes that can be referenced by the sessions
+	// of the ASes.
+	Classes pktcls.ClassMap `json:",omitempty"`
+	// AnnouncedNets are the local networks that are announced to the SIGs of
+	// the ASes that have Announce set.
+	AnnouncedNets []*IPNet `json:",omitempty"`
 	ConfigVersion uint64
 }
 
@@ -41,9 +51,153 @@ func LoadFromFile(path string) (*Cfg, error) {
 	if err := json.Unmarshal(b, cfg); err != nil {
 		return nil, common.NewBasicError("Unable to parse SIG config", err)
 	}
+	if err := cfg.Validate(); err != nil {
+		return nil, common.NewBasicError("Invalid SIG config", err)
+	}
 	return cfg, nil
 }
 
+// Validate checks that the sessions of all ASes reference existing traffic
+// classes, and that the session IDs are unique per AS.
+func (cfg *Cfg) Validate() error {
+	for ia, entry := range cfg.ASes {
+		ids := make(map[sig_mgmt.SessionType]bool)
+		for _, sess := range entry.Sessions {
+			if sess.ID == DefaultSessionID {
+				return common.NewBasicError("Session ID is reserved for the default session",
+					nil, "ia", ia, "id", sess.ID)
+			}
+			if ids[sess.ID] {
+				return common.NewBasicError("Duplicate session ID", nil, "ia", ia, "id", sess.ID)
+			}
+			ids[sess.ID] = true
+			if _, ok := cfg.Classes[sess.Class]; !ok {
+				return common.NewBasicError("Unknown traffic class", nil,
+					"ia", ia, "id", sess.ID, "class", sess.Class)
+			}
+			if err := sess.Multipath.Validate(); err != nil {
+				return common.NewBasicError("Invalid multipath config", err,
+					"ia", ia, "id", sess.ID)
+			}
+		}
+		if err := entry.Multipath.Validate(); err != nil {
+			return common.NewBasicError("Invalid multipath config", err, "ia", ia)
+		}
+		if err := entry.ImportFilter.Validate(); err != nil {
+			return common.NewBasicError("Invalid import filter", err, "ia", ia)
+		}
+	}
+	return nil
+}
+
+// DefaultSessionID is the ID of the session that carries the traffic that is
+// not matched by any of the configured sessions.
+const DefaultSessionID sig_mgmt.SessionType = 0
+
 type ASEntry struct {
 	Nets []*IPNet
+	// Sessions are additional sessions that carry the traffic of a traffic
+	// class. A packet is sent on the first session whose class matches it. All
+	// other packets are sent on the default session.
+	Sessions []*Session `json:",omitempty"`
+	// Multipath configures the default session to spread its traffic over
+	// multiple paths. If it is not set, a single path is used.
+	Multipath *Multipath `json:",omitempty"`
+	// Announce enables announcing Cfg.AnnouncedNets to the SIG of the AS.
+	Announce bool `json:",omitempty"`
+	// ImportFilter enables learning networks from the prefix announcements of
+	// the SIG of the AS. The announced networks that pass the filter are used
+	// in addition to Nets. If it is not set, announcements are ignored.
+	ImportFilter *ImportFilter `json:",omitempty"`
+	// Encryption enables the encryption of the frames sent to the SIG of the
+	// AS. Unencrypted frames received from the AS are dropped.
+	Encryption bool `json:",omitempty"`
+}
+
+// Session maps a traffic class to a session.
+type Session struct {
+	// ID is the session ID. It must be unique per AS, and must not be the
+	// DefaultSessionID.
+	ID sig_mgmt.SessionType
+	// Class is the name of the traffic class in Cfg.Classes.
+	Class string
+	// PathPolicy restricts the paths used by the session. If it is not set,
+	// all paths are used.
+	PathPolicy *pathpol.Policy `json:",omitempty"`
+	// Multipath configures the session to spread its traffic over multiple
+	// paths. If it is not set, a single path is used.
+	Multipath *Multipath `json:",omitempty"`
+}
+
+// Load-balancing modes of multipath sessions.
+const (
+	// LoadBalancingRoundRobin spreads the frames of the session over the paths
+	// by weighted round-robin.
+	LoadBalancingRoundRobin = "weighted_rr"
+	// LoadBalancingFlowHash sends all packets of a flow, identified by its
+	// 5-tuple, over the same path.
+	LoadBalancingFlowHash = "flow_hash"
+)
+
+// Multipath configures how a session spreads its traffic over multiple paths.
+// The paths are weighted by their probe RTT and timeouts.
+type Multipath struct {
+	// Mode is the load-balancing mode, either LoadBalancingRoundRobin or
+	// LoadBalancingFlowHash.
+	Mode string
+	// MaxPaths is the maximum number of paths used at the same time. If it is
+	// not set, a default is used.
+	MaxPaths int `json:",omitempty"`
+}
+
+// Validate checks that the load-balancing mode is known and that MaxPaths is
+// not negative. A nil config is valid.
+func (m *Multipath) Validate() error {
+	if m == nil {
+		return nil
+	}
+	if m.Mode != LoadBalancingRoundRobin && m.Mode != LoadBalancingFlowHash {
+		return common.NewBasicError("Unknown load balancing mode", nil, "mode", m.Mode)
+	}
+	if m.MaxPaths < 0 {
+		return common.NewBasicError("Negative MaxPaths", nil, "max_paths", m.MaxPaths)
+	}
+	return nil
+}
+
+// ImportFilter restricts the networks that are learned from the prefix
+// announcements of a remote SIG.
+type ImportFilter struct {
+	// Allowed are the networks that imported networks must be contained in.
+	Allowed []*IPNet
+	// MaxNets is the maximum number of networks imported from the remote SIG.
+	// Announcements with more networks are rejected. If it is not set, the
+	// number of networks is not limited.
+	MaxNets int `json:",omitempty"`
+}
+
+// Validate checks that MaxNets is not negative. A nil filter is valid.
+func (f *ImportFilter) Validate() error {
+	if f == nil {
+		return nil
+	}
+	if f.MaxNets < 0 {
+		return common.NewBasicError("Negative MaxNets", nil, "max_nets", f.MaxNets)
+	}
+	return nil
+}
+
+// Allows returns whether the network is contained in one of the allowed
+// networks. A network is contained in an allowed network of the same address
+// family if its prefix is at least as long and it starts within the allowed
+// network.
+func (f *ImportFilter) Allows(n *net.IPNet) bool {
+	ones, bits := n.Mask.Size()
+	for _, a := range f.Allowed {
+		aOnes, aBits := a.Mask.Size()
+		if bits == aBits && ones >= aOnes && a.IPNet().Contains(n.IP) {
+			return true
+		}
+	}
+	return false
 }
@@ -19,10 +19,13 @@ import (
 	"net"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/pathpol"
+	"github.com/scionproto/scion/go/lib/pktcls"
 	"github.com/scionproto/scion/go/lib/xtest"
 )
 
@@ -71,6 +74,96 @@ func TestLoadFromFile(t *testing.T) {
 				ConfigVersion: 9001,
 			},
 		},
+		{
+			Name:     "sessions",
+			FileName: "02-sessions",
+			Config: Cfg{
+				ASes: map[addr.IA]*ASEntry{
+					xtest.MustParseIA("1-ff00:0:1"): {
+						Nets: []*IPNet{
+							{
+								IP:   net.IP{192, 0, 2, 0},
+								Mask: net.CIDRMask(24, 8*net.IPv4len),
+							},
+						},
+						Sessions: []*Session{
+							{
+								ID:    1,
+								Class: "voip",
+								PathPolicy: &pathpol.Policy{
+									Latency: &pathpol.DurationPredicate{
+										Op:    pathpol.CmpLE,
+										Value: 100 * time.Millisecond,
+									},
+								},
+							},
+							{
+								ID:    2,
+								Class: "bulk",
+								Multipath: &Multipath{
+									Mode:     LoadBalancingFlowHash,
+									MaxPaths: 3,
+								},
+							},
+						},
+						Multipath: &Multipath{Mode: LoadBalancingRoundRobin},
+					},
+				},
+				Classes: pktcls.ClassMap{
+					"voip": pktcls.NewClass("voip",
+						pktcls.NewCondIPv4(&pktcls.IPv4MatchDSCP{DSCP: 0x2e})),
+					"bulk": pktcls.NewClass("bulk",
+						pktcls.NewCondIPv4(&pktcls.IPv4MatchDSCP{DSCP: 0x08})),
+				},
+				ConfigVersion: 1,
+			},
+		},
+		{
+			Name:     "announce",
+			FileName: "03-announce",
+			Config: Cfg{
+				ASes: map[addr.IA]*ASEntry{
+					xtest.MustParseIA("1-ff00:0:1"): {
+						Nets:     []*IPNet{},
+						Announce: true,
+						ImportFilter: &ImportFilter{
+							Allowed: []*IPNet{
+								{
+									IP:   net.IP{10, 0, 0, 0},
+									Mask: net.CIDRMask(8, 8*net.IPv4len),
+								},
+							},
+							MaxNets: 16,
+						},
+					},
+				},
+				AnnouncedNets: []*IPNet{
+					{
+						IP:   net.IP{192, 0, 2, 0},
+						Mask: net.CIDRMask(24, 8*net.IPv4len),
+					},
+				},
+				ConfigVersion: 2,
+			},
+		},
+		{
+			Name:     "encryption",
+			FileName: "04-encryption",
+			Config: Cfg{
+				ASes: map[addr.IA]*ASEntry{
+					xtest.MustParseIA("1-ff00:0:1"): {
+						Nets: []*IPNet{
+							{
+								IP:   net.IP{192, 0, 2, 0},
+								Mask: net.CIDRMask(24, 8*net.IPv4len),
+							},
+						},
+						Encryption: true,
+					},
+				},
+				ConfigVersion: 3,
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -86,6 +179,107 @@ func TestLoadFromFile(t *testing.T) {
 	}
 }
 
+func TestValidate(t *testing.T) {
+	ia := xtest.MustParseIA("1-ff00:0:1")
+	classes := pktcls.ClassMap{
+		"voip": pktcls.NewClass("voip", pktcls.CondTrue),
+	}
+	tests := map[string]struct {
+		Sessions     []*Session
+		ImportFilter *ImportFilter
+		Error        assert.ErrorAssertionFunc
+	}{
+		"no sessions": {
+			Error: assert.NoError,
+		},
+		"valid": {
+			Sessions: []*Session{{ID: 1, Class: "voip"}, {ID: 2, Class: "voip"}},
+			Error:    assert.NoError,
+		},
+		"default session ID": {
+			Sessions: []*Session{{ID: DefaultSessionID, Class: "voip"}},
+			Error:    assert.Error,
+		},
+		"duplicate ID": {
+			Sessions: []*Session{{ID: 1, Class: "voip"}, {ID: 1, Class: "voip"}},
+			Error:    assert.Error,
+		},
+		"unknown class": {
+			Sessions: []*Session{{ID: 1, Class: "bulk"}},
+			Error:    assert.Error,
+		},
+		"multipath": {
+			Sessions: []*Session{
+				{ID: 1, Class: "voip", Multipath: &Multipath{Mode: LoadBalancingRoundRobin}},
+			},
+			Error: assert.NoError,
+		},
+		"unknown multipath mode": {
+			Sessions: []*Session{{ID: 1, Class: "voip", Multipath: &Multipath{Mode: "random"}}},
+			Error:    assert.Error,
+		},
+		"negative multipath max paths": {
+			Sessions: []*Session{{ID: 1, Class: "voip",
+				Multipath: &Multipath{Mode: LoadBalancingFlowHash, MaxPaths: -1}}},
+			Error: assert.Error,
+		},
+		"import filter": {
+			ImportFilter: &ImportFilter{MaxNets: 10},
+			Error:        assert.NoError,
+		},
+		"negative import filter max nets": {
+			ImportFilter: &ImportFilter{MaxNets: -1},
+			Error:        assert.Error,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := &Cfg{
+				ASes: map[addr.IA]*ASEntry{
+					ia: {Sessions: test.Sessions, ImportFilter: test.ImportFilter},
+				},
+				Classes: classes,
+			}
+			test.Error(t, cfg.Validate())
+		})
+	}
+}
+
+func TestImportFilterAllows(t *testing.T) {
+	mustParseNet := func(s string) *net.IPNet {
+		_, n, err := net.ParseCIDR(s)
+		if err != nil {
+			panic(err)
+		}
+		return n
+	}
+	filter := &ImportFilter{
+		Allowed: []*IPNet{
+			(*IPNet)(mustParseNet("10.0.0.0/8")),
+			(*IPNet)(mustParseNet("2001:db8::/32")),
+		},
+	}
+	tests := map[string]struct {
+		Net     string
+		Allowed bool
+	}{
+		"same network":          {Net: "10.0.0.0/8", Allowed: true},
+		"contained network":     {Net: "10.1.0.0/16", Allowed: true},
+		"contained IPv6":        {Net: "2001:db8:1::/48", Allowed: true},
+		"larger network":        {Net: "10.0.0.0/7", Allowed: false},
+		"disjoint network":      {Net: "192.0.2.0/24", Allowed: false},
+		"IPv4 mapped in IPv6":   {Net: "::ffff:10.0.0.0/104", Allowed: false},
+		"default route":         {Net: "0.0.0.0/0", Allowed: false},
+		"larger IPv6 network":   {Net: "2001:db8::/31", Allowed: false},
+		"disjoint IPv6 network": {Net: "2001:db9::/48", Allowed: false},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.Allowed, filter.Allows(mustParseNet(test.Net)))
+		})
+	}
+}
+
 func TestIPNetUnmarshalJSON(t *testing.T) {
 	tests := []struct {
 		Name  string
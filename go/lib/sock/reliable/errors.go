@@ -37,6 +37,7 @@ var (
 	ErrIncompleteMessage     common.ErrMsg = "incomplete message"
 	ErrBadLength             common.ErrMsg = "bad length"
 	ErrBufferTooSmall        common.ErrMsg = "buffer too small"
+	ErrRegistrationRejected  common.ErrMsg = "registration rejected"
 )
 
 // TODO(lukedirtwalker): Refactor methods in here to use `errors`.
@@ -199,16 +199,21 @@ func (l *registrationAddressField) length() int {
 	return 2 + 1 + len(l.Address)
 }
 
+// Confirmation is the reply of the dispatcher to a registration. If the
+// registration was rejected, Port is 0 and Error contains the reason. The
+// reason is appended after the port, such that clients that only read the
+// port remain compatible.
 type Confirmation struct {
-	Port uint16
+	Port  uint16
+	Error string
 }
 
 func (c *Confirmation) SerializeTo(b []byte) (int, error) {
-	if len(b) < 2 {
+	if len(b) < 2+len(c.Error) {
 		return 0, common.NewBasicError(ErrBufferTooSmall, nil)
 	}
 	common.Order.PutUint16(b, c.Port)
-	return 2, nil
+	return 2 + copy(b[2:], c.Error), nil
 }
 
 func (c *Confirmation) DecodeFromBytes(b []byte) error {
@@ -216,5 +221,6 @@ func (c *Confirmation) DecodeFromBytes(b []byte) error {
 		return common.NewBasicError(ErrIncompletePort, nil)
 	}
 	c.Port = common.Order.Uint16(b)
+	c.Error = string(b[2:])
 	return nil
 }
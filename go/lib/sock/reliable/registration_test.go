@@ -260,6 +260,16 @@ func TestConfirmationMessageSerializeTo(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, []byte{0xaa, 0xbb}, b[:n])
 	})
+	t.Run("rejection", func(t *testing.T) {
+		rejection := &Confirmation{Error: "denied"}
+		b := make([]byte, 4)
+		_, err := rejection.SerializeTo(b)
+		xtest.AssertErrorsIs(t, err, ErrBufferTooSmall)
+		b = make([]byte, 1500)
+		n, err := rejection.SerializeTo(b)
+		assert.NoError(t, err)
+		assert.Equal(t, append([]byte{0, 0}, "denied"...), b[:n])
+	})
 }
 
 func TestConfirmationDecodeFromBytes(t *testing.T) {
@@ -276,4 +286,10 @@ func TestConfirmationDecodeFromBytes(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, Confirmation{Port: 0xaabb}, confirmation)
 	})
+	t.Run("rejection", func(t *testing.T) {
+		b := append([]byte{0, 0}, "denied"...)
+		err := confirmation.DecodeFromBytes(b)
+		assert.NoError(t, err)
+		assert.Equal(t, Confirmation{Error: "denied"}, confirmation)
+	})
 }
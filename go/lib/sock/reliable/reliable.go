@@ -44,6 +44,10 @@
 //  +var-byte: Bind Address /
 //  +2-bytes: SVC (optional SVC type)
 //
+// ReliableSocket registration confirmation format:
+//   2-bytes: L4 port (0 if the registration was rejected)
+//  +var-byte: Reason (optional, only present if the registration was rejected)
+//
 // To communicate with SCIOND, clients must first connect to SCIOND's UNIX socket. Messages
 // for SCIOND must set the ADDR TYPE field in the common header to NONE. The payload contains
 // the query for SCIOND (e.g., a request for paths to a SCION destination). The reply header
@@ -249,6 +253,10 @@ func registrationExchange(conn *Conn, reg *Registration) (int, error) {
 		conn.Close()
 		return 0, err
 	}
+	if c.Error != "" {
+		conn.Close()
+		return 0, common.NewBasicError(ErrRegistrationRejected, nil, "reason", c.Error)
+	}
 	return int(c.Port), nil
 
 }
@@ -0,0 +1,79 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package scmpconn sets up the connections that tools, e.g., ping and
+// traceroute, use to send SCMP requests and receive the replies.
+package scmpconn
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/sock/reliable"
+)
+
+// Open registers a connection with the dispatcher for sending SCMP requests to
+// the remote. The SCMP messages are delivered to the handler, all other
+// packets are discarded. The connection is read until it is closed.
+func Open(ctx context.Context, dispatcher reliable.Dispatcher, localIA addr.IA,
+	localIP net.IP, remote *snet.UDPAddr, handler snet.SCMPHandler) (snet.PacketConn, error) {
+
+	if dispatcher == nil {
+		return nil, serrors.New("dispatcher not set")
+	}
+	if remote == nil || remote.Host == nil {
+		return nil, serrors.New("remote address not set")
+	}
+	if remote.NextHop == nil {
+		return nil, serrors.New("remote next hop not set")
+	}
+	svc := snet.DefaultPacketDispatcherService{
+		Dispatcher:  dispatcher,
+		SCMPHandler: handler,
+	}
+	conn, _, err := svc.Register(ctx, localIA, &net.UDPAddr{IP: localIP}, addr.SvcNone)
+	if err != nil {
+		return nil, serrors.WrapStr("unable to register with dispatcher", err)
+	}
+	go func() {
+		defer log.HandlePanic()
+		drain(conn)
+	}()
+	return conn, nil
+}
+
+// drain reads from the connection until it is closed. The SCMP messages are
+// passed to the SCMP handler while reading.
+func drain(conn snet.PacketConn) {
+	var pkt snet.Packet
+	for {
+		if err := conn.ReadFrom(&pkt, nil); err != nil && isSocketError(err) {
+			return
+		}
+	}
+}
+
+// isSocketError returns whether the error is caused by the underlying socket,
+// e.g., because it was closed. Other errors, e.g., parse errors, only affect a
+// single packet.
+func isSocketError(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.EOF)
+}
@@ -0,0 +1,178 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ping
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// MTUResult is the result of the MTU discovery.
+type MTUResult struct {
+	Destination string `json:"destination"`
+	// PathMTU is the MTU announced in the path metadata.
+	PathMTU int `json:"path_mtu"`
+	// MTU is the size of the largest SCION packet that reached the remote
+	// host.
+	MTU    int        `json:"mtu"`
+	Probes []MTUProbe `json:"probes"`
+}
+
+// MTUProbe is the outcome of probing a single packet size.
+type MTUProbe struct {
+	Size    int           `json:"size"`
+	Success bool          `json:"success"`
+	RTT     time.Duration `json:"rtt,omitempty"`
+	Error   string        `json:"error,omitempty"`
+}
+
+// Human writes the result in human readable form to the writer.
+func (r MTUResult) Human(w io.Writer) {
+	for _, p := range r.Probes {
+		switch {
+		case p.Success:
+			fmt.Fprintf(w, "%5d bytes: ok time=%s\n", p.Size, round(p.RTT))
+		case p.Error != "":
+			fmt.Fprintf(w, "%5d bytes: %s\n", p.Size, p.Error)
+		default:
+			fmt.Fprintf(w, "%5d bytes: timeout\n", p.Size)
+		}
+	}
+	fmt.Fprintf(w, "MTU to %s: %d bytes (path MTU %d bytes)\n", r.Destination, r.MTU,
+		r.PathMTU)
+}
+
+// JSON writes the result as a json object to the writer.
+func (r MTUResult) JSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// DiscoverMTU discovers the size of the largest SCION packet that reaches the
+// remote host, by sending echo requests of different sizes. The search is
+// bounded by maxSize, which usually is the MTU announced in the path metadata.
+// Every size is probed with up to cfg.Attempts echo requests, each of which
+// waits cfg.Timeout for the reply. cfg.Interval and cfg.PacketSize are
+// ignored. Because the echo requests are padded in lines, the discovered MTU
+// is rounded down to a multiple of the line length.
+func DiscoverMTU(ctx context.Context, cfg Config, maxSize int) (*MTUResult, error) {
+	cfg.initDefaults()
+	if cfg.Attempts == 0 {
+		cfg.Attempts = 1
+	}
+	p, err := newPinger(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer p.Close()
+
+	res := &MTUResult{
+		Destination: fmt.Sprintf("%s,[%s]", cfg.Remote.IA, cfg.Remote.Host.IP),
+		PathMTU:     maxSize,
+	}
+	var seq uint16
+	probe := func(size int) (bool, error) {
+		for i := 0; i < cfg.Attempts; i++ {
+			mp, err := probeSize(ctx, p, seq, size, cfg.Timeout)
+			seq++
+			if err != nil {
+				return false, err
+			}
+			res.Probes = append(res.Probes, mp)
+			if mp.Success || mp.Error != "" {
+				return mp.Success, nil
+			}
+		}
+		return false, nil
+	}
+
+	low := p.MinSize()
+	if maxSize < low {
+		return nil, serrors.New("maximum size smaller than minimum packet size",
+			"max", maxSize, "min", low)
+	}
+	maxSize = p.PacketSize(maxSize)
+	ok, err := probe(low)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, serrors.New("remote host not reachable", "remote", cfg.Remote)
+	}
+	ok, err = probe(maxSize)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		res.MTU = maxSize
+		return res, nil
+	}
+	// Invariant: low succeeded, high failed.
+	high := maxSize
+	for high-low > common.LineLen {
+		mid := low + (high-low)/2/common.LineLen*common.LineLen
+		ok, err := probe(mid)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			low = mid
+		} else {
+			high = mid
+		}
+	}
+	res.MTU = low
+	return res, nil
+}
+
+// probeSize sends a single echo request of the given size and waits for the
+// reply.
+func probeSize(ctx context.Context, p *pinger, seq uint16, size int,
+	timeout time.Duration) (MTUProbe, error) {
+
+	mp := MTUProbe{Size: size}
+	sent := time.Now()
+	if err := p.Send(seq, size); err != nil {
+		return mp, serrors.WrapStr("unable to send echo request", err, "size", size)
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return mp, ctx.Err()
+		case <-timer.C:
+			return mp, nil
+		case r := <-p.Replies():
+			if r.Seq != seq {
+				// Late reply to an earlier probe.
+				continue
+			}
+			if r.Error != nil {
+				mp.Error = r.Error.Error()
+				return mp, nil
+			}
+			mp.Success, mp.RTT = true, r.Received.Sub(sent)
+			return mp, nil
+		}
+	}
+}
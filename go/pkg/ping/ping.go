@@ -0,0 +1,218 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ping sends SCMP echo requests to a SCION host. It is used to measure
+// the round trip time to the host, and to discover the MTU of a path.
+package ping
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/sock/reliable"
+)
+
+const (
+	// DefaultInterval is the default time between two echo requests.
+	DefaultInterval = time.Second
+	// DefaultTimeout is the default time to wait for an echo reply.
+	DefaultTimeout = 2 * time.Second
+)
+
+// Config configures the ping run.
+type Config struct {
+	// Dispatcher is used to register the local address.
+	Dispatcher reliable.Dispatcher
+	// LocalIA is the ISD-AS of the local host.
+	LocalIA addr.IA
+	// LocalIP is the IP address of the local host.
+	LocalIP net.IP
+	// Remote is the address of the remote host. The path and the next hop
+	// must be set.
+	Remote *snet.UDPAddr
+	// Attempts is the number of echo requests that are sent. If it is zero,
+	// echo requests are sent until the context is done.
+	Attempts int
+	// Interval is the time between two echo requests. If it is zero,
+	// DefaultInterval is used.
+	Interval time.Duration
+	// Timeout is the time to wait for an echo reply. If it is zero,
+	// DefaultTimeout is used.
+	Timeout time.Duration
+	// PacketSize is the size of the SCION packets that are sent, rounded down
+	// to a multiple of the line length. If it is zero, the packets are as small
+	// as possible.
+	PacketSize int
+	// UpdateHandler is called for every echo reply and every timeout.
+	UpdateHandler func(Update)
+}
+
+func (cfg *Config) initDefaults() {
+	if cfg.Interval == 0 {
+		cfg.Interval = DefaultInterval
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+}
+
+// State is the outcome of an echo request.
+type State string
+
+const (
+	// Success indicates that an echo reply was received.
+	Success State = "success"
+	// Timeout indicates that no reply was received in time.
+	Timeout State = "timeout"
+	// Error indicates that an SCMP error was received.
+	Error State = "error"
+)
+
+// Update describes the outcome of an echo request.
+type Update struct {
+	Sequence uint16        `json:"sequence"`
+	State    State         `json:"state"`
+	Source   string        `json:"source,omitempty"`
+	Size     int           `json:"size,omitempty"`
+	RTT      time.Duration `json:"rtt,omitempty"`
+	Error    string        `json:"error,omitempty"`
+}
+
+func (u Update) String() string {
+	switch u.State {
+	case Success:
+		return fmt.Sprintf("%d bytes from %s: scmp_seq=%d time=%s", u.Size, u.Source,
+			u.Sequence, round(u.RTT))
+	case Error:
+		return fmt.Sprintf("From %s: scmp_seq=%d %s", u.Source, u.Sequence, u.Error)
+	default:
+		return fmt.Sprintf("scmp_seq=%d timeout", u.Sequence)
+	}
+}
+
+// Result is the result of a ping run.
+type Result struct {
+	Destination string   `json:"destination"`
+	Path        string   `json:"path,omitempty"`
+	Updates     []Update `json:"replies"`
+	Stats       Stats    `json:"statistics"`
+}
+
+// Human writes the statistics in human readable form to the writer.
+func (r Result) Human(w io.Writer) {
+	fmt.Fprintf(w, "\n--- %s statistics ---\n", r.Destination)
+	fmt.Fprintln(w, r.Stats)
+}
+
+// JSON writes the result as a json object to the writer.
+func (r Result) JSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// Run sends echo requests to the remote host until the configured number of
+// attempts is reached, or the context is done. The updates are reported to the
+// update handler, and accumulated in the result.
+func Run(ctx context.Context, cfg Config) (*Result, error) {
+	cfg.initDefaults()
+	p, err := newPinger(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer p.Close()
+
+	res := &Result{Destination: fmt.Sprintf("%s,[%s]", cfg.Remote.IA, cfg.Remote.Host.IP)}
+	if cfg.Remote.Path != nil {
+		res.Path = fmt.Sprintf("%x", cfg.Remote.Path.Raw)
+	}
+	update := func(u Update) {
+		res.Updates = append(res.Updates, u)
+		if cfg.UpdateHandler != nil {
+			cfg.UpdateHandler(u)
+		}
+	}
+
+	ticker := time.NewTicker(cfg.Interval)
+	defer ticker.Stop()
+	pending := make(map[uint16]time.Time)
+	var seq uint16
+	send := func() error {
+		if err := p.Send(seq, cfg.PacketSize); err != nil {
+			return serrors.WrapStr("unable to send echo request", err, "seq", seq)
+		}
+		pending[seq] = time.Now()
+		res.Stats.Sent++
+		seq++
+		return nil
+	}
+	if err := send(); err != nil {
+		return nil, err
+	}
+	for cfg.Attempts == 0 || res.Stats.Sent < cfg.Attempts || len(pending) > 0 {
+		var expiry <-chan time.Time
+		if oldest, ok := oldestPending(pending); ok {
+			expiry = time.After(time.Until(pending[oldest].Add(cfg.Timeout)))
+		}
+		select {
+		case <-ctx.Done():
+			return res, nil
+		case <-ticker.C:
+			if cfg.Attempts != 0 && res.Stats.Sent >= cfg.Attempts {
+				continue
+			}
+			if err := send(); err != nil {
+				return res, err
+			}
+		case r := <-p.Replies():
+			sent, ok := pending[r.Seq]
+			if !ok {
+				continue
+			}
+			delete(pending, r.Seq)
+			u := Update{Sequence: r.Seq, Source: r.Source, Size: r.Size}
+			if r.Error != nil {
+				u.State, u.Error = Error, r.Error.Error()
+			} else {
+				u.State, u.RTT = Success, r.Received.Sub(sent)
+				res.Stats.Record(u.RTT)
+			}
+			update(u)
+		case <-expiry:
+			oldest, _ := oldestPending(pending)
+			delete(pending, oldest)
+			update(Update{Sequence: oldest, State: Timeout})
+		}
+	}
+	return res, nil
+}
+
+// oldestPending returns the sequence number of the oldest pending request.
+func oldestPending(pending map[uint16]time.Time) (uint16, bool) {
+	var oldest uint16
+	var found bool
+	for seq, sent := range pending {
+		if !found || sent.Before(pending[oldest]) {
+			oldest, found = seq, true
+		}
+	}
+	return oldest, found
+}
@@ -0,0 +1,130 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ping
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/hpkt"
+	"github.com/scionproto/scion/go/lib/scmp"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/spkt"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestStats(t *testing.T) {
+	var s Stats
+	assert.Equal(t, float64(0), s.Loss())
+	s.Sent = 4
+	for _, rtt := range []time.Duration{2 * time.Millisecond, 4 * time.Millisecond} {
+		s.Record(rtt)
+	}
+	assert.Equal(t, 2, s.Received)
+	assert.Equal(t, 2*time.Millisecond, s.MinRTT)
+	assert.Equal(t, 3*time.Millisecond, s.AvgRTT)
+	assert.Equal(t, 4*time.Millisecond, s.MaxRTT)
+	assert.Equal(t, time.Millisecond, s.MdevRTT)
+	assert.Equal(t, float64(50), s.Loss())
+}
+
+func TestPingerPacketSize(t *testing.T) {
+	ia := xtest.MustParseIA("1-ff00:0:110")
+	p := &pinger{
+		id:    42,
+		local: snet.SCIONAddress{IA: ia, Host: addr.HostFromIP(net.IP{192, 0, 2, 1})},
+		remote: &snet.UDPAddr{
+			IA:   ia,
+			Host: &net.UDPAddr{IP: net.IP{192, 0, 2, 2}},
+		},
+	}
+	min := p.MinSize()
+	tests := map[string]struct {
+		Size     int
+		Expected int
+	}{
+		"zero":           {Size: 0, Expected: min},
+		"minimum":        {Size: min, Expected: min},
+		"one line":       {Size: min + common.LineLen, Expected: min + common.LineLen},
+		"round down":     {Size: min + 2*common.LineLen - 1, Expected: min + common.LineLen},
+		"multiple block": {Size: 1472, Expected: 1472},
+		"maximum":        {Size: min + maxPadding, Expected: min + maxPadding},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			size := p.PacketSize(test.Size)
+			assert.Equal(t, test.Expected, size)
+			pld := p.payload(7, size-min)
+			hdr := scmp.NewHdr(
+				scmp.ClassType{Class: scmp.C_General, Type: scmp.T_G_EchoRequest}, pld.Len())
+			pkt := &spkt.ScnPkt{
+				DstIA:   ia,
+				SrcIA:   ia,
+				DstHost: addr.HostFromIP(p.remote.Host.IP),
+				SrcHost: p.local.Host,
+				L4:      hdr,
+				Pld:     pld,
+			}
+			b := make(common.RawBytes, common.MaxMTU)
+			n, err := hpkt.WriteScnPkt(pkt, b)
+			require.NoError(t, err)
+			assert.Equal(t, size, n)
+
+			var parsed spkt.ScnPkt
+			require.NoError(t, hpkt.ParseScnPkt(&parsed, b[:n]))
+			info := parsed.Pld.(*scmp.Payload).Info.(*scmp.InfoEcho)
+			assert.Equal(t, &scmp.InfoEcho{Id: 42, Seq: 7}, info)
+		})
+	}
+}
+
+func TestSCMPHandler(t *testing.T) {
+	replies := make(chan reply, 1)
+	h := scmpHandler{id: 42, replies: replies}
+	source := snet.SCIONAddress{
+		IA:   xtest.MustParseIA("1-ff00:0:110"),
+		Host: addr.HostFromIP(net.IP{192, 0, 2, 2}),
+	}
+	echoReply := func(id uint64) *snet.Packet {
+		return &snet.Packet{
+			Bytes: make(snet.Bytes, 64),
+			PacketInfo: snet.PacketInfo{
+				Source:   source,
+				L4Header: &scmp.Hdr{Class: scmp.C_General, Type: scmp.T_G_EchoReply},
+				Payload: &scmp.Payload{
+					Meta: &scmp.Meta{},
+					Info: &scmp.InfoEcho{Id: id, Seq: 3},
+				},
+			},
+		}
+	}
+
+	require.NoError(t, h.Handle(echoReply(1)))
+	assert.Len(t, replies, 0, "reply with other ID must be ignored")
+
+	require.NoError(t, h.Handle(echoReply(42)))
+	require.Len(t, replies, 1)
+	r := <-replies
+	assert.Equal(t, uint16(3), r.Seq)
+	assert.Equal(t, 64, r.Size)
+	assert.Equal(t, "1-ff00:0:110,192.0.2.2", r.Source)
+	assert.NoError(t, r.Error)
+}
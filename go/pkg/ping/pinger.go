@@ -0,0 +1,241 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ping
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/scmp"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/spkt"
+	"github.com/scionproto/scion/go/pkg/internal/scmpconn"
+)
+
+// maxPadding is the maximum number of padding bytes an echo request can carry.
+// The padding is carried in the five quote blocks of the SCMP payload, each of
+// which is at most 255 lines long.
+const maxPadding = 5 * 255 * common.LineLen
+
+// reply is an echo reply, or an SCMP error triggered by an echo request.
+type reply struct {
+	Received time.Time
+	Source   string
+	Seq      uint16
+	Size     int
+	Error    error
+}
+
+// pinger sends echo requests to the remote and reports the matching replies.
+type pinger struct {
+	id      uint64
+	conn    snet.PacketConn
+	local   snet.SCIONAddress
+	remote  *snet.UDPAddr
+	replies chan reply
+}
+
+func newPinger(ctx context.Context, cfg Config) (*pinger, error) {
+	p := &pinger{
+		id:      rand.Uint64(),
+		local:   snet.SCIONAddress{IA: cfg.LocalIA, Host: addr.HostFromIP(cfg.LocalIP)},
+		remote:  cfg.Remote,
+		replies: make(chan reply, 16),
+	}
+	conn, err := scmpconn.Open(ctx, cfg.Dispatcher, cfg.LocalIA, cfg.LocalIP, cfg.Remote,
+		scmpHandler{id: p.id, replies: p.replies})
+	if err != nil {
+		return nil, err
+	}
+	p.conn = conn
+	return p, nil
+}
+
+// Close closes the underlying connection.
+func (p *pinger) Close() error {
+	return p.conn.Close()
+}
+
+// MinSize returns the size of an echo request without padding.
+func (p *pinger) MinSize() int {
+	return p.packetLen(p.payload(0, 0))
+}
+
+// PacketSize returns the size of the echo request that is sent for the
+// requested size. It is size rounded down to a multiple of the line length, but
+// at least MinSize.
+func (p *pinger) PacketSize(size int) int {
+	min := p.MinSize()
+	if size < min {
+		return min
+	}
+	return min + (size-min)/common.LineLen*common.LineLen
+}
+
+// Send sends an echo request with the sequence number. The request is padded
+// such that the SCION packet is PacketSize(size) bytes long.
+func (p *pinger) Send(seq uint16, size int) error {
+	padding := p.PacketSize(size) - p.MinSize()
+	if padding > maxPadding {
+		return serrors.New("packet size too large", "size", size, "max",
+			p.MinSize()+maxPadding)
+	}
+	pld := p.payload(seq, padding)
+	pkt := &snet.Packet{
+		PacketInfo: snet.PacketInfo{
+			Destination: snet.SCIONAddress{
+				IA:   p.remote.IA,
+				Host: addr.HostFromIP(p.remote.Host.IP),
+			},
+			Source: p.local,
+			Path:   p.remote.Path,
+			L4Header: scmp.NewHdr(
+				scmp.ClassType{Class: scmp.C_General, Type: scmp.T_G_EchoRequest},
+				pld.Len(),
+			),
+			Payload: pld,
+		},
+	}
+	return p.conn.WriteTo(pkt, p.remote.NextHop)
+}
+
+// Replies returns the channel on which echo replies are delivered.
+func (p *pinger) Replies() <-chan reply {
+	return p.replies
+}
+
+// payload creates the SCMP payload of an echo request. The padding is
+// distributed over the quote blocks, which the responder copies into the echo
+// reply.
+func (p *pinger) payload(seq uint16, padding int) *scmp.Payload {
+	info := &scmp.InfoEcho{Id: p.id, Seq: seq}
+	pld := &scmp.Payload{
+		Meta: &scmp.Meta{
+			InfoLen: uint8(info.Len() / common.LineLen),
+			L4Proto: common.L4SCMP,
+		},
+		Info: info,
+	}
+	blocks := []struct {
+		raw *common.RawBytes
+		len *uint8
+	}{
+		{&pld.CmnHdr, &pld.Meta.CmnHdrLen},
+		{&pld.AddrHdr, &pld.Meta.AddrHdrLen},
+		{&pld.PathHdr, &pld.Meta.PathHdrLen},
+		{&pld.ExtHdrs, &pld.Meta.ExtHdrsLen},
+		{&pld.L4Hdr, &pld.Meta.L4HdrLen},
+	}
+	lines := padding / common.LineLen
+	for _, b := range blocks {
+		if lines <= 0 {
+			break
+		}
+		n := lines
+		if n > 255 {
+			n = 255
+		}
+		*b.raw = make(common.RawBytes, n*common.LineLen)
+		*b.len = uint8(n)
+		lines -= n
+	}
+	return pld
+}
+
+func (p *pinger) packetLen(pld *scmp.Payload) int {
+	pkt := &spkt.ScnPkt{
+		DstHost: addr.HostFromIP(p.remote.Host.IP),
+		SrcHost: p.local.Host,
+		Path:    p.remote.Path,
+		L4:      &scmp.Hdr{},
+		Pld:     pld,
+	}
+	return pkt.TotalLen()
+}
+
+type scmpHandler struct {
+	id      uint64
+	replies chan<- reply
+}
+
+func (h scmpHandler) Handle(pkt *snet.Packet) error {
+	hdr, ok := pkt.L4Header.(*scmp.Hdr)
+	if !ok {
+		return nil
+	}
+	pld, ok := pkt.Payload.(*scmp.Payload)
+	if !ok {
+		return nil
+	}
+	r := reply{
+		Received: time.Now(),
+		Source:   fmt.Sprintf("%s,%s", pkt.Source.IA, pkt.Source.Host),
+		Size:     len(pkt.Bytes),
+	}
+	switch {
+	case hdr.Class == scmp.C_General && hdr.Type == scmp.T_G_EchoReply:
+		info, ok := pld.Info.(*scmp.InfoEcho)
+		if !ok || info.Id != h.id {
+			return nil
+		}
+		r.Seq = info.Seq
+	case hdr.Class != scmp.C_General:
+		info, err := quotedEcho(pld)
+		if err != nil || info.Id != h.id {
+			return nil
+		}
+		r.Seq = info.Seq
+		r.Error = scmpError(hdr, pld)
+	default:
+		return nil
+	}
+	select {
+	case h.replies <- r:
+	default:
+		// Nobody is waiting for the reply anymore.
+	}
+	return nil
+}
+
+// quotedEcho extracts the echo info of the request quoted in an SCMP error.
+func quotedEcho(pld *scmp.Payload) (*scmp.InfoEcho, error) {
+	hdr, err := scmp.HdrFromRaw(pld.L4Hdr)
+	if err != nil {
+		return nil, err
+	}
+	if hdr.Class != scmp.C_General || hdr.Type != scmp.T_G_EchoRequest {
+		return nil, serrors.New("quoted packet is not an echo request")
+	}
+	// The quoted L4 header contains the SCMP header, followed by the SCMP meta
+	// and info fields of the echo request.
+	start := hdr.L4Len() + scmp.MetaLen
+	if len(pld.L4Hdr) < start {
+		return nil, serrors.New("incomplete quoted echo request")
+	}
+	return scmp.InfoEchoFromRaw(pld.L4Hdr[start:])
+}
+
+func scmpError(hdr *scmp.Hdr, pld *scmp.Payload) error {
+	ct := scmp.ClassType{Class: hdr.Class, Type: hdr.Type}
+	if info, ok := pld.Info.(*scmp.InfoPktSize); ok {
+		return serrors.New("SCMP error", "type", ct, "size", info.Size, "mtu", info.MTU)
+	}
+	return serrors.New("SCMP error", "type", ct)
+}
@@ -0,0 +1,73 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ping
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Stats summarizes the round trip times of a series of probes. All durations
+// are encoded as nanoseconds in JSON.
+type Stats struct {
+	Sent     int           `json:"sent"`
+	Received int           `json:"received"`
+	MinRTT   time.Duration `json:"min_rtt"`
+	AvgRTT   time.Duration `json:"avg_rtt"`
+	MaxRTT   time.Duration `json:"max_rtt"`
+	MdevRTT  time.Duration `json:"mdev_rtt"`
+
+	sum   float64
+	sumSq float64
+}
+
+// Record records the round trip time of a received reply.
+func (s *Stats) Record(rtt time.Duration) {
+	if s.Received == 0 || rtt < s.MinRTT {
+		s.MinRTT = rtt
+	}
+	if rtt > s.MaxRTT {
+		s.MaxRTT = rtt
+	}
+	s.Received++
+	s.sum += float64(rtt)
+	s.sumSq += float64(rtt) * float64(rtt)
+	avg := s.sum / float64(s.Received)
+	s.AvgRTT = time.Duration(avg)
+	s.MdevRTT = time.Duration(math.Sqrt(math.Max(0, s.sumSq/float64(s.Received)-avg*avg)))
+}
+
+// Loss returns the percentage of probes that were not answered.
+func (s Stats) Loss() float64 {
+	if s.Sent == 0 {
+		return 0
+	}
+	return 100 * float64(s.Sent-s.Received) / float64(s.Sent)
+}
+
+func (s Stats) String() string {
+	str := fmt.Sprintf("%d packets transmitted, %d received, %.1f%% packet loss",
+		s.Sent, s.Received, s.Loss())
+	if s.Received == 0 {
+		return str
+	}
+	return fmt.Sprintf("%s\nrtt min/avg/max/mdev = %s/%s/%s/%s", str, round(s.MinRTT),
+		round(s.AvgRTT), round(s.MaxRTT), round(s.MdevRTT))
+}
+
+func round(d time.Duration) time.Duration {
+	return d.Round(time.Microsecond)
+}
@@ -16,6 +16,8 @@ package showpaths
 
 import (
 	"net"
+
+	"github.com/scionproto/scion/go/lib/pathpol"
 )
 
 // DefaultMaxPaths is the maximum number of paths that are displayed by default.
@@ -35,4 +37,7 @@ type Config struct {
 	Refresh bool
 	// NoProbe configures whether the path status is probed or not.
 	NoProbe bool
+	// Policy is an optional path policy. If it is set, every path is annotated
+	// with the explanation of the policy decision.
+	Policy *pathpol.Policy
 }
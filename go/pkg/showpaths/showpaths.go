@@ -25,6 +25,7 @@ import (
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/pathpol"
 	"github.com/scionproto/scion/go/lib/sciond"
 	"github.com/scionproto/scion/go/lib/sciond/pathprobe"
 	"github.com/scionproto/scion/go/lib/serrors"
@@ -49,6 +50,8 @@ type Path struct {
 	Status      string    `json:"status,omitempty"`
 	StatusInfo  string    `json:"status_info,omitempty"`
 	Local       net.IP    `json:"local_ip,omitempty"`
+	// Policy explains the decision of the configured path policy.
+	Policy *pathpol.Explanation `json:"policy,omitempty"`
 }
 
 // Hop represents an hop on the path.
@@ -69,6 +72,13 @@ func (r Result) Human(w io.Writer, showExpiration bool) {
 		if path.Status != "" {
 			fmt.Fprintf(w, " Status: %s LocalIP: %s", path.Status, path.Local)
 		}
+		if path.Policy != nil {
+			decision := "denied"
+			if path.Policy.Selected {
+				decision = "selected"
+			}
+			fmt.Fprintf(w, " Policy: %s (%s)", decision, path.Policy.Reason)
+		}
 		fmt.Fprintln(w)
 	}
 }
@@ -120,6 +130,15 @@ func Run(ctx context.Context, dst addr.IA, cfg Config) (*Result, error) {
 		}
 	}
 
+	var explanations map[snet.PathFingerprint]*pathpol.Explanation
+	if cfg.Policy != nil {
+		pathSet := make(pathpol.PathSet, len(paths))
+		for _, path := range paths {
+			pathSet[path.Fingerprint()] = path
+		}
+		explanations = cfg.Policy.Explain(pathSet)
+	}
+
 	res := &Result{Destination: dst}
 	for _, path := range paths {
 		rpath := Path{
@@ -137,6 +156,7 @@ func Run(ctx context.Context, dst addr.IA, cfg Config) (*Result, error) {
 			rpath.Status = strings.ToLower(string(status.Status))
 			rpath.StatusInfo = status.AdditionalInfo
 		}
+		rpath.Policy = explanations[path.Fingerprint()]
 		res.Paths = append(res.Paths, rpath)
 	}
 	return res, nil
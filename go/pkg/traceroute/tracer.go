@@ -0,0 +1,187 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package traceroute
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/layers"
+	"github.com/scionproto/scion/go/lib/scmp"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/pkg/internal/scmpconn"
+)
+
+// reply is a traceroute reply.
+type reply struct {
+	Received time.Time
+	SourceIA addr.IA
+	Source   string
+	Info     *scmp.InfoTraceRoute
+}
+
+// tracer sends traceroute requests to the remote and reports the matching
+// replies.
+type tracer struct {
+	id      uint64
+	conn    snet.PacketConn
+	local   snet.SCIONAddress
+	remote  *snet.UDPAddr
+	replies chan reply
+}
+
+func newTracer(ctx context.Context, cfg Config) (*tracer, error) {
+	t := &tracer{
+		id:      rand.Uint64(),
+		local:   snet.SCIONAddress{IA: cfg.LocalIA, Host: addr.HostFromIP(cfg.LocalIP)},
+		remote:  cfg.Remote,
+		replies: make(chan reply, 16),
+	}
+	conn, err := scmpconn.Open(ctx, cfg.Dispatcher, cfg.LocalIA, cfg.LocalIP, cfg.Remote,
+		scmpHandler{id: t.id, replies: t.replies})
+	if err != nil {
+		return nil, err
+	}
+	t.conn = conn
+	return t, nil
+}
+
+// Close closes the underlying connection.
+func (t *tracer) Close() error {
+	return t.conn.Close()
+}
+
+// hdrLen returns the length of the common and address header of the requests.
+func (t *tracer) hdrLen() int {
+	return addrHdrLen(addr.HostFromIP(t.remote.Host.IP), t.local.Host)
+}
+
+// probeHop sends the given number of traceroute requests for the hop field at
+// hopOff, and waits for the replies. If hopOff is zero, the remote host is
+// probed.
+func (t *tracer) probeHop(ctx context.Context, index int, hopOff uint8, in bool, probes int,
+	timeout time.Duration) (Hop, error) {
+
+	hop := Hop{Index: index, RTTs: []time.Duration{}}
+	for i := 0; i < probes; i++ {
+		info := &scmp.InfoTraceRoute{Id: t.id, HopOff: hopOff, In: in}
+		sent := time.Now()
+		if err := t.send(info); err != nil {
+			return hop, serrors.WrapStr("unable to send traceroute request", err,
+				"hop", index)
+		}
+		hop.Stats.Sent++
+		r, ok, err := t.wait(ctx, info, timeout)
+		if err != nil {
+			return hop, err
+		}
+		if !ok {
+			continue
+		}
+		rtt := r.Received.Sub(sent)
+		hop.RTTs = append(hop.RTTs, rtt)
+		hop.Stats.Record(rtt)
+		hop.IA, hop.Source = r.SourceIA, r.Source
+		if hopOff != 0 {
+			hop.IfID = r.Info.IfID
+		}
+	}
+	return hop, nil
+}
+
+func (t *tracer) send(info *scmp.InfoTraceRoute) error {
+	pld := &scmp.Payload{
+		Meta: &scmp.Meta{InfoLen: uint8(info.Len() / common.LineLen)},
+		Info: info,
+	}
+	pkt := &snet.Packet{
+		PacketInfo: snet.PacketInfo{
+			Destination: snet.SCIONAddress{
+				IA:   t.remote.IA,
+				Host: addr.HostFromIP(t.remote.Host.IP),
+			},
+			Source: t.local,
+			Path:   t.remote.Path,
+			L4Header: scmp.NewHdr(
+				scmp.ClassType{Class: scmp.C_General, Type: scmp.T_G_TraceRouteRequest},
+				pld.Len(),
+			),
+			Payload: pld,
+		},
+	}
+	if !t.remote.Path.IsEmpty() {
+		pkt.Extensions = []common.Extension{&layers.ExtnSCMP{HopByHop: true}}
+	}
+	return t.conn.WriteTo(pkt, t.remote.NextHop)
+}
+
+// wait waits for the reply to the request. Replies to earlier requests are
+// discarded.
+func (t *tracer) wait(ctx context.Context, req *scmp.InfoTraceRoute,
+	timeout time.Duration) (reply, bool, error) {
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return reply{}, false, ctx.Err()
+		case <-timer.C:
+			return reply{}, false, nil
+		case r := <-t.replies:
+			if r.Info.HopOff != req.HopOff || r.Info.In != req.In {
+				continue
+			}
+			return r, true, nil
+		}
+	}
+}
+
+type scmpHandler struct {
+	id      uint64
+	replies chan<- reply
+}
+
+func (h scmpHandler) Handle(pkt *snet.Packet) error {
+	hdr, ok := pkt.L4Header.(*scmp.Hdr)
+	if !ok || hdr.Class != scmp.C_General || hdr.Type != scmp.T_G_TraceRouteReply {
+		return nil
+	}
+	pld, ok := pkt.Payload.(*scmp.Payload)
+	if !ok {
+		return nil
+	}
+	info, ok := pld.Info.(*scmp.InfoTraceRoute)
+	if !ok || info.Id != h.id {
+		return nil
+	}
+	r := reply{
+		Received: time.Now(),
+		SourceIA: pkt.Source.IA,
+		Source:   fmt.Sprintf("%s,%s", pkt.Source.IA, pkt.Source.Host),
+		Info:     info,
+	}
+	select {
+	case h.replies <- r:
+	default:
+		// Nobody is waiting for the reply anymore.
+	}
+	return nil
+}
@@ -0,0 +1,225 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package traceroute traces the path to a SCION host with SCMP traceroute
+// requests. Every interface on the path, and the remote host itself, is probed
+// several times, and the round trip times are reported per hop.
+package traceroute
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/sock/reliable"
+	"github.com/scionproto/scion/go/lib/spath"
+	"github.com/scionproto/scion/go/lib/spkt"
+	"github.com/scionproto/scion/go/pkg/ping"
+)
+
+const (
+	// DefaultProbesPerHop is the default number of probes sent to every hop.
+	DefaultProbesPerHop = 3
+	// DefaultTimeout is the default time to wait for a traceroute reply.
+	DefaultTimeout = 2 * time.Second
+)
+
+// Config configures the traceroute run.
+type Config struct {
+	// Dispatcher is used to register the local address.
+	Dispatcher reliable.Dispatcher
+	// LocalIA is the ISD-AS of the local host.
+	LocalIA addr.IA
+	// LocalIP is the IP address of the local host.
+	LocalIP net.IP
+	// Remote is the address of the remote host. The path and the next hop
+	// must be set.
+	Remote *snet.UDPAddr
+	// Interfaces are the interfaces of the path to the remote host.
+	Interfaces []snet.PathInterface
+	// ProbesPerHop is the number of probes sent to every hop. If it is zero,
+	// DefaultProbesPerHop is used.
+	ProbesPerHop int
+	// Timeout is the time to wait for a traceroute reply. If it is zero,
+	// DefaultTimeout is used.
+	Timeout time.Duration
+	// UpdateHandler is called every time all probes of a hop are done.
+	UpdateHandler func(Hop)
+}
+
+func (cfg *Config) initDefaults() {
+	if cfg.ProbesPerHop == 0 {
+		cfg.ProbesPerHop = DefaultProbesPerHop
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+}
+
+// Hop contains the replies of a single hop on the path. The last hop is the
+// remote host.
+type Hop struct {
+	Index int `json:"index"`
+	// IA and IfID identify the interface that replied. The IfID is zero for
+	// the remote host.
+	IA   addr.IA         `json:"isd_as"`
+	IfID common.IFIDType `json:"ifid"`
+	// Source is the address of the host that replied.
+	Source string `json:"source,omitempty"`
+	// RTTs are the round trip times of the received replies.
+	RTTs  []time.Duration `json:"rtts"`
+	Stats ping.Stats      `json:"statistics"`
+}
+
+func (h Hop) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d", h.Index)
+	if h.Source != "" {
+		fmt.Fprintf(&b, " %s", h.Source)
+		if h.IfID != 0 {
+			fmt.Fprintf(&b, " IfID=%d", h.IfID)
+		}
+	}
+	for _, rtt := range h.RTTs {
+		fmt.Fprintf(&b, " %s", rtt.Round(time.Microsecond))
+	}
+	for i := h.Stats.Received; i < h.Stats.Sent; i++ {
+		fmt.Fprint(&b, " *")
+	}
+	return b.String()
+}
+
+// Result is the result of a traceroute run.
+type Result struct {
+	Destination string `json:"destination"`
+	Hops        []Hop  `json:"hops"`
+}
+
+// Human writes the hops in human readable form to the writer.
+func (r Result) Human(w io.Writer) {
+	for _, h := range r.Hops {
+		fmt.Fprintln(w, h)
+	}
+}
+
+// JSON writes the result as a json object to the writer.
+func (r Result) JSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// Run traces the path to the remote host. Every interface on the path is
+// probed, followed by the remote host. The hops are reported to the update
+// handler, and accumulated in the result.
+func Run(ctx context.Context, cfg Config) (*Result, error) {
+	cfg.initDefaults()
+	t, err := newTracer(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer t.Close()
+
+	res := &Result{Destination: fmt.Sprintf("%s,[%s]", cfg.Remote.IA, cfg.Remote.Host.IP)}
+	var offsets *hopOffsets
+	if !cfg.Remote.Path.IsEmpty() {
+		offsets, err = newHopOffsets(cfg.Remote.Path, t.hdrLen())
+		if err != nil {
+			return nil, err
+		}
+	}
+	for i := 0; i <= len(cfg.Interfaces); i++ {
+		var hopOff uint8
+		var in bool
+		if i < len(cfg.Interfaces) && offsets != nil {
+			hopOff, in = offsets.Current()
+		}
+		hop, err := t.probeHop(ctx, i, hopOff, in, cfg.ProbesPerHop, cfg.Timeout)
+		if err != nil {
+			return res, err
+		}
+		res.Hops = append(res.Hops, hop)
+		if cfg.UpdateHandler != nil {
+			cfg.UpdateHandler(hop)
+		}
+		if offsets != nil && i < len(cfg.Interfaces)-1 {
+			if err := offsets.Next(); err != nil {
+				return res, err
+			}
+		}
+	}
+	return res, nil
+}
+
+// hopOffsets iterates over the hop fields of a path, and computes the offset of
+// the hop field relative to the start of the packet, as expected by
+// scmp.InfoTraceRoute. Every hop field is visited twice, once for the egress
+// and once for the ingress interface, except for crossover hop fields, which
+// are skipped for the egress interface.
+type hopOffsets struct {
+	path   *spath.Path
+	hdrLen int
+	in     bool
+}
+
+func newHopOffsets(path *spath.Path, hdrLen int) (*hopOffsets, error) {
+	path = path.Copy()
+	if path.HopOff == 0 {
+		if err := path.InitOffsets(); err != nil {
+			return nil, serrors.WrapStr("unable to initialize path offsets", err)
+		}
+	}
+	return &hopOffsets{path: path, hdrLen: hdrLen}, nil
+}
+
+// Current returns the packet relative offset of the current hop field in
+// lines, and whether the ingress interface is probed.
+func (o *hopOffsets) Current() (uint8, bool) {
+	return uint8((o.hdrLen + o.path.HopOff) / common.LineLen), o.in
+}
+
+// Next advances to the next interface.
+func (o *hopOffsets) Next() error {
+	if !o.in {
+		if err := o.path.IncOffsets(); err != nil {
+			return serrors.WrapStr("unable to advance path offsets", err)
+		}
+	} else {
+		hopF, err := o.path.GetHopField(o.path.HopOff)
+		if err != nil {
+			return serrors.WrapStr("unable to parse hop field", err)
+		}
+		if hopF.Xover {
+			// The egress interface of the crossover hop field is not used.
+			if err := o.path.IncOffsets(); err != nil {
+				return serrors.WrapStr("unable to advance path offsets", err)
+			}
+		}
+	}
+	o.in = !o.in
+	return nil
+}
+
+// addrHdrLen returns the length of the common and the address header.
+func addrHdrLen(dst, src addr.HostAddr) int {
+	return spkt.CmnHdrLen + spkt.AddrHdrLen(dst, src)
+}
@@ -0,0 +1,117 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package traceroute
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/spath"
+	"github.com/scionproto/scion/go/pkg/ping"
+)
+
+// newPath creates a path with an up segment and a down segment, each with two
+// hop fields, joined at a crossover.
+func newPath() *spath.Path {
+	raw := make(common.RawBytes, 2*spath.InfoFieldLength+4*spath.HopFieldLength)
+	segments := [][]spath.HopField{
+		{{ConsEgress: 1}, {ConsIngress: 2, Xover: true}},
+		{{ConsEgress: 3, Xover: true}, {ConsIngress: 4}},
+	}
+	offset := 0
+	for i, hops := range segments {
+		info := spath.InfoField{ConsDir: i == 1, ISD: 1, Hops: uint8(len(hops))}
+		info.Write(raw[offset:])
+		offset += spath.InfoFieldLength
+		for _, hop := range hops {
+			hop.Mac = make(common.RawBytes, spath.MacLen)
+			hop.Write(raw[offset:])
+			offset += spath.HopFieldLength
+		}
+	}
+	return spath.New(raw)
+}
+
+func TestHopOffsets(t *testing.T) {
+	path := newPath()
+	hdrLen := 40
+	offsets, err := newHopOffsets(path, hdrLen)
+	require.NoError(t, err)
+	assert.Zero(t, path.HopOff, "the original path must not be modified")
+
+	type offset struct {
+		HopOff uint8
+		In     bool
+	}
+	expected := []offset{
+		// Egress of the first hop field.
+		{HopOff: uint8((hdrLen + 8) / common.LineLen), In: false},
+		// Ingress of the crossover hop field in the up segment.
+		{HopOff: uint8((hdrLen + 16) / common.LineLen), In: true},
+		// Egress of the crossover hop field in the down segment.
+		{HopOff: uint8((hdrLen + 32) / common.LineLen), In: false},
+		// Ingress of the last hop field.
+		{HopOff: uint8((hdrLen + 40) / common.LineLen), In: true},
+	}
+	var actual []offset
+	for i := range expected {
+		hopOff, in := offsets.Current()
+		actual = append(actual, offset{HopOff: hopOff, In: in})
+		if i < len(expected)-1 {
+			require.NoError(t, offsets.Next())
+		}
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func TestHopString(t *testing.T) {
+	tests := map[string]struct {
+		Hop      Hop
+		Expected string
+	}{
+		"no reply": {
+			Hop:      Hop{Index: 1, Stats: ping.Stats{Sent: 2}},
+			Expected: "1 * *",
+		},
+		"interface": {
+			Hop: Hop{
+				Index:  0,
+				IfID:   5,
+				Source: "1-ff00:0:110,192.0.2.1",
+				RTTs:   []time.Duration{1500 * time.Microsecond},
+				Stats:  ping.Stats{Sent: 2, Received: 1},
+			},
+			Expected: "0 1-ff00:0:110,192.0.2.1 IfID=5 1.5ms *",
+		},
+		"remote host": {
+			Hop: Hop{
+				Index:  2,
+				Source: "1-ff00:0:111,192.0.2.2",
+				RTTs:   []time.Duration{time.Millisecond, 2 * time.Millisecond},
+				Stats:  ping.Stats{Sent: 2, Received: 2},
+			},
+			Expected: "2 1-ff00:0:111,192.0.2.2 1ms 2ms",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.Expected, test.Hop.String())
+		})
+	}
+}
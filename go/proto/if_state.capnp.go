@@ -186,12 +186,12 @@ type IFStateReq struct{ capnp.Struct }
 const IFStateReq_TypeID = 0xe8ed776bc19c66a9
 
 func NewIFStateReq(s *capnp.Segment) (IFStateReq, error) {
-	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 0})
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 0})
 	return IFStateReq{st}, err
 }
 
 func NewRootIFStateReq(s *capnp.Segment) (IFStateReq, error) {
-	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 0})
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 0})
 	return IFStateReq{st}, err
 }
 
@@ -213,12 +213,20 @@ func (s IFStateReq) SetIfID(v uint64) {
 	s.Struct.SetUint64(0, v)
 }
 
+func (s IFStateReq) Drain() bool {
+	return s.Struct.Bit(64)
+}
+
+func (s IFStateReq) SetDrain(v bool) {
+	s.Struct.SetBit(64, v)
+}
+
 // IFStateReq_List is a list of IFStateReq.
 type IFStateReq_List struct{ capnp.List }
 
 // NewIFStateReq creates a new list of IFStateReq.
 func NewIFStateReq_List(s *capnp.Segment, sz int32) (IFStateReq_List, error) {
-	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 0}, sz)
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 16, PointerCount: 0}, sz)
 	return IFStateReq_List{l}, err
 }
 
@@ -239,26 +247,28 @@ func (p IFStateReq_Promise) Struct() (IFStateReq, error) {
 	return IFStateReq{s}, err
 }
 
-const schema_e6d9e9e231c09f51 = "x\xda|\x90\xbfJ+A\x18\xc5\xcf\x99\xdd\xdc\xdcp" +
-	"s\xef\xee\x904\xb7\x10-\xacR\x08I#\xa4I\x8a" +
-	"\xa8\xacD\xc8D\x84t\x12\xc2\x0e,\xe2ndc\xd4" +
-	"\x07\x10_BH'X\xf9\x06\xa2\x95\x9d\x85VZF" +
-	"\x10-,m\xfc\xc3\xca\x04Q\x09h5\xf3\x1d~\xfc" +
-	"8\xdf\xe7\xeeUE1\xe5\x10P\xd9\xd4\xaf\xa4\xf5z" +
-	"\x90\x89\x9e\xb7\xcf\xa0\x1c\x8aD\x0dN\x8a\xc3\xfb\xab[" +
-	"\xa4\x98\x06\xe4\xfa\xb5\xdc1\xef\xe6\x11\x98\x1c\xffyy" +
-	",\x9dO]B:\x1c\x03s\x92\xc3\xdc\xc4\xe8\xf7\x9f" +
-	"\x1509\xd4\xfb\xa7k[\x0fw\xc6\xfa\x05\xb6\x0d\xe1" +
-	"\xf1\"\xb72b\xd5\x88\x0d\xf4j\xdck\xf7|1\xd3" +
-	"iw\xc3n\xd9\x9b_6\xa3\x17Z:j\x90*k" +
-	"\xd9\x80M@\xce\x15\x00U\xb5\xa8\xea\x82\x92\xd5<M" +
-	"\xe8\x95\x01U\xb3\xa8\x1a\x82\x14y\x0a@.-\x02\xaa" +
-	"nQ\xb5\x04\x9d@{5f \x98\x01+\xedN/" +
-	"\xe8\xfb$\x04\x09&q\xd3\xef{\xa1\x8e\x00\xd0M\x9a" +
-	"7O\xb3\xbb\x0b\xa5\x01@\xba?5K\xeb(6\xd5" +
-	"\xec\x8fj\x7fK\x80\xfamQM\x0bN\x06\xa1\x8eb" +
-	"\xfe\x03\x1b\x16\xe9~\x9e\x184\xe1w\xda\xa6\xcf\x8d1" +
-	"i\xe1]\x9a\x1f[\xe3-\x00\x00\xff\xffJJp\xfd"
+const schema_e6d9e9e231c09f51 = "x\xda|\x90\xbfK\x1ba\x1c\xc6\x9f\xe7}/MB" +
+	"\xd3\xdc\x1d\xc9\xd2\xa1\xa4C\x86\xb6\xd0\xd2\x84B!K" +
+	"2\xa4-WR\xc8\x9bR\xc8V\x8e\xf4\x0e\x8e\xd2\xbb" +
+	"\xd4\x8b\x89\xfe\x01\xfe\x0bNB6\xc1\xc9\xff@tr" +
+	"s\xd0I\xc7\x08\xa2\x83\xa3\x8b?8y\x03F\x09\xe8" +
+	"\xf4\xfe\xe0\xe1\xf3}>_k\xb5!*)\x93\x80\xca" +
+	"\xa5\x9e%\xdd\x9b\xf5lt\xb5\xb4\x0beR$j\xbc" +
+	"]\x99\x9c\x1d\x9e \xc54`\xff;\xb2\x97\xf5\xb9\xb8" +
+	"\x09&[\xcf\xaf/\xaa{\xaf\x0f`\x9b\x9c\x0b\x16l" +
+	"N\x0a\xaf\xa6\xb7\x97\xac\x83\xc9\x86\xbf\xb6\xf3wt~" +
+	":G5t\xc2\xe1~\xe1\xd74\xab8\x02\x93\xc0\xff" +
+	"\x1d\x0f\xdc\x81'>\xf4\xdc~\xd8\xaf9_\x7f\xea\xa7" +
+	"\x13J?j\x93*'\x0d\xc0 `\x7fy\x07\xa8\x86" +
+	"\xa4j\x09\xdal\x14\xa9?\x9d\x1a\xa0\x9a\x92\xaa-H" +
+	"Q\xa4\x00\xec\x1f\xdf\x01\xd5\x92T]A3\xf0\x9d&" +
+	"\xb3\x10\xcc\x82u\xb77\x08\x86\x1e\x09A\x82I\xdc\xf1" +
+	"\x86N\xe8G\x00h%\x9d\xe3\xcb\xcf+\xdf\xaac\x80" +
+	"\xb4\x9ej\x96\xf6\xa3XW3f\xd5^T\x01\x95\x91" +
+	"Te\xc1R\x10\xfaQ\xcc<\xd8\x96\xa4u\xbfb\x90" +
+	"\xf9\xc7\xb1\x1d\x8f\xff543\x83\xbe\xd5\xbeeI\xf5" +
+	"\xf1\x81\xef{=\xe9\x8d\xa4\xfa4\xe7V\xfa\xb3\xe0\x06" +
+	"\xe1\x9d\xda\xed\x00x5u\xae"
 
 func init() {
 	schemas.Register(schema_e6d9e9e231c09f51,
@@ -3,6 +3,7 @@
 package proto
 
 import (
+	math "math"
 	strconv "strconv"
 	capnp "zombiezen.com/go/capnproto2"
 	text "zombiezen.com/go/capnproto2/encoding/text"
@@ -26,10 +27,12 @@ const (
 	SCIONDMsg_Which_revReply           SCIONDMsg_Which = 10
 	SCIONDMsg_Which_segTypeHopReq      SCIONDMsg_Which = 11
 	SCIONDMsg_Which_segTypeHopReply    SCIONDMsg_Which = 12
+	SCIONDMsg_Which_pathSubscribeReq   SCIONDMsg_Which = 13
+	SCIONDMsg_Which_pathUpdate         SCIONDMsg_Which = 14
 )
 
 func (w SCIONDMsg_Which) String() string {
-	const s = "unsetpathReqpathReplyasInfoReqasInfoReplyrevNotificationifInfoRequestifInfoReplyserviceInfoRequestserviceInfoReplyrevReplysegTypeHopReqsegTypeHopReply"
+	const s = "unsetpathReqpathReplyasInfoReqasInfoReplyrevNotificationifInfoRequestifInfoReplyserviceInfoRequestserviceInfoReplyrevReplysegTypeHopReqsegTypeHopReplypathSubscribeReqpathUpdate"
 	switch w {
 	case SCIONDMsg_Which_unset:
 		return s[0:5]
@@ -57,6 +60,10 @@ func (w SCIONDMsg_Which) String() string {
 		return s[122:135]
 	case SCIONDMsg_Which_segTypeHopReply:
 		return s[135:150]
+	case SCIONDMsg_Which_pathSubscribeReq:
+		return s[150:166]
+	case SCIONDMsg_Which_pathUpdate:
+		return s[166:176]
 
 	}
 	return "SCIONDMsg_Which(" + strconv.FormatUint(uint64(w), 10) + ")"
@@ -497,6 +504,72 @@ func (s SCIONDMsg) NewSegTypeHopReply() (SegTypeHopReply, error) {
 	return ss, err
 }
 
+func (s SCIONDMsg) PathSubscribeReq() (PathSubscribeReq, error) {
+	if s.Struct.Uint16(8) != 13 {
+		panic("Which() != pathSubscribeReq")
+	}
+	p, err := s.Struct.Ptr(0)
+	return PathSubscribeReq{Struct: p.Struct()}, err
+}
+
+func (s SCIONDMsg) HasPathSubscribeReq() bool {
+	if s.Struct.Uint16(8) != 13 {
+		return false
+	}
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s SCIONDMsg) SetPathSubscribeReq(v PathSubscribeReq) error {
+	s.Struct.SetUint16(8, 13)
+	return s.Struct.SetPtr(0, v.Struct.ToPtr())
+}
+
+// NewPathSubscribeReq sets the pathSubscribeReq field to a newly
+// allocated PathSubscribeReq struct, preferring placement in s's segment.
+func (s SCIONDMsg) NewPathSubscribeReq() (PathSubscribeReq, error) {
+	s.Struct.SetUint16(8, 13)
+	ss, err := NewPathSubscribeReq(s.Struct.Segment())
+	if err != nil {
+		return PathSubscribeReq{}, err
+	}
+	err = s.Struct.SetPtr(0, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s SCIONDMsg) PathUpdate() (PathUpdate, error) {
+	if s.Struct.Uint16(8) != 14 {
+		panic("Which() != pathUpdate")
+	}
+	p, err := s.Struct.Ptr(0)
+	return PathUpdate{Struct: p.Struct()}, err
+}
+
+func (s SCIONDMsg) HasPathUpdate() bool {
+	if s.Struct.Uint16(8) != 14 {
+		return false
+	}
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s SCIONDMsg) SetPathUpdate(v PathUpdate) error {
+	s.Struct.SetUint16(8, 14)
+	return s.Struct.SetPtr(0, v.Struct.ToPtr())
+}
+
+// NewPathUpdate sets the pathUpdate field to a newly
+// allocated PathUpdate struct, preferring placement in s's segment.
+func (s SCIONDMsg) NewPathUpdate() (PathUpdate, error) {
+	s.Struct.SetUint16(8, 14)
+	ss, err := NewPathUpdate(s.Struct.Segment())
+	if err != nil {
+		return PathUpdate{}, err
+	}
+	err = s.Struct.SetPtr(0, ss.Struct.ToPtr())
+	return ss, err
+}
+
 func (s SCIONDMsg) TraceId() ([]byte, error) {
 	p, err := s.Struct.Ptr(1)
 	return []byte(p.Data()), err
@@ -585,6 +658,14 @@ func (p SCIONDMsg_Promise) SegTypeHopReply() SegTypeHopReply_Promise {
 	return SegTypeHopReply_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
 }
 
+func (p SCIONDMsg_Promise) PathSubscribeReq() PathSubscribeReq_Promise {
+	return PathSubscribeReq_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
+}
+
+func (p SCIONDMsg_Promise) PathUpdate() PathUpdate_Promise {
+	return PathUpdate_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
+}
+
 type PathReq struct{ capnp.Struct }
 type PathReq_flags PathReq
 
@@ -592,12 +673,12 @@ type PathReq_flags PathReq
 const PathReq_TypeID = 0xc4c61531dcc4a3eb
 
 func NewPathReq(s *capnp.Segment) (PathReq, error) {
-	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 1})
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 4})
 	return PathReq{st}, err
 }
 
 func NewRootPathReq(s *capnp.Segment) (PathReq, error) {
-	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 1})
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 4})
 	return PathReq{st}, err
 }
 
@@ -653,6 +734,64 @@ func (s PathReq_flags) SetHidden(v bool) {
 	s.Struct.SetBit(145, v)
 }
 
+func (s PathReq_flags) PolicyName() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s PathReq_flags) HasPolicyName() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s PathReq_flags) PolicyNameBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s PathReq_flags) SetPolicyName(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+func (s PathReq_flags) Policy() ([]byte, error) {
+	p, err := s.Struct.Ptr(2)
+	return []byte(p.Data()), err
+}
+
+func (s PathReq_flags) HasPolicy() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s PathReq_flags) SetPolicy(v []byte) error {
+	return s.Struct.SetData(2, v)
+}
+
+func (s PathReq_flags) Order() (capnp.TextList, error) {
+	p, err := s.Struct.Ptr(3)
+	return capnp.TextList{List: p.List()}, err
+}
+
+func (s PathReq_flags) HasOrder() bool {
+	p, err := s.Struct.Ptr(3)
+	return p.IsValid() || err != nil
+}
+
+func (s PathReq_flags) SetOrder(v capnp.TextList) error {
+	return s.Struct.SetPtr(3, v.List.ToPtr())
+}
+
+// NewOrder sets the order field to a newly
+// allocated capnp.TextList, preferring placement in s's segment.
+func (s PathReq_flags) NewOrder(n int32) (capnp.TextList, error) {
+	l, err := capnp.NewTextList(s.Struct.Segment(), n)
+	if err != nil {
+		return capnp.TextList{}, err
+	}
+	err = s.Struct.SetPtr(3, l.List.ToPtr())
+	return l, err
+}
+
 func (s PathReq) HpCfgs() (HPGroupId_List, error) {
 	p, err := s.Struct.Ptr(0)
 	return HPGroupId_List{List: p.List()}, err
@@ -683,7 +822,7 @@ type PathReq_List struct{ capnp.List }
 
 // NewPathReq creates a new list of PathReq.
 func NewPathReq_List(s *capnp.Segment, sz int32) (PathReq_List, error) {
-	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 24, PointerCount: 1}, sz)
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 24, PointerCount: 4}, sz)
 	return PathReq_List{l}, err
 }
 
@@ -798,18 +937,195 @@ func (p PathReply_Promise) Struct() (PathReply, error) {
 	return PathReply{s}, err
 }
 
+type PathSubscribeReq struct{ capnp.Struct }
+
+// PathSubscribeReq_TypeID is the unique identifier for the type PathSubscribeReq.
+const PathSubscribeReq_TypeID = 0xde974fc37e31e7d3
+
+func NewPathSubscribeReq(s *capnp.Segment) (PathSubscribeReq, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 0})
+	return PathSubscribeReq{st}, err
+}
+
+func NewRootPathSubscribeReq(s *capnp.Segment) (PathSubscribeReq, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 0})
+	return PathSubscribeReq{st}, err
+}
+
+func ReadRootPathSubscribeReq(msg *capnp.Message) (PathSubscribeReq, error) {
+	root, err := msg.RootPtr()
+	return PathSubscribeReq{root.Struct()}, err
+}
+
+func (s PathSubscribeReq) String() string {
+	str, _ := text.Marshal(0xde974fc37e31e7d3, s.Struct)
+	return str
+}
+
+func (s PathSubscribeReq) Dst() uint64 {
+	return s.Struct.Uint64(0)
+}
+
+func (s PathSubscribeReq) SetDst(v uint64) {
+	s.Struct.SetUint64(0, v)
+}
+
+func (s PathSubscribeReq) Src() uint64 {
+	return s.Struct.Uint64(8)
+}
+
+func (s PathSubscribeReq) SetSrc(v uint64) {
+	s.Struct.SetUint64(8, v)
+}
+
+func (s PathSubscribeReq) MaxPaths() uint16 {
+	return s.Struct.Uint16(16)
+}
+
+func (s PathSubscribeReq) SetMaxPaths(v uint16) {
+	s.Struct.SetUint16(16, v)
+}
+
+func (s PathSubscribeReq) ExpiryLead() uint32 {
+	return s.Struct.Uint32(20)
+}
+
+func (s PathSubscribeReq) SetExpiryLead(v uint32) {
+	s.Struct.SetUint32(20, v)
+}
+
+// PathSubscribeReq_List is a list of PathSubscribeReq.
+type PathSubscribeReq_List struct{ capnp.List }
+
+// NewPathSubscribeReq creates a new list of PathSubscribeReq.
+func NewPathSubscribeReq_List(s *capnp.Segment, sz int32) (PathSubscribeReq_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 24, PointerCount: 0}, sz)
+	return PathSubscribeReq_List{l}, err
+}
+
+func (s PathSubscribeReq_List) At(i int) PathSubscribeReq { return PathSubscribeReq{s.List.Struct(i)} }
+
+func (s PathSubscribeReq_List) Set(i int, v PathSubscribeReq) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s PathSubscribeReq_List) String() string {
+	str, _ := text.MarshalList(0xde974fc37e31e7d3, s.List)
+	return str
+}
+
+// PathSubscribeReq_Promise is a wrapper for a PathSubscribeReq promised by a client call.
+type PathSubscribeReq_Promise struct{ *capnp.Pipeline }
+
+func (p PathSubscribeReq_Promise) Struct() (PathSubscribeReq, error) {
+	s, err := p.Pipeline.Struct()
+	return PathSubscribeReq{s}, err
+}
+
+type PathUpdate struct{ capnp.Struct }
+
+// PathUpdate_TypeID is the unique identifier for the type PathUpdate.
+const PathUpdate_TypeID = 0xca62c33457da1785
+
+func NewPathUpdate(s *capnp.Segment) (PathUpdate, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return PathUpdate{st}, err
+}
+
+func NewRootPathUpdate(s *capnp.Segment) (PathUpdate, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return PathUpdate{st}, err
+}
+
+func ReadRootPathUpdate(msg *capnp.Message) (PathUpdate, error) {
+	root, err := msg.RootPtr()
+	return PathUpdate{root.Struct()}, err
+}
+
+func (s PathUpdate) String() string {
+	str, _ := text.Marshal(0xca62c33457da1785, s.Struct)
+	return str
+}
+
+func (s PathUpdate) Reason() uint16 {
+	return s.Struct.Uint16(0)
+}
+
+func (s PathUpdate) SetReason(v uint16) {
+	s.Struct.SetUint16(0, v)
+}
+
+func (s PathUpdate) ErrorCode() uint16 {
+	return s.Struct.Uint16(2)
+}
+
+func (s PathUpdate) SetErrorCode(v uint16) {
+	s.Struct.SetUint16(2, v)
+}
+
+func (s PathUpdate) Entries() (PathReplyEntry_List, error) {
+	p, err := s.Struct.Ptr(0)
+	return PathReplyEntry_List{List: p.List()}, err
+}
+
+func (s PathUpdate) HasEntries() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s PathUpdate) SetEntries(v PathReplyEntry_List) error {
+	return s.Struct.SetPtr(0, v.List.ToPtr())
+}
+
+// NewEntries sets the entries field to a newly
+// allocated PathReplyEntry_List, preferring placement in s's segment.
+func (s PathUpdate) NewEntries(n int32) (PathReplyEntry_List, error) {
+	l, err := NewPathReplyEntry_List(s.Struct.Segment(), n)
+	if err != nil {
+		return PathReplyEntry_List{}, err
+	}
+	err = s.Struct.SetPtr(0, l.List.ToPtr())
+	return l, err
+}
+
+// PathUpdate_List is a list of PathUpdate.
+type PathUpdate_List struct{ capnp.List }
+
+// NewPathUpdate creates a new list of PathUpdate.
+func NewPathUpdate_List(s *capnp.Segment, sz int32) (PathUpdate_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1}, sz)
+	return PathUpdate_List{l}, err
+}
+
+func (s PathUpdate_List) At(i int) PathUpdate { return PathUpdate{s.List.Struct(i)} }
+
+func (s PathUpdate_List) Set(i int, v PathUpdate) error { return s.List.SetStruct(i, v.Struct) }
+
+func (s PathUpdate_List) String() string {
+	str, _ := text.MarshalList(0xca62c33457da1785, s.List)
+	return str
+}
+
+// PathUpdate_Promise is a wrapper for a PathUpdate promised by a client call.
+type PathUpdate_Promise struct{ *capnp.Pipeline }
+
+func (p PathUpdate_Promise) Struct() (PathUpdate, error) {
+	s, err := p.Pipeline.Struct()
+	return PathUpdate{s}, err
+}
+
 type PathReplyEntry struct{ capnp.Struct }
 
 // PathReplyEntry_TypeID is the unique identifier for the type PathReplyEntry.
 const PathReplyEntry_TypeID = 0xc5ff2e54709776ec
 
 func NewPathReplyEntry(s *capnp.Segment) (PathReplyEntry, error) {
-	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4})
 	return PathReplyEntry{st}, err
 }
 
 func NewRootPathReplyEntry(s *capnp.Segment) (PathReplyEntry, error) {
-	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4})
 	return PathReplyEntry{st}, err
 }
 
@@ -873,12 +1189,62 @@ func (s PathReplyEntry) NewHostInfo() (HostInfo, error) {
 	return ss, err
 }
 
+func (s PathReplyEntry) Health() (PathHealth, error) {
+	p, err := s.Struct.Ptr(2)
+	return PathHealth{Struct: p.Struct()}, err
+}
+
+func (s PathReplyEntry) HasHealth() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s PathReplyEntry) SetHealth(v PathHealth) error {
+	return s.Struct.SetPtr(2, v.Struct.ToPtr())
+}
+
+// NewHealth sets the health field to a newly
+// allocated PathHealth struct, preferring placement in s's segment.
+func (s PathReplyEntry) NewHealth() (PathHealth, error) {
+	ss, err := NewPathHealth(s.Struct.Segment())
+	if err != nil {
+		return PathHealth{}, err
+	}
+	err = s.Struct.SetPtr(2, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s PathReplyEntry) LinkInfo() (PathLinkInfo_List, error) {
+	p, err := s.Struct.Ptr(3)
+	return PathLinkInfo_List{List: p.List()}, err
+}
+
+func (s PathReplyEntry) HasLinkInfo() bool {
+	p, err := s.Struct.Ptr(3)
+	return p.IsValid() || err != nil
+}
+
+func (s PathReplyEntry) SetLinkInfo(v PathLinkInfo_List) error {
+	return s.Struct.SetPtr(3, v.List.ToPtr())
+}
+
+// NewLinkInfo sets the linkInfo field to a newly
+// allocated PathLinkInfo_List, preferring placement in s's segment.
+func (s PathReplyEntry) NewLinkInfo(n int32) (PathLinkInfo_List, error) {
+	l, err := NewPathLinkInfo_List(s.Struct.Segment(), n)
+	if err != nil {
+		return PathLinkInfo_List{}, err
+	}
+	err = s.Struct.SetPtr(3, l.List.ToPtr())
+	return l, err
+}
+
 // PathReplyEntry_List is a list of PathReplyEntry.
 type PathReplyEntry_List struct{ capnp.List }
 
 // NewPathReplyEntry creates a new list of PathReplyEntry.
 func NewPathReplyEntry_List(s *capnp.Segment, sz int32) (PathReplyEntry_List, error) {
-	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2}, sz)
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4}, sz)
 	return PathReplyEntry_List{l}, err
 }
 
@@ -907,6 +1273,211 @@ func (p PathReplyEntry_Promise) HostInfo() HostInfo_Promise {
 	return HostInfo_Promise{Pipeline: p.Pipeline.GetPipeline(1)}
 }
 
+func (p PathReplyEntry_Promise) Health() PathHealth_Promise {
+	return PathHealth_Promise{Pipeline: p.Pipeline.GetPipeline(2)}
+}
+
+type PathLinkInfo struct{ capnp.Struct }
+
+// PathLinkInfo_TypeID is the unique identifier for the type PathLinkInfo.
+const PathLinkInfo_TypeID = 0x871b96876b50c9c5
+
+func NewPathLinkInfo(s *capnp.Segment) (PathLinkInfo, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 1})
+	return PathLinkInfo{st}, err
+}
+
+func NewRootPathLinkInfo(s *capnp.Segment) (PathLinkInfo, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 1})
+	return PathLinkInfo{st}, err
+}
+
+func ReadRootPathLinkInfo(msg *capnp.Message) (PathLinkInfo, error) {
+	root, err := msg.RootPtr()
+	return PathLinkInfo{root.Struct()}, err
+}
+
+func (s PathLinkInfo) String() string {
+	str, _ := text.Marshal(0x871b96876b50c9c5, s.Struct)
+	return str
+}
+
+func (s PathLinkInfo) Latency() uint32 {
+	return s.Struct.Uint32(0)
+}
+
+func (s PathLinkInfo) SetLatency(v uint32) {
+	s.Struct.SetUint32(0, v)
+}
+
+func (s PathLinkInfo) Bandwidth() uint64 {
+	return s.Struct.Uint64(8)
+}
+
+func (s PathLinkInfo) SetBandwidth(v uint64) {
+	s.Struct.SetUint64(8, v)
+}
+
+func (s PathLinkInfo) HasGeo() bool {
+	return s.Struct.Bit(32)
+}
+
+func (s PathLinkInfo) SetHasGeo(v bool) {
+	s.Struct.SetBit(32, v)
+}
+
+func (s PathLinkInfo) Latitude() float32 {
+	return math.Float32frombits(s.Struct.Uint32(16))
+}
+
+func (s PathLinkInfo) SetLatitude(v float32) {
+	s.Struct.SetUint32(16, math.Float32bits(v))
+}
+
+func (s PathLinkInfo) Longitude() float32 {
+	return math.Float32frombits(s.Struct.Uint32(20))
+}
+
+func (s PathLinkInfo) SetLongitude(v float32) {
+	s.Struct.SetUint32(20, math.Float32bits(v))
+}
+
+func (s PathLinkInfo) Address() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s PathLinkInfo) HasAddress() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s PathLinkInfo) AddressBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s PathLinkInfo) SetAddress(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+// PathLinkInfo_List is a list of PathLinkInfo.
+type PathLinkInfo_List struct{ capnp.List }
+
+// NewPathLinkInfo creates a new list of PathLinkInfo.
+func NewPathLinkInfo_List(s *capnp.Segment, sz int32) (PathLinkInfo_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 24, PointerCount: 1}, sz)
+	return PathLinkInfo_List{l}, err
+}
+
+func (s PathLinkInfo_List) At(i int) PathLinkInfo { return PathLinkInfo{s.List.Struct(i)} }
+
+func (s PathLinkInfo_List) Set(i int, v PathLinkInfo) error { return s.List.SetStruct(i, v.Struct) }
+
+func (s PathLinkInfo_List) String() string {
+	str, _ := text.MarshalList(0x871b96876b50c9c5, s.List)
+	return str
+}
+
+// PathLinkInfo_Promise is a wrapper for a PathLinkInfo promised by a client call.
+type PathLinkInfo_Promise struct{ *capnp.Pipeline }
+
+func (p PathLinkInfo_Promise) Struct() (PathLinkInfo, error) {
+	s, err := p.Pipeline.Struct()
+	return PathLinkInfo{s}, err
+}
+
+type PathHealth struct{ capnp.Struct }
+
+// PathHealth_TypeID is the unique identifier for the type PathHealth.
+const PathHealth_TypeID = 0xc899be7e17429dcc
+
+func NewPathHealth(s *capnp.Segment) (PathHealth, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 0})
+	return PathHealth{st}, err
+}
+
+func NewRootPathHealth(s *capnp.Segment) (PathHealth, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 0})
+	return PathHealth{st}, err
+}
+
+func ReadRootPathHealth(msg *capnp.Message) (PathHealth, error) {
+	root, err := msg.RootPtr()
+	return PathHealth{root.Struct()}, err
+}
+
+func (s PathHealth) String() string {
+	str, _ := text.Marshal(0xc899be7e17429dcc, s.Struct)
+	return str
+}
+
+func (s PathHealth) State() uint8 {
+	return s.Struct.Uint8(0)
+}
+
+func (s PathHealth) SetState(v uint8) {
+	s.Struct.SetUint8(0, v)
+}
+
+func (s PathHealth) Rtt() uint32 {
+	return s.Struct.Uint32(4)
+}
+
+func (s PathHealth) SetRtt(v uint32) {
+	s.Struct.SetUint32(4, v)
+}
+
+func (s PathHealth) Loss() float32 {
+	return math.Float32frombits(s.Struct.Uint32(8))
+}
+
+func (s PathHealth) SetLoss(v float32) {
+	s.Struct.SetUint32(8, math.Float32bits(v))
+}
+
+func (s PathHealth) Probes() uint16 {
+	return s.Struct.Uint16(2)
+}
+
+func (s PathHealth) SetProbes(v uint16) {
+	s.Struct.SetUint16(2, v)
+}
+
+func (s PathHealth) LastProbe() uint32 {
+	return s.Struct.Uint32(12)
+}
+
+func (s PathHealth) SetLastProbe(v uint32) {
+	s.Struct.SetUint32(12, v)
+}
+
+// PathHealth_List is a list of PathHealth.
+type PathHealth_List struct{ capnp.List }
+
+// NewPathHealth creates a new list of PathHealth.
+func NewPathHealth_List(s *capnp.Segment, sz int32) (PathHealth_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 16, PointerCount: 0}, sz)
+	return PathHealth_List{l}, err
+}
+
+func (s PathHealth_List) At(i int) PathHealth { return PathHealth{s.List.Struct(i)} }
+
+func (s PathHealth_List) Set(i int, v PathHealth) error { return s.List.SetStruct(i, v.Struct) }
+
+func (s PathHealth_List) String() string {
+	str, _ := text.MarshalList(0xc899be7e17429dcc, s.List)
+	return str
+}
+
+// PathHealth_Promise is a wrapper for a PathHealth promised by a client call.
+type PathHealth_Promise struct{ *capnp.Pipeline }
+
+func (p PathHealth_Promise) Struct() (PathHealth, error) {
+	s, err := p.Pipeline.Struct()
+	return PathHealth{s}, err
+}
+
 type HostInfo struct{ capnp.Struct }
 type HostInfo_addrs HostInfo
 
@@ -2262,134 +2833,173 @@ func (p SegTypeHopReplyEntry_Promise) Struct() (SegTypeHopReplyEntry, error) {
 	return SegTypeHopReplyEntry{s}, err
 }
 
-const schema_8f4bd412642c9517 = "x\xda\x94W}l\x1bg\x19\x7f\x9e\xf7\xec\xd8Nl" +
-	"\x9f\xddsF\x09\x82\xd0\xaaU\x9bj\xa9\x96t\x85\xae" +
-	"\x82\xa5M\xdb,\x1et\xcd\xd9\x11\x7fLC\xcc\x8b_" +
-	"'FN\xec\xdc]\xd2z\xa2\x84\xa2\x06XaZ\xab" +
-	"-\x82\xd1N\xb4\x1b\x94\x06\x86\xd8J5\xb1\x82&\xd0" +
-	":>\xa2\x09\x98T4-\x1a\xac-\xeb\xdat\xabX" +
-	"\xba\x8e~\xd0r\xe8\xb9;\xdf]/\xd7\x8f\xe5\xaf7" +
-	"\xfe=\xfe\xbd\xcf\xd7\xefy^\xdf1/\xb8\x86\xb5\x05" +
-	"\xff\x1d\x02\x90\x1f\x08\xd6\xe9\x1f<\xf7\x8b\xfd\xef\x9e\x7f" +
-	"\xf8\xdb\x90\x8c\xa1\xfe\xb1\x89\xdb\xf3\xf3\x8e~\xe11\x08" +
-	"b\x08@\xfa\x870-\xcd\x08t:)t\x00\xea\xe7" +
-	"\xa7/}\xe5\xa5\xa9\xb7v\x80\x1cC\xb71#\x93\xc6" +
-	"\xc0\x94\xb4 @\xa7O\x06N\x01\xeaM\xc9=]o" +
-	"+\xdb\x1e\xf3\x18\x1b\x16\xc1\xe0A)\x16\xa4S$H" +
-	"\xc4]/w\x8d\x1d\xda}f\x17\xd92\xc7v\x03\x0b" +
-	"\xc50 \xb5\x04\x0fKmd\xbd\xa25\xb8I\x00\xd4" +
-	"\x9f:\x9d:\xb1t\xfe\xd7\x9f\xf0\xf3\xf9\xcf\xe1)\xe9" +
-	"\xefa:\xbd\x16&\xea}[\x1b\x0e\xac\\S\x9d\xf0" +
-	"P\x1bn`dZ\x8aE\x0c7\"\x9b\x01\xf5\x99\xce" +
-	"\xb7\xc6\x7f:^\xb7\xdb\x8f\x97G\xceH\xc3\x86\xed`" +
-	"\x84x\xa7\xdf\xd8q\xfax\xf0/\xbbAnDA\x7f" +
-	"\xf7\x99#o\xb65\xfe\xe1\x084b\x08\x01\xa4]\x91" +
-	"i@i\xc2`\x9d\xd7\xb6\xb7\xed\x81\xf0\xa6I\x1f\xd6" +
-	"\x15\xb3\x11\x86\xd2U\x83\xf6\x92A{hvR\xbe\x7f" +
-	"\xfe\xc5g\xbd)6\xac[\xeb\xe7\xa1\xf4\xf9z\xb2\xbe" +
-	"\xab\xfe\x97\x80\xfa\xa7\x17?\xbe9\xb8\xa4\xe9\xa0oA" +
-	"f\xea\x0fJ\xb3\x86\xf1\xd9z\xf2\xe3\xf4\xb9\xdbFO" +
-	"\x9e]\xf3\xb2_t\xad\x0dg\xa4\xbb\x1a\xe8\xb4\xb2\x81" +
-	"\xdc\xb0\xe3\x91c(\xccIE\xc3\xcf\xa4A2^Q" +
-	"lhF@\xfd\xbd\xd1\xefWz\x97\xeb\xafx\x98\x0d" +
-	"/\x86\xa3'\xa4\xadQ:U\xa3\xe4\x85\xc8\xff\xba\xb6" +
-	"s\xfb\xa7\xa6\xfc\xda\xe2\x8d\xe8\xb4t\xd2\xb0=\x1e%" +
-	"/~\xf2\xce\xa2=\x07\x9e\xe6\xaf\xfa\xd9b\xec\xb0\x14" +
-	"\x89\x19\xcd\x14#\xdb7\x8f\xfff\xff#\x8f/9\xe5" +
-	"\x9b\xb8\xc5\xb1&\x94V\x1a\xd6m1J\\\xe9X\xe6" +
-	"KM\xaf]8\xe5\x97\x8b\xe3\xb1)\xe9\xaca;c" +
-	"0\xafZ\xf2\xfa\xb7\xfa\x1b_y\xdf\x8fY\xfax\xfc" +
-	"\x9c\xb48N\xa7\x05q\x0a\xaf\xe3\x9d\xbb[^\x98\x11" +
-	"g}\x8d\xb7\xc5\x0fK\x8f\x18\xc6\xe3\x86\xf1\x8b/m" +
-	"\x99\xfc\xee\xeb\xfb/\xf8y\xf1a\xfc\x9c\x84\"\x9d\xae" +
-	"\xc6\xc9\x8bh\xd3?\x7f\xde\xbf\xf8\xe4%\x90oCW" +
-	"\xe1\x1b\x99\xd1o\xad\xe2\x09@\xa9M$\xd6_\xbd\xf0" +
-	"\xf0=\x87\x9ey\xfe\xb2_\xc7?*\x9e\x93\x9e4X" +
-	"'D\xca\x83\xdaW,\x0f\xe5\x97\xf7\xb1\\e\xa8\xb2" +
-	":\xdd\x95\x1e*\x943|x\x84\x0b\xaa\xd6\x83(\x07" +
-	"\x84\x00@\x00\x01\x92\xb1v\x009,\xa0\xbc\x88as" +
-	"\xb1\x90^\xafb\x1c\xb0G@\x8c\x00\xa3\xa3\x87\xabk" +
-	"s\xbe'\xa7\x0dl\xe4Z\x0e\x80\xa8\x126U\xae\x93" +
-	"f\x8f\x80\xf2\x00C\xc4\x14\xd2g|!\x80\xfc\xa0\x80" +
-	"r\x89a\x92a\x0a\x19@\xb2x?\x80< \xa0\xbc" +
-	"\x9daR\xc0\x14\x0a\x00\xc9m\xf4\xed\xaf\x09(\x7f\x87" +
-	"\xe1X\xc1\xbc\x05c\xc00\x06\x18\x1a\xd4F0\x04\x0c" +
-	"C\x80zqH\xe3J!\xd7\x07\x02\xb7}M8\xf3" +
-	"\x00\x90>\x1c\xe3[*\xbd\xc5A\x8ea`\x18vE" +
-	"\x81F\x14\x19>\xda\x9c\xe1\x95R\xd5\x93\x8c\xd5V2" +
-	"R\x0c;\x14\xae\x8e\x944\xfb\xdak\x09\xb2\xeb\xd2\x1d" +
-	"\x9b\xee[\xbfQ\xed'\x86\xcf\xd5\x18\xa4\x09l\x02\xc8" +
-	"\xeeD\x01\xb3{\x90a\x0cu\xddH\x84\xf4$\xb6\x03" +
-	"d\x9f `/\x01\xec\x7f\xba\x91\x0c\xe9)\xec\x04\xc8" +
-	"\xfe\x80\x80\x1f\x13 \\\xd5\x8d\x84H\xfb0\x03\x90\xdd" +
-	"K\xc0\xb3\x04\x04\xae\xe8)\x0c\x00H\x93\x06p\x80\x80" +
-	"C\x04\x04\xff\xab\xa70\x08 =\x8f\x0f\x01d\x9f#" +
-	"\xe0\xb7\x04\xd4]\xd6SX\x07 \xbd\x88\xdf\x04\xc8\xfe" +
-	"\x9a\x80#\x04\x84.\xe9)\xa3\x1b\x7f\x8f\x0a@\xf6w" +
-	"\x04\xbcJ@\xf8\xa2\x9e\xc20\x8d[\x83\xeaO\x04\x1c" +
-	"% rAOa\x84\xa6/\xfe\x10 {\x94\x80c" +
-	"\x04\xd4\xffGOa=-\x15\xdc\x01\x90=F\xc0{" +
-	"\x044|\xa8\xa7\xb0\x81\xd4\x86\xf7\x02dO\x13p\x9e" +
-	"\x80\xe8y=\x85Q\x00i\xd6\xb8\xfc}\x02\xae\x10\x10" +
-	"\xfb@Oa\x8cF\xa6\xe1\xeeE\x02\x02\x8ca2\x8e" +
-	")\x8c\xd3l`\x94\xaa+\xf4y\x981\x14\x8ay\xa3" +
-	"I#\x80\xcd#C*\xd7\xa0n\xac\x92\xd3\x062|" +
-	"\x18\x13\xce\xa8\x03\xc4\x04\xa0n\"\x95\x12`\x15\x13\x8e" +
-	"\x9e-4\xa7\x9a\x12\x01\xa4\xef\xda\x03\xca\x8b\x86*%" +
-	"\xfa\xb6\xbd\xa8,\\\xe1\xa3\xf7\x95\xb5b\x01\x8b}9" +
-	"\xadX\x1e\x02L8K\xc7\xb2)\x16,\x8e\xe6\xe1\x11" +
-	"\xaej\x98pV\xb4\xd7\xc2\xba\xc5\x1ef\x16\xaere" +
-	"\xb4\xd8\xc7\xd3\xe8\x123&\x9c=\xe4kV)U\x81" +
-	"\xdc\xb1g\x92\xe3\xb2\x05\x12j/u\x9b\xa3\xbf\xb7Z" +
-	"\xe1\xdd\xd0\\\xae\x98\xe9\xb4\xe7\xbb\xc7\x02\xc9\x80x0" +
-	"\xe1l\"\xd3fLSr}<\x9d\xaf\xa9\xd83I" +
-	"\xd6f\xd3\x8e\x87\x1e\x1dv:Ci\x8c\x0fiJ\xd1" +
-	"-u{\x10\x9aR\xf7\xd0\xd2\xdcH\x9b#B\xe8\xe3" +
-	"\xc4\x1b\xb6y[h\xd8-\x12P\xbe\x83a\xb26\xa2" +
-	"Z\x97\x01\xc8K\x05\x94\xef\xa4\x09\xa8\xe6sj\xad\xab" +
-	"D\x9a\x87\xb5\x7f<\xd7d\xac\x92\x17\xfbr\"\x95\xdc" +
-	"\x13\xc0\xbd\x00rT@y>C]\xcd\xf0Q\x0a\xd5" +
-	"Lu\xe6_\x97?;~O\xfb\x8f\xecD\xce\xf1>" +
-	"\xc3\x87\x97\x17J9\xa1_%\xd7\x13;\xcd\xf9\xd8\xd2" +
-	"\xe9\xf6}\x971\x0a\x92\xad\xab\x1d\xdf\xc7\x14^P\xb8" +
-	":\x80\x08\x0c\x11\xb0c\xa0\x98\xcf\xf3\xa1\xda\xbf\xf6E" +
-	"\x829\xc0\xac.\xa9\xf5\x92\xaayk\xf0U+\x84\xa5" +
-	"\xcc\xee\xa9^\x10\xab\x15\xa7\x14\xa2\xae\xf5\xff\xed\x13-" +
-	"\xad\x99\x13\xdeR\xd4\xee0{\xc4j\x91\x0dC\x9a\x82" +
-	"\xc6\xc4\x8d\xda\xb7l\xa0U\xb0^@\xf9Agg|" +
-	"9\xe3\xec\x11{g\xf0Ng\x91\xdc\xda\x0a\xd0\xb5\xe2" +
-	" W\xb5\xdc `\xa5\xb6\x06n\xb2\x16\xba\xcbj\xb3" +
-	"F)\xf1\xb4\xcd2'\xf5\xf4\xe7,\xeddk;0" +
-	"\xb1RV\xec=\xd1\x9c\xcb\xe7\x15\xd5SUW\"D" +
-	"\x9f\xads\xc3n\xb7_\x8e\x9e\x14c\xad_Dj\x18" +
-	"bL\xd9\x8c[i\xebn\xb1\x16l-\xad\xdb\x16:" +
-	"\x0b6\xc9\xc2fZ\xc7\xa9Q\xb7\x0b(\xefd\x88\x02" +
-	"\xba\xde\xbf\xc9G\xdb\x81a\xc0\xd8,\xc9\x11\xea\xb3\x8a" +
-	"\x80\xf2\xf7\x18\x86\xf2\xaaV\x13EHU\xfal\x81\x0c" +
-	"\xe6\xb6P\xfb\xaa\xd4\xe7\xb5l\x14J\xb9~\xb5c\xa0" +
-	"\xb2\xae\xd0\xef\x8ai\xfe\x86\xb7\xef\x96\xfe\xb8\xe0\xf0\xf5" +
-	"\x15l5LHS\xaa\xd7\xaf\x85#a\x8a\xe2v\x01" +
-	"\xe5U\x0cE\x1a\xf4\x98p~\xd4X:\x1b(\xab\x9a" +
-	"\xa3B\xfb\xa1\xe5\xabBW\xbd\x043\xb7\xaej-s" +
-	"\xde\x08\xa2V\xadp\x14\xf5o\xacz\xba\x9eO^\xd8" +
-	"Gl\xe2\x9c\x1a\xad\xcd\xa6;L\x99]\xe7\xe9\x95\xf2" +
-	"\x0e\x9e\x1bi\xd5\x14\x92\xa0x\x85\xf4\x90%\xa4\x1eW" +
-	"^6R\xc9\xbb\x05\x94{\x19\"3+.\x93\xbaz" +
-	",!\xd9\xba\x0e\x99\x81\xb8\xf5,\x02\x864\xaddK" +
-	"\xc5N \xba*\xe9\xcec\xfc\xba\x0f\xcf\x8f<\xe2\xed" +
-	"\xb7\xf9\xcdh\x9bi\xa8To$W\x9f\x0e\xb9f\xac" +
-	"\xdfZ_\xd8j\xeb\x18\xb0\xdf\x8d\xae\x1b3\xce\x18\xae" +
-	"\xdd\xd8\xd6i\xdd\xd8\xcdP\xe7\x8aRV\xd6\x95\xf3\x80" +
-	"\xbc&\x8d\xb9A\xdb?\xb7|\x83v5\x81\xef\xd3\xf5" +
-	"\x86\xf9\xb4\x7fE\xf9Rw[)X\x9e\xcb\x87\xf2\x8a" +
-	"j\x06fF1Gn\xcc\xb31\xc5be\xf4\xce\xda" +
-	"\x86\xa7\x7f>s\xf3u\xef\x14\xcd\xd5\xbf$\x865\x02" +
-	"\xca_\xa4\x8b\x02\xe6\xed\xe9\x85\xae\xa6f=\xe6\xed\x1b" +
-	"W;M}\xadl\xdc?\x13:\x8a\xea\xba\xb2\xc2k" +
-	"\xdb\xef\xff\x01\x00\x00\xff\xff\x18V:\xa1"
+const schema_8f4bd412642c9517 = "x\xda\xa4X}l\x1c\xd5\xb5?\xe7\xce\xae\xd7\x8ew" +
+	"\xbd\xbb\x9e\xd9\xe0\x17\xc43DA$\x11\x89\xb0\x93\xf7" +
+	"\x1eX\x0f6q>\xb0\xd3\x84xvCQ#P\x19" +
+	"\xef^\xdbS\xd6\xbb\x9b\x99\xb1\x13#\x82I\x85\x0b\xa1" +
+	"D\x10\x81KS\x88\xca\x87\xa0I\x0b*\xd0\x80\x94\xb4" +
+	"\xa2\x85\x90\x96D\xd0B\x0b\x82X\x14H\xf8H\xf8\x92" +
+	"H\x08\x0d\xa1\xb4S\x9d\x99\xd9\x99\xc9x\x92\x14\xd5\x7f" +
+	"\xdd\xbd\xe7\xcc\xb9\xf7\xfc\xce\xef|\\_\xf4n\xdd\x02" +
+	"\xd6\x16]\xd9\x00 \x97\xa2u\xe6\x9e\xbd=\xd7\xdd\xf2" +
+	"\xa3\xb3o\x019\x81\x82y\xd6\xf8\x85\xc5\xe6W\xbfu" +
+	"\x07D1\x06 n\x8a\xfcY\xdc\x12\x89\x01\xcc\x1b\x8f" +
+	"\\\x85\x80\xe6\xe7\x8f?\xf6\xc8\xc7\xc7\xae\xbf\x05\xd2\x09" +
+	"\x0c*\x7f\x1a\x9d\x10ODi\xf5E4\x0bh\x1e\x9b" +
+	"8\xf1\xddg\xf6\xbd}\x1bY\xf6+3R9\xbfn" +
+	"\x9f\xd8VG\xab9u\x87\x00\xcdi\xe9\xfb\x96\xbe\xa7" +
+	"m\xb8#\xa0Lg\x8b\x99\xd8\x93\xe291Z\xfdW" +
+	"\x8c\x0c/\xdd\xbdtt\xc7\xbd\x1fm&]\xe6\xe9." +
+	"a\xb1$F\xc4Kb\xbb\xc4\x85\xa4=\xef\xd2\xd8o" +
+	"\x05@s\xeba\xe9\xe0\xcc\x96\x1b\xef\x0e\xbb\xf35\x8d" +
+	"\xfbD\xb5\x91V\xbc\x91L?\xb0\xbeq\xdb\xff,\x18" +
+	"\x19\x0f\x98\xb6\xae1\xde8!>`\xe9nm\\\x0b" +
+	"h~\xd8\xf9\xf6\xd8\xcf\xc6\xea\xee\x0d\xb3{\xa2\xf1#" +
+	"1\x1a\xa7\x15\xc6\xc9\xee\xc4\xfe\xdb\x0e\x1f\x88\xfe\xf1^" +
+	"\x903(\x98\x1f?\xf4\xfc\x9bm\x99\xdf?\x0f\x99H" +
+	"\x0c\x09\x8c\xf8\x04\xe0\xbcY\xf1V\x044\x9b\xdb\xeeo" +
+	"\xbb\xba~\xe5\xf6\x10\xb3\xf3V$\x18\x8a\xdfI\x90\xdd" +
+	"+\x13dw\xc7\x91\xed\xf2\xea\x96/\x1f\x0dbli" +
+	"oL4\xa3\xb8\xc5\xd2\x1eO\xfc\x12\xd0<\xf7\xfc\xbb" +
+	"\xd6F/\x98\xf6dhD\x964=)\xaeh\xa2U" +
+	"w\x13\xb9w\xf8\xe8\xd4\xe1\xf7?]\xb0;\xcc\xbd\x8d" +
+	"M\x1f\x89\xe3\x96\xee\xe6&\xba\x86\xebP\x80D\x16n" +
+	"\xcf6\xfd\\\xdcK\xca\xf3\xf64Y\x1e~2|O" +
+	"u\xd5\\sO\xc0\xb2\xa5\xfcR\xf2\xa0\xb8?I\xab" +
+	"\xd7\x92\xc4\x8b\x17\xb7v\x9eu\xe33[^\x08\x0b\xc8" +
+	"\xb3\xa9\xe7\xc4\xbd)\xcbp\xca2\x9c\xe4\x7fZ\xd8y" +
+	"\xf3\x7f\xef\x0b#\xd1\xfe\xf4\x84\xf8~\x9aV\x07\xd2t" +
+	"\xe5\xb1\xb3&\xae\x9a\xbf\xbbw_\x18r\"6?'" +
+	"64\xd3*\xdaL\xc0=\xfc\xc1\x8c\xfb\xb6=\xc8_" +
+	"\x0c3\xfcD\xf3.q\xa7\xa5\xfbT3\x19\xfe\xcb\xa1" +
+	"\xb6\x1bw\xaf\xbc\xe7\xad\x00\x16\x96\xeek\xcdG\xc5\x03" +
+	"\x96\xee_\x9b\xc9\xbb7\x0f\xfc\xfa\x91\x8dw]p(" +
+	"4|;\xc5i(\xee\x15I{\x8fH\xb7(\xbd\x93" +
+	"\xfb\xf6\xb4W\x8e\x1f\x0a\x8b\xc8\x90\xb4O\xdc \xd1j" +
+	"\xbdD\xb7\xb8\xf8\x82\xd7\x7f\xd0\x9f\xd9\xf3Y\xa8{\x8f" +
+	"IG\xc5\x9d\x96\xf2S\x12\x85:\xfb\xc1e\xb3\x9e\xfe" +
+	"0y$T9\x93\xd9%\x9e\x93\xb1\xb2/C\xca;" +
+	"\x9fY\xb7\xfd\x87\xaf?r<\x94\x17\x99\xa3\xe2\xb8\xa5" +
+	"\xbb9C\xb7\x88O{\xeb\x17\xfd\xe7\xbf\x7f\x02\xe4\xa9" +
+	"\xe8\xa3_\x86Y\xb4\x7f6s\x10P\xdccY\xfd\xd5" +
+	"\xd3\xd7_\xbe\xe3\xa1'\xbe\x0a\x8b\xf3yS\x8f\x8as" +
+	"\xa6\xd2j\xd6T\xc2A/\xa8\x95rqn\x81)\xd5" +
+	"r\xb5\xa3G1\x06\x96\xab\xe5\xeb\xba\xcb}X\xe9A" +
+	"\x94[\x84\x08@\x04\x01\xd2[:\x01\xe4\xbb\x05\x94\xef" +
+	"g\x98F\x94\x906\xb7\xe6\x00\xe4\xfb\x04\x94\xb71L" +
+	"\xb3s%d\x00\xe9\x87;\x00\xe4\xfb\x05\x94\x1fe\x98" +
+	"\x16\"\x12\x0a\x00\xe9\xed\xcb\x00\xe4m\x02\xca;\x18\xa6" +
+	"#Q\x09#\x00\xe9'\xe8\xf3\xc7\x05\x94\x7f\xc3\x10\xa3" +
+	"\x12F\x01\xd2;\xe9\x9c\x1d\x02\xca\xbfc8ZR\x0c" +
+	"^.\x8c`=0\xac\x074{\x95rq\xadZ4" +
+	"\x00\x07\xb0\x01\x186\x00f\x07\x14\xfdr^A\x04\x86" +
+	"H\x81U\x0c\xd5\x18*r\x00\xc0)\xc0p\x0a\xedU" +
+	"\xca\xfd\xb4\x09\xc8k{\xa3J\xb1\xa8q]\xc780" +
+	"\x8cO\x02\xa2{iw\xb9\xaf\x92\xe3k\x86\xb8\xa0\x1b" +
+	"\x84D\xc4E\"\xd1\x0e \xd7\x0b(\xcf`\xd8\xaa\xf6" +
+	"u/\xd6\xb1\x09\xb0G@\xebJM\x93l-][" +
+	"$\\WpC\x01 S)\xd7\x94B\xce^-\xa0" +
+	"<\xc0\xb0\x86)\x9f\x0e _+\xa0\\\"L\xd1\xc6" +
+	"T]\x0d \x0f\x08(\xdfL\x98\xa2\x8d\xe9\x06\xfa\xfa" +
+	"\x06\x01\xe5[\x19\x8e\xf6\xd9\xa7`\x02\x18&\x00c\x83" +
+	"\xc6\x10\xc6\x80a\x0c\xd0T\xcb\x06\xd7\xfa\x94\x02\x08\xdc" +
+	"\xbdk\xca\xab\xcf\x80\xb49\xca\xd7UW\xa9\x83\xdc\x05" +
+	"\xbb\xe6\x05Z^\xe4\xf8pk\x8eWK#\x010:" +
+	"\x1c0$\x86Y\x8d\xebC%\xc3=\xf6d\x03\xf9E" +
+	"\xdd\xd9\x95W,^\xa1\xf7\x93\x85\xc55\x0b\xe2K8" +
+	"\x0d \xff\x02\x0a\x98\x7f\x15\x19&\xd04- \xc4W" +
+	"\xb0\x1d \xff\"\x09\xde \x01\xfb\xa7i\x81!\xbe\x86" +
+	"\x9d\x00\xf9\x97I\xf0&\x09\x84\x7f\x98\x16 \xe2~\xcc" +
+	"\x01\xe4\xdf \xc1{$\x88|mZD\x13\x0fX\x82" +
+	"wH\xf0\x09\x09\xa2\x7f7-\xb6\x89\x1fb/@\xfe" +
+	"0\x09\x8e\x91\xa0\xee+S\xc2:\x00\xf1\x08~\x1f " +
+	"\xff\x19\x09\xbe&A\xec\x84)\xd9\xdd\x085\x80\xfc\x97" +
+	"$\x880\x86\x89\xfa/M\x09\xeb\x01Dd\xbd\x009" +
+	"&`>N\xfb\x0d\xc7M\x09\x1b\x00\xc4\x06\xf6\x13\x80" +
+	"|\x9c\x04-$\x98\xf27S\xc2)T\x0c\xd8m\x00" +
+	"\xf9\x16\x12\xcc A\xe3\x17\xa6\x84\x8d\x94\xa3l\x19@" +
+	"\xfe\\\x12\\H\x82\xf81S\xc28\xa5,\xa3\xb3g" +
+	"\x92`>\x09\x12\x9f\x9b\x12&\x00\xc46F\xb7\xbd\x88" +
+	"\x04\xff\xcf\x18\xa6S(a\x13\x80x\x09#\xa4\xe6\xd3" +
+	"\xfe\x02\xfa\xa0\xe9\xa8)a\x12@\xbc\xd4:{\x01\x09" +
+	"\x96\x93 y\xc4\x940E\xfd\x8a\xad\x06\xc8w\x91`" +
+	"\x15c(\xa8\xc5Z\xa2\xb5\x0e\x95un@\xddhU" +
+	"1\x06r|\x0d\xa6\xbcV\x05\x88)@\xd3\x96TK" +
+	"\x80#\x98\xf2*\xa1#Ut;\xa7\x00\xe9[\xb7\x0d" +
+	"\x04\xa5\xb1j\x89\xbev'\x0dG\xae\xf1\xe1+*\x86" +
+	"\xda\x87jA1\xd4J\x190\xe5M\x0d\x8e\x8e\xda\xe7" +
+	"\xd8h]3\xc4u\x03S\xde\x8c\x15\xd4pNq\xdb" +
+	"\x80#\xd7\xb96\xac\x16x7\xfa\xb2\x1fS\xde\x1c\x11" +
+	"\xaaV-\x8d\x00]\xc7\xad\xe6\xde\x95\x1d!I\xdd\xa9" +
+	"\xcc\xb5\xd1\xbfj\xa4\xca\xbb\xa0\xb5R\xb5\xe1t[n" +
+	"@\x03+U\xdb\x0e\xa6\xbcI\xc2\xd6\x1954\xa5\xc0" +
+	"\xbb\x8b\xb5\xb4\xb7B\x90\x1f\xea\xd5\xb1\xa0\xa9\xbd<\xc7" +
+	"\xd7Xg\xbb}\xd4\x17\xa8+\xabE\x05\x04\x83c\xca" +
+	"\xeb\xdf\xb5\x83O\xaa_\x0b\xf3\xdd\x9e\x9b\x81\xec\xef\xf4" +
+	"J\xe1(/\x1b\x9a\xea/0n\x1f\xb2\x0bLH\xaf" +
+	"\xe9\xb6\x0b\x93P\xe0d\xb7\xde\xb5;\x8bJ\xec\x0c\x01" +
+	"\xe5\x8b|\xcdf\xcel\x00y\xa6\x80\xf2|\xaa\xbbz" +
+	"Q\xd1k\xd4LR\x15\xae\xfd\x08\x1c\x93sx\xa3\x16" +
+	"\x94$\xf1&\xe0\x00\xb5\xa5\xb8\x80r\x0bCS\xcf\xf1" +
+	"ar\xd5\x8eW\xee\xdd\xaf\xfeo\xec\xf2\xf6\x9f\x86\x83" +
+	"\xd2c'\xc1\xdc\xbe\x92\"\xf4\xeb\xb2$DRw\xda" +
+	"Uy=a\xb2\xce)\xd5\xb8\xd9\xeet\x1b:\xbcR" +
+	"m\x15\xf5:\x80\xf4\x18\x15\xf5\x9b\x05\x94\xef\xa4\xa2\xce" +
+	"\xac\xfa\x92\xdeD\x9a\xb7\x0a(\xdfM\x8dR\xb0jK" +
+	"z3\xe1q\xbb\xddgG5\xde\xa7q}\xa0\xd6\xf1" +
+	"\xb2\x03j\xb1\xc8\xcb\xb5\x9ff\xb5RR\x0b#W(" +
+	" \x0c\xf2Zw\xcb\xda\x9b5\x96\xb4V\xb4\"\xd7j" +
+	"\x81\x8a\x9bF\xff\xcbg\xcf\x9a\x93;\x18\x0c\x94`\x17" +
+	"n\x87\xec\xb5\x94\xd0\x8d \x0b\xbe\xe7\x808\x93\xb9\xa9" +
+	"\xb1\x0a\x92#U\x8f\x0c\xc93\x9eaS\xdda\xfa\x92" +
+	"\xb2\xa1\xa1\xd5i\xe2\xee)K\x08\xad\xc5\x02\xca\xd7z" +
+	"\xbd\xf2\x9a\x9c\xd7?\xdd^\xc9;\xbd\x06\xfa\xef\xb5>" +
+	"\xd3P\x07\xb9n(\x83\x80\xd5Z\xfb;C;\xec\xaa" +
+	"\xe8\xad\x06A\x12 \xeel\x8f\xb8\xf4\xe7Mm\xe99" +
+	"\xed\xc0\x92\xd5\x8a\xe6\xf6\xc7V\x1aA\xf4\x00\xaf|@" +
+	"$C\xba\xedi\xf3\xcd}\xc0\x04 \xc6\x1ac\x93D" +
+	"Y\xb2(\xb9\x16\xd7O\xf7\xb3\xd5\x81u\xc3t?[" +
+	"\xebmX\xc7\x96ylE\x01}\xef\xb0\xf4\xa6v`" +
+	"\x18\xb1\xe7\xb7!\xe2oU@\xf9v\x86\xb1\xa2n\xd4" +
+	"\xd22\xa6k\x85\xda\xda\x1cT\xd6Q\x02\xe9\x00\xe0\xa2" +
+	"\xd1WR\xfa\xf5\xec@uQ_\xbf\xcf\xa7\x96%\xef" +
+	"]&\xfe\xe1\xbc]\xa7\xae!\x0eab\x866\x12\x18" +
+	"\xaef\xfb\xc8\xe1NW\xe4EQ@\xb9J\xae1\xdb" +
+	"\xb5\xc1\x0eg\xba2(\x11\x05;\x8f\xd7,\xf3\x1cI" +
+	"R\xc1\xc4\x94\xf7\x0cwj\xc2@E7\xbc\x8a\xe1\xce" +
+	"\xe4\xb64;\xc0\x95\x92\xf5\x99\xfb\xf0r>+\xd9#" +
+	"\xb6\xf5\x99\xeb\xa9\xfb\xcf\x83Sz\xdae\xd9\x83@\x00" +
+	"\xdb\xcf\x18@\xc7\xcb1\xc2\xe3&\xdb!w\x86\xdc\xd8" +
+	"\xe1\xabA\x11\xc1\xaeV\x9brN\xb9\xf91\xc3V\xdd" +
+	"P\x0c\x8eu\xc0\xb0\x0e0\xa6\x19F-#\x92\xa5\x8a" +
+	"\xae\xd7\xe6\xe9lU\xab\xf4r\xdd\x9d\xfdJ\x8an\xf4" +
+	"h\x95^\xc0\xc9\x194\x89\xe9\x02_\x13\xe0\xf9lo" +
+	"\xaaL\x1a#U\x8eI\xf3\xa6\x8b\x1f\x9c\xc2\xb7\x1f\x7f" +
+	"\x00\x001\x19\x8a\x0fu4\x83C\xa0l\x90\x83\x0b\x04" +
+	"\x94\x97\xfb\xf0\xe9&\x07\xbb\x04\x94W1D\x07\x1e\x99" +
+	"rk\xb9M\x97\xac\xc6\x15\xbdRv\xbd\xe1\x9aV\xd1" +
+	"\x16U\xac\x07\x84\xb379\xfd\xdc\xa7xh\xfa-\xcc" +
+	"wg\xed\x0az\x8a\xd7\x84\x14\xecj!\x1eRg/" +
+	"hjk/w\xec\xf8\xd8>=\x8c\xed'\xbd%\x9c" +
+	"DV\x97\xf9\xd9\x1eu\xd8\xbe\xdaa\xfb\x0d\xdf,m" +
+	"M\xbe\xae\xaaj#\xcb9\x08JqR\xa4C\x1a\x88" +
+	"]\xdd\x05-X\xdd{\x9d\xea\xde\xe3\xbb\xfe\x8a\xe9!" +
+	"a\xa2\xd0\xf58\xd5\xddm61\x9b#\xfe&\x93\x04" +
+	"\x8c\x19F\xc9\xbd\x93\x9b\xab\xe8\x8b\x99?e\x9bN\xf9" +
+	"\x0a\xfc\xc6\x93\x8f\xfb\x1f\x833\x99m\xa5N7r\xba" +
+	"\x1e\xe2\xcc>\x14\xb3\x0b\x05\x94/f\x81i\xe7\xb4%" +
+	"hr\x0b\xc8\x0e\xb8\x8f8\xdf\x899o\xb2\xaa\x9d\xd8" +
+	"\xd6\xe9\x9c\xd8\xc5\xfeC\xfe\xb3 \x09B\xdf\x91\xa7\xc5" +
+	"\xd3\xfd\xdfN\xa8\xe9.\x07\x82\xb9J1V\xd4t\xdb" +
+	"1\x09\x83XZ\xb4b\x81A2\xa9V\x87\xe7\xd7\xe6" +
+	"\"\xfa\xf1\xbf\xb5\x1f\xa7\x9e\x82\xbd\xa0\xf9\xf8\xdb\xee/" +
+	"3\x11\xa7\xccL\xf7\x91\x9a\xf5\xd8\xa7\xaf\xe8\xf0H}" +
+	"r\xc2\xfb\xdf\xecYU_T\xd1xm\xaa\xfb\xd7\x00" +
+	"\x1f\xa6]\xfe"
 
 func init() {
 	schemas.Register(schema_8f4bd412642c9517,
+		0x871b96876b50c9c5,
 		0x877af4eba6adb0f3,
 		0x8adfcabe5ff9daf4,
 		0x8f8172e4469c111a,
@@ -2404,8 +3014,11 @@ func init() {
 		0xc340ede57616f2e8,
 		0xc4c61531dcc4a3eb,
 		0xc5ff2e54709776ec,
+		0xc899be7e17429dcc,
 		0xca1e844241cf650f,
+		0xca62c33457da1785,
 		0xcc65a2a89c24e6a5,
+		0xde974fc37e31e7d3,
 		0xe7279389a6bbe1dc,
 		0xe7f7d11a5652e06c,
 		0xf0c5156786d72738,
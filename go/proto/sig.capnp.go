@@ -13,13 +13,16 @@ type SIGCtrl struct{ capnp.Struct }
 type SIGCtrl_Which uint16
 
 const (
-	SIGCtrl_Which_unset   SIGCtrl_Which = 0
-	SIGCtrl_Which_pollReq SIGCtrl_Which = 1
-	SIGCtrl_Which_pollRep SIGCtrl_Which = 2
+	SIGCtrl_Which_unset          SIGCtrl_Which = 0
+	SIGCtrl_Which_pollReq        SIGCtrl_Which = 1
+	SIGCtrl_Which_pollRep        SIGCtrl_Which = 2
+	SIGCtrl_Which_prefixAnnounce SIGCtrl_Which = 3
+	SIGCtrl_Which_keyAnnounce    SIGCtrl_Which = 4
+	SIGCtrl_Which_keyAck         SIGCtrl_Which = 5
 )
 
 func (w SIGCtrl_Which) String() string {
-	const s = "unsetpollReqpollRep"
+	const s = "unsetpollReqpollRepprefixAnnouncekeyAnnouncekeyAck"
 	switch w {
 	case SIGCtrl_Which_unset:
 		return s[0:5]
@@ -27,6 +30,12 @@ func (w SIGCtrl_Which) String() string {
 		return s[5:12]
 	case SIGCtrl_Which_pollRep:
 		return s[12:19]
+	case SIGCtrl_Which_prefixAnnounce:
+		return s[19:33]
+	case SIGCtrl_Which_keyAnnounce:
+		return s[33:44]
+	case SIGCtrl_Which_keyAck:
+		return s[44:50]
 
 	}
 	return "SIGCtrl_Which(" + strconv.FormatUint(uint64(w), 10) + ")"
@@ -137,6 +146,105 @@ func (s SIGCtrl) NewPollRep() (SIGPoll, error) {
 	return ss, err
 }
 
+func (s SIGCtrl) PrefixAnnounce() (SIGPrefixAnnounce, error) {
+	if s.Struct.Uint16(8) != 3 {
+		panic("Which() != prefixAnnounce")
+	}
+	p, err := s.Struct.Ptr(0)
+	return SIGPrefixAnnounce{Struct: p.Struct()}, err
+}
+
+func (s SIGCtrl) HasPrefixAnnounce() bool {
+	if s.Struct.Uint16(8) != 3 {
+		return false
+	}
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s SIGCtrl) SetPrefixAnnounce(v SIGPrefixAnnounce) error {
+	s.Struct.SetUint16(8, 3)
+	return s.Struct.SetPtr(0, v.Struct.ToPtr())
+}
+
+// NewPrefixAnnounce sets the prefixAnnounce field to a newly
+// allocated SIGPrefixAnnounce struct, preferring placement in s's segment.
+func (s SIGCtrl) NewPrefixAnnounce() (SIGPrefixAnnounce, error) {
+	s.Struct.SetUint16(8, 3)
+	ss, err := NewSIGPrefixAnnounce(s.Struct.Segment())
+	if err != nil {
+		return SIGPrefixAnnounce{}, err
+	}
+	err = s.Struct.SetPtr(0, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s SIGCtrl) KeyAnnounce() (SIGKeyAnnounce, error) {
+	if s.Struct.Uint16(8) != 4 {
+		panic("Which() != keyAnnounce")
+	}
+	p, err := s.Struct.Ptr(0)
+	return SIGKeyAnnounce{Struct: p.Struct()}, err
+}
+
+func (s SIGCtrl) HasKeyAnnounce() bool {
+	if s.Struct.Uint16(8) != 4 {
+		return false
+	}
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s SIGCtrl) SetKeyAnnounce(v SIGKeyAnnounce) error {
+	s.Struct.SetUint16(8, 4)
+	return s.Struct.SetPtr(0, v.Struct.ToPtr())
+}
+
+// NewKeyAnnounce sets the keyAnnounce field to a newly
+// allocated SIGKeyAnnounce struct, preferring placement in s's segment.
+func (s SIGCtrl) NewKeyAnnounce() (SIGKeyAnnounce, error) {
+	s.Struct.SetUint16(8, 4)
+	ss, err := NewSIGKeyAnnounce(s.Struct.Segment())
+	if err != nil {
+		return SIGKeyAnnounce{}, err
+	}
+	err = s.Struct.SetPtr(0, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s SIGCtrl) KeyAck() (SIGKeyAck, error) {
+	if s.Struct.Uint16(8) != 5 {
+		panic("Which() != keyAck")
+	}
+	p, err := s.Struct.Ptr(0)
+	return SIGKeyAck{Struct: p.Struct()}, err
+}
+
+func (s SIGCtrl) HasKeyAck() bool {
+	if s.Struct.Uint16(8) != 5 {
+		return false
+	}
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s SIGCtrl) SetKeyAck(v SIGKeyAck) error {
+	s.Struct.SetUint16(8, 5)
+	return s.Struct.SetPtr(0, v.Struct.ToPtr())
+}
+
+// NewKeyAck sets the keyAck field to a newly
+// allocated SIGKeyAck struct, preferring placement in s's segment.
+func (s SIGCtrl) NewKeyAck() (SIGKeyAck, error) {
+	s.Struct.SetUint16(8, 5)
+	ss, err := NewSIGKeyAck(s.Struct.Segment())
+	if err != nil {
+		return SIGKeyAck{}, err
+	}
+	err = s.Struct.SetPtr(0, ss.Struct.ToPtr())
+	return ss, err
+}
+
 // SIGCtrl_List is a list of SIGCtrl.
 type SIGCtrl_List struct{ capnp.List }
 
@@ -171,6 +279,18 @@ func (p SIGCtrl_Promise) PollRep() SIGPoll_Promise {
 	return SIGPoll_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
 }
 
+func (p SIGCtrl_Promise) PrefixAnnounce() SIGPrefixAnnounce_Promise {
+	return SIGPrefixAnnounce_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
+}
+
+func (p SIGCtrl_Promise) KeyAnnounce() SIGKeyAnnounce_Promise {
+	return SIGKeyAnnounce_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
+}
+
+func (p SIGCtrl_Promise) KeyAck() SIGKeyAck_Promise {
+	return SIGKeyAck_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
+}
+
 type SIGPoll struct{ capnp.Struct }
 
 // SIGPoll_TypeID is the unique identifier for the type SIGPoll.
@@ -368,33 +488,457 @@ func (p SIGAddr_Promise) Data() HostInfo_Promise {
 	return HostInfo_Promise{Pipeline: p.Pipeline.GetPipeline(1)}
 }
 
-const schema_8273379c3e06a721 = "x\xdat\x91Ok\x1aQ\x14\xc5\xefy\xcf\x19\xffP" +
-	"q\x06]\x09\xa5-X\xaa\x82\xa5J\xa5 \xb4\xd4\x96" +
-	"R\xb2\xf3%\xdb\x1028C\x1c\x98\xe883\xe2R" +
-	"\xc8G0\xbb$\xcb@6Y\xe5{d\x91E\xc8*" +
-	"\x0bWYg\x1f\xf3\xc2c\x12\x95HV\x17\xce\xb9\xdc" +
-	"\xdf;\xef\x18\xe7\xbfY]\xcb\x81H\xbc\xd3t\xd9\xb6" +
-	"N\x9b\xacusD\"\x03\xc8Og\xfa\xaf\x93\x1f\xe1" +
-	"\x01iH\x12\x99\xfbSs\xa4\xe6pL\x98\x97\x8f\xdf" +
-	"\xcf\x1e\xeeo\xcd\xcc\xea\x1aS\xf6\xe5\xd4\xbcV\xf3j" +
-	"L\x90\xa9\x9f\x8d\xb0R\xda\x99\xa9\x83l\xb9\xf9\x0fI" +
-	"\x8eD\xbe\x86i\xbe\xa9n\xe7\xeb\xb8#\xc8\xd0\xdd\xfb" +
-	"\xda\xb5\xfc>\xfc\xd6\xd6\xc6\xff\xce\xc0\x83\xd7\x01D\x8a" +
-	"'\x88\x12 2+U\"Q\xe2\x10\xdf\x18\x80\x02\x94" +
-	"V\xfbC$\xca\x1c\xe2;C\xce\xb2\xed\x00\xc6\xcb\xeb" +
-	"\x080\x08\x93\xd0\x09Cw\xd0\x87N\x0c\xfa\x1a\xa6m" +
-	"\xdb\x08\xde\xc6\x98\x0bNu\x85\xd3\x8d\x02\x0f\x86\xfc\xf8" +
-	"\xf9p\xac})^PL\xca\xd9Vd\xad\xcb\xaf\x80" +
-	"\x7f\xa3 \xcee,\x80V\x91Hls\x88\x1eC\x16" +
-	"R\xc6D\xa7A$v9\x84\xc7\x90e\x8f\xb2\x00F" +
-	"d\xba*\xaf\xcd!|\x86,\x9f\xcb\x02\xb8*G\xa9" +
-	"=\x0e\x111p\xd7F\x9a\x18\xd2\x84\x0f\xa3~\xe8D" +
-	"\xa4O\xfc\x81\xe7m:C\x18\xcb\x8e\x9f\x7f'v\xfc" +
-	"u\xe7)\x00\x00\xff\xff\x12\xacx\x8f"
+type SIGPrefixAnnounce struct{ capnp.Struct }
+
+// SIGPrefixAnnounce_TypeID is the unique identifier for the type SIGPrefixAnnounce.
+const SIGPrefixAnnounce_TypeID = 0xba1ec5d95807aedd
+
+func NewSIGPrefixAnnounce(s *capnp.Segment) (SIGPrefixAnnounce, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 1})
+	return SIGPrefixAnnounce{st}, err
+}
+
+func NewRootSIGPrefixAnnounce(s *capnp.Segment) (SIGPrefixAnnounce, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 1})
+	return SIGPrefixAnnounce{st}, err
+}
+
+func ReadRootSIGPrefixAnnounce(msg *capnp.Message) (SIGPrefixAnnounce, error) {
+	root, err := msg.RootPtr()
+	return SIGPrefixAnnounce{root.Struct()}, err
+}
+
+func (s SIGPrefixAnnounce) String() string {
+	str, _ := text.Marshal(0xba1ec5d95807aedd, s.Struct)
+	return str
+}
+
+func (s SIGPrefixAnnounce) Version() uint64 {
+	return s.Struct.Uint64(0)
+}
+
+func (s SIGPrefixAnnounce) SetVersion(v uint64) {
+	s.Struct.SetUint64(0, v)
+}
+
+func (s SIGPrefixAnnounce) Lifetime() uint32 {
+	return s.Struct.Uint32(8)
+}
+
+func (s SIGPrefixAnnounce) SetLifetime(v uint32) {
+	s.Struct.SetUint32(8, v)
+}
+
+func (s SIGPrefixAnnounce) Prefixes() (SIGPrefix_List, error) {
+	p, err := s.Struct.Ptr(0)
+	return SIGPrefix_List{List: p.List()}, err
+}
+
+func (s SIGPrefixAnnounce) HasPrefixes() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s SIGPrefixAnnounce) SetPrefixes(v SIGPrefix_List) error {
+	return s.Struct.SetPtr(0, v.List.ToPtr())
+}
+
+// NewPrefixes sets the prefixes field to a newly
+// allocated SIGPrefix_List, preferring placement in s's segment.
+func (s SIGPrefixAnnounce) NewPrefixes(n int32) (SIGPrefix_List, error) {
+	l, err := NewSIGPrefix_List(s.Struct.Segment(), n)
+	if err != nil {
+		return SIGPrefix_List{}, err
+	}
+	err = s.Struct.SetPtr(0, l.List.ToPtr())
+	return l, err
+}
+
+// SIGPrefixAnnounce_List is a list of SIGPrefixAnnounce.
+type SIGPrefixAnnounce_List struct{ capnp.List }
+
+// NewSIGPrefixAnnounce creates a new list of SIGPrefixAnnounce.
+func NewSIGPrefixAnnounce_List(s *capnp.Segment, sz int32) (SIGPrefixAnnounce_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 16, PointerCount: 1}, sz)
+	return SIGPrefixAnnounce_List{l}, err
+}
+
+func (s SIGPrefixAnnounce_List) At(i int) SIGPrefixAnnounce {
+	return SIGPrefixAnnounce{s.List.Struct(i)}
+}
+
+func (s SIGPrefixAnnounce_List) Set(i int, v SIGPrefixAnnounce) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s SIGPrefixAnnounce_List) String() string {
+	str, _ := text.MarshalList(0xba1ec5d95807aedd, s.List)
+	return str
+}
+
+// SIGPrefixAnnounce_Promise is a wrapper for a SIGPrefixAnnounce promised by a client call.
+type SIGPrefixAnnounce_Promise struct{ *capnp.Pipeline }
+
+func (p SIGPrefixAnnounce_Promise) Struct() (SIGPrefixAnnounce, error) {
+	s, err := p.Pipeline.Struct()
+	return SIGPrefixAnnounce{s}, err
+}
+
+type SIGPrefix struct{ capnp.Struct }
+
+// SIGPrefix_TypeID is the unique identifier for the type SIGPrefix.
+const SIGPrefix_TypeID = 0xf7b4413c3b5cec08
+
+func NewSIGPrefix(s *capnp.Segment) (SIGPrefix, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return SIGPrefix{st}, err
+}
+
+func NewRootSIGPrefix(s *capnp.Segment) (SIGPrefix, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return SIGPrefix{st}, err
+}
+
+func ReadRootSIGPrefix(msg *capnp.Message) (SIGPrefix, error) {
+	root, err := msg.RootPtr()
+	return SIGPrefix{root.Struct()}, err
+}
+
+func (s SIGPrefix) String() string {
+	str, _ := text.Marshal(0xf7b4413c3b5cec08, s.Struct)
+	return str
+}
+
+func (s SIGPrefix) Ip() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return []byte(p.Data()), err
+}
+
+func (s SIGPrefix) HasIp() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s SIGPrefix) SetIp(v []byte) error {
+	return s.Struct.SetData(0, v)
+}
+
+func (s SIGPrefix) Length() uint8 {
+	return s.Struct.Uint8(0)
+}
+
+func (s SIGPrefix) SetLength(v uint8) {
+	s.Struct.SetUint8(0, v)
+}
+
+// SIGPrefix_List is a list of SIGPrefix.
+type SIGPrefix_List struct{ capnp.List }
+
+// NewSIGPrefix creates a new list of SIGPrefix.
+func NewSIGPrefix_List(s *capnp.Segment, sz int32) (SIGPrefix_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1}, sz)
+	return SIGPrefix_List{l}, err
+}
+
+func (s SIGPrefix_List) At(i int) SIGPrefix { return SIGPrefix{s.List.Struct(i)} }
+
+func (s SIGPrefix_List) Set(i int, v SIGPrefix) error { return s.List.SetStruct(i, v.Struct) }
+
+func (s SIGPrefix_List) String() string {
+	str, _ := text.MarshalList(0xf7b4413c3b5cec08, s.List)
+	return str
+}
+
+// SIGPrefix_Promise is a wrapper for a SIGPrefix promised by a client call.
+type SIGPrefix_Promise struct{ *capnp.Pipeline }
+
+func (p SIGPrefix_Promise) Struct() (SIGPrefix, error) {
+	s, err := p.Pipeline.Struct()
+	return SIGPrefix{s}, err
+}
+
+type SIGKeyAnnounce struct{ capnp.Struct }
+
+// SIGKeyAnnounce_TypeID is the unique identifier for the type SIGKeyAnnounce.
+const SIGKeyAnnounce_TypeID = 0xade9363dc3828ba6
+
+func NewSIGKeyAnnounce(s *capnp.Segment) (SIGKeyAnnounce, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 3})
+	return SIGKeyAnnounce{st}, err
+}
+
+func NewRootSIGKeyAnnounce(s *capnp.Segment) (SIGKeyAnnounce, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 3})
+	return SIGKeyAnnounce{st}, err
+}
+
+func ReadRootSIGKeyAnnounce(msg *capnp.Message) (SIGKeyAnnounce, error) {
+	root, err := msg.RootPtr()
+	return SIGKeyAnnounce{root.Struct()}, err
+}
+
+func (s SIGKeyAnnounce) String() string {
+	str, _ := text.Marshal(0xade9363dc3828ba6, s.Struct)
+	return str
+}
+
+func (s SIGKeyAnnounce) Addr() (SIGAddr, error) {
+	p, err := s.Struct.Ptr(0)
+	return SIGAddr{Struct: p.Struct()}, err
+}
+
+func (s SIGKeyAnnounce) HasAddr() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s SIGKeyAnnounce) SetAddr(v SIGAddr) error {
+	return s.Struct.SetPtr(0, v.Struct.ToPtr())
+}
+
+// NewAddr sets the addr field to a newly
+// allocated SIGAddr struct, preferring placement in s's segment.
+func (s SIGKeyAnnounce) NewAddr() (SIGAddr, error) {
+	ss, err := NewSIGAddr(s.Struct.Segment())
+	if err != nil {
+		return SIGAddr{}, err
+	}
+	err = s.Struct.SetPtr(0, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s SIGKeyAnnounce) Session() uint8 {
+	return s.Struct.Uint8(0)
+}
+
+func (s SIGKeyAnnounce) SetSession(v uint8) {
+	s.Struct.SetUint8(0, v)
+}
+
+func (s SIGKeyAnnounce) Epoch() uint16 {
+	return s.Struct.Uint16(2)
+}
+
+func (s SIGKeyAnnounce) SetEpoch(v uint16) {
+	s.Struct.SetUint16(2, v)
+}
+
+func (s SIGKeyAnnounce) SrcChainVersion() uint64 {
+	return s.Struct.Uint64(8)
+}
+
+func (s SIGKeyAnnounce) SetSrcChainVersion(v uint64) {
+	s.Struct.SetUint64(8, v)
+}
+
+func (s SIGKeyAnnounce) DstChainVersion() uint64 {
+	return s.Struct.Uint64(16)
+}
+
+func (s SIGKeyAnnounce) SetDstChainVersion(v uint64) {
+	s.Struct.SetUint64(16, v)
+}
+
+func (s SIGKeyAnnounce) Nonce() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return []byte(p.Data()), err
+}
+
+func (s SIGKeyAnnounce) HasNonce() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s SIGKeyAnnounce) SetNonce(v []byte) error {
+	return s.Struct.SetData(1, v)
+}
+
+func (s SIGKeyAnnounce) Key() ([]byte, error) {
+	p, err := s.Struct.Ptr(2)
+	return []byte(p.Data()), err
+}
+
+func (s SIGKeyAnnounce) HasKey() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s SIGKeyAnnounce) SetKey(v []byte) error {
+	return s.Struct.SetData(2, v)
+}
+
+// SIGKeyAnnounce_List is a list of SIGKeyAnnounce.
+type SIGKeyAnnounce_List struct{ capnp.List }
+
+// NewSIGKeyAnnounce creates a new list of SIGKeyAnnounce.
+func NewSIGKeyAnnounce_List(s *capnp.Segment, sz int32) (SIGKeyAnnounce_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 24, PointerCount: 3}, sz)
+	return SIGKeyAnnounce_List{l}, err
+}
+
+func (s SIGKeyAnnounce_List) At(i int) SIGKeyAnnounce { return SIGKeyAnnounce{s.List.Struct(i)} }
+
+func (s SIGKeyAnnounce_List) Set(i int, v SIGKeyAnnounce) error { return s.List.SetStruct(i, v.Struct) }
+
+func (s SIGKeyAnnounce_List) String() string {
+	str, _ := text.MarshalList(0xade9363dc3828ba6, s.List)
+	return str
+}
+
+// SIGKeyAnnounce_Promise is a wrapper for a SIGKeyAnnounce promised by a client call.
+type SIGKeyAnnounce_Promise struct{ *capnp.Pipeline }
+
+func (p SIGKeyAnnounce_Promise) Struct() (SIGKeyAnnounce, error) {
+	s, err := p.Pipeline.Struct()
+	return SIGKeyAnnounce{s}, err
+}
+
+func (p SIGKeyAnnounce_Promise) Addr() SIGAddr_Promise {
+	return SIGAddr_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
+}
+
+type SIGKeyAck struct{ capnp.Struct }
+
+// SIGKeyAck_TypeID is the unique identifier for the type SIGKeyAck.
+const SIGKeyAck_TypeID = 0xa22480e8c3c4b36b
+
+func NewSIGKeyAck(s *capnp.Segment) (SIGKeyAck, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return SIGKeyAck{st}, err
+}
+
+func NewRootSIGKeyAck(s *capnp.Segment) (SIGKeyAck, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return SIGKeyAck{st}, err
+}
+
+func ReadRootSIGKeyAck(msg *capnp.Message) (SIGKeyAck, error) {
+	root, err := msg.RootPtr()
+	return SIGKeyAck{root.Struct()}, err
+}
+
+func (s SIGKeyAck) String() string {
+	str, _ := text.Marshal(0xa22480e8c3c4b36b, s.Struct)
+	return str
+}
+
+func (s SIGKeyAck) Session() uint8 {
+	return s.Struct.Uint8(0)
+}
+
+func (s SIGKeyAck) SetSession(v uint8) {
+	s.Struct.SetUint8(0, v)
+}
+
+func (s SIGKeyAck) Epoch() uint16 {
+	return s.Struct.Uint16(2)
+}
+
+func (s SIGKeyAck) SetEpoch(v uint16) {
+	s.Struct.SetUint16(2, v)
+}
+
+func (s SIGKeyAck) Mac() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return []byte(p.Data()), err
+}
+
+func (s SIGKeyAck) HasMac() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s SIGKeyAck) SetMac(v []byte) error {
+	return s.Struct.SetData(0, v)
+}
+
+// SIGKeyAck_List is a list of SIGKeyAck.
+type SIGKeyAck_List struct{ capnp.List }
+
+// NewSIGKeyAck creates a new list of SIGKeyAck.
+func NewSIGKeyAck_List(s *capnp.Segment, sz int32) (SIGKeyAck_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1}, sz)
+	return SIGKeyAck_List{l}, err
+}
+
+func (s SIGKeyAck_List) At(i int) SIGKeyAck { return SIGKeyAck{s.List.Struct(i)} }
+
+func (s SIGKeyAck_List) Set(i int, v SIGKeyAck) error { return s.List.SetStruct(i, v.Struct) }
+
+func (s SIGKeyAck_List) String() string {
+	str, _ := text.MarshalList(0xa22480e8c3c4b36b, s.List)
+	return str
+}
+
+// SIGKeyAck_Promise is a wrapper for a SIGKeyAck promised by a client call.
+type SIGKeyAck_Promise struct{ *capnp.Pipeline }
+
+func (p SIGKeyAck_Promise) Struct() (SIGKeyAck, error) {
+	s, err := p.Pipeline.Struct()
+	return SIGKeyAck{s}, err
+}
+
+const schema_8273379c3e06a721 = "x\xda\x8c\x94M\x88\x1cU\x10\xc7\xeb_\xaf\xa7{\x12" +
+	"\x9cd\x9a\x9e\x8bKb\\\xd9\xc5$\x10\x89\x1b?`" +
+	"5n&1h\xfc\x80y*\xe2A\x85\xb1\xe7m\xb6" +
+	"\x99\xde\x9eI\xf7\xc4$\x82\xac.x\xf2 \xac7\xf5" +
+	"\xb4\x88\x9e4~D0\x92@$\x1bH$\x81\x04\x14" +
+	"\x15\xcc!\x9e\x14\xbcx\x124\xf1\xc9\x9b\xee\x99\x1eg" +
+	"&\xe2\xedQU]\xf5\xaf\xaa_\xd7N\x9b\xf7\xf0\xdd" +
+	"\x85\x16\x13\xc9M\x05[W\xeb\xef\xdf\xcb\xb3\xdf\xbfC" +
+	"r=\xa0'?\xb4\x1fz\xef\xfed\x99\x0ap\x88\xbc" +
+	"\xcdX\xf1\xa6\xbb\xafI\x1c!\xe8\xe6\xe7kg\x7fy" +
+	"mjul\xf0\xabX\xf5\xde\xe8\xbe^\xc7q\x82\xfe" +
+	"\xe0\xcd\xe5\xb3\xbb\xef\xfb\xf5#\x13,\x06\x82\x85\x09\xb9" +
+	"\x95\xbf\xf1\xa6\xd9!\xda5\xc9o\x81\xa0\xaf~\xec<" +
+	"\xf7\xe3\xb9\xdb\xbe2\xd1<\x9c\xfaS\xf1\xb3w\xba\xfb" +
+	"\xddIq\x9cpc\xeb\xbb\x9b\xaf]\xff\xfd\xaa\xbb~" +
+	"P\x84\xc9\xe6\x05\xd6\x8aw\xc82\xafE\xcb(.\xee" +
+	"\x9eI\xb6M\xbdxm(\xed~86,\xef\x82\xb5" +
+	"\xe2]1\xd1\xbb.Y]\x15\xc5\xdf\x9e\x7f\xe0\xc1\xea" +
+	"\x89?\xc66\xf8\x82\xbd\xea)\xdb\xbc\xea\xb6\xc9\x9d\x04" +
+	"\x07\xef\xf2\xeb\xed\x08\xed\xd9\xa7\x0f<Rk\x85\x08k" +
+	"\x80,\x0a\x8b\xc8\x02\x91\xbbm;\x91\x9c\x12\x90;\x19" +
+	"@\x05\xc6\xb6c/\x91\xdc* \xefal\xac7\x1a" +
+	"1\xca\xbdv\x08(\x13\x96\x12\x95$A+\x82M\x0c" +
+	"{\xa4\xcc\xe3\xea\x98S\xf5\x9b\xa6\xd0-\xfdB\xfbM" +
+	"\xd2=\x02\xf2\x09\x86\xdb\xabt`\x86H>, k" +
+	"\x0cp\x05L\xe4>y\x07\x91|T@>\xc3#\x85" +
+	"\xb6\xa8v\xcb_\x80C\x0c\x87\xe0,\xd6}\x94\x88Q" +
+	"\x1a\x90\xc0=\x09\xd5(j\x1d\x8e|Ed\x84l\xea" +
+	"\x0b\xf9\xc2t\xfc\x89\x80<\x95w|\xd2\x88;! " +
+	"\xcf0\\F*\xe4\xb4\x11\xf7\xa5\x80\\c\xb8\x02\x15" +
+	"\x08\"\xf7\xebe\"yF@^d\xb8\x16W`\x11" +
+	"\xb9\x17\x8c\xf1\xbc\x80\xfc\x96\xe1\x16PA\x81\xc8\xbdb" +
+	">\xbf( \x7f`\xb86W`\x13\xb9\xdf\x99\xe6." +
+	"\x0b\xc8\x9f\xfe\xe7h\xff\xdd\xb1Nb\x7f\xdfB=\x88" +
+	"\xf0\xac\x8aM\x18a\x1d1\xd6\x11t#\xe9\x8c\xf7l" +
+	"\x89Z\x91\xafzsr\x9a\xea\xd8MfV\x8b\xd5|" +
+	"p\xb4;6'\xf2\xd5\x7f\xad\x8f\xb3\xf5=\x96\xaf\xaa" +
+	"\xb7>il5\x01\x192\x96^N\xb5\xf4E\x86\xc1" +
+	"\xbc\xea\x04\x8b\x8a\x88P$F\x91\xa0\xdb\xdd\xb2*1" +
+	"\xb6\x0d\x84\x9a\x00\xca9\xe4\x04l\x18P\x9a\x02Vm" +
+	"4\x10\xdf\x9c\xe3>^;\xb6\x0f\x80\xecw\xe2\x10e" +
+	"}\xfb\xf4\xdbG\x0awN|F\xe9\xbc76\xea\x9d" +
+	"\xfa\xa8y\xa8\xe0\xbeN\x8cp\x08\xa3\x89\x1c\xa3\x12\xb4" +
+	"\xce@\x9a\xc9A*\xf1\xdf:#ioNRI\xdc" +
+	"\xd0\x19J\xc6zJ@\x9eg\x94\xac\xeb:e\xe9\xdc" +
+	"+DrM@^f\x94\x0a\x7f\xe9\x14\xa6K/\xe5" +
+	"0\x95\xec?uF\xd3lN\x93\x08\x1a\xfd\x8d\x1f\x8e" +
+	"\x12\xd5!{\xa9\xdd\x0a\xc3\xa7\xd4!\x94\xf3\x13\x9aQ" +
+	"\x96z\xda\xa3\x9el\x1d\xd5\x88\xe6\xd2\xdf\x07\xe5\xfc\xee" +
+	"e!\xcd\xec\xe7\"'\xf5\xf7\xafh\xea\x9f3~\xbf" +
+	"\x89r~\x8b\xc7\x0e\xb5\x16+g>8:\xb4\xc7\x89" +
+	"1\xf7h6_\xa3\x08\xda=|\xe7B\x15\x1d\xec," +
+	"\xf4\xfe\x94\x7f\x06\x00\xe8cjj"
 
 func init() {
 	schemas.Register(schema_8273379c3e06a721,
 		0x9ad73a0235a46141,
+		0xa22480e8c3c4b36b,
+		0xade9363dc3828ba6,
+		0xba1ec5d95807aedd,
 		0xddf1fce11d9b0028,
-		0xe15e242973323d08)
+		0xe15e242973323d08,
+		0xf7b4413c3b5cec08)
 }
@@ -0,0 +1,101 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/sock/reliable"
+	"github.com/scionproto/scion/go/pkg/ping"
+)
+
+var mtuFlags struct {
+	probeFlags
+	attempts int
+	max      int
+}
+
+var mtuCmd = &cobra.Command{
+	Use:   "mtu [flags] <remote>",
+	Short: "Discover the MTU of the path to a remote SCION host",
+	Args:  cobra.ExactArgs(1),
+	Example: `  scion mtu 1-ff00:0:110,10.0.0.1
+  scion mtu 1-ff00:0:110,10.0.0.1 --max 9000 --json`,
+	Long: `'mtu' discovers the size of the largest SCION packet that reaches a remote
+SCION host, by sending SCMP echo requests of different sizes.
+
+The search is bounded by the MTU announced in the path metadata, or by --max if
+it is set. The discovered MTU is a multiple of 8 bytes.
+
+The path to the remote host is the first path returned by the SCION Daemon that
+matches the --sequence, see doc/PathPolicy.md for the syntax of the sequence.
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		// Only critical log messages of the libraries are shown.
+		log.Setup(log.Config{Console: log.ConsoleConfig{Level: "crit"}})
+
+		ctx, cancel := interruptContext()
+		defer cancel()
+		resolveCtx, resolveCancel := context.WithTimeout(ctx, resolveTimeout)
+		defer resolveCancel()
+		target, err := resolveTarget(resolveCtx, args[0], mtuFlags.probeFlags)
+		if err != nil {
+			return err
+		}
+		max := mtuFlags.max
+		if max == 0 && target.path != nil {
+			max = int(target.path.MTU())
+		}
+		if max == 0 {
+			max = common.MaxMTU
+		}
+		if !mtuFlags.json && target.path != nil {
+			fmt.Printf("Using path:\n  %s\n\n", target.path)
+		}
+		res, err := ping.DiscoverMTU(ctx, ping.Config{
+			Dispatcher: reliable.NewDispatcher(mtuFlags.dispatcher),
+			LocalIA:    target.localIA,
+			LocalIP:    target.localIP,
+			Remote:     target.remote,
+			Attempts:   mtuFlags.attempts,
+			Timeout:    mtuFlags.timeout,
+		}, max)
+		if err != nil {
+			return err
+		}
+		if mtuFlags.json {
+			return res.JSON(os.Stdout)
+		}
+		res.Human(os.Stdout)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(mtuCmd)
+	mtuFlags.register(mtuCmd)
+	mtuCmd.Flags().IntVar(&mtuFlags.attempts, "attempts", 3,
+		"Number of echo requests sent for every packet size")
+	mtuCmd.Flags().IntVar(&mtuFlags.max, "max", 0,
+		"Upper bound of the MTU in bytes. If zero, the MTU of the path is used")
+}
@@ -0,0 +1,109 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/sock/reliable"
+	"github.com/scionproto/scion/go/pkg/ping"
+)
+
+var pingFlags struct {
+	probeFlags
+	count    int
+	interval time.Duration
+	size     int
+}
+
+var pingCmd = &cobra.Command{
+	Use:   "ping [flags] <remote>",
+	Short: "Test connectivity to a remote SCION host using SCMP echo packets",
+	Args:  cobra.ExactArgs(1),
+	Example: `  scion ping 1-ff00:0:110,10.0.0.1
+  scion ping 1-ff00:0:110,10.0.0.1 -c 5 --json
+  scion ping 1-ff00:0:110,10.0.0.1 --sequence "1-ff00:0:111#0 1-ff00:0:110#0"`,
+	Long: `'ping' sends SCMP echo requests to a remote SCION host, and reports the
+round trip time of every reply. When all requests are done, or the command is
+interrupted, the statistics are printed.
+
+The path to the remote host is the first path returned by the SCION Daemon that
+matches the --sequence, see doc/PathPolicy.md for the syntax of the sequence.
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if pingFlags.count < 0 || pingFlags.count > 1<<16-1 {
+			return serrors.New("count out of range", "count", pingFlags.count,
+				"min", 0, "max", 1<<16-1)
+		}
+		cmd.SilenceUsage = true
+
+		// Only critical log messages of the libraries are shown.
+		log.Setup(log.Config{Console: log.ConsoleConfig{Level: "crit"}})
+
+		ctx, cancel := interruptContext()
+		defer cancel()
+		resolveCtx, resolveCancel := context.WithTimeout(ctx, resolveTimeout)
+		defer resolveCancel()
+		target, err := resolveTarget(resolveCtx, args[0], pingFlags.probeFlags)
+		if err != nil {
+			return err
+		}
+		cfg := ping.Config{
+			Dispatcher: reliable.NewDispatcher(pingFlags.dispatcher),
+			LocalIA:    target.localIA,
+			LocalIP:    target.localIP,
+			Remote:     target.remote,
+			Attempts:   pingFlags.count,
+			Interval:   pingFlags.interval,
+			Timeout:    pingFlags.timeout,
+			PacketSize: pingFlags.size,
+		}
+		if !pingFlags.json {
+			if target.path != nil {
+				fmt.Printf("Using path:\n  %s\n\n", target.path)
+			}
+			cfg.UpdateHandler = func(u ping.Update) {
+				fmt.Println(u)
+			}
+		}
+		res, err := ping.Run(ctx, cfg)
+		if err != nil {
+			return err
+		}
+		if pingFlags.json {
+			return res.JSON(os.Stdout)
+		}
+		res.Human(os.Stdout)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(pingCmd)
+	pingFlags.register(pingCmd)
+	pingCmd.Flags().IntVarP(&pingFlags.count, "count", "c", 0,
+		"Number of echo requests to send. If zero, send until interrupted")
+	pingCmd.Flags().DurationVar(&pingFlags.interval, "interval", ping.DefaultInterval,
+		"Time between two echo requests")
+	pingCmd.Flags().IntVarP(&pingFlags.size, "size", "s", 0,
+		"Size of the SCION packets in bytes. If zero, the packets are as small as possible")
+}
@@ -0,0 +1,115 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/scionproto/scion/go/lib/pathpol"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+var policyLintFlags struct {
+	json   bool
+	strict bool
+}
+
+var policyCmd = &cobra.Command{
+	Use:   "policy",
+	Short: "Work with path policies",
+	Args:  cobra.NoArgs,
+}
+
+var policyLintCmd = &cobra.Command{
+	Use:   "lint <file>...",
+	Short: "Check path policy files for problems",
+	Args:  cobra.MinimumNArgs(1),
+	Example: `  scion policy lint policies.json
+  scion policy lint --strict --json policies.json`,
+	Long: `'lint' checks files containing a JSON map of named path policies.
+
+It reports errors, such as references to policies that do not exist, circular
+extensions, ACLs without a default entry, and sequences that require hops that
+are denied by the ACL. It also reports warnings, such as ACL entries that are
+shadowed by a catch-all entry, and options that are never evaluated.
+
+The command fails if any error is found. With --strict, it also fails on
+warnings.
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		failed := false
+		var all []fileDiagnostic
+		for _, file := range args {
+			diags, err := lintPolicyFile(file)
+			if err != nil {
+				return err
+			}
+			if diags.HasErrors() || (policyLintFlags.strict && len(diags) > 0) {
+				failed = true
+			}
+			for _, d := range diags {
+				all = append(all, fileDiagnostic{File: file, Diagnostic: d})
+			}
+		}
+		if policyLintFlags.json {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "    ")
+			enc.SetEscapeHTML(false)
+			if err := enc.Encode(all); err != nil {
+				return err
+			}
+		} else {
+			for _, d := range all {
+				fmt.Printf("%s: %s\n", d.File, d.Diagnostic)
+			}
+		}
+		if failed {
+			return serrors.New("policy lint failed", "diagnostics", len(all))
+		}
+		return nil
+	},
+}
+
+type fileDiagnostic struct {
+	File string `json:"file"`
+	pathpol.Diagnostic
+}
+
+func lintPolicyFile(file string) (pathpol.Diagnostics, error) {
+	raw, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, serrors.WrapStr("unable to read policy file", err, "file", file)
+	}
+	var policies pathpol.PolicyMap
+	if err := json.Unmarshal(raw, &policies); err != nil {
+		return nil, serrors.WrapStr("unable to parse policy file", err, "file", file)
+	}
+	return policies.Lint(), nil
+}
+
+func init() {
+	rootCmd.AddCommand(policyCmd)
+	policyCmd.AddCommand(policyLintCmd)
+	policyLintCmd.Flags().BoolVarP(&policyLintFlags.json, "json", "j", false,
+		"Write the diagnostics as machine readable json")
+	policyLintCmd.Flags().BoolVar(&policyLintFlags.strict, "strict", false,
+		"Fail on warnings")
+}
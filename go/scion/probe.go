@@ -0,0 +1,166 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/pathpol"
+	"github.com/scionproto/scion/go/lib/sciond"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/snet/addrutil"
+	"github.com/scionproto/scion/go/lib/sock/reliable"
+	"github.com/scionproto/scion/go/lib/topology"
+)
+
+// resolveTimeout is the time allowed for choosing the path to the remote host.
+const resolveTimeout = 5 * time.Second
+
+// probeFlags are the flags shared by the commands that send SCMP probes to a
+// remote host.
+type probeFlags struct {
+	sciond     string
+	dispatcher string
+	local      net.IP
+	sequence   string
+	refresh    bool
+	timeout    time.Duration
+	json       bool
+}
+
+func (f *probeFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.sciond, "sciond", sciond.DefaultSCIONDAddress,
+		"SCION Deamon address")
+	cmd.Flags().StringVar(&f.dispatcher, "dispatcher", reliable.DefaultDispPath,
+		"Path to the dispatcher socket")
+	cmd.Flags().IPVarP(&f.local, "local", "l", nil,
+		"Optional local IP address. If not set, it is derived from the path")
+	cmd.Flags().StringVar(&f.sequence, "sequence", "",
+		"Space separated list of hop predicates the path must match, see doc/PathPolicy.md")
+	cmd.Flags().BoolVarP(&f.refresh, "refresh", "r", false,
+		"Set refresh flag for SCION Deamon path request")
+	cmd.Flags().DurationVar(&f.timeout, "timeout", 2*time.Second,
+		"Time to wait for a reply")
+	cmd.Flags().BoolVarP(&f.json, "json", "j", false,
+		"Write the output as machine readable json")
+}
+
+// probeTarget is the remote host together with the path to reach it.
+type probeTarget struct {
+	localIA addr.IA
+	localIP net.IP
+	// remote is the remote host, with path and next hop set.
+	remote *snet.UDPAddr
+	// path is the path to the remote host. It is nil if the remote host is in
+	// the local AS.
+	path snet.Path
+}
+
+// resolveTarget parses the remote address, and chooses a path to it that
+// matches the sequence.
+func resolveTarget(ctx context.Context, rawRemote string, f probeFlags) (*probeTarget, error) {
+	remote, err := snet.ParseUDPAddr(rawRemote)
+	if err != nil {
+		return nil, serrors.WrapStr("invalid remote address", err)
+	}
+	sdConn, err := sciond.NewService(f.sciond).Connect(ctx)
+	if err != nil {
+		return nil, serrors.WrapStr("error connecting to SCIOND", err)
+	}
+	defer sdConn.Close(ctx)
+	localIA, err := sdConn.LocalIA(ctx)
+	if err != nil {
+		return nil, serrors.WrapStr("error determining local ISD-AS", err)
+	}
+	t := &probeTarget{localIA: localIA, remote: remote}
+	if remote.IA.Equal(localIA) {
+		remote.Path = nil
+		remote.NextHop = &net.UDPAddr{IP: remote.Host.IP, Port: topology.EndhostPort}
+	} else {
+		if t.path, err = choosePath(ctx, sdConn, remote.IA, f); err != nil {
+			return nil, err
+		}
+		remote.Path = t.path.Path()
+		remote.NextHop = t.path.UnderlayNextHop()
+	}
+	if t.localIP = f.local; t.localIP == nil {
+		if t.localIP, err = addrutil.ResolveLocal(remote.NextHop.IP); err != nil {
+			return nil, serrors.WrapStr("failed to determine local IP", err)
+		}
+	}
+	return t, nil
+}
+
+// choosePath returns the first path to the destination that matches the
+// sequence. If the sequence is empty, the first path is returned.
+func choosePath(ctx context.Context, sdConn sciond.Connector, dst addr.IA,
+	f probeFlags) (snet.Path, error) {
+
+	paths, err := sdConn.Paths(ctx, dst, addr.IA{}, sciond.PathReqFlags{Refresh: f.refresh})
+	if err != nil {
+		return nil, serrors.WrapStr("failed to retrieve paths from SCIOND", err)
+	}
+	if f.sequence == "" {
+		if len(paths) == 0 {
+			return nil, serrors.New("no path found", "destination", dst)
+		}
+		return paths[0], nil
+	}
+	seq, err := pathpol.NewSequence(f.sequence)
+	if err != nil {
+		return nil, serrors.WrapStr("invalid sequence", err)
+	}
+	pathSet := make(pathpol.PathSet, len(paths))
+	for _, path := range paths {
+		pathSet[path.Fingerprint()] = path
+	}
+	matching := seq.Eval(pathSet)
+	// Keep the order of SCIOND, the paths are ordered by preference.
+	for _, path := range paths {
+		if _, ok := matching[path.Fingerprint()]; ok {
+			return path, nil
+		}
+	}
+	return nil, serrors.New("no path matches the sequence", "destination", dst,
+		"sequence", f.sequence)
+}
+
+// interruptContext returns a context that is cancelled when the process
+// receives SIGINT or SIGTERM.
+func interruptContext() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		defer log.HandlePanic()
+		select {
+		case <-sig:
+			cancel()
+		case <-ctx.Done():
+		}
+		signal.Stop(sig)
+	}()
+	return ctx, cancel
+}
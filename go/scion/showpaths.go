@@ -16,6 +16,8 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"io/ioutil"
 	"os"
 	"time"
 
@@ -23,6 +25,7 @@ import (
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/pathpol"
 	"github.com/scionproto/scion/go/lib/sciond"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/pkg/showpaths"
@@ -33,6 +36,7 @@ var showpathsFlags struct {
 	cfg        showpaths.Config
 	expiration bool
 	json       bool
+	policy     string
 }
 
 var showpathsCmd = &cobra.Command{
@@ -42,7 +46,8 @@ var showpathsCmd = &cobra.Command{
 	Args:    cobra.ExactArgs(1),
 	Example: `  scion showpaths 1-ff00:0:110 --expiration
   scion showpaths 1-ff00:0:110 --local 127.0.0.55 --json
-  scion showpaths 1-ff00:0:110 --no-probe`,
+  scion showpaths 1-ff00:0:110 --no-probe
+  scion showpaths 1-ff00:0:110 --policy policy.json`,
 	Long: `'showpaths' lists available paths between the local and the specified SCION ASe a.
 
 By default, the paths are probed. Paths served from the SCION Deamon's might not
@@ -50,6 +55,10 @@ forward traffic successfully (e.g. if a network link went down, or there is a bl
 hole on the path). To disable path probing, set the appropriate flag.
 
 'showpaths' can be instructed to output the paths as json using the the --json flag.
+
+If a path policy file is provided with the --policy flag, every path is annotated
+with the policy decision, and the reason for it. The file contains a single policy
+in JSON format, see doc/PathPolicy.md.
 `,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		dst, err := addr.IAFromString(args[0])
@@ -63,6 +72,14 @@ hole on the path). To disable path probing, set the appropriate flag.
 		// See https://github.com/spf13/cobra/issues/340
 		cmd.SilenceUsage = true
 
+		if showpathsFlags.policy != "" {
+			policy, err := loadPolicy(showpathsFlags.policy)
+			if err != nil {
+				return err
+			}
+			showpathsFlags.cfg.Policy = policy
+		}
+
 		// FIXME(roosd): This practically turns of logging done in libraries. We
 		// should not have to do this.
 		log.Setup(log.Config{Console: log.ConsoleConfig{Level: "crit"}})
@@ -81,6 +98,18 @@ hole on the path). To disable path probing, set the appropriate flag.
 	},
 }
 
+func loadPolicy(file string) (*pathpol.Policy, error) {
+	raw, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, serrors.WrapStr("unable to read policy file", err, "file", file)
+	}
+	var policy pathpol.Policy
+	if err := json.Unmarshal(raw, &policy); err != nil {
+		return nil, serrors.WrapStr("unable to parse policy file", err, "file", file)
+	}
+	return pathpol.PolicyFromExtPolicy(&pathpol.ExtPolicy{Policy: &policy}, nil)
+}
+
 func init() {
 	rootCmd.AddCommand(showpathsCmd)
 	showpathsCmd.Flags().StringVar(&showpathsFlags.cfg.SCIOND, "sciond",
@@ -98,4 +127,6 @@ func init() {
 		"Write the output as machine readable json")
 	showpathsCmd.Flags().IPVarP(&showpathsFlags.cfg.Local, "local", "l", nil,
 		"Optional local IP address to use for probing health checks")
+	showpathsCmd.Flags().StringVar(&showpathsFlags.policy, "policy", "",
+		"Path policy file, explains the policy decision for every path")
 }
@@ -0,0 +1,98 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/sock/reliable"
+	"github.com/scionproto/scion/go/pkg/traceroute"
+)
+
+var tracerouteFlags struct {
+	probeFlags
+	probes int
+}
+
+var tracerouteCmd = &cobra.Command{
+	Use:     "traceroute [flags] <remote>",
+	Short:   "Trace the SCION route to a remote SCION host",
+	Aliases: []string{"tr"},
+	Args:    cobra.ExactArgs(1),
+	Example: `  scion traceroute 1-ff00:0:110,10.0.0.1
+  scion traceroute 1-ff00:0:110,10.0.0.1 --json
+  scion traceroute 1-ff00:0:110,10.0.0.1 --sequence "1-ff00:0:111#0 1-ff00:0:110#0"`,
+	Long: `'traceroute' sends SCMP traceroute requests to every interface on the path
+to a remote SCION host, and to the remote host itself. For every hop, the round
+trip time of every reply is printed.
+
+The path to the remote host is the first path returned by the SCION Daemon that
+matches the --sequence, see doc/PathPolicy.md for the syntax of the sequence.
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		// Only critical log messages of the libraries are shown.
+		log.Setup(log.Config{Console: log.ConsoleConfig{Level: "crit"}})
+
+		ctx, cancel := interruptContext()
+		defer cancel()
+		resolveCtx, resolveCancel := context.WithTimeout(ctx, resolveTimeout)
+		defer resolveCancel()
+		target, err := resolveTarget(resolveCtx, args[0], tracerouteFlags.probeFlags)
+		if err != nil {
+			return err
+		}
+		cfg := traceroute.Config{
+			Dispatcher:   reliable.NewDispatcher(tracerouteFlags.dispatcher),
+			LocalIA:      target.localIA,
+			LocalIP:      target.localIP,
+			Remote:       target.remote,
+			ProbesPerHop: tracerouteFlags.probes,
+			Timeout:      tracerouteFlags.timeout,
+		}
+		if target.path != nil {
+			cfg.Interfaces = target.path.Interfaces()
+		}
+		if !tracerouteFlags.json {
+			if target.path != nil {
+				fmt.Printf("Using path:\n  %s\n\n", target.path)
+			}
+			cfg.UpdateHandler = func(h traceroute.Hop) {
+				fmt.Println(h)
+			}
+		}
+		res, err := traceroute.Run(ctx, cfg)
+		if err != nil {
+			return err
+		}
+		if tracerouteFlags.json {
+			return res.JSON(os.Stdout)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(tracerouteCmd)
+	tracerouteFlags.register(tracerouteCmd)
+	tracerouteCmd.Flags().IntVar(&tracerouteFlags.probes, "probes",
+		traceroute.DefaultProbesPerHop, "Number of probes sent to every hop")
+}
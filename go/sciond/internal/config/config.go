@@ -98,6 +98,21 @@ type SDConfig struct {
 	// QueryInterval specifies after how much time segments
 	// for a destination should be refetched.
 	QueryInterval util.DurWrap `toml:"query_interval,omitempty"`
+	// PathPolicies is the JSON file containing the named path policies that
+	// clients can refer to in path requests. If empty, no named policies are
+	// available.
+	PathPolicies string `toml:"path_policies,omitempty"`
+	// LinkMetadata is the JSON file containing the static metadata of the
+	// inter-AS links, i.e., latency, bandwidth and location. It is used to
+	// evaluate path policies and is added to the paths in the replies. If
+	// empty, no link metadata is available.
+	LinkMetadata string `toml:"link_metadata,omitempty"`
+	// ProbeInterval is the interval at which the paths that are fetched
+	// are probed. If zero, paths are not probed.
+	ProbeInterval util.DurWrap `toml:"probe_interval,omitempty"`
+	// DropDeadPaths excludes dead paths from the replies, unless all paths
+	// are dead. It only has an effect, if paths are probed.
+	DropDeadPaths bool `toml:"drop_dead_paths,omitempty"`
 }
 
 func (cfg *SDConfig) InitDefaults() {
@@ -62,4 +62,8 @@ func CheckTestConfig(t *testing.T, cfg *Config, id string) {
 func CheckTestSDConfig(t *testing.T, cfg *SDConfig, id string) {
 	assert.Equal(t, sciond.DefaultSCIONDAddress, cfg.Address)
 	assert.Equal(t, DefaultQueryInterval, cfg.QueryInterval.Duration)
+	assert.Empty(t, cfg.PathPolicies)
+	assert.Empty(t, cfg.LinkMetadata)
+	assert.Zero(t, cfg.ProbeInterval.Duration)
+	assert.False(t, cfg.DropDeadPaths)
 }
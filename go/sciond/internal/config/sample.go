@@ -23,4 +23,23 @@ address = "127.0.0.1:30255"
 
 # The time after which segments for a destination are refetched. (default 5m)
 query_interval = "5m"
+
+# The JSON file containing the named path policies that clients can refer to in
+# path requests. (default "", no named policies are available)
+path_policies = ""
+
+# The JSON file containing the static metadata of the inter-AS links, keyed by
+# interface, e.g., "1-ff00:0:110#1". The metadata is used to evaluate the
+# latency, bandwidth and location attributes of path policies, and is returned
+# with the paths. (default "", no link metadata is available)
+link_metadata = ""
+
+# The interval at which the paths that are fetched are probed. The health
+# of a path is returned with the path, and dead paths are ordered last.
+# (default "0s", paths are not probed)
+probe_interval = "0s"
+
+# Exclude dead paths from the replies, unless all paths are dead. Only has an
+# effect, if paths are probed. (default false)
+drop_dead_paths = false
 `
@@ -19,6 +19,7 @@ package fetcher
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"net"
 	"time"
@@ -30,6 +31,7 @@ import (
 	"github.com/scionproto/scion/go/lib/infra/modules/segfetcher"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/pathdb"
+	"github.com/scionproto/scion/go/lib/pathpol"
 	"github.com/scionproto/scion/go/lib/revcache"
 	"github.com/scionproto/scion/go/lib/sciond"
 	"github.com/scionproto/scion/go/lib/serrors"
@@ -56,12 +58,19 @@ type Fetcher interface {
 }
 
 type fetcher struct {
-	pather segfetcher.Pather
-	config config.SDConfig
+	pather   segfetcher.Pather
+	config   config.SDConfig
+	policies pathpol.PolicyMap
+	links    pathpol.LinkMetadata
 }
 
+// NewFetcher creates a new fetcher. The policies are the named path policies
+// that path requests can refer to, they may be nil. The link metadata is used
+// to evaluate the path policies, and is added to the returned paths. It may be
+// nil.
 func NewFetcher(requestAPI segfetcher.RequestAPI, pathDB pathdb.PathDB, inspector infra.ASInspector,
 	verificationFactory infra.VerificationFactory, revCache revcache.RevCache, cfg config.SDConfig,
+	policies pathpol.PolicyMap, links pathpol.LinkMetadata,
 	topoProvider topology.Provider) Fetcher {
 
 	localIA := topoProvider.Get().IA()
@@ -87,14 +96,18 @@ func NewFetcher(requestAPI segfetcher.RequestAPI, pathDB pathdb.PathDB, inspecto
 				LocalInfo:        neverLocal{},
 			}.New(),
 		},
-		config: cfg,
+		config:   cfg,
+		policies: policies,
+		links:    links,
 	}
 }
 
 // GetPaths fulfills the path request described by req. GetPaths will attempt
 // to build paths at start, after earlyReplyInterval and at context expiration
 // (or whenever all background workers return). An earlyReplyInterval of 0
-// means no early reply attempt is made.
+// means no early reply attempt is made. If the request specifies a path policy
+// or an order, only the paths matching the policy are returned, in the
+// requested order.
 func (f *fetcher) GetPaths(ctx context.Context, req *sciond.PathReq,
 	earlyReplyInterval time.Duration) (*sciond.PathReply, error) {
 
@@ -111,6 +124,10 @@ func (f *fetcher) GetPaths(ctx context.Context, req *sciond.PathReq,
 		return &sciond.PathReply{ErrorCode: sciond.ErrorBadSrcIA},
 			serrors.New("Bad source AS", "src", req.Src.IA())
 	}
+	policy, err := f.policy(req.Flags)
+	if err != nil {
+		return &sciond.PathReply{ErrorCode: sciond.ErrorBadPolicy}, err
+	}
 	cPaths, err := f.pather.GetPaths(ctx, req.Dst.IA(), req.Flags.Refresh)
 	switch {
 	case err == nil:
@@ -122,6 +139,13 @@ func (f *fetcher) GetPaths(ctx context.Context, req *sciond.PathReq,
 	default:
 		return &sciond.PathReply{ErrorCode: sciond.ErrorInternal}, err
 	}
+	if policy != nil {
+		cPaths = Rank(cPaths, policy, pathpol.FilterOptions{LinkMetadata: f.links})
+		if len(cPaths) == 0 {
+			return &sciond.PathReply{ErrorCode: sciond.ErrorNoPaths},
+				serrors.New("no paths match the path policy", "policy", policy.Name)
+		}
+	}
 	var paths []sciond.PathReplyEntry
 	var errs serrors.List
 	for _, path := range cPaths {
@@ -144,6 +168,46 @@ func (f *fetcher) GetPaths(ctx context.Context, req *sciond.PathReq,
 	return &sciond.PathReply{ErrorCode: sciond.ErrorOk, Entries: paths}, nil
 }
 
+// policy returns the path policy of the request. An inline policy takes
+// precedence over a named policy. The order of the request overrides the order
+// of the policy. If the request neither specifies a policy nor an order, nil is
+// returned.
+func (f *fetcher) policy(flags sciond.PathReqFlags) (*pathpol.Policy, error) {
+	var policy *pathpol.Policy
+	switch {
+	case len(flags.Policy) > 0:
+		ext := &pathpol.ExtPolicy{Policy: &pathpol.Policy{}}
+		if err := json.Unmarshal(flags.Policy, ext.Policy); err != nil {
+			return nil, serrors.WrapStr("unable to parse path policy", err)
+		}
+		var err error
+		if policy, err = pathpol.PolicyFromExtPolicy(ext, nil); err != nil {
+			return nil, err
+		}
+	case flags.PolicyName != "":
+		var err error
+		if policy, err = f.policies.Resolve(flags.PolicyName); err != nil {
+			return nil, err
+		}
+	}
+	if len(flags.Order) == 0 {
+		return policy, nil
+	}
+	for _, key := range flags.Order {
+		// Validate the keys, they are not checked when decoding the request.
+		if err := new(pathpol.OrderKey).UnmarshalText([]byte(key)); err != nil {
+			return nil, err
+		}
+	}
+	ordered := &pathpol.Policy{}
+	if policy != nil {
+		// Copy the policy, such that named policies are not modified.
+		*ordered = *policy
+	}
+	ordered.Order = flags.Order
+	return ordered, nil
+}
+
 // translate returns a translated sciond.PathReplyEntry objects from the
 // combinator path.
 //
@@ -181,10 +245,30 @@ func (f *fetcher) translate(path *combinator.Path) (sciond.PathReplyEntry, error
 			ExpTime:    uint32(path.ComputeExpTime().Unix()),
 		},
 		HostInfo: hostinfo.FromUDPAddr(*nextHop),
+		LinkInfo: f.linkInfo(path.Interfaces),
 	}
 	return entry, nil
 }
 
+// linkInfo returns the link metadata of the interfaces. If the metadata of no
+// interface is known, nil is returned.
+func (f *fetcher) linkInfo(intfs []sciond.PathInterface) []sciond.PathLinkInfo {
+	if f.links == nil {
+		return nil
+	}
+	var known bool
+	infos := make([]sciond.PathLinkInfo, 0, len(intfs))
+	for _, intf := range intfs {
+		info, ok := f.links.Link(intf.IA(), intf.ID())
+		known = known || ok
+		infos = append(infos, sciond.NewPathLinkInfo(info))
+	}
+	if !known {
+		return nil
+	}
+	return infos
+}
+
 type dstProvider struct {
 	IA addr.IA
 }
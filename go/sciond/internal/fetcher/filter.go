@@ -17,6 +17,7 @@ package fetcher
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/scionproto/scion/go/lib/infra/modules/combinator"
 	"github.com/scionproto/scion/go/lib/pathpol"
@@ -34,6 +35,28 @@ func Filter(paths []*combinator.Path, policy Policy) []*combinator.Path {
 	return psToPaths(policy.Filter(pathsToPs(paths)))
 }
 
+// Rank filters the given paths with the given policy and orders them according
+// to the order of the policy. If the policy does not define an order, the
+// remaining paths keep their relative order.
+func Rank(paths []*combinator.Path, policy *pathpol.Policy,
+	opts pathpol.FilterOptions) []*combinator.Path {
+
+	filtered := policy.FilterOpt(pathsToPs(paths), opts)
+	ranked := make([]*combinator.Path, 0, len(filtered))
+	if len(policy.Order) > 0 {
+		for _, wp := range policy.SortOpt(filtered, opts) {
+			ranked = append(ranked, wp.(pathWrap).origPath)
+		}
+		return ranked
+	}
+	for _, path := range paths {
+		if _, ok := filtered[newPathWrap(path).Fingerprint()]; ok {
+			ranked = append(ranked, path)
+		}
+	}
+	return ranked
+}
+
 func pathsToPs(paths []*combinator.Path) pathpol.PathSet {
 	ps := make(pathpol.PathSet, len(paths))
 	for _, path := range paths {
@@ -73,3 +96,5 @@ func newPathWrap(p *combinator.Path) pathWrap {
 
 func (p pathWrap) Interfaces() []snet.PathInterface  { return p.intfs }
 func (p pathWrap) Fingerprint() snet.PathFingerprint { return p.key }
+func (p pathWrap) MTU() uint16                       { return p.origPath.Mtu }
+func (p pathWrap) Expiry() time.Time                 { return p.origPath.ComputeExpTime() }
@@ -15,16 +15,20 @@
 package fetcher_test
 
 import (
+	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/ctrl/seg"
 	"github.com/scionproto/scion/go/lib/infra/modules/combinator"
 	"github.com/scionproto/scion/go/lib/pathpol"
+	"github.com/scionproto/scion/go/lib/util"
 	"github.com/scionproto/scion/go/lib/xtest"
 	"github.com/scionproto/scion/go/lib/xtest/graph"
 	"github.com/scionproto/scion/go/sciond/internal/fetcher"
@@ -82,3 +86,60 @@ func TestFilter(t *testing.T) {
 		})
 	}
 }
+
+func TestRank(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	g := graph.NewDefaultGraph(ctrl)
+	ia110 := xtest.MustParseIA("1-ff00:0:110")
+	ia111 := xtest.MustParseIA("1-ff00:0:111")
+	seg110To120 := g.Beacon([]common.IFIDType{graph.If_110_X_120_A})
+	seg110To130 := g.Beacon([]common.IFIDType{graph.If_110_X_130_A})
+	seg120To111 := g.Beacon([]common.IFIDType{graph.If_120_X_111_B})
+	seg130To111 := g.Beacon([]common.IFIDType{graph.If_130_B_111_A})
+
+	paths111To110 := combinator.Combine(ia111, ia110,
+		[]*seg.PathSegment{seg120To111, seg130To111},
+		[]*seg.PathSegment{seg110To120, seg110To130},
+		nil)
+	via130 := combinator.Combine(ia111, ia110,
+		[]*seg.PathSegment{seg130To111},
+		[]*seg.PathSegment{seg110To130},
+		nil)
+
+	t.Run("filter keeps the relative order", func(t *testing.T) {
+		seq, err := pathpol.NewSequence("0+ 1-ff00:0:130 0+")
+		require.NoError(t, err)
+		ranked := fetcher.Rank(paths111To110, pathpol.NewPolicy("", nil, seq, nil),
+			pathpol.FilterOptions{})
+		assert.Equal(t, via130, ranked)
+	})
+	t.Run("empty policy keeps all paths", func(t *testing.T) {
+		ranked := fetcher.Rank(paths111To110, &pathpol.Policy{}, pathpol.FilterOptions{})
+		assert.Equal(t, paths111To110, ranked)
+	})
+	t.Run("order", func(t *testing.T) {
+		policy := &pathpol.Policy{Order: []pathpol.OrderKey{pathpol.OrderHops}}
+		ranked := fetcher.Rank(paths111To110, policy, pathpol.FilterOptions{})
+		assert.ElementsMatch(t, paths111To110, ranked)
+		// Ties are broken deterministically.
+		assert.Equal(t, ranked, fetcher.Rank(paths111To110, policy, pathpol.FilterOptions{}))
+	})
+	t.Run("link metadata", func(t *testing.T) {
+		md := pathpol.StaticLinkMetadata{}
+		for _, path := range via130 {
+			for _, intf := range path.Interfaces {
+				md[fmt.Sprintf("%s#%d", intf.IA(), intf.ID())] = pathpol.LinkInfo{
+					Latency: util.DurWrap{Duration: 10 * time.Millisecond},
+				}
+			}
+		}
+		policy := &pathpol.Policy{
+			Latency: &pathpol.DurationPredicate{Op: pathpol.CmpLE, Value: time.Second},
+		}
+		ranked := fetcher.Rank(paths111To110, policy, pathpol.FilterOptions{LinkMetadata: md})
+		assert.Equal(t, via130, ranked)
+		// Without link metadata, the latency is unknown.
+		assert.Empty(t, fetcher.Rank(paths111To110, policy, pathpol.FilterOptions{}))
+	})
+}
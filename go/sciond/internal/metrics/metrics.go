@@ -28,6 +28,8 @@ const (
 	Namespace = "sd"
 
 	subsystemPath       = "path"
+	subsystemPathSub    = "path_subscription"
+	subsystemPathProbe  = "path_probe"
 	subsystemASInfo     = "as_info"
 	subsystemIFInfo     = "if_info"
 	subsystemSVCInfo    = "service_info"
@@ -56,6 +58,10 @@ const (
 var (
 	// PathRequests contains metrics for path requests.
 	PathRequests = newPathRequest()
+	// PathSubscriptions contains metrics for path subscriptions.
+	PathSubscriptions = newPathSubscription()
+	// PathProbes contains metrics for path probing.
+	PathProbes = newPathProbe()
 	// Revocations contains metrics for revocations.
 	Revocations = newRevocation()
 	// ASInfos contains metrics for AS info requests.
@@ -102,6 +108,37 @@ func (l PathRequestLabels) WithResult(result string) PathRequestLabels {
 	return l
 }
 
+// PathUpdateLabels are the labels for path updates pushed to subscribers.
+type PathUpdateLabels struct {
+	Result string
+	Reason string
+}
+
+// Labels returns the labels.
+func (l PathUpdateLabels) Labels() []string {
+	return []string{prom.LabelResult, "reason"}
+}
+
+// Values returns the values for the labels.
+func (l PathUpdateLabels) Values() []string {
+	return []string{l.Result, l.Reason}
+}
+
+// PathProbeLabels are the labels for path probe metrics.
+type PathProbeLabels struct {
+	Status string
+}
+
+// Labels returns the labels.
+func (l PathProbeLabels) Labels() []string {
+	return []string{"status"}
+}
+
+// Values returns the values for the labels.
+func (l PathProbeLabels) Values() []string {
+	return []string{l.Status}
+}
+
 // RevocationLabels are the labels for revocation metrics.
 type RevocationLabels struct {
 	Result string
@@ -150,6 +187,56 @@ func (r PathRequest) Start() func(PathRequestLabels) {
 	}
 }
 
+// PathSubscription contains the metrics for path subscriptions.
+type PathSubscription struct {
+	active  prometheus.Gauge
+	updates *prometheus.CounterVec
+}
+
+func newPathSubscription() PathSubscription {
+	return PathSubscription{
+		active: prom.NewGauge(Namespace, subsystemPathSub, "active",
+			"The number of currently active path subscriptions."),
+		updates: prom.NewCounterVecWithLabels(Namespace, subsystemPathSub, "updates_total",
+			"The amount of path updates pushed to subscribers.", PathUpdateLabels{}),
+	}
+}
+
+// Active returns the gauge for the active path subscriptions.
+func (s PathSubscription) Active() prometheus.Gauge {
+	return s.active
+}
+
+// Updates returns the counter for pushed path updates.
+func (s PathSubscription) Updates(l PathUpdateLabels) prometheus.Counter {
+	return s.updates.WithLabelValues(l.Values()...)
+}
+
+// PathProbe contains the metrics for path probing.
+type PathProbe struct {
+	tracked prometheus.Gauge
+	probes  *prometheus.CounterVec
+}
+
+func newPathProbe() PathProbe {
+	return PathProbe{
+		tracked: prom.NewGauge(Namespace, subsystemPathProbe, "tracked_paths",
+			"The number of paths that are currently probed."),
+		probes: prom.NewCounterVecWithLabels(Namespace, subsystemPathProbe, "probes_total",
+			"The amount of probes sent on paths.", PathProbeLabels{}),
+	}
+}
+
+// Tracked returns the gauge for the probed paths.
+func (p PathProbe) Tracked() prometheus.Gauge {
+	return p.tracked
+}
+
+// Probes returns the counter for the probes.
+func (p PathProbe) Probes(l PathProbeLabels) prometheus.Counter {
+	return p.probes.WithLabelValues(l.Values()...)
+}
+
 // Revocation contains the metrics for revocation processing.
 type Revocation struct {
 	count   *prometheus.CounterVec
@@ -23,5 +23,7 @@ import (
 
 func TestLabels(t *testing.T) {
 	promtest.CheckLabelsStruct(t, metrics.PathRequestLabels{})
+	promtest.CheckLabelsStruct(t, metrics.PathUpdateLabels{})
+	promtest.CheckLabelsStruct(t, metrics.PathProbeLabels{})
 	promtest.CheckLabelsStruct(t, metrics.RevocationLabels{})
 }
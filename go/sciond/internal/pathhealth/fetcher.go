@@ -0,0 +1,58 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathhealth
+
+import (
+	"context"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/sciond"
+	"github.com/scionproto/scion/go/sciond/internal/fetcher"
+)
+
+var _ fetcher.Fetcher = (*Fetcher)(nil)
+
+// Fetcher wraps a fetcher. The paths of the replies are annotated with their
+// health and dead paths are demoted. All fetched paths are tracked by the
+// monitor for probing, including the paths that are demoted or cut off. This
+// keeps dead paths demoted, and allows to detect when they recover.
+type Fetcher struct {
+	Fetcher fetcher.Fetcher
+	Monitor *Monitor
+	// DropDead excludes dead paths from the replies, unless all paths are
+	// dead.
+	DropDead bool
+}
+
+// GetPaths fetches the paths with the wrapped fetcher and ranks them by their
+// health.
+func (f *Fetcher) GetPaths(ctx context.Context, req *sciond.PathReq,
+	earlyReplyInterval time.Duration) (*sciond.PathReply, error) {
+
+	// Fetch all paths, such that dead paths can be replaced by paths that
+	// would otherwise be cut off.
+	all := req.Copy()
+	all.Flags.PathCount = 0
+	reply, err := f.Fetcher.GetPaths(ctx, all, earlyReplyInterval)
+	if err != nil || reply.ErrorCode != sciond.ErrorOk {
+		return reply, err
+	}
+	f.Monitor.Track(ctx, req.Dst.IA(), reply.Entries, time.Now())
+	entries := f.Monitor.Annotate(reply.Entries, f.DropDead)
+	if count := int(req.Flags.PathCount); count != 0 && len(entries) > count {
+		entries = entries[:count]
+	}
+	return &sciond.PathReply{ErrorCode: reply.ErrorCode, Entries: entries}, nil
+}
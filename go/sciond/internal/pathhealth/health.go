@@ -0,0 +1,387 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pathhealth probes the paths that SCIOND hands out and keeps a history
+// of the probe results per path. The history is used to annotate the paths in
+// the replies with their health, and to demote or exclude dead paths.
+package pathhealth
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/sciond"
+	"github.com/scionproto/scion/go/lib/sciond/pathprobe"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/snet/addrutil"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/sciond/internal/metrics"
+)
+
+const (
+	// DefaultHistorySize is the number of probe results that are kept per
+	// path.
+	DefaultHistorySize = 10
+	// DefaultDeadThreshold is the number of consecutive failed probes after
+	// which a path is considered dead.
+	DefaultDeadThreshold = 3
+	// DefaultTrackTimeout is the time after which a path that has not been
+	// fetched is no longer probed.
+	DefaultTrackTimeout = 10 * time.Minute
+)
+
+// Prober probes paths to a destination. The returned statuses are keyed by
+// pathprobe.PathKey. If an error is returned, the statuses may contain the
+// results of the paths that could be probed.
+type Prober interface {
+	Probe(ctx context.Context, dst addr.IA,
+		paths []snet.Path) (map[string]pathprobe.Status, error)
+}
+
+// DefaultProber probes the paths with SCMP messages using pathprobe.Prober.
+type DefaultProber struct {
+	LocalIA addr.IA
+	// LocalIP is the address the probes are sent from. If nil, it is resolved
+	// per next hop of the paths.
+	LocalIP net.IP
+}
+
+// Probe probes the paths to the destination. Each path is probed from the local
+// IP that routes to its next hop, paths that share the local IP are probed
+// together. The statuses of the groups that could be probed are returned,
+// even if probing other groups failed.
+func (p DefaultProber) Probe(ctx context.Context, dst addr.IA,
+	paths []snet.Path) (map[string]pathprobe.Status, error) {
+
+	groups, errs := p.group(paths)
+	var mtx sync.Mutex
+	var wg sync.WaitGroup
+	statuses := make(map[string]pathprobe.Status)
+	for _, g := range groups {
+		wg.Add(1)
+		go func(g probeGroup) {
+			defer log.HandlePanic()
+			defer wg.Done()
+			prober := pathprobe.Prober{
+				DstIA:   dst,
+				LocalIA: p.LocalIA,
+				LocalIP: g.localIP,
+			}
+			res, err := prober.GetStatuses(ctx, g.paths)
+			mtx.Lock()
+			defer mtx.Unlock()
+			if err != nil {
+				errs = append(errs, serrors.WrapStr("unable to probe paths", err,
+					"local_ip", g.localIP))
+				return
+			}
+			for key, status := range res {
+				statuses[key] = status
+			}
+		}(g)
+	}
+	wg.Wait()
+	return statuses, errs.ToError()
+}
+
+// probeGroup is a set of paths that are probed from the same local IP.
+type probeGroup struct {
+	localIP net.IP
+	paths   []snet.Path
+}
+
+// group groups the paths by the local IP they are probed from. The local IP is
+// resolved once per next hop. Paths for which no local IP can be resolved are
+// reported as errors.
+func (p DefaultProber) group(paths []snet.Path) ([]probeGroup, serrors.List) {
+	if len(paths) == 0 {
+		return nil, nil
+	}
+	if p.LocalIP != nil {
+		return []probeGroup{{localIP: p.LocalIP, paths: paths}}, nil
+	}
+	var errs serrors.List
+	var groups []probeGroup
+	byLocalIP := make(map[string]int)
+	localIPs := make(map[string]net.IP)
+	for _, path := range paths {
+		nextHop := path.UnderlayNextHop()
+		if nextHop == nil {
+			errs = append(errs, serrors.New("path without next hop", "path", path))
+			continue
+		}
+		localIP, ok := localIPs[nextHop.IP.String()]
+		if !ok {
+			var err error
+			if localIP, err = addrutil.ResolveLocal(nextHop.IP); err != nil {
+				errs = append(errs, serrors.WrapStr("unable to resolve local IP", err,
+					"next_hop", nextHop))
+				continue
+			}
+			localIPs[nextHop.IP.String()] = localIP
+		}
+		i, ok := byLocalIP[localIP.String()]
+		if !ok {
+			i = len(groups)
+			byLocalIP[localIP.String()] = i
+			groups = append(groups, probeGroup{localIP: localIP})
+		}
+		groups[i].paths = append(groups[i].paths, path)
+	}
+	return groups, errs
+}
+
+// Monitor keeps track of the paths that are fetched and of their probe
+// results. Monitor implements periodic.Task, each run probes all tracked
+// paths.
+type Monitor struct {
+	Prober Prober
+	// HistorySize is the number of probe results that are kept per path. If
+	// zero, DefaultHistorySize is used.
+	HistorySize int
+	// DeadThreshold is the number of consecutive failed probes after which a
+	// path is considered dead. It is capped at the history size. If zero,
+	// DefaultDeadThreshold is used.
+	DeadThreshold int
+	// TrackTimeout is the time after which a path that has not been fetched
+	// is no longer probed. If zero, DefaultTrackTimeout is used.
+	TrackTimeout time.Duration
+
+	mtx   sync.Mutex
+	paths map[snet.PathFingerprint]*trackedPath
+}
+
+type trackedPath struct {
+	dst       addr.IA
+	path      snet.Path
+	lastUsed  time.Time
+	lastProbe time.Time
+	// history contains the probe results, the most recent result is last.
+	history []probeResult
+}
+
+type probeResult struct {
+	alive bool
+	rtt   time.Duration
+}
+
+// Track registers the paths to the destination for probing. Paths that are
+// already tracked are refreshed, e.g., with their new expiration time. Empty
+// paths are not tracked.
+func (m *Monitor) Track(ctx context.Context, dst addr.IA, entries []sciond.PathReplyEntry,
+	now time.Time) {
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if m.paths == nil {
+		m.paths = make(map[snet.PathFingerprint]*trackedPath)
+	}
+	for _, entry := range entries {
+		if len(entry.Path.Interfaces) == 0 {
+			continue
+		}
+		path, err := sciond.PathReplyEntryToPath(entry, dst)
+		if err != nil {
+			log.FromCtx(ctx).Debug("Unable to track path", "path", entry.Path, "err", err)
+			continue
+		}
+		tp, ok := m.paths[path.Fingerprint()]
+		if !ok {
+			tp = &trackedPath{dst: dst}
+			m.paths[path.Fingerprint()] = tp
+		}
+		tp.path = path
+		tp.lastUsed = now
+	}
+	metrics.PathProbes.Tracked().Set(float64(len(m.paths)))
+}
+
+// Annotate sets the health of the entries and moves the dead paths to the end.
+// Otherwise, the relative order of the entries is kept. If dropDead is set,
+// the dead paths are removed, unless all paths are dead. Empty paths are not
+// annotated.
+func (m *Monitor) Annotate(entries []sciond.PathReplyEntry,
+	dropDead bool) []sciond.PathReplyEntry {
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	live := make([]sciond.PathReplyEntry, 0, len(entries))
+	var dead []sciond.PathReplyEntry
+	for _, entry := range entries {
+		if len(entry.Path.Interfaces) == 0 {
+			live = append(live, entry)
+			continue
+		}
+		entry.Health = &sciond.PathHealth{}
+		if tp, ok := m.paths[entry.Path.Fingerprint()]; ok {
+			entry.Health = m.health(tp)
+		}
+		if entry.Health.State == sciond.PathLivenessDead {
+			dead = append(dead, entry)
+			continue
+		}
+		live = append(live, entry)
+	}
+	if dropDead && len(live) > 0 {
+		return live
+	}
+	return append(live, dead...)
+}
+
+// Name returns the name of the task.
+func (m *Monitor) Name() string {
+	return "sd_path_prober"
+}
+
+// Run probes all tracked paths.
+func (m *Monitor) Run(ctx context.Context) {
+	m.probe(ctx, time.Now())
+}
+
+func (m *Monitor) probe(ctx context.Context, now time.Time) {
+	byDst := m.expire(now)
+	var wg sync.WaitGroup
+	for dst, paths := range byDst {
+		wg.Add(1)
+		go func(dst addr.IA, paths []snet.Path) {
+			defer log.HandlePanic()
+			defer wg.Done()
+			// Partial results are recorded, even if probing some paths failed.
+			statuses, err := m.Prober.Probe(ctx, dst, paths)
+			if err != nil {
+				log.FromCtx(ctx).Info("Unable to probe paths", "dst", dst, "err", err)
+			}
+			m.record(paths, statuses, now)
+		}(dst, paths)
+	}
+	wg.Wait()
+}
+
+// expire removes the paths that expired or have not been fetched within
+// the track timeout. It returns the remaining paths grouped by destination.
+func (m *Monitor) expire(now time.Time) map[addr.IA][]snet.Path {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	timeout := durationOrDefault(m.TrackTimeout, DefaultTrackTimeout)
+	byDst := make(map[addr.IA][]snet.Path)
+	for fp, tp := range m.paths {
+		if now.Sub(tp.lastUsed) > timeout || tp.path.Expiry().Before(now) {
+			delete(m.paths, fp)
+			continue
+		}
+		byDst[tp.dst] = append(byDst[tp.dst], tp.path)
+	}
+	metrics.PathProbes.Tracked().Set(float64(len(m.paths)))
+	return byDst
+}
+
+func (m *Monitor) record(paths []snet.Path, statuses map[string]pathprobe.Status,
+	now time.Time) {
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	size := intOrDefault(m.HistorySize, DefaultHistorySize)
+	for _, path := range paths {
+		status, ok := statuses[pathprobe.PathKey(path)]
+		if !ok {
+			continue
+		}
+		labels := metrics.PathProbeLabels{Status: strings.ToLower(string(status.Status))}
+		metrics.PathProbes.Probes(labels).Inc()
+		// The path might have been removed while it was probed.
+		tp, ok := m.paths[path.Fingerprint()]
+		if !ok {
+			continue
+		}
+		tp.lastProbe = now
+		tp.history = append(tp.history, probeResult{
+			alive: status.Status == pathprobe.StatusAlive,
+			rtt:   status.RTT,
+		})
+		if len(tp.history) > size {
+			tp.history = tp.history[len(tp.history)-size:]
+		}
+	}
+}
+
+func (m *Monitor) health(tp *trackedPath) *sciond.PathHealth {
+	h := &sciond.PathHealth{Probes: uint16(len(tp.history))}
+	if len(tp.history) == 0 {
+		return h
+	}
+	h.LastProbe = util.TimeToSecs(tp.lastProbe)
+	var lost int
+	var rtt time.Duration
+	for _, r := range tp.history {
+		if !r.alive {
+			lost++
+			continue
+		}
+		rtt += r.rtt
+	}
+	h.Loss = float32(lost) / float32(len(tp.history))
+	if alive := len(tp.history) - lost; alive > 0 {
+		h.Rtt = uint32(rtt / time.Duration(alive) / time.Microsecond)
+	}
+	h.State = m.state(tp.history)
+	return h
+}
+
+// state returns alive if the last probe succeeded, and dead if the last
+// probes up to the dead threshold failed.
+func (m *Monitor) state(history []probeResult) sciond.PathLiveness {
+	if history[len(history)-1].alive {
+		return sciond.PathLivenessAlive
+	}
+	threshold := m.deadThreshold()
+	if len(history) < threshold {
+		return sciond.PathLivenessUnknown
+	}
+	for _, r := range history[len(history)-threshold:] {
+		if r.alive {
+			return sciond.PathLivenessUnknown
+		}
+	}
+	return sciond.PathLivenessDead
+}
+
+// deadThreshold returns the dead threshold, capped at the history size. A
+// larger threshold could never be reached, as the history holds fewer results.
+func (m *Monitor) deadThreshold() int {
+	threshold := intOrDefault(m.DeadThreshold, DefaultDeadThreshold)
+	if size := intOrDefault(m.HistorySize, DefaultHistorySize); threshold > size {
+		return size
+	}
+	return threshold
+}
+
+func durationOrDefault(d, def time.Duration) time.Duration {
+	if d == 0 {
+		return def
+	}
+	return d
+}
+
+func intOrDefault(v, def int) int {
+	if v == 0 {
+		return def
+	}
+	return v
+}
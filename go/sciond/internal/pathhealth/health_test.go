@@ -0,0 +1,342 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathhealth
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/hostinfo"
+	"github.com/scionproto/scion/go/lib/sciond"
+	"github.com/scionproto/scion/go/lib/sciond/pathprobe"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+var (
+	ia110 = xtest.MustParseIA("1-ff00:0:110")
+	ia111 = xtest.MustParseIA("1-ff00:0:111")
+	ia112 = xtest.MustParseIA("1-ff00:0:112")
+)
+
+func TestMonitorAnnotate(t *testing.T) {
+	now := time.Now()
+	exp := now.Add(time.Hour)
+	pathA := newEntry(1, exp, ia111, 1, ia110, 2)
+	pathB := newEntry(2, exp, ia111, 3, ia112, 4, ia112, 5, ia110, 6)
+	empty := sciond.PathReplyEntry{Path: &sciond.FwdPathMeta{}}
+
+	t.Run("not probed", func(t *testing.T) {
+		m := &Monitor{Prober: &fakeProber{}}
+		m.Track(context.Background(), ia110, []sciond.PathReplyEntry{pathA}, now)
+		entries := m.Annotate([]sciond.PathReplyEntry{pathA, empty}, false)
+		require.Len(t, entries, 2)
+		assert.Equal(t, &sciond.PathHealth{}, entries[0].Health)
+		assert.Nil(t, entries[1].Health)
+	})
+	t.Run("dead paths are demoted", func(t *testing.T) {
+		prober := &fakeProber{dead: fingerprints(pathB), rtt: 2 * time.Millisecond}
+		m := &Monitor{Prober: prober}
+		m.Track(context.Background(), ia110, []sciond.PathReplyEntry{pathA, pathB}, now)
+		for i := 0; i < DefaultDeadThreshold; i++ {
+			m.probe(context.Background(), now.Add(time.Duration(i)*time.Second))
+		}
+		entries := m.Annotate([]sciond.PathReplyEntry{pathB, pathA}, false)
+		require.Len(t, entries, 2)
+		assert.Equal(t, pathA.Path, entries[0].Path)
+		assert.Equal(t, &sciond.PathHealth{
+			State:     sciond.PathLivenessAlive,
+			Rtt:       2000,
+			Probes:    DefaultDeadThreshold,
+			LastProbe: util.TimeToSecs(now.Add(2 * time.Second)),
+		}, entries[0].Health)
+		assert.Equal(t, pathB.Path, entries[1].Path)
+		assert.Equal(t, sciond.PathLivenessDead, entries[1].Health.State)
+		assert.Equal(t, float32(1), entries[1].Health.Loss)
+		// The reply entries are not modified.
+		assert.Nil(t, pathA.Health)
+	})
+	t.Run("dead paths are dropped", func(t *testing.T) {
+		m := &Monitor{Prober: &fakeProber{dead: fingerprints(pathB)}}
+		m.Track(context.Background(), ia110, []sciond.PathReplyEntry{pathA, pathB}, now)
+		for i := 0; i < DefaultDeadThreshold; i++ {
+			m.probe(context.Background(), now)
+		}
+		entries := m.Annotate([]sciond.PathReplyEntry{pathB, pathA}, true)
+		require.Len(t, entries, 1)
+		assert.Equal(t, pathA.Path, entries[0].Path)
+		// If all paths are dead, they are kept.
+		entries = m.Annotate([]sciond.PathReplyEntry{pathB}, true)
+		require.Len(t, entries, 1)
+		assert.Equal(t, pathB.Path, entries[0].Path)
+	})
+	t.Run("failed probes below threshold", func(t *testing.T) {
+		m := &Monitor{Prober: &fakeProber{dead: fingerprints(pathA)}}
+		m.Track(context.Background(), ia110, []sciond.PathReplyEntry{pathA, pathB}, now)
+		m.probe(context.Background(), now)
+		entries := m.Annotate([]sciond.PathReplyEntry{pathA, pathB}, true)
+		require.Len(t, entries, 2)
+		assert.Equal(t, pathA.Path, entries[0].Path)
+		assert.Equal(t, sciond.PathLivenessUnknown, entries[0].Health.State)
+	})
+	t.Run("partial results are recorded", func(t *testing.T) {
+		m := &Monitor{Prober: &fakeProber{err: serrors.New("test")}}
+		m.Track(context.Background(), ia110, []sciond.PathReplyEntry{pathA}, now)
+		m.probe(context.Background(), now)
+		entries := m.Annotate([]sciond.PathReplyEntry{pathA}, false)
+		assert.Equal(t, sciond.PathLivenessAlive, entries[0].Health.State)
+	})
+	t.Run("dead threshold is capped at the history size", func(t *testing.T) {
+		m := &Monitor{
+			Prober:        &fakeProber{dead: fingerprints(pathA)},
+			HistorySize:   2,
+			DeadThreshold: 5,
+		}
+		m.Track(context.Background(), ia110, []sciond.PathReplyEntry{pathA}, now)
+		m.probe(context.Background(), now)
+		entries := m.Annotate([]sciond.PathReplyEntry{pathA}, false)
+		assert.Equal(t, sciond.PathLivenessUnknown, entries[0].Health.State)
+		m.probe(context.Background(), now)
+		entries = m.Annotate([]sciond.PathReplyEntry{pathA}, false)
+		assert.Equal(t, sciond.PathLivenessDead, entries[0].Health.State)
+	})
+	t.Run("history size", func(t *testing.T) {
+		m := &Monitor{Prober: &fakeProber{}, HistorySize: 2}
+		m.Track(context.Background(), ia110, []sciond.PathReplyEntry{pathA}, now)
+		for i := 0; i < 5; i++ {
+			m.probe(context.Background(), now)
+		}
+		entries := m.Annotate([]sciond.PathReplyEntry{pathA}, false)
+		assert.Equal(t, uint16(2), entries[0].Health.Probes)
+	})
+}
+
+func TestMonitorExpire(t *testing.T) {
+	now := time.Now()
+	pathA := newEntry(1, now.Add(time.Hour), ia111, 1, ia110, 2)
+	pathB := newEntry(2, now.Add(time.Minute), ia111, 3, ia110, 4)
+	prober := &fakeProber{}
+	m := &Monitor{Prober: prober, TrackTimeout: 10 * time.Minute}
+	m.Track(context.Background(), ia110, []sciond.PathReplyEntry{pathA, pathB}, now)
+	m.probe(context.Background(), now)
+	assert.Equal(t, 2, prober.probed)
+	// Path B expired.
+	m.probe(context.Background(), now.Add(2*time.Minute))
+	assert.Equal(t, 3, prober.probed)
+	// Path A has not been fetched within the track timeout.
+	m.probe(context.Background(), now.Add(11*time.Minute))
+	assert.Equal(t, 3, prober.probed)
+}
+
+func TestFetcher(t *testing.T) {
+	now := time.Now()
+	exp := now.Add(time.Hour)
+	pathA := newEntry(1, exp, ia111, 1, ia110, 2)
+	pathB := newEntry(2, exp, ia111, 3, ia112, 4, ia112, 5, ia110, 6)
+	pathC := newEntry(3, exp, ia111, 7, ia110, 8)
+	inner := &fakeFetcher{
+		reply: &sciond.PathReply{
+			ErrorCode: sciond.ErrorOk,
+			Entries:   []sciond.PathReplyEntry{pathB, pathA, pathC},
+		},
+	}
+	m := &Monitor{Prober: &fakeProber{dead: fingerprints(pathB)}}
+	f := &Fetcher{Fetcher: inner, Monitor: m}
+	req := &sciond.PathReq{
+		Dst:   ia110.IAInt(),
+		Src:   ia111.IAInt(),
+		Flags: sciond.PathReqFlags{PathCount: 2},
+	}
+	reply, err := f.GetPaths(context.Background(), req, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []*sciond.FwdPathMeta{pathB.Path, pathA.Path}, paths(reply))
+	// All paths are fetched from the wrapped fetcher.
+	assert.Equal(t, uint16(0), inner.req.Flags.PathCount)
+	// All fetched paths are probed, also the ones that are cut off.
+	for i := 0; i < DefaultDeadThreshold; i++ {
+		m.probe(context.Background(), now)
+	}
+	assert.Len(t, m.paths, 3)
+	reply, err = f.GetPaths(context.Background(), req, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []*sciond.FwdPathMeta{pathA.Path, pathC.Path}, paths(reply))
+}
+
+func TestFetcherDroppedPathsStayTracked(t *testing.T) {
+	exp := time.Now().Add(time.Hour)
+	pathA := newEntry(1, exp, ia111, 1, ia110, 2)
+	pathB := newEntry(2, exp, ia111, 3, ia110, 4)
+	inner := &fakeFetcher{
+		reply: &sciond.PathReply{
+			ErrorCode: sciond.ErrorOk,
+			Entries:   []sciond.PathReplyEntry{pathB, pathA},
+		},
+	}
+	prober := &fakeProber{dead: fingerprints(pathB)}
+	m := &Monitor{Prober: prober}
+	f := &Fetcher{Fetcher: inner, Monitor: m, DropDead: true}
+	req := &sciond.PathReq{
+		Dst:   ia110.IAInt(),
+		Src:   ia111.IAInt(),
+		Flags: sciond.PathReqFlags{PathCount: 1},
+	}
+	_, err := f.GetPaths(context.Background(), req, 0)
+	require.NoError(t, err)
+	for i := 0; i < DefaultDeadThreshold; i++ {
+		m.probe(context.Background(), time.Now())
+	}
+	// Let the tracked paths age, such that they expire unless the next fetch
+	// refreshes them.
+	for _, tp := range m.paths {
+		tp.lastUsed = time.Now().Add(-2 * DefaultTrackTimeout)
+	}
+	reply, err := f.GetPaths(context.Background(), req, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []*sciond.FwdPathMeta{pathA.Path}, paths(reply))
+	// The dropped dead path is still probed and stays dropped.
+	m.probe(context.Background(), time.Now())
+	assert.Len(t, m.paths, 2)
+	reply, err = f.GetPaths(context.Background(), req, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []*sciond.FwdPathMeta{pathA.Path}, paths(reply))
+	// The recovery of the path is detected.
+	prober.mtx.Lock()
+	prober.dead = nil
+	prober.mtx.Unlock()
+	m.probe(context.Background(), time.Now())
+	reply, err = f.GetPaths(context.Background(), req, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []*sciond.FwdPathMeta{pathB.Path}, paths(reply))
+}
+
+func TestDefaultProberGroup(t *testing.T) {
+	exp := time.Now().Add(time.Hour)
+	toPath := func(entry sciond.PathReplyEntry, nextHop net.IP) snet.Path {
+		entry.HostInfo = hostinfo.FromUDPAddr(net.UDPAddr{IP: nextHop, Port: 30041})
+		path, err := sciond.PathReplyEntryToPath(entry, ia110)
+		require.NoError(t, err)
+		return path
+	}
+	pathA := toPath(newEntry(1, exp, ia111, 1, ia110, 2), net.IPv4(127, 0, 0, 1))
+	pathB := toPath(newEntry(2, exp, ia111, 3, ia110, 4), net.IPv4(127, 0, 0, 2))
+	pathC := toPath(newEntry(3, exp, ia111, 5, ia110, 6), net.IPv4(127, 0, 0, 1))
+	noNextHop, err := sciond.PathReplyEntryToPath(sciond.PathReplyEntry{
+		Path: &sciond.FwdPathMeta{},
+	}, ia110)
+	require.NoError(t, err)
+
+	t.Run("local IP resolved per next hop", func(t *testing.T) {
+		groups, errs := DefaultProber{}.group([]snet.Path{pathA, pathB, noNextHop, pathC})
+		assert.Len(t, errs, 1)
+		// All loopback next hops are reached from the same local IP.
+		require.Len(t, groups, 1)
+		assert.Equal(t, net.IPv4(127, 0, 0, 1).To4(), groups[0].localIP.To4())
+		assert.Equal(t, []snet.Path{pathA, pathB, pathC}, groups[0].paths)
+	})
+	t.Run("configured local IP", func(t *testing.T) {
+		localIP := net.IPv4(192, 0, 2, 1)
+		groups, errs := DefaultProber{LocalIP: localIP}.group([]snet.Path{pathA, pathB})
+		assert.Empty(t, errs)
+		require.Len(t, groups, 1)
+		assert.Equal(t, localIP, groups[0].localIP)
+		assert.Equal(t, []snet.Path{pathA, pathB}, groups[0].paths)
+	})
+}
+
+type fakeProber struct {
+	mtx    sync.Mutex
+	dead   map[snet.PathFingerprint]struct{}
+	rtt    time.Duration
+	err    error
+	probed int
+}
+
+func (p *fakeProber) Probe(_ context.Context, _ addr.IA,
+	paths []snet.Path) (map[string]pathprobe.Status, error) {
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	statuses := make(map[string]pathprobe.Status)
+	for _, path := range paths {
+		p.probed++
+		if _, ok := p.dead[path.Fingerprint()]; ok {
+			statuses[pathprobe.PathKey(path)] = pathprobe.Status{Status: pathprobe.StatusTimeout}
+			continue
+		}
+		statuses[pathprobe.PathKey(path)] = pathprobe.Status{
+			Status: pathprobe.StatusAlive,
+			RTT:    p.rtt,
+		}
+	}
+	return statuses, p.err
+}
+
+type fakeFetcher struct {
+	req   *sciond.PathReq
+	reply *sciond.PathReply
+}
+
+func (f *fakeFetcher) GetPaths(_ context.Context, req *sciond.PathReq,
+	_ time.Duration) (*sciond.PathReply, error) {
+
+	f.req = req
+	return f.reply, nil
+}
+
+// newEntry creates a path entry with the interfaces specified as IA, IFID
+// pairs. The id is used to create a unique forwarding path.
+func newEntry(id byte, exp time.Time, intfs ...interface{}) sciond.PathReplyEntry {
+	// An info field followed by two hop fields.
+	fwdPath := make([]byte, 24)
+	fwdPath[7] = 2
+	fwdPath[23] = id
+	meta := &sciond.FwdPathMeta{FwdPath: fwdPath, ExpTime: util.TimeToSecs(exp)}
+	for i := 0; i < len(intfs); i += 2 {
+		meta.Interfaces = append(meta.Interfaces, sciond.PathInterface{
+			RawIsdas: intfs[i].(addr.IA).IAInt(),
+			IfID:     common.IFIDType(intfs[i+1].(int)),
+		})
+	}
+	return sciond.PathReplyEntry{
+		Path:     meta,
+		HostInfo: hostinfo.FromUDPAddr(net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 30041}),
+	}
+}
+
+func fingerprints(entries ...sciond.PathReplyEntry) map[snet.PathFingerprint]struct{} {
+	fps := make(map[snet.PathFingerprint]struct{})
+	for _, entry := range entries {
+		fps[entry.Path.Fingerprint()] = struct{}{}
+	}
+	return fps
+}
+
+func paths(reply *sciond.PathReply) []*sciond.FwdPathMeta {
+	var res []*sciond.FwdPathMeta
+	for _, entry := range reply.Entries {
+		res = append(res, entry.Path)
+	}
+	return res
+}
@@ -0,0 +1,341 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servers
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/revcache"
+	"github.com/scionproto/scion/go/lib/sciond"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/proto"
+	"github.com/scionproto/scion/go/sciond/internal/fetcher"
+	"github.com/scionproto/scion/go/sciond/internal/metrics"
+)
+
+const (
+	// DefaultSubscriptionCheckInterval is the interval at which subscribed
+	// paths are checked for revocations and upcoming expiration.
+	DefaultSubscriptionCheckInterval = time.Second
+	// DefaultSubscriptionFetchInterval is the interval at which the paths of
+	// a subscription are fetched again to discover new paths.
+	DefaultSubscriptionFetchInterval = 10 * time.Second
+	// DefaultExpiryLead is the time before the expiration of a path at which
+	// an update is pushed, if the subscriber does not specify it.
+	DefaultExpiryLead = 5 * time.Minute
+)
+
+// PathSubscriptionHandler represents the shared global state for the handling
+// of all PathSubscribeReq messages. The SCIOND API spawns a goroutine with
+// method Handle for each subscription it receives. The connection is kept open
+// until the client closes it, and a PathUpdate is pushed whenever new paths
+// appear, a path is revoked, or a path nears its expiration.
+type PathSubscriptionHandler struct {
+	Fetcher  fetcher.Fetcher
+	RevCache revcache.RevCache
+	// CheckInterval is the interval at which revocations and expiration are
+	// checked. If zero, DefaultSubscriptionCheckInterval is used.
+	CheckInterval time.Duration
+	// FetchInterval is the interval at which the paths are fetched again. If
+	// zero, DefaultSubscriptionFetchInterval is used.
+	FetchInterval time.Duration
+}
+
+func (h *PathSubscriptionHandler) Handle(ctx context.Context, conn net.Conn, src net.Addr,
+	pld *sciond.Pld) {
+
+	defer conn.Close()
+	metrics.PathSubscriptions.Active().Inc()
+	defer metrics.PathSubscriptions.Active().Dec()
+	logger := log.FromCtx(ctx)
+	logger.Debug("[PathSubscriptionHandler] Received subscription", "req", pld.PathSubscribeReq)
+	ctx, cancelF := context.WithCancel(ctx)
+	defer cancelF()
+	// The client does not send anything after the subscription request. The
+	// read only returns once the client closed the connection.
+	go func() {
+		defer log.HandlePanic()
+		defer cancelF()
+		io.Copy(ioutil.Discard, conn)
+	}()
+
+	sub := newSubscription(pld.PathSubscribeReq, h.Fetcher, h.RevCache,
+		durationOrDefault(h.FetchInterval, DefaultSubscriptionFetchInterval))
+	workCtx, workCancelF := context.WithTimeout(ctx, DefaultWorkTimeout)
+	update := sub.Initial(workCtx, time.Now())
+	workCancelF()
+	if !h.send(ctx, conn, src, pld.Id, update) {
+		return
+	}
+	ticker := time.NewTicker(durationOrDefault(h.CheckInterval,
+		DefaultSubscriptionCheckInterval))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Debug("[PathSubscriptionHandler] Subscription closed by client",
+				"req", pld.PathSubscribeReq)
+			return
+		case <-ticker.C:
+		}
+		workCtx, workCancelF := context.WithTimeout(ctx, DefaultWorkTimeout)
+		update := sub.Check(workCtx, time.Now())
+		workCancelF()
+		if update == nil {
+			continue
+		}
+		if !h.send(ctx, conn, src, pld.Id, update) {
+			return
+		}
+	}
+}
+
+func (h *PathSubscriptionHandler) send(ctx context.Context, conn net.Conn, src net.Addr,
+	id uint64, update *sciond.PathUpdate) bool {
+
+	labels := metrics.PathUpdateLabels{Result: metrics.OkSuccess, Reason: update.Reason.String()}
+	reply := &sciond.Pld{
+		Id:         id,
+		Which:      proto.SCIONDMsg_Which_pathUpdate,
+		PathUpdate: update,
+	}
+	conn.SetWriteDeadline(time.Now().Add(DefaultReplyTimeout))
+	if err := sciond.Send(reply, conn); err != nil {
+		log.FromCtx(ctx).Warn("Unable to push path update to client", "client", src, "err", err)
+		labels.Result = metrics.ErrNetwork
+		metrics.PathSubscriptions.Updates(labels).Inc()
+		return false
+	}
+	log.FromCtx(ctx).Debug("Pushed path update", "reason", update.Reason,
+		"num_paths", len(update.Entries), "err_code", update.ErrorCode)
+	metrics.PathSubscriptions.Updates(labels).Inc()
+	return true
+}
+
+// expiryKey identifies a path with a specific expiration time. Paths that are
+// renewed keep their fingerprint, but get a new expiration time.
+type expiryKey struct {
+	fingerprint snet.PathFingerprint
+	expTime     uint32
+}
+
+// subscription keeps track of the paths that have been pushed to a subscriber
+// and computes the updates.
+type subscription struct {
+	req           *sciond.PathSubscribeReq
+	fetcher       fetcher.Fetcher
+	revCache      revcache.RevCache
+	lead          time.Duration
+	fetchInterval time.Duration
+
+	current   []sciond.PathReplyEntry
+	lastFetch time.Time
+	// expiring contains the paths that have already been announced as
+	// expiring. Paths that are no longer part of the current set are removed.
+	expiring map[expiryKey]struct{}
+}
+
+func newSubscription(req *sciond.PathSubscribeReq, f fetcher.Fetcher,
+	revCache revcache.RevCache, fetchInterval time.Duration) *subscription {
+
+	lead := time.Duration(req.ExpiryLead) * time.Second
+	return &subscription{
+		req:           req,
+		fetcher:       f,
+		revCache:      revCache,
+		lead:          durationOrDefault(lead, DefaultExpiryLead),
+		fetchInterval: fetchInterval,
+		expiring:      make(map[expiryKey]struct{}),
+	}
+}
+
+// Initial returns the initial update of the subscription.
+func (s *subscription) Initial(ctx context.Context, now time.Time) *sciond.PathUpdate {
+	reply := s.fetch(ctx, now)
+	return s.update(sciond.PathUpdateInitial, reply)
+}
+
+// Check checks the current paths for revocations and upcoming expiration, and
+// periodically fetches the paths again to discover new ones. It returns nil, if
+// no update has to be pushed to the subscriber.
+func (s *subscription) Check(ctx context.Context, now time.Time) *sciond.PathUpdate {
+	revoked := s.revoked(ctx)
+	expiring := s.nearExpiry(now)
+	if len(revoked) == 0 && len(expiring) == 0 && now.Sub(s.lastFetch) < s.fetchInterval {
+		return nil
+	}
+	reply := s.fetch(ctx, now)
+	for _, key := range expiring {
+		s.expiring[key] = struct{}{}
+	}
+	switch {
+	case len(revoked) > 0:
+		return s.update(sciond.PathUpdateRevoked, filter(reply, revoked))
+	case len(expiring) > 0:
+		return s.update(sciond.PathUpdateExpiring, reply)
+	case s.hasNew(reply):
+		return s.update(sciond.PathUpdateNewPaths, reply)
+	default:
+		s.refresh(reply)
+		return nil
+	}
+}
+
+// refresh replaces the current paths without pushing an update. This keeps
+// renewed paths from being announced as expiring, and removed paths from
+// being checked for revocations. A failed fetch keeps the current paths.
+func (s *subscription) refresh(reply *sciond.PathReply) {
+	if reply.ErrorCode != sciond.ErrorOk {
+		return
+	}
+	s.current = reply.Entries
+	s.pruneExpiring()
+}
+
+func (s *subscription) update(reason sciond.PathUpdateReason,
+	reply *sciond.PathReply) *sciond.PathUpdate {
+
+	s.current = reply.Entries
+	s.pruneExpiring()
+	return &sciond.PathUpdate{
+		Reason:    reason,
+		ErrorCode: reply.ErrorCode,
+		Entries:   reply.Entries,
+	}
+}
+
+func (s *subscription) fetch(ctx context.Context, now time.Time) *sciond.PathReply {
+	s.lastFetch = now
+	req := &sciond.PathReq{
+		Dst:   s.req.Dst,
+		Src:   s.req.Src,
+		Flags: sciond.PathReqFlags{PathCount: s.req.MaxPaths},
+	}
+	reply, err := s.fetcher.GetPaths(ctx, req, DefaultEarlyReply)
+	if err != nil {
+		log.FromCtx(ctx).Debug("Unable to get paths for subscription", "err", err)
+	}
+	if reply == nil {
+		reply = &sciond.PathReply{ErrorCode: sciond.ErrorInternal}
+	}
+	return reply
+}
+
+// revoked returns the fingerprints of the current paths that traverse a
+// revoked interface.
+func (s *subscription) revoked(ctx context.Context) map[snet.PathFingerprint]struct{} {
+	keys := make(revcache.KeySet)
+	for _, entry := range s.current {
+		for _, intf := range entry.Path.Interfaces {
+			keys[revcache.Key{IA: intf.IA(), IfId: intf.ID()}] = struct{}{}
+		}
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	revs, err := s.revCache.Get(ctx, keys)
+	if err != nil {
+		log.FromCtx(ctx).Error("Failed to get revocations", "err", err)
+		return nil
+	}
+	if len(revs) == 0 {
+		return nil
+	}
+	revoked := make(map[snet.PathFingerprint]struct{})
+	for _, entry := range s.current {
+		for _, intf := range entry.Path.Interfaces {
+			if _, ok := revs[revcache.Key{IA: intf.IA(), IfId: intf.ID()}]; ok {
+				revoked[entry.Path.Fingerprint()] = struct{}{}
+				break
+			}
+		}
+	}
+	return revoked
+}
+
+// nearExpiry returns the current paths that expire within the expiry lead and
+// have not been announced yet.
+func (s *subscription) nearExpiry(now time.Time) []expiryKey {
+	var keys []expiryKey
+	for _, entry := range s.current {
+		key := expiryKey{fingerprint: entry.Path.Fingerprint(), expTime: entry.Path.ExpTime}
+		if _, ok := s.expiring[key]; ok {
+			continue
+		}
+		if util.SecsToTime(key.expTime).Sub(now) <= s.lead {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// pruneExpiring removes the announced expiring paths that are no longer part
+// of the current set of paths.
+func (s *subscription) pruneExpiring() {
+	current := make(map[snet.PathFingerprint]struct{}, len(s.current))
+	for _, entry := range s.current {
+		current[entry.Path.Fingerprint()] = struct{}{}
+	}
+	for key := range s.expiring {
+		if _, ok := current[key.fingerprint]; !ok {
+			delete(s.expiring, key)
+		}
+	}
+}
+
+// hasNew indicates whether the reply contains a path that is not in the
+// current set of paths.
+func (s *subscription) hasNew(reply *sciond.PathReply) bool {
+	known := make(map[snet.PathFingerprint]struct{}, len(s.current))
+	for _, entry := range s.current {
+		known[entry.Path.Fingerprint()] = struct{}{}
+	}
+	for _, entry := range reply.Entries {
+		if _, ok := known[entry.Path.Fingerprint()]; !ok {
+			return true
+		}
+	}
+	return false
+}
+
+// filter removes the revoked paths from the reply. The fetcher already filters
+// revoked paths, but a revocation might have been inserted concurrently.
+func filter(reply *sciond.PathReply,
+	revoked map[snet.PathFingerprint]struct{}) *sciond.PathReply {
+
+	res := &sciond.PathReply{ErrorCode: reply.ErrorCode}
+	for _, entry := range reply.Entries {
+		if _, ok := revoked[entry.Path.Fingerprint()]; !ok {
+			res.Entries = append(res.Entries, entry)
+		}
+	}
+	if reply.ErrorCode == sciond.ErrorOk && len(res.Entries) == 0 {
+		res.ErrorCode = sciond.ErrorNoPaths
+	}
+	return res
+}
+
+func durationOrDefault(d, def time.Duration) time.Duration {
+	if d == 0 {
+		return def
+	}
+	return d
+}
@@ -0,0 +1,230 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/revcache"
+	"github.com/scionproto/scion/go/lib/revcache/memrevcache"
+	"github.com/scionproto/scion/go/lib/sciond"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/proto"
+)
+
+var (
+	ia110 = xtest.MustParseIA("1-ff00:0:110")
+	ia111 = xtest.MustParseIA("1-ff00:0:111")
+	ia112 = xtest.MustParseIA("1-ff00:0:112")
+)
+
+func TestSubscriptionCheck(t *testing.T) {
+	now := time.Now()
+	later := now.Add(time.Hour)
+	pathA := newEntry(later, ia111, 1, ia110, 2)
+	pathB := newEntry(later, ia111, 3, ia112, 4, ia112, 5, ia110, 6)
+	pathC := newEntry(later, ia111, 7, ia110, 8)
+
+	t.Run("initial", func(t *testing.T) {
+		f := &fakeFetcher{replies: []*sciond.PathReply{reply(pathA, pathB)}}
+		sub := newSubscription(subReq(0), f, memrevcache.New(), 10*time.Second)
+		update := sub.Initial(context.Background(), now)
+		assert.Equal(t, sciond.PathUpdateInitial, update.Reason)
+		assert.Equal(t, sciond.ErrorOk, update.ErrorCode)
+		assert.Equal(t, []sciond.PathReplyEntry{pathA, pathB}, update.Entries)
+	})
+	t.Run("no fetch before interval", func(t *testing.T) {
+		f := &fakeFetcher{replies: []*sciond.PathReply{reply(pathA)}}
+		sub := newSubscription(subReq(0), f, memrevcache.New(), 10*time.Second)
+		sub.Initial(context.Background(), now)
+		assert.Nil(t, sub.Check(context.Background(), now.Add(time.Second)))
+		assert.Equal(t, 1, f.calls)
+	})
+	t.Run("unchanged", func(t *testing.T) {
+		f := &fakeFetcher{replies: []*sciond.PathReply{reply(pathA, pathB), reply(pathB)}}
+		sub := newSubscription(subReq(0), f, memrevcache.New(), 10*time.Second)
+		sub.Initial(context.Background(), now)
+		assert.Nil(t, sub.Check(context.Background(), now.Add(10*time.Second)))
+		assert.Equal(t, 2, f.calls)
+	})
+	t.Run("new paths", func(t *testing.T) {
+		f := &fakeFetcher{replies: []*sciond.PathReply{reply(pathA), reply(pathA, pathC)}}
+		sub := newSubscription(subReq(0), f, memrevcache.New(), 10*time.Second)
+		sub.Initial(context.Background(), now)
+		update := sub.Check(context.Background(), now.Add(10*time.Second))
+		require.NotNil(t, update)
+		assert.Equal(t, sciond.PathUpdateNewPaths, update.Reason)
+		assert.Equal(t, []sciond.PathReplyEntry{pathA, pathC}, update.Entries)
+	})
+	t.Run("revoked", func(t *testing.T) {
+		revCache := memrevcache.New()
+		f := &fakeFetcher{
+			replies: []*sciond.PathReply{reply(pathA, pathB), reply(pathA, pathB)},
+		}
+		sub := newSubscription(subReq(0), f, revCache, 10*time.Second)
+		sub.Initial(context.Background(), now)
+		insertRev(t, revCache, ia112, 5)
+		update := sub.Check(context.Background(), now.Add(time.Second))
+		require.NotNil(t, update)
+		assert.Equal(t, sciond.PathUpdateRevoked, update.Reason)
+		assert.Equal(t, sciond.ErrorOk, update.ErrorCode)
+		assert.Equal(t, []sciond.PathReplyEntry{pathA}, update.Entries)
+		// The revoked path is no longer tracked.
+		assert.Nil(t, sub.Check(context.Background(), now.Add(2*time.Second)))
+	})
+	t.Run("all revoked", func(t *testing.T) {
+		revCache := memrevcache.New()
+		f := &fakeFetcher{replies: []*sciond.PathReply{reply(pathA), reply(pathA)}}
+		sub := newSubscription(subReq(0), f, revCache, 10*time.Second)
+		sub.Initial(context.Background(), now)
+		insertRev(t, revCache, ia110, 2)
+		update := sub.Check(context.Background(), now.Add(time.Second))
+		require.NotNil(t, update)
+		assert.Equal(t, sciond.PathUpdateRevoked, update.Reason)
+		assert.Equal(t, sciond.ErrorNoPaths, update.ErrorCode)
+		assert.Empty(t, update.Entries)
+	})
+	t.Run("expiring", func(t *testing.T) {
+		soon := newEntry(now.Add(2*time.Minute), ia111, 1, ia110, 2)
+		f := &fakeFetcher{
+			replies: []*sciond.PathReply{reply(soon, pathB), reply(soon, pathB)},
+		}
+		sub := newSubscription(subReq(3*60), f, memrevcache.New(), 10*time.Second)
+		sub.Initial(context.Background(), now)
+		update := sub.Check(context.Background(), now.Add(time.Second))
+		require.NotNil(t, update)
+		assert.Equal(t, sciond.PathUpdateExpiring, update.Reason)
+		assert.Equal(t, []sciond.PathReplyEntry{soon, pathB}, update.Entries)
+		// The expiring path is only announced once.
+		assert.Nil(t, sub.Check(context.Background(), now.Add(2*time.Second)))
+	})
+	t.Run("expiring paths are pruned", func(t *testing.T) {
+		soon := newEntry(now.Add(2*time.Minute), ia111, 1, ia110, 2)
+		f := &fakeFetcher{
+			replies: []*sciond.PathReply{reply(soon, pathB), reply(soon, pathB),
+				reply(pathB, pathC)},
+		}
+		sub := newSubscription(subReq(3*60), f, memrevcache.New(), 10*time.Second)
+		sub.Initial(context.Background(), now)
+		require.NotNil(t, sub.Check(context.Background(), now.Add(time.Second)))
+		assert.Len(t, sub.expiring, 1)
+		update := sub.Check(context.Background(), now.Add(time.Minute))
+		require.NotNil(t, update)
+		assert.Equal(t, sciond.PathUpdateNewPaths, update.Reason)
+		assert.Empty(t, sub.expiring)
+	})
+	t.Run("renewed paths are not expiring", func(t *testing.T) {
+		soon := newEntry(now.Add(5*time.Minute), ia111, 1, ia110, 2)
+		renewed := newEntry(later, ia111, 1, ia110, 2)
+		f := &fakeFetcher{
+			replies: []*sciond.PathReply{reply(soon, pathB), reply(renewed, pathB),
+				reply(renewed, pathB)},
+		}
+		sub := newSubscription(subReq(3*60), f, memrevcache.New(), 10*time.Second)
+		sub.Initial(context.Background(), now)
+		assert.Nil(t, sub.Check(context.Background(), now.Add(10*time.Second)))
+		assert.Nil(t, sub.Check(context.Background(), now.Add(3*time.Minute)))
+		assert.Equal(t, 3, f.calls)
+	})
+	t.Run("removed paths are not tracked", func(t *testing.T) {
+		revCache := memrevcache.New()
+		f := &fakeFetcher{replies: []*sciond.PathReply{reply(pathA, pathB), reply(pathA)}}
+		sub := newSubscription(subReq(0), f, revCache, 10*time.Second)
+		sub.Initial(context.Background(), now)
+		assert.Nil(t, sub.Check(context.Background(), now.Add(10*time.Second)))
+		insertRev(t, revCache, ia112, 5)
+		assert.Nil(t, sub.Check(context.Background(), now.Add(11*time.Second)))
+		assert.Equal(t, []sciond.PathReplyEntry{pathA}, sub.current)
+	})
+	t.Run("failed fetch keeps the current paths", func(t *testing.T) {
+		f := &fakeFetcher{replies: []*sciond.PathReply{reply(pathA),
+			{ErrorCode: sciond.ErrorInternal}}}
+		sub := newSubscription(subReq(0), f, memrevcache.New(), 10*time.Second)
+		sub.Initial(context.Background(), now)
+		assert.Nil(t, sub.Check(context.Background(), now.Add(10*time.Second)))
+		assert.Equal(t, []sciond.PathReplyEntry{pathA}, sub.current)
+	})
+	t.Run("default expiry lead", func(t *testing.T) {
+		soon := newEntry(now.Add(2*time.Minute), ia111, 1, ia110, 2)
+		f := &fakeFetcher{replies: []*sciond.PathReply{reply(soon), reply(soon)}}
+		sub := newSubscription(subReq(0), f, memrevcache.New(), 10*time.Second)
+		sub.Initial(context.Background(), now)
+		update := sub.Check(context.Background(), now.Add(time.Second))
+		require.NotNil(t, update)
+		assert.Equal(t, sciond.PathUpdateExpiring, update.Reason)
+	})
+}
+
+type fakeFetcher struct {
+	replies []*sciond.PathReply
+	calls   int
+}
+
+func (f *fakeFetcher) GetPaths(_ context.Context, _ *sciond.PathReq,
+	_ time.Duration) (*sciond.PathReply, error) {
+
+	r := f.replies[f.calls]
+	f.calls++
+	return r, nil
+}
+
+func subReq(lead uint32) *sciond.PathSubscribeReq {
+	return &sciond.PathSubscribeReq{
+		Src:        ia111.IAInt(),
+		Dst:        ia110.IAInt(),
+		ExpiryLead: lead,
+	}
+}
+
+func reply(entries ...sciond.PathReplyEntry) *sciond.PathReply {
+	return &sciond.PathReply{ErrorCode: sciond.ErrorOk, Entries: entries}
+}
+
+// newEntry creates a path entry with the interfaces specified as IA, IFID
+// pairs.
+func newEntry(exp time.Time, intfs ...interface{}) sciond.PathReplyEntry {
+	meta := &sciond.FwdPathMeta{ExpTime: util.TimeToSecs(exp)}
+	for i := 0; i < len(intfs); i += 2 {
+		meta.Interfaces = append(meta.Interfaces, sciond.PathInterface{
+			RawIsdas: intfs[i].(addr.IA).IAInt(),
+			IfID:     common.IFIDType(intfs[i+1].(int)),
+		})
+	}
+	return sciond.PathReplyEntry{Path: meta}
+}
+
+func insertRev(t *testing.T, revCache revcache.RevCache, ia addr.IA, ifID common.IFIDType) {
+	t.Helper()
+	sRev, err := path_mgmt.NewSignedRevInfo(&path_mgmt.RevInfo{
+		IfID:         ifID,
+		RawIsdas:     ia.IAInt(),
+		LinkType:     proto.LinkType_core,
+		RawTimestamp: util.TimeToSecs(time.Now()),
+		RawTTL:       10,
+	}, infra.NullSigner)
+	require.NoError(t, err)
+	_, err = revCache.Insert(context.Background(), sRev)
+	require.NoError(t, err)
+}
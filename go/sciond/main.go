@@ -41,6 +41,7 @@ import (
 	"github.com/scionproto/scion/go/lib/infra/modules/trust/trustdbmetrics"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/pathdb"
+	"github.com/scionproto/scion/go/lib/pathpol"
 	"github.com/scionproto/scion/go/lib/pathstorage"
 	"github.com/scionproto/scion/go/lib/periodic"
 	"github.com/scionproto/scion/go/lib/prom"
@@ -50,6 +51,7 @@ import (
 	"github.com/scionproto/scion/go/proto"
 	"github.com/scionproto/scion/go/sciond/internal/config"
 	"github.com/scionproto/scion/go/sciond/internal/fetcher"
+	"github.com/scionproto/scion/go/sciond/internal/pathhealth"
 	"github.com/scionproto/scion/go/sciond/internal/servers"
 )
 
@@ -145,17 +147,55 @@ func realMain() int {
 		return 1
 	}
 
+	var policies pathpol.PolicyMap
+	if cfg.SD.PathPolicies != "" {
+		if policies, err = pathpol.LoadPolicyMap(cfg.SD.PathPolicies); err != nil {
+			log.Crit("Unable to load path policies", "err", err)
+			return 1
+		}
+		log.Info("Loaded path policies", "policies", len(policies))
+	}
+	var links pathpol.LinkMetadata
+	if cfg.SD.LinkMetadata != "" {
+		md, err := pathpol.LoadLinkMetadata(cfg.SD.LinkMetadata)
+		if err != nil {
+			log.Crit("Unable to load link metadata", "err", err)
+			return 1
+		}
+		log.Info("Loaded link metadata", "interfaces", len(md))
+		links = md
+	}
+	pathFetcher := fetcher.NewFetcher(
+		msger,
+		pathDB,
+		trustStore,
+		verificationFactory{Provider: trustStore},
+		revCache,
+		cfg.SD,
+		policies,
+		links,
+		itopo.Provider(),
+	)
+	if cfg.SD.ProbeInterval.Duration > 0 {
+		monitor := &pathhealth.Monitor{
+			Prober: pathhealth.DefaultProber{LocalIA: itopo.Get().IA()},
+		}
+		prober := periodic.Start(monitor, cfg.SD.ProbeInterval.Duration,
+			cfg.SD.ProbeInterval.Duration)
+		defer prober.Stop()
+		pathFetcher = &pathhealth.Fetcher{
+			Fetcher:  pathFetcher,
+			Monitor:  monitor,
+			DropDead: cfg.SD.DropDeadPaths,
+		}
+	}
 	handlers := servers.HandlerMap{
 		proto.SCIONDMsg_Which_pathReq: &servers.PathRequestHandler{
-			Fetcher: fetcher.NewFetcher(
-				msger,
-				pathDB,
-				trustStore,
-				verificationFactory{Provider: trustStore},
-				revCache,
-				cfg.SD,
-				itopo.Provider(),
-			),
+			Fetcher: pathFetcher,
+		},
+		proto.SCIONDMsg_Which_pathSubscribeReq: &servers.PathSubscriptionHandler{
+			Fetcher:  pathFetcher,
+			RevCache: revCache,
 		},
 		proto.SCIONDMsg_Which_asInfoReq: &servers.ASInfoRequestHandler{
 			ASInspector: trustStore,
@@ -34,6 +34,11 @@ func Init(tunIO io.ReadWriteCloser) {
 		defer log.HandlePanic()
 		reader.NewReader(tunIO).Run()
 	}()
+	// Import the networks announced by remote SIGs.
+	go func() {
+		defer log.HandlePanic()
+		asmap.PrefixAnnounceHdlr()
+	}()
 }
 
 func ReloadConfig(cfg *sigjson.Cfg) bool {
@@ -0,0 +1,220 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package asmap
+
+import (
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/sig_mgmt"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/sigdisp"
+	"github.com/scionproto/scion/go/lib/sigjson"
+	"github.com/scionproto/scion/go/sig/internal/metrics"
+)
+
+// importedNets are the networks learned from the prefix announcements of a
+// remote SIG. The networks are stored unfiltered, such that a changed import
+// filter applies to them.
+type importedNets struct {
+	version uint64
+	nets    []*net.IPNet
+	expiry  time.Time
+}
+
+// PrefixAnnounceHdlr handles the prefix announcements of remote SIGs. The
+// announced networks are imported into the entry of the announcing AS.
+func PrefixAnnounceHdlr() {
+	log.Info("PrefixAnnounceHdlr: starting")
+	for rpld := range sigdisp.Dispatcher.PrefixAnnounceC {
+		ann, ok := rpld.P.(*sig_mgmt.PrefixAnnounce)
+		if !ok {
+			log.Error("PrefixAnnounceHdlr: Unexpected payload type",
+				"type", common.TypeOf(rpld.P), "src", rpld.Addr)
+			continue
+		}
+		src := rpld.Addr.IA.String()
+		ae := Map.ASEntry(rpld.Addr.IA)
+		if ae == nil {
+			log.Warn("PrefixAnnounceHdlr: Announcement from unknown AS", "src", rpld.Addr)
+			metrics.PrefixAnnouncesRecv.WithLabelValues(src, "err_unknown_as").Inc()
+			continue
+		}
+		if err := ae.ImportAnnouncement(ann); err != nil {
+			ae.logger.Warn("Unable to import prefix announcement", "src", rpld.Addr,
+				"announcement", ann, "err", err)
+			metrics.PrefixAnnouncesRecv.WithLabelValues(src, "err_rejected").Inc()
+			continue
+		}
+		metrics.PrefixAnnouncesRecv.WithLabelValues(src, "ok").Inc()
+	}
+}
+
+// ImportAnnouncement imports the networks announced by the SIG of the remote
+// AS. Announcements with an older version than the currently imported one are
+// rejected, announcements with the same version only extend the lifetime of
+// the imported networks. A newer version replaces the imported networks.
+func (ae *ASEntry) ImportAnnouncement(ann *sig_mgmt.PrefixAnnounce) error {
+	ae.Lock()
+	defer ae.Unlock()
+	if ae.importFilter == nil {
+		return common.NewBasicError("Importing announcements not enabled", nil)
+	}
+	expiry := time.Now().Add(ann.LifetimeDuration())
+	if ae.imported != nil {
+		if ann.Version < ae.imported.version {
+			return common.NewBasicError("Outdated announcement", nil,
+				"version", ann.Version, "current", ae.imported.version)
+		}
+		if ann.Version == ae.imported.version {
+			ae.imported.expiry = expiry
+			return nil
+		}
+	}
+	nets, err := ann.Nets()
+	if err != nil {
+		return err
+	}
+	if _, err := ae.filterImported(nets); err != nil {
+		return err
+	}
+	ae.imported = &importedNets{version: ann.Version, nets: nets, expiry: expiry}
+	ae.syncNets()
+	return nil
+}
+
+// expireImported withdraws the imported networks if their lifetime has passed.
+func (ae *ASEntry) expireImported() {
+	ae.Lock()
+	defer ae.Unlock()
+	if ae.imported == nil || time.Now().Before(ae.imported.expiry) {
+		return
+	}
+	ae.logger.Info("Imported networks expired", "version", ae.imported.version)
+	ae.imported = nil
+	ae.syncNets()
+}
+
+// filterImported returns the networks that pass the import filter. An error
+// is returned if more networks pass than the filter allows.
+func (ae *ASEntry) filterImported(nets []*net.IPNet) ([]*net.IPNet, error) {
+	filtered := make([]*net.IPNet, 0, len(nets))
+	for _, n := range nets {
+		if !ae.importFilter.Allows(n) {
+			ae.logger.Debug("Announced network not allowed by import filter", "net", n)
+			continue
+		}
+		filtered = append(filtered, n)
+	}
+	if max := ae.importFilter.MaxNets; max > 0 && len(filtered) > max {
+		return nil, common.NewBasicError("Too many announced networks", nil,
+			"nets", len(filtered), "max", max)
+	}
+	return filtered, nil
+}
+
+// wantedNets returns the configured networks and the imported networks that
+// pass the import filter, keyed by their string representation.
+func (ae *ASEntry) wantedNets() (map[string]*net.IPNet, int) {
+	wanted := make(map[string]*net.IPNet, len(ae.staticNets))
+	for _, n := range ae.staticNets {
+		wanted[n.String()] = n
+	}
+	if ae.imported == nil || ae.importFilter == nil {
+		return wanted, 0
+	}
+	imported, err := ae.filterImported(ae.imported.nets)
+	if err != nil {
+		ae.logger.Error("Ignoring imported networks", "err", err)
+		return wanted, 0
+	}
+	for _, n := range imported {
+		wanted[n.String()] = n
+	}
+	return wanted, len(imported)
+}
+
+// syncNets adds the wanted networks that are not currently set up, and
+// deletes the networks that are no longer wanted. Deletion happens first, such
+// that networks moving between the configured and imported sets do not
+// overlap.
+func (ae *ASEntry) syncNets() bool {
+	s := true
+	wanted, imported := ae.wantedNets()
+	for k, v := range ae.Nets {
+		if _, ok := wanted[k]; ok {
+			continue
+		}
+		if err := ae.delNet(v); err != nil {
+			ae.logger.Error("Unable to delete network", "net", k, "err", err)
+			s = false
+		}
+	}
+	for _, n := range wanted {
+		if err := ae.addNet(n); err != nil {
+			ae.logger.Error("Unable to add network", "net", n, "err", err)
+			s = false
+		}
+	}
+	metrics.ImportedNets.WithLabelValues(ae.IAString).Set(float64(imported))
+	return s
+}
+
+// reloadAnnouncement updates the networks announced to the SIG of the remote
+// AS. A new version is only used if the announced networks changed.
+func (ae *ASEntry) reloadAnnouncement(cfg *sigjson.Cfg, cfgEntry *sigjson.ASEntry) {
+	var nets []*net.IPNet
+	if cfgEntry.Announce {
+		nets = make([]*net.IPNet, 0, len(cfg.AnnouncedNets))
+		for _, n := range cfg.AnnouncedNets {
+			nets = append(nets, n.IPNet())
+		}
+	}
+	key := netsKey(nets)
+	if key == ae.announced && ae.Session.Announcement() != nil {
+		return
+	}
+	if !cfgEntry.Announce && ae.Session.Announcement() == nil {
+		// Nothing was ever announced, there is nothing to withdraw.
+		return
+	}
+	ae.announced = key
+	ae.announceVersion = nextVersion(ae.announceVersion)
+	ae.Session.SetAnnouncement(ae.announceVersion, nets)
+	ae.logger.Info("Announcing networks", "version", ae.announceVersion, "nets", nets)
+}
+
+// netsKey returns a canonical string representation of the set of networks.
+func netsKey(nets []*net.IPNet) string {
+	keys := make([]string, 0, len(nets))
+	for _, n := range nets {
+		keys = append(keys, n.String())
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ",")
+}
+
+// nextVersion returns the announcement version following prev. The version is
+// based on the current time, such that it also increases across restarts.
+func nextVersion(prev uint64) uint64 {
+	v := uint64(time.Now().UnixNano())
+	if v <= prev {
+		return prev + 1
+	}
+	return v
+}
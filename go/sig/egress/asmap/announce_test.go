@@ -0,0 +1,194 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package asmap
+
+import (
+	"net"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/ctrl/sig_mgmt"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/ringbuf"
+	"github.com/scionproto/scion/go/lib/sigjson"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/sig/egress/router"
+)
+
+func TestImportAnnouncement(t *testing.T) {
+	announced := []*net.IPNet{
+		mustParseCIDR(t, "10.1.0.0/16"),
+		mustParseCIDR(t, "2001:db8:1::/48"),
+	}
+	filter := &sigjson.ImportFilter{
+		Allowed: []*sigjson.IPNet{
+			ipNet(t, "10.0.0.0/8"),
+			ipNet(t, "2001:db8::/32"),
+		},
+		MaxNets: 2,
+	}
+
+	t.Run("import disabled", func(t *testing.T) {
+		ae := newTestEntry(t, nil, nil)
+		err := ae.ImportAnnouncement(sig_mgmt.NewPrefixAnnounce(1, time.Minute, announced))
+		assert.Error(t, err)
+		assert.Empty(t, ae.Nets)
+	})
+	t.Run("new version replaces the imported networks", func(t *testing.T) {
+		ae := newTestEntry(t, nil, filter)
+		err := ae.ImportAnnouncement(sig_mgmt.NewPrefixAnnounce(1, time.Minute, announced))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"10.1.0.0/16", "2001:db8:1::/48"}, netKeys(ae))
+		newer := []*net.IPNet{mustParseCIDR(t, "10.2.0.0/16")}
+		err = ae.ImportAnnouncement(sig_mgmt.NewPrefixAnnounce(2, time.Minute, newer))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"10.2.0.0/16"}, netKeys(ae))
+		assert.Equal(t, uint64(2), ae.imported.version)
+	})
+	t.Run("older version is rejected", func(t *testing.T) {
+		ae := newTestEntry(t, nil, filter)
+		err := ae.ImportAnnouncement(sig_mgmt.NewPrefixAnnounce(2, time.Minute, announced))
+		require.NoError(t, err)
+		older := []*net.IPNet{mustParseCIDR(t, "10.2.0.0/16")}
+		err = ae.ImportAnnouncement(sig_mgmt.NewPrefixAnnounce(1, time.Minute, older))
+		assert.Error(t, err)
+		assert.Equal(t, []string{"10.1.0.0/16", "2001:db8:1::/48"}, netKeys(ae))
+		assert.Equal(t, uint64(2), ae.imported.version)
+	})
+	t.Run("same version refreshes the lifetime", func(t *testing.T) {
+		ae := newTestEntry(t, nil, filter)
+		err := ae.ImportAnnouncement(sig_mgmt.NewPrefixAnnounce(1, time.Minute, announced))
+		require.NoError(t, err)
+		expiry := ae.imported.expiry
+		// The networks of a repeated announcement are not reevaluated.
+		other := []*net.IPNet{mustParseCIDR(t, "10.2.0.0/16")}
+		err = ae.ImportAnnouncement(sig_mgmt.NewPrefixAnnounce(1, time.Hour, other))
+		require.NoError(t, err)
+		assert.True(t, ae.imported.expiry.After(expiry))
+		assert.Equal(t, []string{"10.1.0.0/16", "2001:db8:1::/48"}, netKeys(ae))
+	})
+	t.Run("too many networks are rejected", func(t *testing.T) {
+		ae := newTestEntry(t, nil, filter)
+		tooMany := append([]*net.IPNet{mustParseCIDR(t, "10.2.0.0/16")}, announced...)
+		err := ae.ImportAnnouncement(sig_mgmt.NewPrefixAnnounce(1, time.Minute, tooMany))
+		assert.Error(t, err)
+		assert.Nil(t, ae.imported)
+		assert.Empty(t, ae.Nets)
+	})
+	t.Run("disallowed networks do not count towards the limit", func(t *testing.T) {
+		ae := newTestEntry(t, nil, filter)
+		nets := append([]*net.IPNet{mustParseCIDR(t, "192.0.2.0/24")}, announced...)
+		err := ae.ImportAnnouncement(sig_mgmt.NewPrefixAnnounce(1, time.Minute, nets))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"10.1.0.0/16", "2001:db8:1::/48"}, netKeys(ae))
+	})
+}
+
+func TestExpireImported(t *testing.T) {
+	filter := &sigjson.ImportFilter{Allowed: []*sigjson.IPNet{ipNet(t, "10.0.0.0/8")}}
+	static := []*net.IPNet{mustParseCIDR(t, "192.0.2.0/24")}
+	ae := newTestEntry(t, static, filter)
+	ann := sig_mgmt.NewPrefixAnnounce(1, time.Minute,
+		[]*net.IPNet{mustParseCIDR(t, "10.1.0.0/16")})
+	require.NoError(t, ae.ImportAnnouncement(ann))
+
+	ae.expireImported()
+	assert.Equal(t, []string{"10.1.0.0/16", "192.0.2.0/24"}, netKeys(ae))
+
+	ae.imported.expiry = time.Now().Add(-time.Second)
+	ae.expireImported()
+	assert.Nil(t, ae.imported)
+	assert.Equal(t, []string{"192.0.2.0/24"}, netKeys(ae))
+	ia, _ := router.NetMap.Lookup(net.IP{10, 1, 0, 1})
+	assert.True(t, ia.IsZero())
+}
+
+func TestSyncNets(t *testing.T) {
+	static := []*net.IPNet{
+		mustParseCIDR(t, "192.0.2.0/24"),
+		mustParseCIDR(t, "2001:db8:f::/48"),
+	}
+	filter := &sigjson.ImportFilter{Allowed: []*sigjson.IPNet{ipNet(t, "10.0.0.0/8")}}
+	ae := newTestEntry(t, static, filter)
+	ae.imported = &importedNets{
+		version: 1,
+		nets: []*net.IPNet{
+			mustParseCIDR(t, "10.1.0.0/16"),
+			// Configured networks that are also announced are set up once.
+			mustParseCIDR(t, "192.0.2.0/24"),
+			// Not allowed by the import filter.
+			mustParseCIDR(t, "198.51.100.0/24"),
+		},
+		expiry: time.Now().Add(time.Minute),
+	}
+	require.True(t, ae.syncNets())
+	assert.Equal(t, []string{"10.1.0.0/16", "192.0.2.0/24", "2001:db8:f::/48"}, netKeys(ae))
+	for _, ip := range []net.IP{{10, 1, 0, 1}, {192, 0, 2, 1}} {
+		ia, ring := router.NetMap.Lookup(ip)
+		assert.Equal(t, ae.IA, ia, ip)
+		assert.Equal(t, ae.egressRing, ring, ip)
+	}
+
+	// Networks moving from the imported to the configured set are kept, the
+	// networks that are neither configured nor imported are removed.
+	ae.staticNets = []*net.IPNet{mustParseCIDR(t, "10.1.0.0/16")}
+	ae.imported = nil
+	require.True(t, ae.syncNets())
+	assert.Equal(t, []string{"10.1.0.0/16"}, netKeys(ae))
+	ia, _ := router.NetMap.Lookup(net.IP{192, 0, 2, 1})
+	assert.True(t, ia.IsZero())
+}
+
+// newTestEntry creates an entry with the configured networks and import
+// filter. The networks are added to an empty network map.
+func newTestEntry(t *testing.T, static []*net.IPNet, filter *sigjson.ImportFilter) *ASEntry {
+	router.NetMap = &router.Networks{}
+	ia := xtest.MustParseIA("1-ff00:0:110")
+	ae := &ASEntry{
+		logger:       log.New("ia", ia),
+		IA:           ia,
+		IAString:     ia.String(),
+		Nets:         make(map[string]*net.IPNet),
+		egressRing:   ringbuf.New(8, nil, "test"),
+		staticNets:   static,
+		importFilter: filter,
+	}
+	require.True(t, ae.syncNets())
+	return ae
+}
+
+// netKeys returns the sorted keys of the networks of the entry.
+func netKeys(ae *ASEntry) []string {
+	keys := make([]string, 0, len(ae.Nets))
+	for k := range ae.Nets {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func ipNet(t *testing.T, s string) *sigjson.IPNet {
+	return (*sigjson.IPNet)(mustParseCIDR(t, s))
+}
+
+func mustParseCIDR(t *testing.T, s string) *net.IPNet {
+	_, n, err := net.ParseCIDR(s)
+	require.NoError(t, err)
+	return n
+}
@@ -16,6 +16,8 @@
 package asmap
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"net"
 	"sync"
@@ -23,7 +25,9 @@ import (
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/sig_mgmt"
 	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/pktcls"
 	"github.com/scionproto/scion/go/lib/ringbuf"
 	"github.com/scionproto/scion/go/lib/sigjson"
 	"github.com/scionproto/scion/go/sig/egress/dispatcher"
@@ -32,6 +36,7 @@ import (
 	"github.com/scionproto/scion/go/sig/egress/selector"
 	"github.com/scionproto/scion/go/sig/egress/session"
 	"github.com/scionproto/scion/go/sig/internal/base"
+	"github.com/scionproto/scion/go/sig/internal/sigcrypto"
 )
 
 const (
@@ -50,6 +55,29 @@ type ASEntry struct {
 	logger            log.Logger
 
 	Session *session.Session
+	// classSessions are the sessions that carry the traffic of a traffic
+	// class, keyed by session ID.
+	classSessions map[sig_mgmt.SessionType]*classSession
+	selector      *selector.ClassSelector
+
+	// staticNets are the configured networks of the remote AS.
+	staticNets []*net.IPNet
+	// importFilter restricts the networks imported from the prefix
+	// announcements of the remote SIG, nil if announcements are ignored.
+	importFilter *sigjson.ImportFilter
+	// imported are the networks imported from the last announcement.
+	imported *importedNets
+	// announced is the key of the networks announced to the remote SIG, and
+	// announceVersion the version of the announcement.
+	announced       string
+	announceVersion uint64
+}
+
+// classSession is a session that carries the traffic of a traffic class.
+type classSession struct {
+	cfg     *sigjson.Session
+	class   *pktcls.Class
+	session *session.Session
 }
 
 func newASEntry(ia addr.IA) (*ASEntry, error) {
@@ -59,59 +87,142 @@ func newASEntry(ia addr.IA) (*ASEntry, error) {
 		IAString:          ia.String(),
 		Nets:              make(map[string]*net.IPNet),
 		healthMonitorStop: make(chan struct{}),
+		classSessions:     make(map[sig_mgmt.SessionType]*classSession),
 	}
 	var err error
 	pool, err := session.NewPathPool(ia)
 	if err != nil {
 		return nil, err
 	}
-	ae.Session, err = session.NewSession(ia, 0, ae.logger, pool)
+	ae.Session, err = session.NewSession(ia, sigjson.DefaultSessionID, ae.logger, pool)
 	if err != nil {
 		return nil, err
 	}
+	ae.selector = selector.NewClassSelector(ae.Session)
 	return ae, nil
 }
 
 func (ae *ASEntry) ReloadConfig(cfg *sigjson.Cfg, cfgEntry *sigjson.ASEntry) bool {
 	ae.Lock()
 	defer ae.Unlock()
+	ae.Session.SetMultipath(multipathConfig(cfgEntry.Multipath))
 	// Method calls first to prevent skips due to logical short-circuit
-	s := ae.addNewNets(cfgEntry.Nets)
-	return ae.delOldNets(cfgEntry.Nets) && s
+	s := ae.reloadSessions(cfg.Classes, cfgEntry.Sessions)
+	ae.staticNets = make([]*net.IPNet, 0, len(cfgEntry.Nets))
+	for _, ipnet := range cfgEntry.Nets {
+		ae.staticNets = append(ae.staticNets, ipnet.IPNet())
+	}
+	ae.importFilter = cfgEntry.ImportFilter
+	if ae.importFilter == nil {
+		ae.imported = nil
+	}
+	ae.reloadAnnouncement(cfg, cfgEntry)
+	ae.setEncryption(cfgEntry.Encryption)
+	return ae.syncNets() && s
 }
 
-// addNewNets adds the networks in ipnets that are not currently configured.
-func (ae *ASEntry) addNewNets(ipnets []*sigjson.IPNet) bool {
-	s := true
-	for _, ipnet := range ipnets {
-		err := ae.addNet(ipnet.IPNet())
-		if err != nil {
-			ae.logger.Error("Unable to add network", "net", ipnet, "err", err)
-			s = false
-		}
+// setEncryption enables or disables the frame encryption of all sessions to
+// the remote AS, and whether the frames received from it must be encrypted.
+func (ae *ASEntry) setEncryption(encrypted bool) {
+	ae.Session.SetEncrypted(encrypted)
+	for _, cs := range ae.classSessions {
+		cs.session.SetEncrypted(encrypted)
 	}
-	return s
+	sigcrypto.RxKeys.SetRequired(ae.IA, encrypted)
 }
 
-// delOldNets deletes currently configured networks that are not in ipnets.
-func (ae *ASEntry) delOldNets(ipnets []*sigjson.IPNet) bool {
+// reloadSessions creates the configured class sessions, replaces the sessions
+// whose class or path policy changed, removes the sessions that are no longer
+// configured, and updates the session selector.
+func (ae *ASEntry) reloadSessions(classes pktcls.ClassMap, cfgSessions []*sigjson.Session) bool {
 	s := true
-Top:
-	for k, v := range ae.Nets {
-		for _, ipnet := range ipnets {
-			if k == ipnet.IPNet().String() {
-				continue Top
-			}
+	configured := make(map[sig_mgmt.SessionType]bool, len(cfgSessions))
+	var selected []selector.ClassSession
+	var replaced []*session.Session
+	for _, cfgSess := range cfgSessions {
+		configured[cfgSess.ID] = true
+		class := classes[cfgSess.Class]
+		cs, ok := ae.classSessions[cfgSess.ID]
+		if ok && !sessionChanged(cs, class, cfgSess) {
+			// The multipath config can be changed without restarting the session.
+			cs.cfg = cfgSess
+			cs.session.SetMultipath(multipathConfig(cfgSess.Multipath))
+			selected = append(selected, selector.ClassSession{Class: class, Session: cs.session})
+			continue
+		}
+		if ok {
+			replaced = append(replaced, cs.session)
+			delete(ae.classSessions, cfgSess.ID)
 		}
-		err := ae.delNet(v)
+		sess, err := ae.newClassSession(cfgSess)
 		if err != nil {
-			ae.logger.Error("Unable to delete network", "net", k, "err", err)
+			ae.logger.Error("Unable to create session", "sessId", cfgSess.ID, "err", err)
 			s = false
+			continue
+		}
+		ae.classSessions[cfgSess.ID] = &classSession{cfg: cfgSess, class: class, session: sess}
+		selected = append(selected, selector.ClassSession{Class: class, Session: sess})
+		ae.logger.Info("Added session", "sessId", cfgSess.ID, "class", cfgSess.Class)
+	}
+	// Update the selector before removing sessions, such that no packets are
+	// dispatched to sessions that are cleaned up.
+	ae.selector.Set(ae.Session, selected)
+	for _, sess := range replaced {
+		ae.cleanSession(sess)
+	}
+	for id, cs := range ae.classSessions {
+		if configured[id] {
+			continue
 		}
+		ae.cleanSession(cs.session)
+		delete(ae.classSessions, id)
+		ae.logger.Info("Removed session", "sessId", id)
 	}
 	return s
 }
 
+func (ae *ASEntry) newClassSession(cfgSess *sigjson.Session) (*session.Session, error) {
+	pool, err := session.NewPathPoolWithPolicy(ae.IA, cfgSess.PathPolicy)
+	if err != nil {
+		return nil, err
+	}
+	sess, err := session.NewSession(ae.IA, cfgSess.ID, ae.logger, pool)
+	if err != nil {
+		return nil, err
+	}
+	sess.SetMultipath(multipathConfig(cfgSess.Multipath))
+	if ae.egressRing != nil {
+		// The network is already set up, otherwise the session is started by
+		// setupNet.
+		sess.Start()
+	}
+	return sess, nil
+}
+
+// sessionChanged returns whether the class or the path policy of the session
+// differ from the configuration.
+func sessionChanged(cs *classSession, class *pktcls.Class, cfgSess *sigjson.Session) bool {
+	return !jsonEqual(cs.class, class) || !jsonEqual(cs.cfg.PathPolicy, cfgSess.PathPolicy)
+}
+
+// multipathConfig converts the multipath configuration of a session. A nil
+// configuration disables multipath.
+func multipathConfig(cfg *sigjson.Multipath) iface.MultipathConfig {
+	if cfg == nil {
+		return iface.MultipathConfig{}
+	}
+	return iface.MultipathConfig{
+		Mode:     iface.LoadBalancing(cfg.Mode),
+		MaxPaths: cfg.MaxPaths,
+	}
+}
+
+func jsonEqual(a, b interface{}) bool {
+	rawA, errA := json.Marshal(a)
+	rawB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(rawA, rawB)
+}
+
 func (ae *ASEntry) addNet(ipnet *net.IPNet) error {
 	if ae.egressRing == nil {
 		// Ensure that the network setup is done
@@ -172,6 +283,7 @@ Top:
 		case <-ae.healthMonitorStop:
 			break Top
 		case <-ticker.C:
+			ae.expireImported()
 			ae.performHealthCheck(&prevHealth, &prevVersion)
 		}
 	}
@@ -204,6 +316,10 @@ func (ae *ASEntry) performHealthCheck(prevHealth *bool, prevVersion *uint64) {
 }
 
 func (ae *ASEntry) checkHealth() bool {
+	// An entry without a session cannot forward traffic.
+	if ae.Session == nil {
+		return false
+	}
 	return ae.Session.Healthy()
 }
 
@@ -221,12 +337,21 @@ func (ae *ASEntry) Cleanup() error {
 	ae.egressRing.Close()
 	// Clean up sessions, and associated workers.
 	ae.cleanSessions()
+	sigcrypto.RxKeys.SetRequired(ae.IA, false)
 	return nil
 }
 
 func (ae *ASEntry) cleanSessions() {
-	if err := ae.Session.Cleanup(); err != nil {
-		ae.Session.Logger().Error("Error cleaning up session", "err", err)
+	ae.cleanSession(ae.Session)
+	for id, cs := range ae.classSessions {
+		ae.cleanSession(cs.session)
+		delete(ae.classSessions, id)
+	}
+}
+
+func (ae *ASEntry) cleanSession(sess *session.Session) {
+	if err := sess.Cleanup(); err != nil {
+		sess.Logger().Error("Error cleaning up session", "err", err)
 	}
 }
 
@@ -234,13 +359,15 @@ func (ae *ASEntry) setupNet() {
 	ae.egressRing = ringbuf.New(iface.EgressRemotePkts, nil, fmt.Sprintf("egress_%s", ae.IAString))
 	go func() {
 		defer log.HandlePanic()
-		dispatcher.NewDispatcher(ae.IA, ae.egressRing,
-			&selector.SingleSession{Session: ae.Session}).Run()
+		dispatcher.NewDispatcher(ae.IA, ae.egressRing, ae.selector).Run()
 	}()
 	go func() {
 		defer log.HandlePanic()
 		ae.monitorHealth()
 	}()
 	ae.Session.Start()
+	for _, cs := range ae.classSessions {
+		cs.session.Start()
+	}
 	ae.logger.Info("Network setup done")
 }
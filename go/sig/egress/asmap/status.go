@@ -0,0 +1,136 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package asmap
+
+import (
+	"sort"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/ctrl/sig_mgmt"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/sig/egress/session"
+)
+
+var (
+	// ErrUnknownAS indicates that the remote AS is not configured.
+	ErrUnknownAS = serrors.New("unknown AS")
+	// ErrUnknownSession indicates that the session is not configured.
+	ErrUnknownSession = serrors.New("unknown session")
+)
+
+// ASStatus is a snapshot of the state of a remote AS.
+type ASStatus struct {
+	IA      addr.IA
+	Healthy bool
+	// Nets are the networks traffic is routed to the AS for, including the
+	// imported ones.
+	Nets []string
+	// ImportedNets are the networks imported from the prefix announcements of
+	// the remote SIG.
+	ImportedNets []string `json:",omitempty"`
+	Sessions     []SessionStatus
+}
+
+// SessionStatus is a snapshot of the state of a session to the remote AS.
+type SessionStatus struct {
+	// Class is the traffic class of the session, empty for the default
+	// session.
+	Class string `json:",omitempty"`
+	// Started indicates that the session monitor published a status. If it
+	// is false, only the ID is set.
+	Started bool
+	*session.Status
+}
+
+// Status returns the state of all remote ASes, sorted by IA.
+func (am *ASMap) Status() []ASStatus {
+	var res []ASStatus
+	am.Range(func(_ addr.IAInt, ae *ASEntry) bool {
+		res = append(res, ae.Status())
+		return true
+	})
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].IA.IAInt() < res[j].IA.IAInt()
+	})
+	return res
+}
+
+// SwitchPath requests the session to the remote AS to switch to a different
+// path.
+func (am *ASMap) SwitchPath(ia addr.IA, id sig_mgmt.SessionType) error {
+	ae := am.ASEntry(ia)
+	if ae == nil {
+		return serrors.WithCtx(ErrUnknownAS, "ia", ia)
+	}
+	return ae.SwitchPath(id)
+}
+
+// Status returns the state of the remote AS.
+func (ae *ASEntry) Status() ASStatus {
+	ae.RLock()
+	defer ae.RUnlock()
+	s := ASStatus{
+		IA:      ae.IA,
+		Healthy: ae.checkHealth(),
+	}
+	for k := range ae.Nets {
+		s.Nets = append(s.Nets, k)
+	}
+	sort.Strings(s.Nets)
+	if ae.imported != nil && ae.importFilter != nil {
+		if nets, err := ae.filterImported(ae.imported.nets); err == nil {
+			for _, n := range nets {
+				s.ImportedNets = append(s.ImportedNets, n.String())
+			}
+			sort.Strings(s.ImportedNets)
+		}
+	}
+	s.Sessions = append(s.Sessions, sessionStatus(ae.Session, ""))
+	ids := make([]int, 0, len(ae.classSessions))
+	for id := range ae.classSessions {
+		ids = append(ids, int(id))
+	}
+	sort.Ints(ids)
+	for _, id := range ids {
+		cs := ae.classSessions[sig_mgmt.SessionType(id)]
+		s.Sessions = append(s.Sessions, sessionStatus(cs.session, cs.cfg.Class))
+	}
+	return s
+}
+
+// SwitchPath requests the session to switch to a different path.
+func (ae *ASEntry) SwitchPath(id sig_mgmt.SessionType) error {
+	ae.RLock()
+	defer ae.RUnlock()
+	sess := ae.Session
+	if id != sess.SessId {
+		cs, ok := ae.classSessions[id]
+		if !ok {
+			return serrors.WithCtx(ErrUnknownSession, "ia", ae.IA, "session", id)
+		}
+		sess = cs.session
+	}
+	ae.logger.Info("Path switch requested", "sessId", id)
+	sess.SwitchPath()
+	return nil
+}
+
+func sessionStatus(sess *session.Session, class string) SessionStatus {
+	status := sess.Status()
+	if status == nil {
+		return SessionStatus{Class: class, Status: &session.Status{ID: sess.SessId}}
+	}
+	return SessionStatus{Class: class, Started: true, Status: status}
+}
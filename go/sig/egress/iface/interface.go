@@ -28,6 +28,7 @@ import (
 	"github.com/scionproto/scion/go/lib/snet"
 	"github.com/scionproto/scion/go/lib/spath/spathmeta"
 	"github.com/scionproto/scion/go/sig/egress/siginfo"
+	"github.com/scionproto/scion/go/sig/internal/sigcrypto"
 )
 
 func Init() {
@@ -75,6 +76,17 @@ type Session interface {
 type RemoteInfo struct {
 	Sig      *siginfo.Sig
 	SessPath *SessPath
+	// LoadBalancing is the mode used to spread the traffic over Paths.
+	LoadBalancing LoadBalancing
+	// Paths are the paths of a multipath session. If it is empty, all traffic
+	// is sent over SessPath.
+	Paths []WeightedSessPath
+	// Encrypted indicates that the frames must be encrypted with Key. If Key
+	// is not set, no frames are sent.
+	Encrypted bool
+	// Key is the key the frames are encrypted with. The key is shared, not
+	// copied, by Copy.
+	Key *sigcrypto.Key
 }
 
 // Copy created a deep copy of the object.
@@ -82,9 +94,17 @@ func (r *RemoteInfo) Copy() *RemoteInfo {
 	if r == nil {
 		return nil
 	}
+	var paths []WeightedSessPath
+	for _, p := range r.Paths {
+		paths = append(paths, WeightedSessPath{SessPath: p.SessPath.Copy(), Weight: p.Weight})
+	}
 	return &RemoteInfo{
-		Sig:      r.Sig.Copy(),
-		SessPath: r.SessPath.Copy(),
+		Sig:           r.Sig.Copy(),
+		SessPath:      r.SessPath.Copy(),
+		LoadBalancing: r.LoadBalancing,
+		Paths:         paths,
+		Encrypted:     r.Encrypted,
+		Key:           r.Key,
 	}
 }
 
@@ -0,0 +1,245 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iface
+
+import (
+	"math"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/sigjson"
+)
+
+// LoadBalancing is the mode a session uses to spread its traffic over
+// multiple paths. The modes are the ones of the SIG configuration.
+type LoadBalancing string
+
+const (
+	// LoadBalancingNone sends all traffic of a session over a single path.
+	LoadBalancingNone LoadBalancing = ""
+	// LoadBalancingRoundRobin spreads the frames of a session over the paths
+	// by weighted round-robin. Packets of the same flow can be reordered.
+	LoadBalancingRoundRobin LoadBalancing = sigjson.LoadBalancingRoundRobin
+	// LoadBalancingFlowHash sends all packets of a flow, identified by its
+	// 5-tuple, over the same path. Flows are mapped to paths according to the
+	// path weights.
+	LoadBalancingFlowHash LoadBalancing = sigjson.LoadBalancingFlowHash
+)
+
+// DefaultMultipathMaxPaths is the number of paths a multipath session uses if
+// no maximum is configured.
+const DefaultMultipathMaxPaths = 4
+
+// MultipathConfig configures how a session spreads its traffic over multiple
+// paths.
+type MultipathConfig struct {
+	// Mode is the load-balancing mode. LoadBalancingNone disables multipath.
+	Mode LoadBalancing
+	// MaxPaths is the maximum number of paths that are used at the same time.
+	// If it is zero, DefaultMultipathMaxPaths is used.
+	MaxPaths int
+}
+
+// Enabled returns whether traffic is spread over multiple paths.
+func (c MultipathConfig) Enabled() bool {
+	return c.Mode != LoadBalancingNone
+}
+
+// PathLimit returns the maximum number of paths that are used at the same time.
+func (c MultipathConfig) PathLimit() int {
+	if c.MaxPaths <= 0 {
+		return DefaultMultipathMaxPaths
+	}
+	return c.MaxPaths
+}
+
+// WeightedSessPath is a path of a multipath session together with its share of
+// the traffic relative to the other paths of the session.
+type WeightedSessPath struct {
+	SessPath *SessPath
+	Weight   int
+}
+
+// PathSelector chooses the path for each frame of a multipath session. It is
+// not safe for concurrent use; each egress worker owns its own selector.
+type PathSelector struct {
+	mode  LoadBalancing
+	paths []WeightedSessPath
+	// current holds the smooth weighted round-robin state of each path.
+	current []int
+	total   int
+}
+
+// NewPathSelector creates a selector that is initially empty.
+func NewPathSelector() *PathSelector {
+	return &PathSelector{}
+}
+
+// Update sets the paths to choose from. The round-robin state is only reset
+// if the paths or their weights changed.
+func (s *PathSelector) Update(mode LoadBalancing, paths []WeightedSessPath) {
+	changed := mode != s.mode || !sameWeightedPaths(paths, s.paths)
+	// Always store the paths, the metadata of the paths might have changed.
+	s.paths = paths
+	if !changed {
+		return
+	}
+	s.mode = mode
+	s.current = make([]int, len(paths))
+	s.total = 0
+	for _, p := range paths {
+		s.total += p.Weight
+	}
+}
+
+// Mode returns the load-balancing mode the selector currently uses.
+func (s *PathSelector) Mode() LoadBalancing {
+	if len(s.paths) == 0 {
+		return LoadBalancingNone
+	}
+	return s.mode
+}
+
+// Contains returns whether the path is selectable.
+func (s *PathSelector) Contains(path *SessPath) bool {
+	if path == nil {
+		return false
+	}
+	for _, p := range s.paths {
+		if p.SessPath.Key() == path.Key() {
+			return true
+		}
+	}
+	return false
+}
+
+// Next returns the next path in weighted round-robin order, or nil if the
+// selector is empty. The smooth weighted round-robin algorithm is used, such
+// that paths with high weights are interleaved with the other paths instead of
+// being used in bursts.
+func (s *PathSelector) Next() *SessPath {
+	if len(s.paths) == 0 {
+		return nil
+	}
+	best := 0
+	for i, p := range s.paths {
+		s.current[i] += p.Weight
+		if s.current[i] > s.current[best] {
+			best = i
+		}
+	}
+	s.current[best] -= s.total
+	return s.paths[best].SessPath
+}
+
+// ForFlow returns the path for the flow the packet belongs to, or nil if the
+// selector is empty. Weighted rendezvous hashing is used to map flows to
+// paths, such that changing the weight of a path or removing it only moves the
+// flows from or to that path.
+func (s *PathSelector) ForFlow(pkt common.RawBytes) *SessPath {
+	if len(s.paths) == 0 {
+		return nil
+	}
+	var flow [8]byte
+	common.Order.PutUint64(flow[:], FlowHash(pkt))
+	var best *SessPath
+	bestScore := math.Inf(-1)
+	for _, p := range s.paths {
+		h := fnvAdd(fnvAddString(fnvOffset, string(p.SessPath.Key())), flow[:])
+		// Map the hash into the open interval (0, 1).
+		u := (float64(h>>11) + 0.5) / (1 << 53)
+		score := -float64(p.Weight) / math.Log(u)
+		if score > bestScore {
+			best, bestScore = p.SessPath, score
+		}
+	}
+	return best
+}
+
+// FlowHash returns a hash of the 5-tuple of the IPv4 or IPv6 packet. For
+// protocols other than TCP, UDP and SCTP, or for non-first fragments, only the
+// addresses and the protocol are hashed. IPv6 extension headers are not
+// skipped.
+func FlowHash(pkt common.RawBytes) uint64 {
+	h := fnvOffset
+	if len(pkt) == 0 {
+		return h
+	}
+	var proto uint8
+	var l4 common.RawBytes
+	switch pkt[0] >> 4 {
+	case 4:
+		ihl := int(pkt[0]&0x0f) * 4
+		if ihl < 20 || len(pkt) < ihl {
+			break
+		}
+		proto = pkt[9]
+		h = fnvAdd(h, pkt[12:20])
+		// Only the first fragment contains the transport header.
+		if common.Order.Uint16(pkt[6:8])&0x1fff == 0 {
+			l4 = pkt[ihl:]
+		}
+	case 6:
+		if len(pkt) < 40 {
+			break
+		}
+		proto = pkt[6]
+		h = fnvAdd(h, pkt[8:40])
+		l4 = pkt[40:]
+	}
+	h = fnvAdd(h, []byte{proto})
+	switch proto {
+	case 6, 17, 132:
+		// TCP, UDP and SCTP start with the source and destination ports.
+		if len(l4) >= 4 {
+			h = fnvAdd(h, l4[:4])
+		}
+	}
+	return h
+}
+
+// The FNV-1a hash is computed inline, to not allocate a hash.Hash64 for each
+// packet.
+const (
+	fnvOffset uint64 = 14695981039346656037
+	fnvPrime  uint64 = 1099511628211
+)
+
+func fnvAdd(h uint64, b []byte) uint64 {
+	for _, c := range b {
+		h ^= uint64(c)
+		h *= fnvPrime
+	}
+	return h
+}
+
+func fnvAddString(h uint64, s string) uint64 {
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= fnvPrime
+	}
+	return h
+}
+
+func sameWeightedPaths(a, b []WeightedSessPath) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Weight != b[i].Weight || a[i].SessPath.Key() != b[i].SessPath.Key() {
+			return false
+		}
+	}
+	return true
+}
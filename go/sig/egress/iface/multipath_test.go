@@ -0,0 +1,145 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iface_test
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/snet/mock_snet"
+	"github.com/scionproto/scion/go/lib/spath/spathmeta"
+	"github.com/scionproto/scion/go/sig/egress/iface"
+)
+
+func TestPathSelectorNext(t *testing.T) {
+	a := iface.NewSessPath("a", nil)
+	b := iface.NewSessPath("b", nil)
+	s := iface.NewPathSelector()
+	assert.Nil(t, s.Next())
+	assert.Equal(t, iface.LoadBalancingNone, s.Mode())
+
+	s.Update(iface.LoadBalancingRoundRobin, []iface.WeightedSessPath{
+		{SessPath: a, Weight: 3},
+		{SessPath: b, Weight: 1},
+	})
+	assert.Equal(t, iface.LoadBalancingRoundRobin, s.Mode())
+	var picked []snet.PathFingerprint
+	for i := 0; i < 8; i++ {
+		picked = append(picked, s.Next().Key())
+	}
+	// Smooth weighted round-robin interleaves the paths.
+	assert.Equal(t, []snet.PathFingerprint{"a", "a", "b", "a", "a", "a", "b", "a"}, picked)
+}
+
+func TestPathSelectorForFlow(t *testing.T) {
+	paths := []iface.WeightedSessPath{
+		{SessPath: iface.NewSessPath("a", nil), Weight: 1},
+		{SessPath: iface.NewSessPath("b", nil), Weight: 1},
+		{SessPath: iface.NewSessPath("c", nil), Weight: 2},
+	}
+	s := iface.NewPathSelector()
+	s.Update(iface.LoadBalancingFlowHash, paths)
+
+	const flows = 2000
+	assigned := make(map[uint16]snet.PathFingerprint, flows)
+	counts := make(map[snet.PathFingerprint]int)
+	for port := uint16(1); port <= flows; port++ {
+		path := s.ForFlow(newUDPPacket(t, port, []byte{1, 2, 3}))
+		// Packets of the same flow always take the same path.
+		assert.Equal(t, path, s.ForFlow(newUDPPacket(t, port, []byte{4, 5, 6, 7})))
+		assigned[port] = path.Key()
+		counts[path.Key()]++
+	}
+	// The flows are spread according to the weights.
+	assert.InDelta(t, flows/4, counts["a"], flows/10)
+	assert.InDelta(t, flows/4, counts["b"], flows/10)
+	assert.InDelta(t, flows/2, counts["c"], flows/10)
+
+	// Removing a path only moves the flows of that path.
+	s.Update(iface.LoadBalancingFlowHash, paths[1:])
+	for port := uint16(1); port <= flows; port++ {
+		key := s.ForFlow(newUDPPacket(t, port, nil)).Key()
+		if assigned[port] != "a" {
+			assert.Equal(t, assigned[port], key)
+		} else {
+			assert.NotEqual(t, snet.PathFingerprint("a"), key)
+		}
+	}
+}
+
+func TestFlowHash(t *testing.T) {
+	base := iface.FlowHash(newUDPPacket(t, 1000, []byte{1}))
+	assert.Equal(t, base, iface.FlowHash(newUDPPacket(t, 1000, []byte{2, 3})))
+	assert.NotEqual(t, base, iface.FlowHash(newUDPPacket(t, 1001, []byte{1})))
+	assert.NotPanics(t, func() { iface.FlowHash(common.RawBytes{0x45, 0}) })
+	assert.NotPanics(t, func() { iface.FlowHash(common.RawBytes{0x60}) })
+	assert.NotPanics(t, func() { iface.FlowHash(nil) })
+}
+
+func TestSessPathPoolGetMultiple(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	aps := make(spathmeta.AppPathSet)
+	for _, key := range []snet.PathFingerprint{"a", "b", "c"} {
+		path := mock_snet.NewMockPath(ctrl)
+		path.EXPECT().Expiry().Return(time.Now().Add(time.Hour)).AnyTimes()
+		aps[key] = path
+	}
+	pool := iface.NewSessPathPool()
+	pool.Update(aps)
+
+	// Paths with replies are preferred over paths without RTT samples.
+	pool.Reply(pool.GetByKey("b"), time.Now().Add(-5*time.Millisecond))
+	paths := pool.GetMultiple(2)
+	require.Len(t, paths, 2)
+	assert.Equal(t, snet.PathFingerprint("b"), paths[0].SessPath.Key())
+	assert.Greater(t, paths[0].Weight, paths[1].Weight)
+
+	// Paths that time out repeatedly are no longer used.
+	for i := 0; i < 3; i++ {
+		pool.Timeout(pool.GetByKey("a"), time.Now())
+	}
+	paths = pool.GetMultiple(3)
+	require.Len(t, paths, 2)
+	for _, p := range paths {
+		assert.NotEqual(t, snet.PathFingerprint("a"), p.SessPath.Key())
+	}
+}
+
+func newUDPPacket(t *testing.T, srcPort uint16, pld []byte) common.RawBytes {
+	ip := &layers.IPv4{
+		Version:  4,
+		TTL:      64,
+		Protocol: layers.IPProtocolUDP,
+		SrcIP:    net.IP{192, 0, 2, 1},
+		DstIP:    net.IP{198, 51, 100, 1},
+	}
+	udp := &layers.UDP{SrcPort: layers.UDPPort(srcPort), DstPort: 53}
+	buf := gopacket.NewSerializeBuffer()
+	err := gopacket.SerializeLayers(buf, gopacket.SerializeOptions{FixLengths: true},
+		ip, udp, gopacket.Payload(pld))
+	require.NoError(t, err)
+	return buf.Bytes()
+}
@@ -16,13 +16,25 @@ package iface
 
 import (
 	"math"
+	"sort"
 	"time"
 
 	"github.com/scionproto/scion/go/lib/snet"
 	"github.com/scionproto/scion/go/lib/spath/spathmeta"
 )
 
-const pathFailExpiration = 5 * time.Minute
+const (
+	pathFailExpiration = 5 * time.Minute
+	// multipathMaxFails is the number of recent probe timeouts after which a
+	// path is no longer used by multipath sessions.
+	multipathMaxFails = 3
+	// weightRTT is the RTT at which a path gets half of maxPathWeight.
+	weightRTT = 10 * time.Millisecond
+	// maxPathWeight is the weight of a path with a negligible RTT.
+	maxPathWeight = 100
+	// unknownPathRTT is the RTT assumed for paths without any probe reply.
+	unknownPathRTT = 500 * time.Millisecond
+)
 
 type SessPathPool map[snet.PathFingerprint]*SessPathStats
 
@@ -66,6 +78,34 @@ func (spp SessPathPool) Get(exclude snet.PathFingerprint) *SessPath {
 	return res.SessPath
 }
 
+// GetMultiple returns up to max healthy paths with their load-balancing
+// weights, best paths first. Paths that are close to expiry or that recently
+// timed out repeatedly are not considered healthy.
+func (spp SessPathPool) GetMultiple(max int) []WeightedSessPath {
+	var candidates []*SessPathStats
+	for _, v := range spp {
+		if v.failCount >= multipathMaxFails || v.SessPath.IsCloseToExpiry() {
+			continue
+		}
+		candidates = append(candidates, v)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		wi, wj := candidates[i].Weight(), candidates[j].Weight()
+		if wi != wj {
+			return wi > wj
+		}
+		return candidates[i].SessPath.Key() < candidates[j].SessPath.Key()
+	})
+	if len(candidates) > max {
+		candidates = candidates[:max]
+	}
+	var paths []WeightedSessPath
+	for _, c := range candidates {
+		paths = append(paths, WeightedSessPath{SessPath: c.SessPath, Weight: c.Weight()})
+	}
+	return paths
+}
+
 func (spp SessPathPool) GetByKey(key snet.PathFingerprint) *SessPath {
 	res := spp[key]
 	if res == nil {
@@ -100,6 +140,17 @@ func (spp SessPathPool) Update(aps spathmeta.AppPathSet) {
 // Reply is called when a probe reply arrives.
 // 'sent' is the time when the original probe was sent.
 func (spp SessPathPool) Reply(path *SessPath, sent time.Time) {
+	sp := spp[path.Key()]
+	if sp == nil {
+		return
+	}
+	rtt := time.Since(sent)
+	if sp.rtt == 0 {
+		sp.rtt = rtt
+		return
+	}
+	// Smooth the RTT the same way TCP does, with a gain of 1/8.
+	sp.rtt += (rtt - sp.rtt) / 8
 }
 
 // Timeout is called when a reply to a probe is not received in time.
@@ -127,6 +178,39 @@ type SessPathStats struct {
 	SessPath  *SessPath
 	lastFail  time.Time
 	failCount uint16
+	// rtt is the smoothed RTT of the probes sent over the path. It is zero if
+	// no reply was received yet.
+	rtt time.Duration
+}
+
+// RTT returns the smoothed probe RTT of the path, or zero if it is unknown.
+func (sp *SessPathStats) RTT() time.Duration {
+	return sp.rtt
+}
+
+// FailCount returns the number of recent probe timeouts of the path.
+func (sp *SessPathStats) FailCount() uint16 {
+	return sp.failCount
+}
+
+// LastFail returns when the last probe over the path timed out.
+func (sp *SessPathStats) LastFail() time.Time {
+	return sp.lastFail
+}
+
+// Weight returns the share of traffic the path should carry in a multipath
+// session. The weight is inversely proportional to the RTT, and is divided by
+// the number of recent timeouts. It is always at least 1.
+func (sp *SessPathStats) Weight() int {
+	rtt := sp.rtt
+	if rtt == 0 {
+		rtt = unknownPathRTT
+	}
+	w := int(maxPathWeight*weightRTT/(rtt+weightRTT)) / (1 + int(sp.failCount))
+	if w < 1 {
+		return 1
+	}
+	return w
 }
 
 func newSessPathStats(key snet.PathFingerprint, path snet.Path) *SessPathStats {
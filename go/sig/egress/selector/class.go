@@ -0,0 +1,75 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"sync/atomic"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/pktcls"
+	"github.com/scionproto/scion/go/sig/egress/iface"
+)
+
+var _ iface.SessionSelector = (*ClassSelector)(nil)
+
+// ClassSession maps a traffic class to a session.
+type ClassSession struct {
+	Class   *pktcls.Class
+	Session iface.Session
+}
+
+// ClassSelector implements iface.SessionSelector. It classifies packets, and
+// returns the session of the first class that matches the packet. If no class
+// matches, the default session is returned. The sessions can be updated
+// concurrently with ChooseSess.
+type ClassSelector struct {
+	state atomic.Value
+}
+
+type classSelectorState struct {
+	sessions []ClassSession
+	def      iface.Session
+}
+
+// NewClassSelector creates a selector that returns def for all packets, until
+// the class sessions are set.
+func NewClassSelector(def iface.Session) *ClassSelector {
+	cs := &ClassSelector{}
+	cs.Set(def, nil)
+	return cs
+}
+
+// Set replaces the default session and the class sessions. The class sessions
+// are evaluated in order.
+func (cs *ClassSelector) Set(def iface.Session, sessions []ClassSession) {
+	cs.state.Store(&classSelectorState{
+		sessions: append([]ClassSession(nil), sessions...),
+		def:      def,
+	})
+}
+
+func (cs *ClassSelector) ChooseSess(b common.RawBytes) iface.Session {
+	state := cs.state.Load().(*classSelectorState)
+	if len(state.sessions) == 0 {
+		return state.def
+	}
+	pkt := pktcls.NewPacket(b)
+	for _, s := range state.sessions {
+		if s.Class.Eval(pkt) {
+			return s.Session
+		}
+	}
+	return state.def
+}
@@ -0,0 +1,83 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"net"
+	"testing"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/pktcls"
+	"github.com/scionproto/scion/go/sig/egress/iface"
+)
+
+func TestClassSelector(t *testing.T) {
+	def, voip, bulk := &testSession{}, &testSession{}, &testSession{}
+	cs := NewClassSelector(def)
+	efPkt := ipv4Packet(t, 0x2e<<2)
+	cs1Pkt := ipv4Packet(t, 0x08<<2)
+	bePkt := ipv4Packet(t, 0)
+
+	assert.True(t, def == cs.ChooseSess(efPkt))
+
+	cs.Set(def, []ClassSession{
+		{
+			Class: pktcls.NewClass("voip",
+				pktcls.NewCondIPv4(&pktcls.IPv4MatchDSCP{DSCP: 0x2e})),
+			Session: voip,
+		},
+		{
+			Class: pktcls.NewClass("bulk",
+				pktcls.NewCondIPv4(&pktcls.IPv4MatchDSCP{DSCP: 0x08})),
+			Session: bulk,
+		},
+		{
+			Class:   pktcls.NewClass("all", pktcls.CondTrue),
+			Session: bulk,
+		},
+	})
+	assert.True(t, voip == cs.ChooseSess(efPkt))
+	assert.True(t, bulk == cs.ChooseSess(cs1Pkt))
+	// The first matching class wins.
+	assert.True(t, bulk == cs.ChooseSess(bePkt))
+
+	cs.Set(def, []ClassSession{{Class: pktcls.NewClass("none", pktcls.CondFalse)}})
+	assert.True(t, def == cs.ChooseSess(efPkt))
+}
+
+func ipv4Packet(t *testing.T, tos uint8) common.RawBytes {
+	buf := gopacket.NewSerializeBuffer()
+	ip := &layers.IPv4{
+		Version:  4,
+		TOS:      tos,
+		TTL:      64,
+		Protocol: layers.IPProtocolUDP,
+		SrcIP:    net.IP{192, 0, 2, 1},
+		DstIP:    net.IP{192, 0, 2, 2},
+	}
+	opts := gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}
+	require.NoError(t, gopacket.SerializeLayers(buf, opts, ip, gopacket.Payload([]byte{0})))
+	return buf.Bytes()
+}
+
+// testSession is a distinct session value. Its methods are not used.
+type testSession struct {
+	iface.Session
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/scionproto/scion/go/lib/ctrl/sig_mgmt"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/pathmgr"
+	"github.com/scionproto/scion/go/lib/pathpol"
 	"github.com/scionproto/scion/go/lib/pktdisp"
 	"github.com/scionproto/scion/go/lib/ringbuf"
 	"github.com/scionproto/scion/go/lib/snet"
@@ -60,6 +61,17 @@ type Session struct {
 	pktDispStop    chan struct{}
 	pktDispStopped chan struct{}
 	workerStopped  chan struct{}
+	// multipath holds the iface.MultipathConfig of the session.
+	multipath atomic.Value
+	// announcement holds the *sig_mgmt.PrefixAnnounce that is sent to the
+	// remote SIG, nil if no prefixes are announced.
+	announcement atomic.Value
+	// encrypted holds whether the frames of the session are encrypted.
+	encrypted atomic.Value
+	// status holds the *Status published by the session monitor.
+	status atomic.Value
+	// switchPath requests the session monitor to switch to a different path.
+	switchPath chan struct{}
 }
 
 func NewSession(dstIA addr.IA, sessId sig_mgmt.SessionType, logger log.Logger,
@@ -74,6 +86,11 @@ func NewSession(dstIA addr.IA, sessId sig_mgmt.SessionType, logger log.Logger,
 	}
 	s.currRemote.Store((*iface.RemoteInfo)(nil))
 	s.healthy.Store(false)
+	s.multipath.Store(iface.MultipathConfig{})
+	s.announcement.Store((*sig_mgmt.PrefixAnnounce)(nil))
+	s.encrypted.Store(false)
+	s.status.Store((*Status)(nil))
+	s.switchPath = make(chan struct{}, 1)
 	s.ring = ringbuf.New(64, nil, fmt.Sprintf("egress_%s_%s", dstIA, sessId))
 	// Not using a fixed local port, as this is for outgoing data only.
 	s.conn, err = sigcmn.Network.Listen(context.Background(), "udp",
@@ -153,6 +170,60 @@ func (s *Session) Healthy() bool {
 	return s.healthy.Load().(bool)
 }
 
+// SetMultipath configures how the session spreads its traffic over multiple
+// paths. It can be called at any time; the session monitor picks up the change
+// on its next tick.
+func (s *Session) SetMultipath(cfg iface.MultipathConfig) {
+	s.multipath.Store(cfg)
+}
+
+// Multipath returns the multipath configuration of the session.
+func (s *Session) Multipath() iface.MultipathConfig {
+	return s.multipath.Load().(iface.MultipathConfig)
+}
+
+// SetAnnouncement sets the local networks that are announced to the remote
+// SIG. The version must be increased whenever the networks change. An empty
+// set of networks withdraws all previously announced networks. The session
+// monitor sends the announcement periodically.
+func (s *Session) SetAnnouncement(version uint64, nets []*net.IPNet) {
+	s.announcement.Store(sig_mgmt.NewPrefixAnnounce(version, AnnounceLifetime, nets))
+}
+
+// Announcement returns the announcement that is sent to the remote SIG, or nil
+// if no networks are announced.
+func (s *Session) Announcement() *sig_mgmt.PrefixAnnounce {
+	return s.announcement.Load().(*sig_mgmt.PrefixAnnounce)
+}
+
+// SetEncrypted enables or disables the encryption of the frames of the
+// session. If enabled, no frames are sent until the remote SIG acknowledged a
+// frame key. The session monitor picks up the change on its next tick.
+func (s *Session) SetEncrypted(encrypted bool) {
+	s.encrypted.Store(encrypted)
+}
+
+// Encrypted returns whether the frames of the session are encrypted.
+func (s *Session) Encrypted() bool {
+	return s.encrypted.Load().(bool)
+}
+
+// Status returns the last status published by the session monitor, or nil if
+// the session was not started yet.
+func (s *Session) Status() *Status {
+	return s.status.Load().(*Status)
+}
+
+// SwitchPath requests the session monitor to switch to a different path, even
+// if the current path is healthy. The switch happens asynchronously.
+func (s *Session) SwitchPath() {
+	select {
+	case s.switchPath <- struct{}{}:
+	default:
+		// A switch is already pending.
+	}
+}
+
 func (s *Session) PathPool() iface.PathPool {
 	return s.pool
 }
@@ -169,7 +240,19 @@ type PathPool struct {
 var _ iface.PathPool = (*PathPool)(nil)
 
 func NewPathPool(dst addr.IA) (*PathPool, error) {
-	pool, err := sigcmn.PathMgr.Watch(context.TODO(), sigcmn.IA, dst)
+	return NewPathPoolWithPolicy(dst, nil)
+}
+
+// NewPathPoolWithPolicy creates a path pool that only contains the paths
+// allowed by the policy. If the policy is nil, all paths are used.
+func NewPathPoolWithPolicy(dst addr.IA, policy *pathpol.Policy) (*PathPool, error) {
+	var pool *pathmgr.SyncPaths
+	var err error
+	if policy != nil {
+		pool, err = sigcmn.PathMgr.WatchFilter(context.TODO(), sigcmn.IA, dst, policy)
+	} else {
+		pool, err = sigcmn.PathMgr.Watch(context.TODO(), sigcmn.IA, dst)
+	}
 	if err != nil {
 		return nil, common.NewBasicError("Unable to register watch", err)
 	}
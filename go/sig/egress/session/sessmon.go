@@ -16,6 +16,7 @@
 package session
 
 import (
+	"context"
 	"time"
 
 	"github.com/scionproto/scion/go/lib/addr"
@@ -25,10 +26,13 @@ import (
 	"github.com/scionproto/scion/go/lib/infra"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/sigdisp"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/proto"
 	"github.com/scionproto/scion/go/sig/egress/iface"
 	"github.com/scionproto/scion/go/sig/egress/siginfo"
 	"github.com/scionproto/scion/go/sig/internal/metrics"
 	"github.com/scionproto/scion/go/sig/internal/sigcmn"
+	"github.com/scionproto/scion/go/sig/internal/sigcrypto"
 )
 
 const (
@@ -37,6 +41,13 @@ const (
 	tout          = 1 * time.Second
 	writeTout     = 100 * time.Millisecond
 	pathExpiryLen = 10 * time.Second
+	// How often the prefix announcement is repeated.
+	announceInterval = 10 * time.Second
+	// AnnounceLifetime is how long a remote SIG keeps the announced prefixes
+	// if the announcement is not repeated.
+	AnnounceLifetime = 3 * announceInterval
+	// How often an unacknowledged frame key is announced again.
+	keyAnnounceInterval = 1 * time.Second
 )
 
 // sessMonitor is responsible for monitoring a session, polling remote SIGs, and switching
@@ -59,6 +70,23 @@ type sessMonitor struct {
 	updateMsgId sig_mgmt.MsgIdType
 	// the last time a PollRep was received.
 	lastReply time.Time
+	// the paths of a multipath session that are probed in addition to the
+	// path in smRemote, keyed by the id of the outstanding PollReq.
+	probes map[sig_mgmt.MsgIdType]*iface.SessPath
+	// the version of the last prefix announcement sent, when that version
+	// was first sent, when it was last sent, and to which remote SIG.
+	announceVersion uint64
+	announceSince   time.Time
+	lastAnnounce    time.Time
+	announceRemote  *siginfo.Sig
+	// the frame key that is announced to the remote SIG, but not yet
+	// acknowledged, when it was last announced, and the remote SIG the
+	// current key was acknowledged by.
+	pendingKey      *sigcrypto.Key
+	lastKeyAnnounce time.Time
+	keyRemote       *siginfo.Sig
+	// the most recent changes of the session health, oldest first.
+	healthHistory []HealthChange
 }
 
 func newSessMonitor(sess *Session) *sessMonitor {
@@ -70,6 +98,7 @@ func newSessMonitor(sess *Session) *sessMonitor {
 		sess:         sess,
 		pool:         sess.pool,
 		sessPathPool: iface.NewSessPathPool(),
+		probes:       make(map[sig_mgmt.MsgIdType]*iface.SessPath),
 	}
 }
 
@@ -101,8 +130,19 @@ Top:
 		case <-reqTick.C:
 			sm.updatePaths()
 			sm.updateRemote()
+			sm.updateMultipath()
 			sm.sendReq()
+			sm.sendMultipathProbes()
+			sm.sendAnnouncement()
+			sm.updateKey()
+			sm.sess.status.Store(sm.newStatus())
+		case <-sm.sess.switchPath:
+			sm.forcePathSwitch()
 		case rpld := <-regc:
+			if ack, ok := rpld.P.(*sig_mgmt.KeyAck); ok {
+				sm.handleKeyAck(rpld, ack)
+				continue
+			}
 			sm.handleRep(rpld)
 		case <-pathExpiryTick.C:
 			sm.sessPathPool.ExpireFails()
@@ -149,7 +189,7 @@ func (sm *sessMonitor) updateRemote() {
 		metrics.SessionTimedOut.WithLabelValues(
 			sm.sess.IA().String(),
 			sm.sess.SessId.String()).Inc()
-		sm.setHealth(false)
+		sm.setHealth(false, "timeout")
 		if sm.smRemote.SessPath != nil {
 			// Update path statistics. This is a bit of a stretch. The path
 			// may be OK, but the remote SIG may be down. However, we accept
@@ -171,7 +211,7 @@ func (sm *sessMonitor) updateRemote() {
 	// but also when the pool is empty. Try to get a new path.
 	if sm.smRemote.SessPath == nil {
 		sm.logger.Info("sessMonitor: Path not available", "remote", sm.smRemote)
-		sm.setHealth(false)
+		sm.setHealth(false, "no_path")
 		// Start monitoring the new path.
 		sm.smRemote.SessPath = sm.getNewPath(sm.smRemote.SessPath, "no_path")
 		sm.updateSessSnap()
@@ -209,6 +249,59 @@ func (sm *sessMonitor) updateRemote() {
 	}
 }
 
+// forcePathSwitch switches to a different path on request of an operator. The
+// new path is used immediately, and is checked by the regular polling.
+func (sm *sessMonitor) forcePathSwitch() {
+	if sm.smRemote.SessPath == nil {
+		sm.logger.Info("sessMonitor: Ignoring forced path switch, no path available")
+		return
+	}
+	old := sm.smRemote.SessPath
+	sm.smRemote.SessPath = sm.getNewPath(old, "forced")
+	sm.updateSessSnap()
+	sm.sess.status.Store(sm.newStatus())
+	sm.logger.Info("sessMonitor: Forced path switch", "old", old, "remote", sm.smRemote)
+}
+
+// updateMultipath updates the set of paths a multipath session spreads its
+// traffic over. Multipath is only used if there are at least two healthy paths;
+// otherwise, all traffic is sent over the path in smRemote.
+func (sm *sessMonitor) updateMultipath() {
+	cfg := sm.sess.Multipath()
+	var paths []iface.WeightedSessPath
+	if cfg.Enabled() {
+		paths = sm.sessPathPool.GetMultiple(cfg.PathLimit())
+		if len(paths) < 2 {
+			paths = nil
+		}
+	}
+	metrics.SessionActivePaths.WithLabelValues(sm.sess.IA().String(),
+		sm.sess.SessId.String()).Set(float64(len(paths)))
+	if cfg.Mode == sm.smRemote.LoadBalancing && !multipathChanged(sm.smRemote.Paths, paths) {
+		return
+	}
+	sm.smRemote.LoadBalancing = cfg.Mode
+	sm.smRemote.Paths = paths
+	sm.updateSessSnap()
+	sm.logger.Debug("sessMonitor: Updated multipath set", "mode", cfg.Mode, "paths", len(paths))
+}
+
+// multipathChanged returns whether the paths, their weights or their metadata
+// differ.
+func multipathChanged(old, new []iface.WeightedSessPath) bool {
+	if len(old) != len(new) {
+		return true
+	}
+	for i := range old {
+		o, n := old[i].SessPath.Path(), new[i].SessPath.Path()
+		if old[i].SessPath.Key() != new[i].SessPath.Key() || old[i].Weight != new[i].Weight ||
+			o.Expiry() != n.Expiry() || o.MTU() != n.MTU() {
+			return true
+		}
+	}
+	return false
+}
+
 // updateSessSnap updates the remote snapshot in the session. If the new remote
 // SIG host is an SVC address, the previous host of the session is kept.
 func (sm *sessMonitor) updateSessSnap() {
@@ -257,9 +350,205 @@ func (sm *sessMonitor) sendReq() {
 		return
 	}
 	sm.updateMsgId = sig_mgmt.MsgIdType(time.Now().UnixNano())
+	sm.sendPoll(sm.smRemote.SessPath, sm.updateMsgId)
+}
+
+// sendMultipathProbes probes the paths of a multipath session that are not
+// probed by sendReq. Probes that were not answered in time are reported as
+// timeouts to the path pool, such that broken paths are no longer used.
+func (sm *sessMonitor) sendMultipathProbes() {
+	for id, path := range sm.probes {
+		if time.Since(id.Time()) > tout {
+			sm.sessPathPool.Timeout(path, id.Time())
+			delete(sm.probes, id)
+		}
+	}
+	// Only probe once the remote SIG is known, anycast polls would not measure
+	// the path to the SIG the traffic is sent to.
+	if sm.smRemote.Sig.Host.Equal(addr.SvcSIG) {
+		return
+	}
+	now := time.Now()
+	for i, p := range sm.smRemote.Paths {
+		if sm.smRemote.SessPath != nil && p.SessPath.Key() == sm.smRemote.SessPath.Key() {
+			continue
+		}
+		// Offset the id by the index, such that all probes have different ids.
+		id := sig_mgmt.MsgIdType(now.UnixNano() + int64(i))
+		sm.probes[id] = p.SessPath
+		sm.sendPoll(p.SessPath, id)
+	}
+}
+
+// sendAnnouncement announces the local networks to the remote SIG. The
+// announcement is sent again every announceInterval, and immediately if the
+// announcement or the remote SIG changed. A withdrawal of all networks is only
+// repeated for one lifetime, after which the remote SIG has expired them.
+func (sm *sessMonitor) sendAnnouncement() {
+	ann := sm.sess.Announcement()
+	// Only announce once the remote SIG is known, such that all announcements
+	// reach the same SIG.
+	if ann == nil || sm.smRemote.SessPath == nil || sm.smRemote.Sig.Host.Equal(addr.SvcSIG) {
+		return
+	}
+	now := time.Now()
+	if ann.Version != sm.announceVersion {
+		sm.announceVersion = ann.Version
+		sm.announceSince = now
+		sm.lastAnnounce = time.Time{}
+	}
+	if len(ann.Prefixes) == 0 && now.Sub(sm.announceSince) > ann.LifetimeDuration() {
+		return
+	}
+	if now.Sub(sm.lastAnnounce) < announceInterval && sm.smRemote.Sig.Equal(sm.announceRemote) {
+		return
+	}
+	sm.lastAnnounce = now
+	sm.announceRemote = sm.smRemote.Sig
+	sm.logger.Debug("sessMonitor: announcing prefixes", "remote", sm.smRemote.Sig,
+		"announcement", ann)
+	sm.sendCtrl(ann, sm.smRemote.SessPath, sig_mgmt.MsgIdType(now.UnixNano()))
+}
+
+// updateKey rotates the frame key of an encrypted session. A new key is
+// generated every sigcrypto.RekeyInterval, when the sequence numbers of the
+// current key are exhausted, or when the remote SIG changes. The new key is
+// announced to the remote SIG until it is acknowledged, the current key is used
+// in the meantime. A new key that is not acknowledged within
+// sigcrypto.AnnounceFreshness is replaced, as the remote SIG rejects it.
+func (sm *sessMonitor) updateKey() {
+	encrypted := sm.sess.Encrypted()
+	if encrypted != sm.smRemote.Encrypted {
+		sm.logger.Info("sessMonitor: frame encryption changed", "encrypted", encrypted)
+		sm.smRemote.Encrypted = encrypted
+		sm.smRemote.Key = nil
+		sm.pendingKey = nil
+		sm.keyRemote = nil
+		sm.updateSessSnap()
+	}
+	// Keys can only be announced once the remote SIG is known.
+	if !encrypted || sm.smRemote.SessPath == nil || sm.smRemote.Sig.Host.Equal(addr.SvcSIG) {
+		return
+	}
+	now := time.Now()
+	if curr := sm.smRemote.Key; curr != nil && now.After(curr.NotAfter) {
+		sm.logger.Info("sessMonitor: frame key expired", "epoch", curr.Epoch)
+		sm.smRemote.Key = nil
+		sm.updateSessSnap()
+	}
+	force := !sm.smRemote.Sig.Equal(sm.keyRemote)
+	if force {
+		sm.keyRemote = sm.smRemote.Sig
+		sm.pendingKey = nil
+	}
+	if sm.pendingKey != nil && now.Sub(sm.pendingKey.Created) > sigcrypto.AnnounceFreshness {
+		sm.logger.Info("sessMonitor: frame key not acknowledged, replacing",
+			"epoch", sm.pendingKey.Epoch)
+		sm.pendingKey = nil
+		force = true
+	}
+	if sm.pendingKey == nil && (force || sm.needsKey(now)) {
+		key, err := sigcrypto.GenKey(sm.nextEpoch(now))
+		if err != nil {
+			sm.logger.Error("sessMonitor: unable to generate frame key", "err", err)
+			return
+		}
+		sm.pendingKey = key
+		sm.lastKeyAnnounce = time.Time{}
+	}
+	if sm.pendingKey == nil || now.Sub(sm.lastKeyAnnounce) < keyAnnounceInterval {
+		return
+	}
+	sm.lastKeyAnnounce = now
+	sm.sendKey(sm.pendingKey)
+}
+
+// needsKey returns whether the current key must be replaced.
+func (sm *sessMonitor) needsKey(now time.Time) bool {
+	curr := sm.smRemote.Key
+	return curr == nil || curr.Exhausted() || now.Sub(curr.Created) > sigcrypto.RekeyInterval
+}
+
+// nextEpoch returns the epoch of a new key. The epoch is derived from the
+// current time, and always differs from the epoch of the current key.
+func (sm *sessMonitor) nextEpoch(now time.Time) uint16 {
+	epoch := uint16(now.Unix())
+	if sm.smRemote.Key != nil && sm.smRemote.Key.Epoch == epoch {
+		epoch++
+	}
+	return epoch
+}
+
+// sendKey announces the frame key to the remote SIG.
+func (sm *sessMonitor) sendKey(key *sigcrypto.Key) {
+	if sigcrypto.Provider == nil {
+		sm.logger.Error("sessMonitor: unable to announce frame key, no key provider")
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), keyAnnounceInterval)
+	defer cancel()
+	ann, err := sigcrypto.SealKey(ctx, sigcrypto.Provider, sm.sess.IA(), sm.sess.SessId, key)
+	if err != nil {
+		sm.logger.Error("sessMonitor: unable to seal frame key", "err", err)
+		return
+	}
+	mgmtAddr := sigcmn.GetMgmtAddr()
+	ann.Addr = &mgmtAddr
+	sm.logger.Debug("sessMonitor: announcing frame key", "remote", sm.smRemote.Sig,
+		"epoch", key.Epoch)
+	sm.sendCtrl(ann, sm.smRemote.SessPath, sig_mgmt.MsgIdType(time.Now().UnixNano()))
+}
+
+// handleKeyAck switches the session to the pending key once the remote SIG
+// acknowledged it. Only acknowledgements from the SIG the key was announced to,
+// and that are authenticated with the pending key, are accepted.
+func (sm *sessMonitor) handleKeyAck(rpld *sigdisp.RegPld, ack *sig_mgmt.KeyAck) {
+	if !sm.sess.IA().Equal(rpld.Addr.IA) || ack.Session != sm.sess.SessId {
+		sm.logger.Error("sessMonitor: SIGKeyAck for wrong session", "src", rpld.Addr,
+			"ack", ack)
+		return
+	}
+	if sm.pendingKey == nil || sm.pendingKey.Epoch != ack.Epoch {
+		sm.logger.Debug("sessMonitor: ignoring SIGKeyAck for unknown key", "ack", ack)
+		return
+	}
+	if !sm.fromKeyRemote(rpld.Addr) {
+		sm.logger.Error("sessMonitor: SIGKeyAck from wrong SIG", "src", rpld.Addr,
+			"expected", sm.keyRemote, "ack", ack)
+		return
+	}
+	if !sm.pendingKey.VerifyAckMAC(ack.Session, ack.Mac) {
+		sm.logger.Error("sessMonitor: SIGKeyAck authentication failed", "src", rpld.Addr,
+			"ack", ack)
+		return
+	}
+	sm.logger.Info("sessMonitor: switching frame key", "epoch", ack.Epoch)
+	sm.smRemote.Key = sm.pendingKey
+	sm.pendingKey = nil
+	sm.updateSessSnap()
+}
+
+// fromKeyRemote returns whether the address is the control address of the
+// remote SIG the pending key was announced to.
+func (sm *sessMonitor) fromKeyRemote(src *snet.UDPAddr) bool {
+	if sm.keyRemote == nil || src.Host == nil {
+		return false
+	}
+	return addr.HostFromIP(src.Host.IP).Equal(sm.keyRemote.Host) &&
+		src.Host.Port == sm.keyRemote.CtrlL4Port
+}
+
+func (sm *sessMonitor) sendPoll(path *iface.SessPath, id sig_mgmt.MsgIdType) {
 	mgmtAddr := sigcmn.GetMgmtAddr()
-	spld, err := sig_mgmt.NewPld(sm.updateMsgId, sig_mgmt.NewPollReq(&mgmtAddr,
-		sm.sess.SessId))
+	sm.sendCtrl(sig_mgmt.NewPollReq(&mgmtAddr, sm.sess.SessId), path, id)
+	metrics.SessionProbes.WithLabelValues(sm.sess.IA().String(), sm.sess.SessId.String()).Inc()
+}
+
+// sendCtrl sends the SIG ctrl message to the remote SIG over the path.
+func (sm *sessMonitor) sendCtrl(u proto.Cerealizable, path *iface.SessPath,
+	id sig_mgmt.MsgIdType) {
+
+	spld, err := sig_mgmt.NewPld(id, u)
 	if err != nil {
 		sm.logger.Error("sessMonitor: Error creating SIGCtrl payload", "err", err)
 		return
@@ -280,8 +569,8 @@ func (sm *sessMonitor) sendReq() {
 		return
 	}
 	raddr := sm.smRemote.Sig.CtrlSnetAddr(
-		sm.smRemote.SessPath.Path().Path(),
-		sm.smRemote.SessPath.Path().UnderlayNextHop(),
+		path.Path().Path(),
+		path.Path().UnderlayNextHop(),
 	)
 	// XXX(kormat): if this blocks, both the sessMon and egress worker
 	// goroutines will block. Can't just use SetWriteDeadline, as both
@@ -290,7 +579,6 @@ func (sm *sessMonitor) sendReq() {
 	if err != nil {
 		sm.logger.Error("sessMonitor: Error sending signed Ctrl payload", "err", err)
 	}
-	metrics.SessionProbes.WithLabelValues(sm.sess.IA().String(), sm.sess.SessId.String()).Inc()
 }
 
 func (sm *sessMonitor) handleRep(rpld *sigdisp.RegPld) {
@@ -308,6 +596,13 @@ func (sm *sessMonitor) handleRep(rpld *sigdisp.RegPld) {
 	metrics.SessionProbeReplies.WithLabelValues(sm.sess.IA().String(),
 		sm.sess.SessId.String()).Inc()
 
+	// Replies to multipath probes only update the statistics of the probed path.
+	if path, ok := sm.probes[rpld.Id]; ok {
+		delete(sm.probes, rpld.Id)
+		sm.sessPathPool.Reply(path, rpld.Id.Time())
+		return
+	}
+
 	// Inform SessPathPool that a reply has arrived.
 	if sm.smRemote.SessPath != nil {
 		sm.sessPathPool.Reply(sm.smRemote.SessPath, rpld.Id.Time())
@@ -333,7 +628,7 @@ func (sm *sessMonitor) handleRep(rpld *sigdisp.RegPld) {
 			metrics.SessionRemoteSwitched.WithLabelValues(sm.sess.IA().String(),
 				sm.sess.SessId.String()).Inc()
 		}
-		sm.setHealth(true)
+		sm.setHealth(true, "reply")
 
 		latency := time.Now().Sub(rpld.Id.Time())
 		metrics.SessionProbeRTT.WithLabelValues(sm.sess.IA().String(),
@@ -348,7 +643,12 @@ func (sm *sessMonitor) handleRep(rpld *sigdisp.RegPld) {
 	}
 }
 
-func (sm *sessMonitor) setHealth(healthy bool) {
+// setHealth sets the health of the session. Changes are recorded in the health
+// history with the reason.
+func (sm *sessMonitor) setHealth(healthy bool, reason string) {
+	if healthy != sm.sess.Healthy() || len(sm.healthHistory) == 0 {
+		sm.recordHealth(healthy, reason)
+	}
 	sm.sess.healthy.Store(healthy)
 	var healthVal float64
 	if healthy {
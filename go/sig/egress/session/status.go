@@ -0,0 +1,151 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/ctrl/sig_mgmt"
+	"github.com/scionproto/scion/go/lib/snet"
+)
+
+// healthHistoryLen is the number of health changes kept in the status.
+const healthHistoryLen = 16
+
+// Status is a snapshot of the state of a session. It is published by the
+// session monitor, and must not be modified.
+type Status struct {
+	ID        sig_mgmt.SessionType
+	Healthy   bool
+	Encrypted bool
+	// Remote is the remote SIG and path the traffic is sent to. It is nil if
+	// no remote SIG was discovered yet.
+	Remote *RemoteStatus `json:",omitempty"`
+	// LastReply is when the last reply of the remote SIG was received.
+	LastReply time.Time
+	// Paths are the paths in the path pool of the session.
+	Paths []PathStatus
+	// HealthHistory are the most recent health changes, oldest first.
+	HealthHistory []HealthChange
+}
+
+// RemoteStatus describes the remote SIG of a session.
+type RemoteStatus struct {
+	// Sig is the address of the remote SIG.
+	Sig string
+	// Path is the fingerprint of the path the traffic is sent over.
+	Path string `json:",omitempty"`
+	// LoadBalancing is the multipath mode, and Paths are the fingerprints of
+	// the paths the traffic is spread over. Paths is empty if a single path is
+	// used.
+	LoadBalancing string
+	Paths         []string `json:",omitempty"`
+	// KeyEpoch is the epoch of the frame key, if the frames are encrypted.
+	KeyEpoch *uint16 `json:",omitempty"`
+}
+
+// PathStatus describes a path in the path pool of a session.
+type PathStatus struct {
+	Fingerprint string
+	Hops        string
+	Expiry      time.Time
+	MTU         uint16
+	// RTT is the smoothed probe RTT, empty if it is unknown.
+	RTT       string `json:",omitempty"`
+	FailCount uint16
+	LastFail  time.Time
+	// Weight is the share of traffic the path carries in a multipath session.
+	Weight int
+	// Active indicates that traffic is sent over the path.
+	Active bool
+}
+
+// HealthChange records a change of the session health.
+type HealthChange struct {
+	Time    time.Time
+	Healthy bool
+	Reason  string
+}
+
+// newStatus creates the status from the state of the session monitor.
+func (sm *sessMonitor) newStatus() *Status {
+	s := &Status{
+		ID:            sm.sess.SessId,
+		Healthy:       sm.sess.Healthy(),
+		Encrypted:     sm.sess.Encrypted(),
+		LastReply:     sm.lastReply,
+		HealthHistory: append([]HealthChange(nil), sm.healthHistory...),
+	}
+	active := make(map[snet.PathFingerprint]bool)
+	if remote := sm.sess.Remote(); remote != nil {
+		s.Remote = &RemoteStatus{
+			Sig:           remote.Sig.String(),
+			LoadBalancing: string(remote.LoadBalancing),
+		}
+		if remote.SessPath != nil {
+			s.Remote.Path = remote.SessPath.Key().String()
+			active[remote.SessPath.Key()] = true
+		}
+		for _, p := range remote.Paths {
+			s.Remote.Paths = append(s.Remote.Paths, p.SessPath.Key().String())
+			active[p.SessPath.Key()] = true
+		}
+		if remote.Key != nil {
+			epoch := remote.Key.Epoch
+			s.Remote.KeyEpoch = &epoch
+		}
+	}
+	for key, stats := range *sm.sessPathPool {
+		path := stats.SessPath.Path()
+		ps := PathStatus{
+			Fingerprint: key.String(),
+			Hops:        hops(path),
+			Expiry:      path.Expiry(),
+			MTU:         path.MTU(),
+			FailCount:   stats.FailCount(),
+			LastFail:    stats.LastFail(),
+			Weight:      stats.Weight(),
+			Active:      active[key],
+		}
+		if rtt := stats.RTT(); rtt != 0 {
+			ps.RTT = rtt.String()
+		}
+		s.Paths = append(s.Paths, ps)
+	}
+	sort.Slice(s.Paths, func(i, j int) bool {
+		return s.Paths[i].Fingerprint < s.Paths[j].Fingerprint
+	})
+	return s
+}
+
+// recordHealth adds the health change to the history.
+func (sm *sessMonitor) recordHealth(healthy bool, reason string) {
+	sm.healthHistory = append(sm.healthHistory,
+		HealthChange{Time: time.Now(), Healthy: healthy, Reason: reason})
+	if len(sm.healthHistory) > healthHistoryLen {
+		sm.healthHistory = sm.healthHistory[len(sm.healthHistory)-healthHistoryLen:]
+	}
+}
+
+func hops(path snet.Path) string {
+	var hops []string
+	for _, intf := range path.Interfaces() {
+		hops = append(hops, fmt.Sprintf("%s#%d", intf.IA(), intf.ID()))
+	}
+	return strings.Join(hops, " ")
+}
@@ -33,6 +33,7 @@ import (
 	"github.com/scionproto/scion/go/sig/egress/siginfo"
 	"github.com/scionproto/scion/go/sig/internal/metrics"
 	"github.com/scionproto/scion/go/sig/internal/sigcmn"
+	"github.com/scionproto/scion/go/sig/internal/sigcrypto"
 )
 
 //   SIG Frame Header, used to encapsulate SIG to SIG traffic. The sequence
@@ -49,6 +50,10 @@ import (
 //
 //   Inside the frame, all encapsulated packets are preceded by a 2B length
 //   field, and then padded to an 8B boundary
+//
+//   If the session is encrypted, the frame payload is encrypted with the key
+//   of the epoch, and the authentication tag is appended to the frame. The
+//   header stays in clear, but is authenticated.
 
 const (
 	PktLenSize = 2
@@ -67,13 +72,23 @@ type worker struct {
 	sess          iface.Session
 	writer        SCIONWriter
 	currSig       *siginfo.Sig
+	currSessPath  *iface.SessPath
 	currPathEntry snet.Path
+	// paths chooses the path of each frame if the session uses multiple paths.
+	paths         *iface.PathSelector
 	frameSentCtrs metrics.CtrPair
 
 	epoch uint16
 	seq   uint32
 	pkts  ringbuf.EntryList
 
+	// encrypted indicates that frames must be encrypted with key.
+	encrypted bool
+	// key is the key of the current remote, currKey the key of the current
+	// epoch.
+	key     *sigcrypto.Key
+	currKey *sigcrypto.Key
+
 	// TODO(sustrik): This is used for testing only. The code should be refactored
 	// in such a way that it's not needed.
 	ignoreAddress bool
@@ -94,7 +109,8 @@ func NewWorker(sess iface.Session, writer SCIONWriter, ignoreAddress bool,
 			Pkts:  metrics.FramesSent.WithLabelValues(sess.IA().String(), sess.ID().String()),
 			Bytes: metrics.FrameBytesSent.WithLabelValues(sess.IA().String(), sess.ID().String()),
 		},
-		pkts: make(ringbuf.EntryList, 0, iface.EgressBufPkts),
+		pkts:  make(ringbuf.EntryList, 0, iface.EgressBufPkts),
+		paths: iface.NewPathSelector(),
 	}
 }
 
@@ -139,6 +155,23 @@ TopLoop:
 }
 
 func (w *worker) processPkt(f *frame, pkt common.RawBytes) error {
+	if w.paths.Mode() == iface.LoadBalancingFlowHash {
+		// All packets of a flow must be sent over the same path, so a frame
+		// can only contain packets of flows that map to the same path. If the
+		// flow maps to a different path, send off the current frame first.
+		path := w.paths.ForFlow(pkt)
+		if w.currSessPath == nil || path.Key() != w.currSessPath.Key() {
+			var err error
+			if f.offset != sigcmn.SIGHdrSize {
+				err = w.write(f)
+			}
+			w.setPath(path)
+			w.sizeFrame(f)
+			if err != nil {
+				return err
+			}
+		}
+	}
 	f.startPkt(uint16(len(pkt)))
 	pktOff := 0
 	// Write chunks of the packet to frames, sending off frames as they fill up.
@@ -175,7 +208,13 @@ func (w *worker) write(f *frame) error {
 	// TODO(kormat): consider looking for an updated path here, and switching
 	// to it if the mtu isn't smaller than the current one.
 	defer w.resetFrame(f)
-	if w.seq == 0 {
+	if w.encrypted {
+		if !w.useKey() {
+			metrics.FramesCryptoDropped.WithLabelValues(w.iaString, w.sess.ID().String(),
+				"no_key").Inc()
+			return nil
+		}
+	} else if w.seq == 0 {
 		w.epoch = uint16(time.Now().Unix() & 0xFFFF)
 	}
 
@@ -187,6 +226,10 @@ func (w *worker) write(f *frame) error {
 	w.seq += 1
 	if w.seq > MaxSeq {
 		w.seq = 0
+		if w.encrypted {
+			// Reusing sequence numbers would reuse nonces, a new key is needed.
+			w.currKey.SetExhausted()
+		}
 	}
 
 	var snetAddr *snet.UDPAddr
@@ -205,7 +248,14 @@ func (w *worker) write(f *frame) error {
 	}
 
 	f.writeHdr(w.sess.ID(), w.epoch, seq)
-	bytesWritten, err := w.writer.WriteTo(f.raw(), snetAddr)
+	raw := f.raw()
+	if w.encrypted {
+		var err error
+		if raw, err = w.currKey.Seal(raw); err != nil {
+			return common.NewBasicError("Unable to encrypt frame", err)
+		}
+	}
+	bytesWritten, err := w.writer.WriteTo(raw, snetAddr)
 	if err != nil {
 		return common.NewBasicError("Egress write error", err)
 	}
@@ -214,25 +264,66 @@ func (w *worker) write(f *frame) error {
 	return nil
 }
 
+// useKey starts a new epoch if the key changed. It returns false if there is
+// no usable key.
+func (w *worker) useKey() bool {
+	if w.key == nil || w.key.Exhausted() {
+		return false
+	}
+	if w.key != w.currKey {
+		w.currKey = w.key
+		w.epoch = w.key.Epoch
+		w.seq = 0
+	}
+	return true
+}
+
 func (w *worker) resetFrame(f *frame) {
-	var mtu uint16 = common.MinMTU
-	var addrLen, pathLen uint16
 	remote := w.sess.Remote()
 	if remote != nil {
 		w.currSig = remote.Sig
-		if w.currSig != nil {
-			addrLen = uint16(spkt.AddrHdrLen(w.currSig.Host,
-				addr.HostFromIP(sigcmn.DataAddr)))
-		}
-		w.currPathEntry = nil
-		if remote.SessPath != nil {
-			w.currPathEntry = remote.SessPath.Path()
-		}
-		if w.currPathEntry != nil {
-			mtu = w.currPathEntry.MTU()
-			pathLen = uint16(len(w.currPathEntry.Path().Raw))
+		w.encrypted = remote.Encrypted
+		w.key = remote.Key
+		w.paths.Update(remote.LoadBalancing, remote.Paths)
+		switch w.paths.Mode() {
+		case iface.LoadBalancingRoundRobin:
+			w.setPath(w.paths.Next())
+		case iface.LoadBalancingFlowHash:
+			// The path is chosen when the first packet is added to the frame.
+			// Until then, keep the current path if it is still usable.
+			if !w.paths.Contains(w.currSessPath) {
+				w.setPath(w.paths.Next())
+			}
+		default:
+			w.setPath(remote.SessPath)
 		}
 	}
+	w.sizeFrame(f)
+}
+
+func (w *worker) setPath(path *iface.SessPath) {
+	w.currSessPath = path
+	w.currPathEntry = nil
+	if path != nil {
+		w.currPathEntry = path.Path()
+	}
+}
+
+// sizeFrame resets the frame such that it fits the MTU of the current path.
+func (w *worker) sizeFrame(f *frame) {
+	var mtu uint16 = common.MinMTU
+	var addrLen, pathLen uint16
+	if w.currSig != nil {
+		addrLen = uint16(spkt.AddrHdrLen(w.currSig.Host,
+			addr.HostFromIP(sigcmn.DataAddr)))
+	}
+	if w.currPathEntry != nil {
+		mtu = w.currPathEntry.MTU()
+		pathLen = uint16(len(w.currPathEntry.Path().Raw))
+	}
+	if w.encrypted {
+		mtu -= sigcrypto.Overhead
+	}
 	// FIXME(kormat): to do this properly, need to account for any ext headers.
 	f.reset(mtu - spkt.CmnHdrLen - addrLen - pathLen - l4.UDPLen)
 }
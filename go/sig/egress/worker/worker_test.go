@@ -18,9 +18,11 @@ import (
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
@@ -31,6 +33,7 @@ import (
 	"github.com/scionproto/scion/go/sig/egress/iface"
 	"github.com/scionproto/scion/go/sig/egress/iface/mock_iface"
 	"github.com/scionproto/scion/go/sig/egress/worker/mock_worker"
+	"github.com/scionproto/scion/go/sig/internal/sigcrypto"
 )
 
 func TestMain(m *testing.M) {
@@ -72,6 +75,7 @@ type WorkerTester struct {
 	mockCtrl *gomock.Controller
 	writer   *mock_worker.MockSCIONWriter
 	ring     *ringbuf.Ring
+	remote   *iface.RemoteInfo
 }
 
 func NewWorkerTester(t *testing.T) *WorkerTester {
@@ -112,7 +116,7 @@ func (wt *WorkerTester) Run() {
 	s.EXPECT().ID().AnyTimes().Return(sig_mgmt.SessionType(0))
 	s.EXPECT().Conn().AnyTimes().Return(nil)
 	s.EXPECT().Ring().AnyTimes().Return(wt.ring)
-	s.EXPECT().Remote().AnyTimes().Return(nil)
+	s.EXPECT().Remote().AnyTimes().Return(wt.remote)
 	s.EXPECT().Cleanup().AnyTimes().Return(nil)
 	s.EXPECT().Healthy().AnyTimes().Return(true)
 	s.EXPECT().PathPool().AnyTimes().Return(nil)
@@ -173,3 +177,42 @@ func TestParsing(t *testing.T) {
 		tester.Run()
 	})
 }
+
+func TestEncryption(t *testing.T) {
+	iface.Init()
+
+	t.Run("encrypted packet", func(t *testing.T) {
+		key, err := sigcrypto.GenKey(42)
+		require.NoError(t, err)
+		tester := NewWorkerTester(t)
+		defer tester.Finish()
+		tester.remote = &iface.RemoteInfo{Encrypted: true, Key: key}
+		tester.SendPacket([]byte{1, 2, 3})
+		tester.writer.EXPECT().WriteTo(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(frame []byte, address *snet.UDPAddr) (int, error) {
+				defer tester.ring.Close()
+				assert.Len(t, frame, 13+sigcrypto.Overhead)
+				plain, err := key.Open(append([]byte(nil), frame...))
+				require.NoError(t, err)
+				assert.Equal(t, []byte{0, 0, 42, 0, 0, 0, 0, 1, 0, 3, 1, 2, 3}, []byte(plain))
+				return len(frame), nil
+			})
+		tester.Run()
+	})
+
+	t.Run("no key", func(t *testing.T) {
+		tester := NewWorkerTester(t)
+		defer tester.Finish()
+		tester.remote = &iface.RemoteInfo{Encrypted: true}
+		tester.SendPacket([]byte{1, 2, 3})
+		// No frame must be written. Close the ring once the packet is read.
+		go func() {
+			for tester.ring.Len() > 0 {
+				time.Sleep(time.Millisecond)
+			}
+			time.Sleep(10 * time.Millisecond)
+			tester.ring.Close()
+		}()
+		tester.Run()
+	})
+}
@@ -26,7 +26,7 @@ import (
 	"github.com/scionproto/scion/go/sig/internal/sigcmn"
 )
 
-func Init(tunIO io.ReadWriteCloser) {
+func Init(tunIO io.ReadWriteCloser, reorder ReorderConfig) {
 	fatal.Check()
 	conn, err := sigcmn.Network.Listen(context.Background(), "udp",
 		&net.UDPAddr{IP: sigcmn.DataAddr, Port: sigcmn.DataPort}, addr.SvcNone)
@@ -34,7 +34,7 @@ func Init(tunIO io.ReadWriteCloser) {
 		log.Crit("Unable to initialize ingress connection", "err", err)
 		fatal.Fatal(err)
 	}
-	d := NewDispatcher(tunIO, conn)
+	d := NewDispatcher(tunIO, conn, reorder)
 	go func() {
 		defer log.HandlePanic()
 		if err := d.Run(); err != nil {
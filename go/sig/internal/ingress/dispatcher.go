@@ -45,12 +45,14 @@ type Dispatcher struct {
 	extConn            *snet.Conn
 	tunIO              io.ReadWriteCloser
 	framesRecvCounters map[metrics.CtrPairKey]metrics.CtrPair
+	reorder            ReorderConfig
 }
 
-func NewDispatcher(tio io.ReadWriteCloser, conn *snet.Conn) *Dispatcher {
+func NewDispatcher(tio io.ReadWriteCloser, conn *snet.Conn, reorder ReorderConfig) *Dispatcher {
 	return &Dispatcher{
 		tunIO:              tio,
 		extConn:            conn,
+		reorder:            reorder,
 		framesRecvCounters: make(map[metrics.CtrPairKey]metrics.CtrPair),
 		workers:            make(map[string]*Worker),
 	}
@@ -102,7 +104,7 @@ func (d *Dispatcher) dispatch(frame *FrameBuf, src *snet.UDPAddr) {
 	// Check if we already have a worker running and start one if not.
 	worker, ok := d.workers[dispatchStr]
 	if !ok {
-		worker = NewWorker(src, frame.sessId, d.tunIO)
+		worker = NewWorker(src, frame.sessId, d.tunIO, d.reorder)
 		d.workers[dispatchStr] = worker
 		go func() {
 			defer log.HandlePanic()
@@ -0,0 +1,186 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingress
+
+import (
+	"sort"
+	"time"
+
+	"github.com/scionproto/scion/go/sig/internal/metrics"
+)
+
+// ReorderConfig configures the reorder window of the ingress workers.
+type ReorderConfig struct {
+	// Frames is the maximum number of frames that are buffered while waiting
+	// for a missing frame. If it is 0, frames are passed on in the order they
+	// arrive, and a missing frame immediately discards the partially
+	// reassembled packet.
+	Frames int
+	// Timeout is the maximum time frames are buffered while waiting for a
+	// missing frame.
+	Timeout time.Duration
+}
+
+// Enabled returns whether frames are reordered.
+func (c ReorderConfig) Enabled() bool {
+	return c.Frames > 0
+}
+
+// reorderBuffer restores the sequence of the frames of an epoch before they are
+// reassembled. Frames that arrive ahead of a missing frame are buffered until
+// the missing frame arrives. If more than the configured number of frames are
+// buffered, or the missing frame did not arrive within the timeout, the missing
+// frames are considered lost and the buffered frames are passed on. Frames that
+// arrive after they were considered lost are dropped as late frames.
+//
+// If reordering is disabled, all frames are passed on immediately and the
+// buffer only keeps the statistics.
+type reorderBuffer struct {
+	cfg  ReorderConfig
+	ctrs metrics.ReorderCtrs
+	// next is the sequence number of the next in-order frame, -1 if no frame
+	// was received yet.
+	next int
+	// seen records which of the frames preceding next were passed on. Bit i
+	// corresponds to sequence number next-1-i.
+	seen uint64
+	// frames are the buffered frames, sorted by sequence number.
+	frames []*FrameBuf
+	// since is when the buffer last advanced while frames were buffered.
+	since time.Time
+}
+
+func newReorderBuffer(cfg ReorderConfig, ctrs metrics.ReorderCtrs) *reorderBuffer {
+	return &reorderBuffer{cfg: cfg, ctrs: ctrs, next: -1}
+}
+
+// Push adds the frame to the buffer, and appends the frames that can be passed
+// on to out, in sequence order.
+func (b *reorderBuffer) Push(frame *FrameBuf, now time.Time, out []*FrameBuf) []*FrameBuf {
+	seqNr := frame.seqNr
+	if b.next < 0 {
+		b.next = seqNr
+	}
+	if seqNr < b.next {
+		if b.passed(seqNr) {
+			b.ctrs.Duplicate.Inc()
+		} else {
+			b.ctrs.Late.Inc()
+		}
+		if !b.cfg.Enabled() {
+			return append(out, frame)
+		}
+		frame.Release()
+		return out
+	}
+	if !b.cfg.Enabled() {
+		b.skip(seqNr)
+		b.advance()
+		return append(out, frame)
+	}
+	i := sort.Search(len(b.frames), func(i int) bool { return b.frames[i].seqNr >= seqNr })
+	if i < len(b.frames) && b.frames[i].seqNr == seqNr {
+		b.ctrs.Duplicate.Inc()
+		frame.Release()
+		return out
+	}
+	if i < len(b.frames) {
+		// A frame with a higher sequence number arrived earlier.
+		b.ctrs.Reordered.Inc()
+	}
+	if len(b.frames) == 0 {
+		b.since = now
+	}
+	b.frames = append(b.frames, nil)
+	copy(b.frames[i+1:], b.frames[i:])
+	b.frames[i] = frame
+	out = b.release(now, out)
+	for len(b.frames) > b.cfg.Frames {
+		b.skip(b.frames[0].seqNr)
+		out = b.release(now, out)
+	}
+	return b.Flush(now, out)
+}
+
+// Flush passes on the buffered frames if the missing frame did not arrive
+// within the timeout. The frames are appended to out, in sequence order.
+func (b *reorderBuffer) Flush(now time.Time, out []*FrameBuf) []*FrameBuf {
+	for len(b.frames) > 0 && now.Sub(b.since) >= b.cfg.Timeout {
+		b.skip(b.frames[0].seqNr)
+		out = b.release(now, out)
+	}
+	return out
+}
+
+// Pending returns whether frames are buffered.
+func (b *reorderBuffer) Pending() bool {
+	return len(b.frames) > 0
+}
+
+// ReleaseAll releases all buffered frames back to the pool of frame buffers.
+func (b *reorderBuffer) ReleaseAll() {
+	for _, frame := range b.frames {
+		frame.Release()
+	}
+	b.frames = nil
+}
+
+// release appends the buffered frames that are in sequence to out.
+func (b *reorderBuffer) release(now time.Time, out []*FrameBuf) []*FrameBuf {
+	n := 0
+	for n < len(b.frames) && b.frames[n].seqNr == b.next {
+		out = append(out, b.frames[n])
+		b.advance()
+		n++
+	}
+	if n == 0 {
+		return out
+	}
+	rest := copy(b.frames, b.frames[n:])
+	for i := rest; i < len(b.frames); i++ {
+		b.frames[i] = nil
+	}
+	b.frames = b.frames[:rest]
+	b.since = now
+	return out
+}
+
+// skip considers the frames up to seqNr lost.
+func (b *reorderBuffer) skip(seqNr int) {
+	lost := seqNr - b.next
+	if lost <= 0 {
+		return
+	}
+	b.ctrs.Lost.Add(float64(lost))
+	b.next = seqNr
+	if lost >= 64 {
+		b.seen = 0
+		return
+	}
+	b.seen <<= uint(lost)
+}
+
+// advance marks the next frame as passed on.
+func (b *reorderBuffer) advance() {
+	b.next++
+	b.seen = b.seen<<1 | 1
+}
+
+// passed returns whether the frame preceding next was passed on. Frames that
+// are too old to be tracked are considered lost.
+func (b *reorderBuffer) passed(seqNr int) bool {
+	i := b.next - 1 - seqNr
+	return i < 64 && b.seen&(1<<uint(i)) != 0
+}
@@ -0,0 +1,152 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingress
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/go/lib/ringbuf"
+	"github.com/scionproto/scion/go/sig/internal/metrics"
+)
+
+type reorderTest struct {
+	t    *testing.T
+	b    *reorderBuffer
+	ctrs metrics.ReorderCtrs
+	now  time.Time
+}
+
+func newReorderTest(t *testing.T, cfg ReorderConfig) *reorderTest {
+	ctrs := metrics.ReorderCtrs{
+		Reordered: prometheus.NewCounter(prometheus.CounterOpts{Name: "reordered"}),
+		Duplicate: prometheus.NewCounter(prometheus.CounterOpts{Name: "duplicate"}),
+		Late:      prometheus.NewCounter(prometheus.CounterOpts{Name: "late"}),
+		Lost:      prometheus.NewCounter(prometheus.CounterOpts{Name: "lost"}),
+	}
+	return &reorderTest{
+		t:    t,
+		b:    newReorderBuffer(cfg, ctrs),
+		ctrs: ctrs,
+		now:  time.Now(),
+	}
+}
+
+// push pushes a frame with the sequence number and checks the sequence
+// numbers of the released frames.
+func (rt *reorderTest) push(seqNr int, released ...int) {
+	rt.t.Helper()
+	frames := make(ringbuf.EntryList, 1)
+	assert.Equal(rt.t, 1, NewFrameBufs(frames))
+	frame := frames[0].(*FrameBuf)
+	frame.seqNr = seqNr
+	rt.check(rt.b.Push(frame, rt.now, nil), released)
+}
+
+// flush advances the time and checks the sequence numbers of the flushed
+// frames.
+func (rt *reorderTest) flush(d time.Duration, released ...int) {
+	rt.t.Helper()
+	rt.now = rt.now.Add(d)
+	rt.check(rt.b.Flush(rt.now, nil), released)
+}
+
+func (rt *reorderTest) check(frames []*FrameBuf, released []int) {
+	rt.t.Helper()
+	seqNrs := []int{}
+	for _, frame := range frames {
+		seqNrs = append(seqNrs, frame.seqNr)
+	}
+	if released == nil {
+		released = []int{}
+	}
+	assert.Equal(rt.t, released, seqNrs)
+}
+
+func (rt *reorderTest) ctrsEqual(reordered, duplicate, late, lost int) {
+	rt.t.Helper()
+	assert.Equal(rt.t, float64(reordered), testutil.ToFloat64(rt.ctrs.Reordered), "reordered")
+	assert.Equal(rt.t, float64(duplicate), testutil.ToFloat64(rt.ctrs.Duplicate), "duplicate")
+	assert.Equal(rt.t, float64(late), testutil.ToFloat64(rt.ctrs.Late), "late")
+	assert.Equal(rt.t, float64(lost), testutil.ToFloat64(rt.ctrs.Lost), "lost")
+}
+
+func TestReorderDisabled(t *testing.T) {
+	rt := newReorderTest(t, ReorderConfig{})
+	rt.push(1, 1)
+	rt.push(3, 3)
+	rt.push(2, 2)
+	rt.push(3, 3)
+	rt.push(4, 4)
+	assert.False(t, rt.b.Pending())
+	rt.ctrsEqual(0, 1, 1, 1)
+}
+
+func TestReorderInOrder(t *testing.T) {
+	rt := newReorderTest(t, ReorderConfig{Frames: 4, Timeout: time.Second})
+	rt.push(5, 5)
+	rt.push(6, 6)
+	rt.push(7, 7)
+	rt.ctrsEqual(0, 0, 0, 0)
+}
+
+func TestReorderSwapped(t *testing.T) {
+	rt := newReorderTest(t, ReorderConfig{Frames: 4, Timeout: time.Second})
+	rt.push(1, 1)
+	rt.push(3)
+	rt.push(4)
+	assert.True(t, rt.b.Pending())
+	rt.push(2, 2, 3, 4)
+	assert.False(t, rt.b.Pending())
+	rt.ctrsEqual(1, 0, 0, 0)
+}
+
+func TestReorderDuplicate(t *testing.T) {
+	rt := newReorderTest(t, ReorderConfig{Frames: 4, Timeout: time.Second})
+	rt.push(1, 1)
+	rt.push(1)
+	rt.push(3)
+	rt.push(3)
+	rt.push(2, 2, 3)
+	rt.ctrsEqual(1, 2, 0, 0)
+}
+
+func TestReorderWindowFull(t *testing.T) {
+	rt := newReorderTest(t, ReorderConfig{Frames: 2, Timeout: time.Second})
+	rt.push(1, 1)
+	rt.push(3)
+	rt.push(4)
+	// The window overflows, frame 2 is considered lost.
+	rt.push(5, 3, 4, 5)
+	rt.push(2)
+	rt.ctrsEqual(0, 0, 1, 1)
+}
+
+func TestReorderTimeout(t *testing.T) {
+	rt := newReorderTest(t, ReorderConfig{Frames: 4, Timeout: 10 * time.Millisecond})
+	rt.push(1, 1)
+	rt.push(4)
+	rt.push(5)
+	rt.flush(5 * time.Millisecond)
+	rt.flush(5*time.Millisecond, 4, 5)
+	assert.False(t, rt.b.Pending())
+	rt.push(3)
+	rt.push(6, 6)
+	rt.ctrsEqual(0, 0, 1, 2)
+}
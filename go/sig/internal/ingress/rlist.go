@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"container/list"
 	"fmt"
+	"time"
 
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/log"
@@ -28,7 +29,8 @@ import (
 // ReassemblyList is used to keep a doubly linked list of SIG frames that are
 // outstanding for reassembly. The frames kept in the reassambly list sorted by
 // their sequence numbers. There is always one reassembly list per epoch to
-// ensure that sequence numbers are monotonically increasing.
+// ensure that sequence numbers are monotonically increasing. Frames pass
+// through the reorder buffer before they are added to the list.
 type ReassemblyList struct {
 	epoch             int
 	capacity          int
@@ -36,11 +38,15 @@ type ReassemblyList struct {
 	markedForDeletion bool
 	entries           *list.List
 	buf               *bytes.Buffer
+	reorder           *reorderBuffer
+	ordered           []*FrameBuf
 }
 
 // NewReassemblyList returns a ReassemblyList object for the given epoch and with
 // given maximum capacity.
-func NewReassemblyList(epoch int, capacity int, s sender) *ReassemblyList {
+func NewReassemblyList(epoch int, capacity int, s sender,
+	reorder *reorderBuffer) *ReassemblyList {
+
 	list := &ReassemblyList{
 		epoch:             epoch,
 		capacity:          capacity,
@@ -48,15 +54,37 @@ func NewReassemblyList(epoch int, capacity int, s sender) *ReassemblyList {
 		markedForDeletion: false,
 		entries:           list.New(),
 		buf:               bytes.NewBuffer(make(common.RawBytes, 0, frameBufCap)),
+		reorder:           reorder,
 	}
 	return list
 }
 
-// Insert inserts a frame into the reassembly list.
-// After inserting the frame at the correct position, Insert tries to reassemble packets
+// Insert passes the frame to the reorder buffer, and inserts the frames that
+// are released by the reorder buffer into the reassembly list.
+func (l *ReassemblyList) Insert(frame *FrameBuf) {
+	l.ordered = l.reorder.Push(frame, time.Now(), l.ordered[:0])
+	l.insertOrdered()
+}
+
+// FlushReorder inserts the frames that were buffered by the reorder buffer for
+// longer than the reorder timeout into the reassembly list.
+func (l *ReassemblyList) FlushReorder(now time.Time) {
+	l.ordered = l.reorder.Flush(now, l.ordered[:0])
+	l.insertOrdered()
+}
+
+func (l *ReassemblyList) insertOrdered() {
+	for i, frame := range l.ordered {
+		l.insert(frame)
+		l.ordered[i] = nil
+	}
+}
+
+// insert inserts a frame into the reassembly list.
+// After inserting the frame at the correct position, insert tries to reassemble packets
 // that involve the newly added frame. Completely processed frames get removed from the
 // list and released to the pool of frame buffers.
-func (l *ReassemblyList) Insert(frame *FrameBuf) {
+func (l *ReassemblyList) insert(frame *FrameBuf) {
 	// If this is the first frame, write all complete packets to the wire and
 	// add the frame to the reassembly list if it contains a fragment at the end.
 	if l.entries.Len() == 0 {
@@ -221,6 +249,12 @@ func (l *ReassemblyList) removeAll() {
 	l.removeBefore(nil)
 }
 
+// release releases all frames, including the ones held by the reorder buffer.
+func (l *ReassemblyList) release() {
+	l.removeAll()
+	l.reorder.ReleaseAll()
+}
+
 func (l *ReassemblyList) removeBefore(ele *list.Element) {
 	var next *list.Element
 	for e := l.entries.Front(); e != ele; e = next {
@@ -18,6 +18,7 @@ package ingress
 import (
 	"fmt"
 	"io"
+	"sync/atomic"
 	"time"
 
 	"github.com/scionproto/scion/go/lib/common"
@@ -26,6 +27,7 @@ import (
 	"github.com/scionproto/scion/go/lib/ringbuf"
 	"github.com/scionproto/scion/go/lib/snet"
 	"github.com/scionproto/scion/go/sig/internal/metrics"
+	"github.com/scionproto/scion/go/sig/internal/sigcrypto"
 )
 
 const (
@@ -49,10 +51,18 @@ type Worker struct {
 	markedForCleanup bool
 	sentCtrs         metrics.CtrPair
 	tunIO            io.ReadWriteCloser
+	reorder          ReorderConfig
+	reorderCtrs      metrics.ReorderCtrs
+	// flushArmed is set while a reorderTick is scheduled.
+	flushArmed int32
 }
 
+// reorderTick is written to the ring of the worker to flush the reorder
+// buffers when no frames arrive.
+type reorderTick struct{}
+
 func NewWorker(remote *snet.UDPAddr, sessId sig_mgmt.SessionType,
-	tunIO io.ReadWriteCloser) *Worker {
+	tunIO io.ReadWriteCloser, reorder ReorderConfig) *Worker {
 
 	worker := &Worker{
 		Logger: log.New("ingress", remote.String(), "sessId", sessId),
@@ -66,7 +76,9 @@ func NewWorker(remote *snet.UDPAddr, sessId sig_mgmt.SessionType,
 			Bytes: metrics.PktBytesSent.WithLabelValues(remote.IA.String(),
 				sessId.String()),
 		},
-		tunIO: tunIO,
+		tunIO:       tunIO,
+		reorder:     reorder,
+		reorderCtrs: metrics.NewReorderCtrs(remote.IA, sessId),
 	}
 	return worker
 }
@@ -88,10 +100,16 @@ func (w *Worker) Run() {
 			break
 		}
 		for i := 0; i < n; i++ {
-			frame := frames[i].(*FrameBuf)
-			w.processFrame(frame)
+			switch e := frames[i].(type) {
+			case *FrameBuf:
+				w.processFrame(e)
+			case reorderTick:
+				atomic.StoreInt32(&w.flushArmed, 0)
+				w.flushReorder()
+			}
 			frames[i] = nil
 		}
+		w.armFlush()
 		if time.Since(lastCleanup) >= rlistCleanUpInterval {
 			w.cleanup()
 			lastCleanup = time.Now()
@@ -107,6 +125,10 @@ func (w *Worker) processFrame(frame *FrameBuf) {
 	epoch := int(common.Order.Uint16(frame.raw[1:3]))
 	seqNr := int(common.Order.UintN(frame.raw[3:6], 3))
 	index := int(common.Order.Uint16(frame.raw[6:8]))
+	if !w.decrypt(frame, uint16(epoch), seqNr) {
+		frame.Release()
+		return
+	}
 	frame.seqNr = seqNr
 	frame.index = index
 	frame.snd = w
@@ -123,10 +145,73 @@ func (w *Worker) processFrame(frame *FrameBuf) {
 	rlist.Insert(frame)
 }
 
+// decrypt decrypts the frame if there is a key for its epoch. Frames without
+// a key are only accepted if the remote AS is not required to encrypt its
+// frames. It returns false if the frame must be dropped.
+func (w *Worker) decrypt(frame *FrameBuf, epoch uint16, seqNr int) bool {
+	key := sigcrypto.RxKeys.Get(w.Remote.IA, w.Remote.Host.IP, w.SessId, epoch)
+	if key == nil {
+		if sigcrypto.RxKeys.Required(w.Remote.IA) {
+			w.cryptoDropped("no_key")
+			return false
+		}
+		return true
+	}
+	raw, err := key.Open(frame.raw[:frame.frameLen], uint32(seqNr))
+	switch {
+	case err == sigcrypto.ErrReplay:
+		w.cryptoDropped("replay")
+		return false
+	case err != nil:
+		w.cryptoDropped("auth")
+		return false
+	}
+	frame.frameLen = len(raw)
+	return true
+}
+
+func (w *Worker) cryptoDropped(reason string) {
+	metrics.FramesCryptoDropped.WithLabelValues(w.Remote.IA.String(), w.SessId.String(),
+		reason).Inc()
+}
+
+// flushReorder passes on the frames that were held by the reorder buffers for
+// longer than the reorder timeout.
+func (w *Worker) flushReorder() {
+	now := time.Now()
+	for _, rlist := range w.rlists {
+		rlist.FlushReorder(now)
+	}
+}
+
+// armFlush schedules a reorderTick if frames are held by a reorder buffer, such
+// that they are passed on even if no further frames arrive.
+func (w *Worker) armFlush() {
+	if !w.reorder.Enabled() || atomic.LoadInt32(&w.flushArmed) == 1 {
+		return
+	}
+	pending := false
+	for _, rlist := range w.rlists {
+		pending = pending || rlist.reorder.Pending()
+	}
+	if !pending {
+		return
+	}
+	atomic.StoreInt32(&w.flushArmed, 1)
+	time.AfterFunc(w.reorder.Timeout, func() {
+		if n, _ := w.Ring.Write(ringbuf.EntryList{reorderTick{}}, false); n != 1 {
+			// The ring is full or closed. The reorder buffers are flushed
+			// once the worker reads the ring again.
+			atomic.StoreInt32(&w.flushArmed, 0)
+		}
+	})
+}
+
 func (w *Worker) getRlist(epoch int) *ReassemblyList {
 	rlist, ok := w.rlists[epoch]
 	if !ok {
-		rlist = NewReassemblyList(epoch, reassemblyListCap, w)
+		rlist = NewReassemblyList(epoch, reassemblyListCap, w,
+			newReorderBuffer(w.reorder, w.reorderCtrs))
 		w.rlists[epoch] = rlist
 	}
 	rlist.markedForDeletion = false
@@ -143,7 +228,7 @@ func (w *Worker) cleanup() {
 			delete(w.rlists, epoch)
 			go func() {
 				defer log.HandlePanic()
-				rlist.removeAll()
+				rlist.release()
 			}()
 		} else {
 			// Mark the reassembly list for deletion. If it is not accessed between now
@@ -17,12 +17,15 @@ package ingress
 import (
 	"net"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/scionproto/scion/go/lib/ringbuf"
 	"github.com/scionproto/scion/go/lib/snet"
 	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/sig/internal/sigcrypto"
 )
 
 type MockTun struct {
@@ -71,7 +74,7 @@ func TestParsing(t *testing.T) {
 		},
 	}
 	mt := &MockTun{}
-	w := NewWorker(addr, 1, mt)
+	w := NewWorker(addr, 1, mt, ReorderConfig{})
 
 	// Single frame with a single 3-bytes long packet inside.
 	SendFrame(t, w, []byte{1, 0, 1, 0, 0, 1, 0, 1,
@@ -125,3 +128,81 @@ func TestParsing(t *testing.T) {
 	mt.AssertPacket(t, []byte{201, 202, 203})
 	mt.AssertDone(t)
 }
+
+func TestDecryption(t *testing.T) {
+	addr := &snet.UDPAddr{
+		IA: xtest.MustParseIA("1-ff00:0:301"),
+		Host: &net.UDPAddr{
+			IP:   net.IP{192, 168, 1, 2},
+			Port: 80,
+		},
+	}
+	key, err := sigcrypto.GenKey(1)
+	require.NoError(t, err)
+	require.NoError(t, sigcrypto.RxKeys.Set(addr.IA, addr.Host.IP, 1, key))
+	defer sigcrypto.RxKeys.SetRequired(addr.IA, false)
+	seal := func(frame []byte) []byte {
+		buf := make([]byte, len(frame), len(frame)+sigcrypto.Overhead)
+		copy(buf, frame)
+		sealed, err := key.Seal(buf)
+		require.NoError(t, err)
+		return sealed
+	}
+	mt := &MockTun{}
+	w := NewWorker(addr, 1, mt, ReorderConfig{})
+
+	// Encrypted frame is decrypted.
+	frame := seal([]byte{1, 0, 1, 0, 0, 1, 0, 1,
+		0, 3, 101, 102, 103, 0, 0, 0})
+	SendFrame(t, w, frame)
+	mt.AssertPacket(t, []byte{101, 102, 103})
+	mt.AssertDone(t)
+
+	// Replayed frame is dropped.
+	SendFrame(t, w, frame)
+	mt.AssertDone(t)
+
+	// Tampered frame is dropped.
+	frame = seal([]byte{1, 0, 1, 0, 0, 2, 0, 1,
+		0, 3, 101, 102, 103, 0, 0, 0})
+	frame[len(frame)-1] ^= 0xff
+	SendFrame(t, w, frame)
+	mt.AssertDone(t)
+
+	// Unencrypted frame of an unknown epoch is accepted, unless encryption is
+	// required.
+	SendFrame(t, w, []byte{1, 0, 2, 0, 0, 1, 0, 1,
+		0, 3, 201, 202, 203, 0, 0, 0})
+	mt.AssertPacket(t, []byte{201, 202, 203})
+	mt.AssertDone(t)
+	sigcrypto.RxKeys.SetRequired(addr.IA, true)
+	SendFrame(t, w, []byte{1, 0, 2, 0, 0, 2, 0, 1,
+		0, 3, 201, 202, 203, 0, 0, 0})
+	mt.AssertDone(t)
+}
+
+func TestReordering(t *testing.T) {
+	addr := &snet.UDPAddr{
+		IA: xtest.MustParseIA("1-ff00:0:302"),
+		Host: &net.UDPAddr{
+			IP:   net.IP{192, 168, 1, 3},
+			Port: 80,
+		},
+	}
+	mt := &MockTun{}
+	w := NewWorker(addr, 1, mt, ReorderConfig{Frames: 4, Timeout: time.Second})
+
+	SendFrame(t, w, []byte{1, 0, 1, 0, 0, 1, 0, 1,
+		0, 3, 101, 102, 103, 0, 0, 0})
+	mt.AssertPacket(t, []byte{101, 102, 103})
+	mt.AssertDone(t)
+
+	// Single packet split into two frames that arrive swapped.
+	SendFrame(t, w, []byte{1, 0, 1, 0, 0, 3, 0, 0,
+		57, 58, 0, 0, 0, 0, 0, 0})
+	mt.AssertDone(t)
+	SendFrame(t, w, []byte{1, 0, 1, 0, 0, 2, 0, 1,
+		0, 8, 51, 52, 53, 54, 55, 56})
+	mt.AssertPacket(t, []byte{51, 52, 53, 54, 55, 56, 57, 58})
+	mt.AssertDone(t)
+}
@@ -34,31 +34,40 @@ const Namespace = "sig"
 
 // Declare prometheus metrics to export.
 var (
-	PktUnroutable         prometheus.Counter
-	PktsRecv              *prometheus.CounterVec
-	PktsSent              *prometheus.CounterVec
-	PktBytesRecv          *prometheus.CounterVec
-	PktBytesSent          *prometheus.CounterVec
-	FramesRecv            *prometheus.CounterVec
-	FramesSent            *prometheus.CounterVec
-	FrameBytesRecv        *prometheus.CounterVec
-	FrameBytesSent        *prometheus.CounterVec
-	FrameDiscardEvents    prometheus.Counter
-	FramesDiscarded       prometheus.Counter
-	FramesTooOld          prometheus.Counter
-	FramesDuplicated      prometheus.Counter
-	SessionTimedOut       *prometheus.CounterVec
-	SessionPathSwitched   *prometheus.CounterVec
-	SessionOldPollReplies *prometheus.CounterVec
-	SessionProbes         *prometheus.CounterVec
-	SessionProbeReplies   *prometheus.CounterVec
-	SessionProbeRTT       *prometheus.HistogramVec
-	SessionPaths          *prometheus.GaugeVec
-	SessionMTU            *prometheus.GaugeVec
-	SessionHealth         *prometheus.GaugeVec
-	SessionRemoteSwitched *prometheus.CounterVec
+	PktUnroutable           prometheus.Counter
+	PktsRecv                *prometheus.CounterVec
+	PktsSent                *prometheus.CounterVec
+	PktBytesRecv            *prometheus.CounterVec
+	PktBytesSent            *prometheus.CounterVec
+	FramesRecv              *prometheus.CounterVec
+	FramesSent              *prometheus.CounterVec
+	FrameBytesRecv          *prometheus.CounterVec
+	FrameBytesSent          *prometheus.CounterVec
+	FrameDiscardEvents      prometheus.Counter
+	FramesDiscarded         prometheus.Counter
+	FramesTooOld            prometheus.Counter
+	FramesDuplicated        prometheus.Counter
+	FramesCryptoDropped     *prometheus.CounterVec
+	SessionFramesReordered  *prometheus.CounterVec
+	SessionFramesDuplicated *prometheus.CounterVec
+	SessionFramesLate       *prometheus.CounterVec
+	SessionFramesLost       *prometheus.CounterVec
+	SessionTimedOut         *prometheus.CounterVec
+	SessionPathSwitched     *prometheus.CounterVec
+	SessionOldPollReplies   *prometheus.CounterVec
+	SessionProbes           *prometheus.CounterVec
+	SessionProbeReplies     *prometheus.CounterVec
+	SessionProbeRTT         *prometheus.HistogramVec
+	SessionPaths            *prometheus.GaugeVec
+	SessionActivePaths      *prometheus.GaugeVec
+	SessionMTU              *prometheus.GaugeVec
+	SessionHealth           *prometheus.GaugeVec
+	SessionRemoteSwitched   *prometheus.CounterVec
 
 	EgressRxQueueFull *prometheus.CounterVec
+
+	PrefixAnnouncesRecv *prometheus.CounterVec
+	ImportedNets        *prometheus.GaugeVec
 )
 
 // Version number of loaded config, atomic
@@ -97,6 +106,16 @@ func init() {
 	FramesDiscarded = newC("frames_discarded_total", "Number of frames discarded.")
 	FramesTooOld = newC("frames_too_old_total", "Number of frames that are too old.")
 	FramesDuplicated = newC("frames_duplicated_total", "Number of duplicate frames.")
+	FramesCryptoDropped = newCVec("frames_crypto_dropped_total",
+		"Number of frames dropped by the frame encryption.", append(iaLabels, "reason"))
+	SessionFramesReordered = newCVec("session_frames_reordered_total",
+		"Number of frames received after a frame with a higher sequence number.", iaLabels)
+	SessionFramesDuplicated = newCVec("session_frames_duplicated_total",
+		"Number of frames received more than once.", iaLabels)
+	SessionFramesLate = newCVec("session_frames_late_total",
+		"Number of frames received after they were considered lost.", iaLabels)
+	SessionFramesLost = newCVec("session_frames_lost_total",
+		"Number of frames considered lost.", iaLabels)
 	SessionTimedOut = newCVec("session_timeout", "Number of pollreq timeouts", iaLabels)
 	SessionPathSwitched = newCVec("session_switch_path", "Number of path switches",
 		append(iaLabels, "reason"))
@@ -108,6 +127,8 @@ func init() {
 	SessionProbeRTT = newHVec("session_probe_rtt", "Probe roundtrip time",
 		iaLabels, prom.DefaultLatencyBuckets)
 	SessionPaths = newGVec("session_paths", "Number of available paths", iaLabels)
+	SessionActivePaths = newGVec("session_active_paths",
+		"Number of paths the traffic is spread over (0: single path)", iaLabels)
 	SessionMTU = newGVec("session_mtu", "MTU used by the session", iaLabels)
 	SessionHealth = newGVec("session_health", "Session health (1: healthy or 0: unhealthy)",
 		iaLabels)
@@ -117,6 +138,11 @@ func init() {
 	EgressRxQueueFull = newCVec("egress_recv_queue_full_total",
 		"Egress packets dropped due to full queues.", []string{"dst_isd_as"})
 
+	PrefixAnnouncesRecv = newCVec("prefix_announces_recv_total",
+		"Number of prefix announcements received.", []string{"src_isd_as", "result"})
+	ImportedNets = newGVec("imported_nets",
+		"Number of networks imported from prefix announcements.", []string{"dst_isd_as"})
+
 	// Add handler for ConfigVersion
 	http.HandleFunc("/configversion", func(w http.ResponseWriter, _ *http.Request) {
 		fmt.Fprintln(w, atomic.LoadUint64(&ConfigVersion))
@@ -129,6 +155,25 @@ type CtrPair struct {
 	Bytes prometheus.Counter
 }
 
+// ReorderCtrs are the counters of the frame reordering of a session.
+type ReorderCtrs struct {
+	Reordered prometheus.Counter
+	Duplicate prometheus.Counter
+	Late      prometheus.Counter
+	Lost      prometheus.Counter
+}
+
+// NewReorderCtrs returns the reorder counters of the session.
+func NewReorderCtrs(ia addr.IA, sessId sig_mgmt.SessionType) ReorderCtrs {
+	l := []string{ia.String(), sessId.String()}
+	return ReorderCtrs{
+		Reordered: SessionFramesReordered.WithLabelValues(l...),
+		Duplicate: SessionFramesDuplicated.WithLabelValues(l...),
+		Late:      SessionFramesLate.WithLabelValues(l...),
+		Lost:      SessionFramesLost.WithLabelValues(l...),
+	}
+}
+
 type CtrPairKey struct {
 	RemoteIA addr.IAInt
 	SessId   sig_mgmt.SessionType
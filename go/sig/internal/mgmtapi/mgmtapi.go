@@ -0,0 +1,150 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mgmtapi implements the HTTP management API of the SIG. The API
+// exposes the state of the remote ASes and their sessions as JSON:
+//
+//   GET  /sig/ases                                    all remote ASes
+//   GET  /sig/ases/<isd-as>                           a single remote AS
+//   POST /sig/ases/<isd-as>/sessions/<id>/switch_path switch to a different path
+//
+// The path switch forces the session to use a different path, even if the
+// current path is healthy. The API is only served if the mgmt_addr of the SIG
+// is configured, on a listener separate from the metrics.
+package mgmtapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/ctrl/sig_mgmt"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/sig/egress/asmap"
+)
+
+// Prefix is the URL path prefix of the API.
+const Prefix = "/sig/"
+
+// Map provides the state of the remote ASes.
+type Map interface {
+	// Status returns the state of all remote ASes.
+	Status() []asmap.ASStatus
+	// SwitchPath requests the session to switch to a different path. It
+	// returns an error that is asmap.ErrUnknownAS or asmap.ErrUnknownSession
+	// if the AS or session do not exist.
+	SwitchPath(ia addr.IA, id sig_mgmt.SessionType) error
+}
+
+// Handler serves the management API.
+type Handler struct {
+	Map Map
+}
+
+func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, Prefix), "/"), "/")
+	switch {
+	case len(parts) == 1 && parts[0] == "ases":
+		h.listASes(w, r)
+	case len(parts) == 2 && parts[0] == "ases":
+		h.getAS(w, r, parts[1])
+	case len(parts) == 5 && parts[0] == "ases" && parts[2] == "sessions" &&
+		parts[4] == "switch_path":
+		h.switchPath(w, r, parts[1], parts[3])
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h Handler) listASes(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodGet) {
+		return
+	}
+	ases := h.Map.Status()
+	if ases == nil {
+		ases = []asmap.ASStatus{}
+	}
+	writeJSON(w, ases)
+}
+
+func (h Handler) getAS(w http.ResponseWriter, r *http.Request, rawIA string) {
+	if !allowMethod(w, r, http.MethodGet) {
+		return
+	}
+	ia, err := addr.IAFromString(rawIA)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid ISD-AS: %s", err), http.StatusBadRequest)
+		return
+	}
+	for _, as := range h.Map.Status() {
+		if as.IA.Equal(ia) {
+			writeJSON(w, as)
+			return
+		}
+	}
+	http.Error(w, fmt.Sprintf("unknown AS: %s", ia), http.StatusNotFound)
+}
+
+func (h Handler) switchPath(w http.ResponseWriter, r *http.Request, rawIA, rawID string) {
+	if !allowMethod(w, r, http.MethodPost) {
+		return
+	}
+	ia, err := addr.IAFromString(rawIA)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid ISD-AS: %s", err), http.StatusBadRequest)
+		return
+	}
+	id, err := strconv.ParseUint(rawID, 10, 8)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid session ID: %s", err), http.StatusBadRequest)
+		return
+	}
+	err = h.Map.SwitchPath(ia, sig_mgmt.SessionType(id))
+	switch {
+	case errors.Is(err, asmap.ErrUnknownAS), errors.Is(err, asmap.ErrUnknownSession):
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	log.Info("Management API: path switch requested", "ia", ia, "sessId", id)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// allowMethod checks the method of the request. If it is not allowed, an error
+// is written and false is returned.
+func allowMethod(w http.ResponseWriter, r *http.Request, method string) bool {
+	if r.Method == method {
+		return true
+	}
+	w.Header().Set("Allow", method)
+	http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	return false
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	raw, err := json.MarshalIndent(v, "", "    ")
+	if err != nil {
+		http.Error(w, fmt.Sprintf("unable to encode response: %s", err),
+			http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprint(w, string(raw)+"\n")
+}
@@ -0,0 +1,184 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mgmtapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/ctrl/sig_mgmt"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/sig/egress/asmap"
+	"github.com/scionproto/scion/go/sig/egress/session"
+)
+
+type fakeMap struct {
+	ases     []asmap.ASStatus
+	switched []sig_mgmt.SessionType
+}
+
+func (m *fakeMap) Status() []asmap.ASStatus {
+	return m.ases
+}
+
+func (m *fakeMap) SwitchPath(ia addr.IA, id sig_mgmt.SessionType) error {
+	for _, as := range m.ases {
+		if !as.IA.Equal(ia) {
+			continue
+		}
+		for _, s := range as.Sessions {
+			if s.ID == id {
+				m.switched = append(m.switched, id)
+				return nil
+			}
+		}
+		return serrors.WithCtx(asmap.ErrUnknownSession, "session", id)
+	}
+	return serrors.WithCtx(asmap.ErrUnknownAS, "ia", ia)
+}
+
+func newFakeMap() *fakeMap {
+	return &fakeMap{
+		ases: []asmap.ASStatus{
+			{
+				IA:      xtest.MustParseIA("1-ff00:0:110"),
+				Healthy: true,
+				Nets:    []string{"192.0.2.0/24"},
+				Sessions: []asmap.SessionStatus{
+					{
+						Started: true,
+						Status: &session.Status{
+							ID:      0,
+							Healthy: true,
+							Remote: &session.RemoteStatus{
+								Sig:  "1-ff00:0:110,[192.0.2.1]:30256:30056",
+								Path: "abcd",
+							},
+							Paths: []session.PathStatus{
+								{Fingerprint: "abcd", MTU: 1472, Weight: 9, Active: true},
+							},
+						},
+					},
+					{Class: "voip", Status: &session.Status{ID: 1}},
+				},
+			},
+		},
+	}
+}
+
+func serve(t *testing.T, m Map, method, path string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	Handler{Map: m}.ServeHTTP(rec, httptest.NewRequest(method, path, nil))
+	return rec
+}
+
+func TestListASes(t *testing.T) {
+	m := newFakeMap()
+	rec := serve(t, m, http.MethodGet, "/sig/ases")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	var ases []asmap.ASStatus
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &ases))
+	assert.Equal(t, m.ases, ases)
+
+	rec = serve(t, &fakeMap{}, http.MethodGet, "/sig/ases")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, "[]", rec.Body.String())
+
+	rec = serve(t, m, http.MethodPost, "/sig/ases")
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestGetAS(t *testing.T) {
+	m := newFakeMap()
+	tests := map[string]struct {
+		Path string
+		Code int
+	}{
+		"known AS":    {Path: "/sig/ases/1-ff00:0:110", Code: http.StatusOK},
+		"unknown AS":  {Path: "/sig/ases/1-ff00:0:111", Code: http.StatusNotFound},
+		"invalid IA":  {Path: "/sig/ases/foo", Code: http.StatusBadRequest},
+		"unknown URL": {Path: "/sig/foo", Code: http.StatusNotFound},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			rec := serve(t, m, http.MethodGet, test.Path)
+			assert.Equal(t, test.Code, rec.Code)
+			if test.Code != http.StatusOK {
+				return
+			}
+			var as asmap.ASStatus
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &as))
+			assert.Equal(t, m.ases[0], as)
+		})
+	}
+}
+
+func TestSwitchPath(t *testing.T) {
+	tests := map[string]struct {
+		Method   string
+		Path     string
+		Code     int
+		Switched []sig_mgmt.SessionType
+	}{
+		"default session": {
+			Method:   http.MethodPost,
+			Path:     "/sig/ases/1-ff00:0:110/sessions/0/switch_path",
+			Code:     http.StatusAccepted,
+			Switched: []sig_mgmt.SessionType{0},
+		},
+		"class session": {
+			Method:   http.MethodPost,
+			Path:     "/sig/ases/1-ff00:0:110/sessions/1/switch_path",
+			Code:     http.StatusAccepted,
+			Switched: []sig_mgmt.SessionType{1},
+		},
+		"unknown session": {
+			Method: http.MethodPost,
+			Path:   "/sig/ases/1-ff00:0:110/sessions/2/switch_path",
+			Code:   http.StatusNotFound,
+		},
+		"unknown AS": {
+			Method: http.MethodPost,
+			Path:   "/sig/ases/1-ff00:0:111/sessions/0/switch_path",
+			Code:   http.StatusNotFound,
+		},
+		"invalid session ID": {
+			Method: http.MethodPost,
+			Path:   "/sig/ases/1-ff00:0:110/sessions/256/switch_path",
+			Code:   http.StatusBadRequest,
+		},
+		"GET not allowed": {
+			Method: http.MethodGet,
+			Path:   "/sig/ases/1-ff00:0:110/sessions/0/switch_path",
+			Code:   http.StatusMethodNotAllowed,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := newFakeMap()
+			rec := serve(t, m, test.Method, test.Path)
+			assert.Equal(t, test.Code, rec.Code)
+			assert.Equal(t, test.Switched, m.switched)
+		})
+	}
+}
@@ -18,12 +18,14 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"time"
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/config"
 	"github.com/scionproto/scion/go/lib/env"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/util"
 )
 
 const (
@@ -31,6 +33,12 @@ const (
 	DefaultEncapPort   = 30056
 	DefaultTunName     = "sig"
 	DefaultTunRTableId = 11
+	// DefaultReorderTimeout is the default maximum time frames are buffered
+	// while waiting for a missing frame.
+	DefaultReorderTimeout = 20 * time.Millisecond
+	// MaxReorderFrames is the maximum number of frames that can be buffered per
+	// session while waiting for a missing frame.
+	MaxReorderFrames = 64
 )
 
 type Config struct {
@@ -103,6 +111,22 @@ type SigConf struct {
 	// dispatcher. If the field is empty bypass is not done and SCION dispatcher is used
 	// instead.
 	DispatcherBypass string `toml:"disaptcher_bypass,omitempty"`
+	// CryptoDir is the directory that contains the certs and keys
+	// subdirectories with the certificate chains and the keys of the AS. It is
+	// required for frame encryption. The certificate chains of the remote ASes
+	// that frames are encrypted for must be present as well.
+	CryptoDir string `toml:"crypto_dir,omitempty"`
+	// ReorderFrames is the maximum number of frames that are buffered per
+	// session while waiting for a missing frame. (default 0, frames are not
+	// reordered)
+	ReorderFrames int `toml:"reorder_frames,omitempty"`
+	// ReorderTimeout is the maximum time frames are buffered while waiting for
+	// a missing frame. (default DefaultReorderTimeout)
+	ReorderTimeout util.DurWrap `toml:"reorder_timeout,omitempty"`
+	// MgmtAddr is the address the HTTP management API listens on. It allows
+	// to change the state of the sessions, and should only be reachable by
+	// operators. (default "", the management API is disabled)
+	MgmtAddr string `toml:"mgmt_addr,omitempty"`
 }
 
 // InitDefaults sets the default values to unset values.
@@ -138,6 +162,21 @@ func (cfg *SigConf) Validate() error {
 	if cfg.TunRTableId == 0 {
 		cfg.TunRTableId = DefaultTunRTableId
 	}
+	if cfg.ReorderFrames < 0 || cfg.ReorderFrames > MaxReorderFrames {
+		return serrors.New("reorder_frames out of range", "value", cfg.ReorderFrames,
+			"max", MaxReorderFrames)
+	}
+	if cfg.ReorderTimeout.Duration < 0 {
+		return serrors.New("reorder_timeout must not be negative")
+	}
+	if cfg.ReorderTimeout.Duration == 0 {
+		cfg.ReorderTimeout.Duration = DefaultReorderTimeout
+	}
+	if cfg.MgmtAddr != "" {
+		if _, _, err := net.SplitHostPort(cfg.MgmtAddr); err != nil {
+			return serrors.WrapStr("invalid mgmt_addr", err, "value", cfg.MgmtAddr)
+		}
+	}
 	return nil
 }
 
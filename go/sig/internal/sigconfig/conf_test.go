@@ -64,4 +64,8 @@ func CheckTestSigConf(t *testing.T, cfg *SigConf, id string) {
 	assert.Equal(t, DefaultEncapPort, int(cfg.EncapPort))
 	assert.Equal(t, DefaultTunName, cfg.Tun)
 	assert.Equal(t, DefaultTunRTableId, cfg.TunRTableId)
+	assert.Empty(t, cfg.CryptoDir)
+	assert.Equal(t, 0, cfg.ReorderFrames)
+	assert.Equal(t, DefaultReorderTimeout, cfg.ReorderTimeout.Duration)
+	assert.Empty(t, cfg.MgmtAddr)
 }
@@ -40,4 +40,25 @@ tun = "sig"
 
 # Id of the routing table. (default 11)
 tun_routing_table_id = 11
+
+# The directory with the certs and keys subdirectories of the AS, used for frame
+# encryption. The certificate chains of the remote ASes that frames are
+# encrypted for must be present as well. (default "", frame encryption is not
+# possible)
+crypto_dir = ""
+
+# The maximum number of frames that are buffered per session while waiting for
+# a missing frame. Reordering lets the SIG tolerate paths that reorder frames,
+# e.g., when traffic is spread over multiple paths. (default 0, frames are not
+# reordered)
+reorder_frames = 0
+
+# The maximum time frames are buffered while waiting for a missing frame.
+# (default 20ms)
+reorder_timeout = "20ms"
+
+# The address of the HTTP management API, e.g., "127.0.0.1:30456". The API
+# allows to switch the paths of sessions, it should only be reachable by
+# operators. (default "", the management API is disabled)
+mgmt_addr = ""
 `
@@ -0,0 +1,107 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sigcrypto
+
+import (
+	"context"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl"
+	"github.com/scionproto/scion/go/lib/ctrl/sig_mgmt"
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/sigdisp"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/sig/internal/sigcmn"
+)
+
+// keyTimeout is the maximum time spent on looking up the AS keys of an
+// announcement.
+const keyTimeout = 5 * time.Second
+
+// KeyAnnounceHdlr handles the frame key announcements of remote SIGs. The
+// keys are added to RxKeys and acknowledged to the announcing SIG. Stale and
+// replayed announcements are not acknowledged.
+func KeyAnnounceHdlr() {
+	log.Info("KeyAnnounceHdlr: starting")
+	for rpld := range sigdisp.Dispatcher.KeyAnnounceC {
+		ann, ok := rpld.P.(*sig_mgmt.KeyAnnounce)
+		if !ok {
+			log.Error("KeyAnnounceHdlr: non-SIGKeyAnnounce payload received",
+				"src", rpld.Addr, "type", common.TypeOf(rpld.P), "Id", rpld.Id, "pld", rpld.P)
+			continue
+		}
+		if ann.Addr == nil || ann.Addr.Ctrl == nil {
+			log.Error("KeyAnnounceHdlr: Incomplete SIGKeyAnnounce received",
+				"src", rpld.Addr, "pld", ann)
+			continue
+		}
+		if Provider == nil {
+			log.Warn("KeyAnnounceHdlr: Frame encryption not configured, ignoring key",
+				"src", rpld.Addr, "pld", ann)
+			continue
+		}
+		ctx, cancelF := context.WithTimeout(context.Background(), keyTimeout)
+		key, err := OpenKey(ctx, Provider, rpld.Addr.IA, ann)
+		cancelF()
+		if err != nil {
+			log.Error("KeyAnnounceHdlr: Unable to open key", "src", rpld.Addr, "pld", ann,
+				"err", err)
+			continue
+		}
+		if err := RxKeys.Set(rpld.Addr.IA, rpld.Addr.Host.IP, ann.Session, key); err != nil {
+			log.Info("KeyAnnounceHdlr: Rejected key", "src", rpld.Addr, "pld", ann,
+				"err", err)
+			continue
+		}
+		log.Debug("KeyAnnounceHdlr: Added key", "src", rpld.Addr, "pld", ann)
+		sendAck(rpld, ann, key)
+	}
+	log.Info("KeyAnnounceHdlr: stopped")
+}
+
+func sendAck(rpld *sigdisp.RegPld, ann *sig_mgmt.KeyAnnounce, key *Key) {
+	ack := sig_mgmt.NewKeyAck(ann.Session, ann.Epoch, key.AckMAC(ann.Session))
+	spld, err := sig_mgmt.NewPld(rpld.Id, ack)
+	if err != nil {
+		log.Error("KeyAnnounceHdlr: Error creating SIGCtrl payload", "err", err)
+		return
+	}
+	cpld, err := ctrl.NewPld(spld, nil)
+	if err != nil {
+		log.Error("KeyAnnounceHdlr: Error creating Ctrl payload", "err", err)
+		return
+	}
+	scpld, err := cpld.SignedPld(infra.NullSigner)
+	if err != nil {
+		log.Error("KeyAnnounceHdlr: Error creating signed Ctrl payload", "err", err)
+		return
+	}
+	raw, err := scpld.PackPld()
+	if err != nil {
+		log.Error("KeyAnnounceHdlr: Error packing signed Ctrl payload", "err", err)
+		return
+	}
+	sigCtrlAddr := &snet.UDPAddr{
+		IA:      rpld.Addr.IA,
+		Path:    rpld.Addr.Path,
+		NextHop: snet.CopyUDPAddr(rpld.Addr.NextHop),
+		Host:    ann.Addr.Ctrl.UDP(),
+	}
+	if _, err := sigcmn.CtrlConn.WriteTo(raw, sigCtrlAddr); err != nil {
+		log.Error("KeyAnnounceHdlr: Error sending Ctrl payload", "dest", rpld.Addr, "err", err)
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sigcrypto implements the authenticated encryption of SIG frames.
+//
+// Each SIG encrypts the frames of a session with a key that is only used for
+// one epoch. The key is generated by the sending SIG and announced to the
+// receiving SIG over the SIG control plane, encrypted with the encryption keys
+// of the certificate chains of the two ASes, together with the creation time of
+// the key. The receiving SIG rejects stale announcements, and announcements of
+// keys that are older than the latest key it accepted for the session. Once the
+// receiving SIG acknowledges the key, the sending SIG starts a new epoch with
+// it.
+//
+// The frame payload is encrypted with AES-GCM. The frame header is
+// authenticated, and the session ID, epoch and sequence number form the
+// nonce, which is unique as long as the sequence numbers do not wrap within
+// an epoch. The receiving SIG rejects replayed frames based on the sequence
+// number.
+package sigcrypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"sync/atomic"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/sig_mgmt"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+const (
+	// KeyLen is the length of a frame key.
+	KeyLen = 16
+	// Overhead is the number of bytes an encrypted frame is longer than the
+	// plaintext frame.
+	Overhead = 16
+	// RekeyInterval is the interval after which a session switches to a new
+	// key.
+	RekeyInterval = 1 * time.Minute
+	// KeyLifetime is the time after which a key is no longer used, even if
+	// no new key could be established.
+	KeyLifetime = 3 * RekeyInterval
+	// AnnounceFreshness is the maximum difference between the creation time
+	// of an announced key and the time it is received. Older announcements
+	// are rejected.
+	AnnounceFreshness = RekeyInterval
+
+	// hdrLen is the length of the SIG frame header.
+	hdrLen = 8
+	// nonceHdrLen is the length of the prefix of the frame header that is used
+	// as nonce, i.e., session ID, epoch and sequence number.
+	nonceHdrLen = 6
+)
+
+var (
+	// ErrAuth indicates that a frame could not be authenticated.
+	ErrAuth = serrors.New("frame authentication failed")
+	// ErrReplay indicates that a frame was received before.
+	ErrReplay = serrors.New("frame replayed")
+)
+
+// Key encrypts and authenticates the frames of a session in one epoch.
+type Key struct {
+	// Epoch is the epoch of the frames that are encrypted with the key.
+	Epoch uint16
+	// NotAfter is the time after which the key must no longer be used.
+	NotAfter time.Time
+	// Created is the time the key was created.
+	Created time.Time
+
+	raw       common.RawBytes
+	aead      cipher.AEAD
+	exhausted uint32
+}
+
+// NewKey creates a key for the epoch from the raw key.
+func NewKey(epoch uint16, raw common.RawBytes) (*Key, error) {
+	if len(raw) != KeyLen {
+		return nil, serrors.New("invalid key length", "expected", KeyLen, "actual", len(raw))
+	}
+	block, err := aes.NewCipher(raw)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	return &Key{
+		Epoch:    epoch,
+		Created:  now,
+		NotAfter: now.Add(KeyLifetime),
+		raw:      raw,
+		aead:     aead,
+	}, nil
+}
+
+// GenKey generates a random key for the epoch.
+func GenKey(epoch uint16) (*Key, error) {
+	raw := make(common.RawBytes, KeyLen)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, serrors.WrapStr("unable to generate key", err)
+	}
+	return NewKey(epoch, raw)
+}
+
+// Raw returns the raw key.
+func (k *Key) Raw() common.RawBytes {
+	return k.raw
+}
+
+// SetExhausted marks that all sequence numbers of the epoch have been used.
+// The key must not be used for any further frames.
+func (k *Key) SetExhausted() {
+	atomic.StoreUint32(&k.exhausted, 1)
+}
+
+// Exhausted returns whether all sequence numbers of the epoch have been used.
+func (k *Key) Exhausted() bool {
+	return atomic.LoadUint32(&k.exhausted) == 1
+}
+
+// Seal encrypts the payload of the frame in place, and authenticates the
+// frame header. The frame must have a capacity of at least len(frame) +
+// Overhead. The encrypted frame is returned.
+func (k *Key) Seal(frame common.RawBytes) (common.RawBytes, error) {
+	if len(frame) < hdrLen {
+		return nil, serrors.New("frame too short", "len", len(frame))
+	}
+	if cap(frame) < len(frame)+Overhead {
+		return nil, serrors.New("insufficient frame capacity", "len", len(frame),
+			"cap", cap(frame))
+	}
+	hdr := frame[:hdrLen]
+	out := k.aead.Seal(frame[hdrLen:hdrLen], k.nonce(hdr), frame[hdrLen:], hdr)
+	return frame[:hdrLen+len(out)], nil
+}
+
+// Open authenticates the frame and decrypts its payload in place. The
+// decrypted frame is returned.
+func (k *Key) Open(frame common.RawBytes) (common.RawBytes, error) {
+	if len(frame) < hdrLen+Overhead {
+		return nil, serrors.New("frame too short", "len", len(frame))
+	}
+	hdr := frame[:hdrLen]
+	out, err := k.aead.Open(frame[hdrLen:hdrLen], k.nonce(hdr), frame[hdrLen:], hdr)
+	if err != nil {
+		return nil, ErrAuth
+	}
+	return frame[:hdrLen+len(out)], nil
+}
+
+// AckMAC returns the MAC that authenticates the acknowledgement of the key for
+// the session.
+func (k *Key) AckMAC(sess sig_mgmt.SessionType) common.RawBytes {
+	return k.aead.Seal(nil, k.ackNonce(), nil, k.ackData(sess))
+}
+
+// VerifyAckMAC returns whether the MAC authenticates the acknowledgement of the
+// key for the session.
+func (k *Key) VerifyAckMAC(sess sig_mgmt.SessionType, mac common.RawBytes) bool {
+	_, err := k.aead.Open(nil, k.ackNonce(), mac, k.ackData(sess))
+	return err == nil
+}
+
+// ackNonce returns the nonce of the acknowledgement MAC. The last byte is set,
+// such that it differs from all frame nonces.
+func (k *Key) ackNonce() []byte {
+	nonce := make([]byte, k.aead.NonceSize())
+	nonce[len(nonce)-1] = 1
+	return nonce
+}
+
+func (k *Key) ackData(sess sig_mgmt.SessionType) []byte {
+	data := append([]byte("SIGKeyAck"), uint8(sess), 0, 0)
+	common.Order.PutUint16(data[len(data)-2:], k.Epoch)
+	return data
+}
+
+func (k *Key) nonce(hdr common.RawBytes) []byte {
+	nonce := make([]byte, k.aead.NonceSize())
+	copy(nonce, hdr[:nonceHdrLen])
+	return nonce
+}
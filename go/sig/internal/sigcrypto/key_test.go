@@ -0,0 +1,206 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sigcrypto_test
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/sig/internal/sigcrypto"
+)
+
+// newFrame returns a frame with the header for the sequence number, and
+// capacity for the encryption overhead.
+func newFrame(seq uint32, payload string) common.RawBytes {
+	frame := make(common.RawBytes, 8, 8+len(payload)+sigcrypto.Overhead)
+	frame[0] = 1
+	common.Order.PutUint16(frame[1:3], 42)
+	common.Order.PutUintN(frame[3:6], uint64(seq), 3)
+	common.Order.PutUint16(frame[6:8], 1)
+	return append(frame, payload...)
+}
+
+func TestKeySealOpen(t *testing.T) {
+	key, err := sigcrypto.GenKey(42)
+	require.NoError(t, err)
+
+	frame := newFrame(7, "some payload")
+	plain := append(common.RawBytes(nil), frame...)
+	sealed, err := key.Seal(frame)
+	require.NoError(t, err)
+	assert.Len(t, sealed, len(plain)+sigcrypto.Overhead)
+	assert.Equal(t, plain[:8], sealed[:8], "header must stay in clear")
+	assert.NotContains(t, string(sealed), "some payload")
+
+	opened, err := key.Open(sealed)
+	require.NoError(t, err)
+	assert.Equal(t, plain, opened)
+}
+
+func TestKeyOpenErrors(t *testing.T) {
+	key, err := sigcrypto.GenKey(42)
+	require.NoError(t, err)
+	other, err := sigcrypto.GenKey(42)
+	require.NoError(t, err)
+
+	tests := map[string]func(common.RawBytes) common.RawBytes{
+		"modified header": func(f common.RawBytes) common.RawBytes {
+			f[7] ^= 1
+			return f
+		},
+		"modified sequence number": func(f common.RawBytes) common.RawBytes {
+			f[5] ^= 1
+			return f
+		},
+		"modified payload": func(f common.RawBytes) common.RawBytes {
+			f[10] ^= 1
+			return f
+		},
+		"truncated": func(f common.RawBytes) common.RawBytes {
+			return f[:len(f)-1]
+		},
+		"wrong key": func(f common.RawBytes) common.RawBytes {
+			f, err := other.Seal(newFrame(7, "some payload"))
+			require.NoError(t, err)
+			return f
+		},
+	}
+	for name, modify := range tests {
+		t.Run(name, func(t *testing.T) {
+			sealed, err := key.Seal(newFrame(7, "some payload"))
+			require.NoError(t, err)
+			_, err = key.Open(modify(sealed))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestKeySealCapacity(t *testing.T) {
+	key, err := sigcrypto.GenKey(42)
+	require.NoError(t, err)
+	frame := newFrame(7, "some payload")
+	_, err = key.Seal(frame[:len(frame):len(frame)])
+	assert.Error(t, err)
+}
+
+func TestKeyAckMAC(t *testing.T) {
+	key, err := sigcrypto.GenKey(42)
+	require.NoError(t, err)
+	other, err := sigcrypto.GenKey(42)
+	require.NoError(t, err)
+	otherEpoch, err := sigcrypto.NewKey(43, key.Raw())
+	require.NoError(t, err)
+
+	mac := key.AckMAC(1)
+	assert.True(t, key.VerifyAckMAC(1, mac))
+	assert.False(t, key.VerifyAckMAC(2, mac))
+	assert.False(t, other.VerifyAckMAC(1, mac))
+	assert.False(t, otherEpoch.VerifyAckMAC(1, mac))
+	assert.False(t, key.VerifyAckMAC(1, nil))
+	mac[0] ^= 1
+	assert.False(t, key.VerifyAckMAC(1, mac))
+}
+
+func TestNewKeyLength(t *testing.T) {
+	_, err := sigcrypto.NewKey(1, make(common.RawBytes, sigcrypto.KeyLen-1))
+	assert.Error(t, err)
+}
+
+func TestRxKeyReplay(t *testing.T) {
+	ia := xtest.MustParseIA("1-ff00:0:1")
+	host := net.IP{192, 0, 2, 1}
+	key, err := sigcrypto.GenKey(42)
+	require.NoError(t, err)
+	store := sigcrypto.NewRxKeyStore()
+	require.NoError(t, store.Set(ia, host, 1, key))
+	assert.Nil(t, store.Get(ia, host, 1, 43))
+	assert.Nil(t, store.Get(ia, net.IP{192, 0, 2, 2}, 1, 42))
+	rxKey := store.Get(ia, host, 1, 42)
+	require.NotNil(t, rxKey)
+
+	open := func(seq uint32) error {
+		sealed, err := key.Seal(newFrame(seq, "payload"))
+		require.NoError(t, err)
+		_, err = rxKey.Open(sealed, seq)
+		return err
+	}
+	assert.NoError(t, open(10))
+	assert.Equal(t, sigcrypto.ErrReplay, open(10))
+	// Reordered frames within the window are accepted once.
+	assert.NoError(t, open(8))
+	assert.Equal(t, sigcrypto.ErrReplay, open(8))
+	assert.NoError(t, open(2000))
+	// Frames that fell out of the window are rejected.
+	assert.Equal(t, sigcrypto.ErrReplay, open(9))
+	assert.NoError(t, open(1500))
+	// Frames that fail authentication do not update the window.
+	sealed, err := key.Seal(newFrame(1600, "payload"))
+	require.NoError(t, err)
+	sealed[9] ^= 1
+	_, err = rxKey.Open(sealed, 1600)
+	assert.Equal(t, sigcrypto.ErrAuth, err)
+	assert.NoError(t, open(1600))
+}
+
+func TestRxKeyStoreSet(t *testing.T) {
+	ia := xtest.MustParseIA("1-ff00:0:1")
+	host := net.IP{192, 0, 2, 1}
+	genKey := func(epoch uint16, created time.Time) *sigcrypto.Key {
+		key, err := sigcrypto.GenKey(epoch)
+		require.NoError(t, err)
+		key.Created = created
+		return key
+	}
+	now := time.Now()
+	store := sigcrypto.NewRxKeyStore()
+	older := genKey(1, now.Add(-2*time.Second))
+	key := genKey(2, now.Add(-time.Second))
+	require.NoError(t, store.Set(ia, host, 1, key))
+	// Retransmitted announcements are accepted.
+	assert.NoError(t, store.Set(ia, host, 1, key))
+	// Keys that are not newer than the latest key are rejected.
+	err := store.Set(ia, host, 1, older)
+	assert.True(t, errors.Is(err, sigcrypto.ErrKeyReplay), err)
+	err = store.Set(ia, host, 1, genKey(2, key.Created))
+	assert.True(t, errors.Is(err, sigcrypto.ErrKeyReplay), err)
+	assert.Nil(t, store.Get(ia, host, 1, 1))
+	// Other sessions and SIGs are independent.
+	assert.NoError(t, store.Set(ia, host, 2, older))
+	assert.NoError(t, store.Set(ia, net.IP{192, 0, 2, 2}, 1, older))
+	// Stale keys are rejected.
+	stale := genKey(3, now.Add(-sigcrypto.AnnounceFreshness-time.Second))
+	err = store.Set(ia, net.IP{192, 0, 2, 3}, 1, stale)
+	assert.True(t, errors.Is(err, sigcrypto.ErrStaleKey), err)
+	future := genKey(3, now.Add(sigcrypto.AnnounceFreshness+time.Second))
+	err = store.Set(ia, net.IP{192, 0, 2, 3}, 1, future)
+	assert.True(t, errors.Is(err, sigcrypto.ErrStaleKey), err)
+}
+
+func TestRxKeyStoreRequired(t *testing.T) {
+	ia := xtest.MustParseIA("1-ff00:0:1")
+	store := sigcrypto.NewRxKeyStore()
+	assert.False(t, store.Required(ia))
+	store.SetRequired(ia, true)
+	assert.True(t, store.Required(ia))
+	store.SetRequired(ia, false)
+	assert.False(t, store.Required(ia))
+}
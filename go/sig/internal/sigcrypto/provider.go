@@ -0,0 +1,187 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sigcrypto
+
+import (
+	"context"
+	"encoding/binary"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/ctrl/sig_mgmt"
+	"github.com/scionproto/scion/go/lib/keyconf"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/scrypto/cert"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// Provider provides the AS keys used to exchange the frame keys. If it is
+// nil, frames cannot be encrypted.
+var Provider KeyProvider
+
+// KeyProvider provides the AS keys that protect the frame keys exchanged
+// between SIGs.
+type KeyProvider interface {
+	// EncryptionKey returns the public encryption key in the certificate
+	// chain of the AS, and the version of the chain. If version is
+	// scrypto.LatestVer, the latest chain is used.
+	EncryptionKey(ctx context.Context, ia addr.IA,
+		version scrypto.Version) (scrypto.Version, scrypto.KeyMeta, error)
+	// DecryptionKey returns the private key of the local AS that matches the
+	// encryption key in the certificate chain, and the version of the chain.
+	// If version is scrypto.LatestVer, the latest chain is used.
+	DecryptionKey(ctx context.Context, version scrypto.Version) (scrypto.Version,
+		keyconf.Key, error)
+}
+
+// ChainFunc returns the raw certificate chain of the AS. If version is
+// scrypto.LatestVer, the latest chain is returned.
+type ChainFunc func(ctx context.Context, ia addr.IA, version scrypto.Version) ([]byte, error)
+
+// ChainKeyProvider provides the encryption keys from the certificate chains,
+// e.g., from the trust store, and the private key of the local AS from the key
+// ring.
+type ChainKeyProvider struct {
+	IA     addr.IA
+	Chains ChainFunc
+	Keys   keyconf.LoadingRing
+}
+
+// EncryptionKey returns the public encryption key in the certificate chain of
+// the AS.
+func (p ChainKeyProvider) EncryptionKey(ctx context.Context, ia addr.IA,
+	version scrypto.Version) (scrypto.Version, scrypto.KeyMeta, error) {
+
+	raw, err := p.Chains(ctx, ia, version)
+	if err != nil {
+		return 0, scrypto.KeyMeta{}, serrors.WrapStr("unable to get certificate chain", err,
+			"ia", ia, "version", version)
+	}
+	chain, err := cert.ParseChain(raw)
+	if err != nil {
+		return 0, scrypto.KeyMeta{}, serrors.WrapStr("unable to parse certificate chain", err,
+			"ia", ia, "version", version)
+	}
+	as, err := chain.AS.Encoded.Decode()
+	if err != nil {
+		return 0, scrypto.KeyMeta{}, serrors.WrapStr("unable to decode AS certificate", err,
+			"ia", ia, "version", version)
+	}
+	key, ok := as.Keys[cert.EncryptionKey]
+	if !ok {
+		return 0, scrypto.KeyMeta{}, serrors.New("AS certificate without encryption key",
+			"ia", ia, "version", as.Version)
+	}
+	return as.Version, key, nil
+}
+
+// DecryptionKey returns the private key of the local AS that matches the
+// encryption key in its certificate chain.
+func (p ChainKeyProvider) DecryptionKey(ctx context.Context,
+	version scrypto.Version) (scrypto.Version, keyconf.Key, error) {
+
+	chainVersion, meta, err := p.EncryptionKey(ctx, p.IA, version)
+	if err != nil {
+		return 0, keyconf.Key{}, err
+	}
+	key, err := p.Keys.PrivateKey(keyconf.ASDecryptionKey, meta.KeyVersion)
+	if err != nil {
+		return 0, keyconf.Key{}, serrors.WrapStr("unable to load decryption key", err,
+			"key_version", meta.KeyVersion)
+	}
+	if key.Algorithm != meta.Algorithm {
+		return 0, keyconf.Key{}, serrors.New("decryption key algorithm mismatch",
+			"expected", meta.Algorithm, "actual", key.Algorithm)
+	}
+	return chainVersion, key, nil
+}
+
+// sealedKeyLen is the length of the sealed part of a key announcement, i.e.,
+// the frame key, the session, the epoch and the creation time of the key.
+const sealedKeyLen = KeyLen + 1 + 2 + 8
+
+// SealKey encrypts the frame key of the session for the SIG in the remote AS.
+// The session, the epoch and the creation time of the key are sealed together
+// with the key, such that the receiver can reject stale and replayed
+// announcements. The returned announcement does not have the address set.
+func SealKey(ctx context.Context, p KeyProvider, remote addr.IA,
+	sess sig_mgmt.SessionType, key *Key) (*sig_mgmt.KeyAnnounce, error) {
+
+	dstVersion, dstKey, err := p.EncryptionKey(ctx, remote, scrypto.LatestVer)
+	if err != nil {
+		return nil, err
+	}
+	srcVersion, srcKey, err := p.DecryptionKey(ctx, scrypto.LatestVer)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := scrypto.Nonce(scrypto.NaClBoxNonceSize)
+	if err != nil {
+		return nil, err
+	}
+	plain := make([]byte, sealedKeyLen)
+	copy(plain, key.Raw())
+	plain[KeyLen] = uint8(sess)
+	binary.BigEndian.PutUint16(plain[KeyLen+1:], key.Epoch)
+	binary.BigEndian.PutUint64(plain[KeyLen+3:], uint64(key.Created.UnixNano()))
+	sealed, err := scrypto.Encrypt(plain, nonce, dstKey.Key, srcKey.Bytes, dstKey.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+	return &sig_mgmt.KeyAnnounce{
+		Session:         sess,
+		Epoch:           key.Epoch,
+		SrcChainVersion: srcVersion,
+		DstChainVersion: dstVersion,
+		Nonce:           nonce,
+		Key:             sealed,
+	}, nil
+}
+
+// OpenKey decrypts the frame key announced by the SIG in the remote AS. The
+// creation time of the returned key is the one set by the remote SIG.
+func OpenKey(ctx context.Context, p KeyProvider, remote addr.IA,
+	ann *sig_mgmt.KeyAnnounce) (*Key, error) {
+
+	_, srcKey, err := p.EncryptionKey(ctx, remote, ann.SrcChainVersion)
+	if err != nil {
+		return nil, err
+	}
+	_, dstKey, err := p.DecryptionKey(ctx, ann.DstChainVersion)
+	if err != nil {
+		return nil, err
+	}
+	plain, err := scrypto.Decrypt(ann.Key, ann.Nonce, srcKey.Key, dstKey.Bytes,
+		srcKey.Algorithm)
+	if err != nil {
+		return nil, serrors.WrapStr("unable to decrypt key", err)
+	}
+	if len(plain) != sealedKeyLen {
+		return nil, serrors.New("invalid sealed key length", "expected", sealedKeyLen,
+			"actual", len(plain))
+	}
+	sess := sig_mgmt.SessionType(plain[KeyLen])
+	epoch := binary.BigEndian.Uint16(plain[KeyLen+1:])
+	if sess != ann.Session || epoch != ann.Epoch {
+		return nil, serrors.New("sealed key does not match announcement",
+			"session", sess, "epoch", epoch)
+	}
+	key, err := NewKey(epoch, plain[:KeyLen])
+	if err != nil {
+		return nil, err
+	}
+	key.Created = time.Unix(0, int64(binary.BigEndian.Uint64(plain[KeyLen+3:])))
+	return key, nil
+}
@@ -0,0 +1,168 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sigcrypto_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/ctrl/sig_mgmt"
+	"github.com/scionproto/scion/go/lib/keyconf"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/sig/internal/sigcrypto"
+)
+
+// asKeys are the encryption key pairs of the ASes.
+type asKeys map[addr.IA][2][]byte
+
+// provider provides the keys of the ASes from the view of the local AS.
+type provider struct {
+	local addr.IA
+	keys  asKeys
+}
+
+func (p provider) EncryptionKey(_ context.Context, ia addr.IA,
+	_ scrypto.Version) (scrypto.Version, scrypto.KeyMeta, error) {
+
+	pair, ok := p.keys[ia]
+	if !ok {
+		return 0, scrypto.KeyMeta{}, serrors.New("unknown AS", "ia", ia)
+	}
+	meta := scrypto.KeyMeta{
+		KeyVersion: 1,
+		Algorithm:  scrypto.Curve25519xSalsa20Poly1305,
+		Key:        pair[0],
+	}
+	return 1, meta, nil
+}
+
+func (p provider) DecryptionKey(_ context.Context,
+	_ scrypto.Version) (scrypto.Version, keyconf.Key, error) {
+
+	key := keyconf.Key{
+		Algorithm: scrypto.Curve25519xSalsa20Poly1305,
+		Bytes:     p.keys[p.local][1],
+	}
+	return 1, key, nil
+}
+
+func TestSealOpenKey(t *testing.T) {
+	ia1 := xtest.MustParseIA("1-ff00:0:1")
+	ia2 := xtest.MustParseIA("1-ff00:0:2")
+	ia3 := xtest.MustParseIA("1-ff00:0:3")
+	keys := genASKeys(t, ia1, ia2, ia3)
+	key, err := sigcrypto.GenKey(42)
+	require.NoError(t, err)
+
+	ann, err := sigcrypto.SealKey(context.Background(), provider{local: ia1, keys: keys},
+		ia2, 3, key)
+	require.NoError(t, err)
+	assert.Equal(t, uint16(42), ann.Epoch)
+	assert.NotContains(t, string(ann.Key), string(key.Raw()))
+
+	opened, err := sigcrypto.OpenKey(context.Background(), provider{local: ia2, keys: keys},
+		ia1, ann)
+	require.NoError(t, err)
+	assert.Equal(t, key.Raw(), opened.Raw())
+	assert.Equal(t, key.Epoch, opened.Epoch)
+	assert.True(t, key.Created.Equal(opened.Created))
+
+	// Only the receiving AS can open the key.
+	_, err = sigcrypto.OpenKey(context.Background(), provider{local: ia3, keys: keys},
+		ia1, ann)
+	assert.Error(t, err)
+	// The key must be opened with the key of the sending AS.
+	_, err = sigcrypto.OpenKey(context.Background(), provider{local: ia2, keys: keys},
+		ia3, ann)
+	assert.Error(t, err)
+}
+
+func TestSealOpenKeyMismatch(t *testing.T) {
+	ia1 := xtest.MustParseIA("1-ff00:0:1")
+	ia2 := xtest.MustParseIA("1-ff00:0:2")
+	keys := genASKeys(t, ia1, ia2)
+	key, err := sigcrypto.GenKey(42)
+	require.NoError(t, err)
+	ann, err := sigcrypto.SealKey(context.Background(), provider{local: ia1, keys: keys},
+		ia2, 3, key)
+	require.NoError(t, err)
+	// The epoch and session are authenticated.
+	ann.Epoch++
+	_, err = sigcrypto.OpenKey(context.Background(), provider{local: ia2, keys: keys}, ia1, ann)
+	assert.Error(t, err)
+	ann.Epoch--
+	ann.Session++
+	_, err = sigcrypto.OpenKey(context.Background(), provider{local: ia2, keys: keys}, ia1, ann)
+	assert.Error(t, err)
+}
+
+func TestReplayKeyAnnounce(t *testing.T) {
+	ia1 := xtest.MustParseIA("1-ff00:0:1")
+	ia2 := xtest.MustParseIA("1-ff00:0:2")
+	host := net.IP{192, 0, 2, 1}
+	keys := genASKeys(t, ia1, ia2)
+	seal := func(epoch uint16, created time.Time) *sig_mgmt.KeyAnnounce {
+		key, err := sigcrypto.GenKey(epoch)
+		require.NoError(t, err)
+		key.Created = created
+		ann, err := sigcrypto.SealKey(context.Background(), provider{local: ia1, keys: keys},
+			ia2, 3, key)
+		require.NoError(t, err)
+		return ann
+	}
+	set := func(store *sigcrypto.RxKeyStore, ann *sig_mgmt.KeyAnnounce) error {
+		key, err := sigcrypto.OpenKey(context.Background(), provider{local: ia2, keys: keys},
+			ia1, ann)
+		require.NoError(t, err)
+		return store.Set(ia1, host, ann.Session, key)
+	}
+	now := time.Now()
+
+	t.Run("expired announcement", func(t *testing.T) {
+		store := sigcrypto.NewRxKeyStore()
+		expired := seal(1, now.Add(-sigcrypto.KeyLifetime))
+		err := set(store, expired)
+		assert.True(t, errors.Is(err, sigcrypto.ErrStaleKey), err)
+		assert.Nil(t, store.Get(ia1, host, 3, 1))
+	})
+	t.Run("announcement older than the latest key", func(t *testing.T) {
+		store := sigcrypto.NewRxKeyStore()
+		old := seal(1, now.Add(-2*time.Second))
+		require.NoError(t, set(store, old))
+		require.NoError(t, set(store, seal(2, now.Add(-time.Second))))
+		// Retransmissions of a replaced key are rejected.
+		err := set(store, old)
+		assert.True(t, errors.Is(err, sigcrypto.ErrKeyReplay), err)
+	})
+}
+
+func genASKeys(t *testing.T, ias ...addr.IA) asKeys {
+	keys := asKeys{}
+	for _, ia := range ias {
+		pub, priv, err := scrypto.GenKeyPair(scrypto.Curve25519xSalsa20Poly1305)
+		require.NoError(t, err)
+		keys[ia] = [2][]byte{pub, priv}
+	}
+	return keys
+}
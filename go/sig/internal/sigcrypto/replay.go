@@ -0,0 +1,78 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sigcrypto
+
+// replayWindowSize is the number of sequence numbers below the highest
+// received one that are tracked. Older frames are rejected.
+const replayWindowSize = 1024
+
+// replayWindow tracks the sequence numbers received in an epoch.
+type replayWindow struct {
+	init bool
+	// max is the highest sequence number received.
+	max uint32
+	// bitmap has a bit set for each received sequence number in the window,
+	// indexed by the sequence number modulo the window size.
+	bitmap [replayWindowSize / 64]uint64
+}
+
+// Check returns whether the sequence number has not been received before and
+// is within the window.
+func (w *replayWindow) Check(seq uint32) bool {
+	if !w.init || seq > w.max {
+		return true
+	}
+	if w.max-seq >= replayWindowSize {
+		return false
+	}
+	return !w.isSet(seq)
+}
+
+// Update marks the sequence number as received. It must only be called for
+// frames that passed Check and were authenticated.
+func (w *replayWindow) Update(seq uint32) {
+	switch {
+	case !w.init:
+		w.init = true
+	case seq > w.max:
+		if seq-w.max >= replayWindowSize {
+			w.bitmap = [replayWindowSize / 64]uint64{}
+		} else {
+			for s := w.max + 1; s < seq; s++ {
+				w.clear(s)
+			}
+		}
+	default:
+		w.set(seq)
+		return
+	}
+	w.max = seq
+	w.set(seq)
+}
+
+func (w *replayWindow) isSet(seq uint32) bool {
+	i := seq % replayWindowSize
+	return w.bitmap[i/64]&(1<<(i%64)) != 0
+}
+
+func (w *replayWindow) set(seq uint32) {
+	i := seq % replayWindowSize
+	w.bitmap[i/64] |= 1 << (i % 64)
+}
+
+func (w *replayWindow) clear(seq uint32) {
+	i := seq % replayWindowSize
+	w.bitmap[i/64] &^= 1 << (i % 64)
+}
@@ -0,0 +1,164 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sigcrypto
+
+import (
+	"bytes"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/sig_mgmt"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+var (
+	// ErrStaleKey indicates that an announced key was not created within
+	// AnnounceFreshness.
+	ErrStaleKey = serrors.New("stale key announcement")
+	// ErrKeyReplay indicates that an announced key is not newer than the
+	// latest key accepted for the session.
+	ErrKeyReplay = serrors.New("key announcement replayed")
+)
+
+// RxKeys holds the keys of the frames received from remote SIGs.
+var RxKeys = NewRxKeyStore()
+
+// RxKey is a key of the frames received from a remote SIG. It keeps track of
+// the received sequence numbers to reject replayed frames.
+type RxKey struct {
+	*Key
+	mu     sync.Mutex
+	window replayWindow
+	expiry time.Time
+}
+
+// Open authenticates and decrypts the frame with the sequence number. Frames
+// that have been received before, or are too old to tell, are rejected with
+// ErrReplay.
+func (k *RxKey) Open(frame common.RawBytes, seq uint32) (common.RawBytes, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if !k.window.Check(seq) {
+		return nil, ErrReplay
+	}
+	out, err := k.Key.Open(frame)
+	if err != nil {
+		return nil, err
+	}
+	k.window.Update(seq)
+	return out, nil
+}
+
+type rxSessID struct {
+	ia   addr.IAInt
+	host string
+	sess sig_mgmt.SessionType
+}
+
+type rxKeyID struct {
+	rxSessID
+	epoch uint16
+}
+
+// RxKeyStore holds the keys of the received frames, identified by the remote
+// SIG, the session and the epoch. It also keeps track of the remote ASes that
+// must encrypt their frames.
+type RxKeyStore struct {
+	mu   sync.RWMutex
+	keys map[rxKeyID]*RxKey
+	// latest is the creation time of the latest key accepted per session of a
+	// remote SIG. It is kept after the keys expire, such that old
+	// announcements cannot be replayed.
+	latest   map[rxSessID]time.Time
+	required map[addr.IAInt]bool
+}
+
+// NewRxKeyStore creates an empty key store.
+func NewRxKeyStore() *RxKeyStore {
+	return &RxKeyStore{
+		keys:     make(map[rxKeyID]*RxKey),
+		latest:   make(map[rxSessID]time.Time),
+		required: make(map[addr.IAInt]bool),
+	}
+}
+
+// Set adds the key of the remote SIG for the session. Keys that were not
+// created within AnnounceFreshness are rejected with ErrStaleKey. Keys that
+// were created before the latest key accepted for the session are rejected
+// with ErrKeyReplay. A retransmitted announcement of the latest key is
+// accepted without modifying the key. Expired keys are removed.
+func (s *RxKeyStore) Set(ia addr.IA, host net.IP, sess sig_mgmt.SessionType, key *Key) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	for id, k := range s.keys {
+		if now.After(k.expiry) {
+			delete(s.keys, id)
+		}
+	}
+	if age := now.Sub(key.Created); age > AnnounceFreshness || age < -AnnounceFreshness {
+		return serrors.WithCtx(ErrStaleKey, "created", key.Created)
+	}
+	sid := rxSessID{ia: ia.IAInt(), host: host.String(), sess: sess}
+	id := rxKeyID{rxSessID: sid, epoch: key.Epoch}
+	latest := s.latest[sid]
+	if key.Created.Equal(latest) {
+		if k, ok := s.keys[id]; ok && bytes.Equal(k.Raw(), key.Raw()) {
+			return nil
+		}
+	}
+	if !key.Created.After(latest) {
+		return serrors.WithCtx(ErrKeyReplay, "created", key.Created, "latest", latest)
+	}
+	s.latest[sid] = key.Created
+	s.keys[id] = &RxKey{Key: key, expiry: now.Add(KeyLifetime)}
+	return nil
+}
+
+// Get returns the key of the remote SIG for the session epoch, or nil if there
+// is none.
+func (s *RxKeyStore) Get(ia addr.IA, host net.IP, sess sig_mgmt.SessionType,
+	epoch uint16) *RxKey {
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	sid := rxSessID{ia: ia.IAInt(), host: host.String(), sess: sess}
+	k, ok := s.keys[rxKeyID{rxSessID: sid, epoch: epoch}]
+	if !ok || time.Now().After(k.expiry) {
+		return nil
+	}
+	return k
+}
+
+// SetRequired sets whether the frames from the remote AS must be encrypted.
+func (s *RxKeyStore) SetRequired(ia addr.IA, required bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if required {
+		s.required[ia.IAInt()] = true
+	} else {
+		delete(s.required, ia.IAInt())
+	}
+}
+
+// Required returns whether the frames from the remote AS must be encrypted.
+func (s *RxKeyStore) Required(ia addr.IA) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.required[ia.IAInt()]
+}
@@ -17,6 +17,7 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"flag"
 	"fmt"
 	"io"
@@ -24,25 +25,35 @@ import (
 	_ "net/http/pprof"
 	"os"
 	"os/user"
+	"path/filepath"
 	"sync/atomic"
 
 	"github.com/BurntSushi/toml"
 	"github.com/syndtr/gocapability/capability"
 
+	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/env"
 	"github.com/scionproto/scion/go/lib/fatal"
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/infra/modules/trust"
+	"github.com/scionproto/scion/go/lib/infra/modules/trust/trustdbsqlite"
+	"github.com/scionproto/scion/go/lib/keyconf"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/prom"
+	"github.com/scionproto/scion/go/lib/scrypto"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/sigdisp"
 	"github.com/scionproto/scion/go/lib/sigjson"
 	"github.com/scionproto/scion/go/sig/egress"
+	"github.com/scionproto/scion/go/sig/egress/asmap"
 	"github.com/scionproto/scion/go/sig/internal/base"
 	"github.com/scionproto/scion/go/sig/internal/ingress"
 	"github.com/scionproto/scion/go/sig/internal/metrics"
+	"github.com/scionproto/scion/go/sig/internal/mgmtapi"
 	"github.com/scionproto/scion/go/sig/internal/sigcmn"
 	"github.com/scionproto/scion/go/sig/internal/sigconfig"
+	"github.com/scionproto/scion/go/sig/internal/sigcrypto"
 	"github.com/scionproto/scion/go/sig/internal/xnet"
 )
 
@@ -93,6 +104,12 @@ func realMain() int {
 			log.Info("reloadOnSIGHUP: reload done", "success", success)
 		},
 	)
+	if cfg.Sig.CryptoDir != "" {
+		if err := setupCrypto(); err != nil {
+			log.Crit("Unable to set up frame encryption", "err", err)
+			return 1
+		}
+	}
 	sigdisp.Init(sigcmn.CtrlConn, false)
 	// Parse sig config
 	if loadConfig(cfg.Sig.SIGConfig) != true {
@@ -104,11 +121,20 @@ func realMain() int {
 		defer log.HandlePanic()
 		base.PollReqHdlr()
 	}()
+	// Accept the frame keys of other SIGs.
+	go func() {
+		defer log.HandlePanic()
+		sigcrypto.KeyAnnounceHdlr()
+	}()
 	egress.Init(tunIO)
-	ingress.Init(tunIO)
+	ingress.Init(tunIO, ingress.ReorderConfig{
+		Frames:  cfg.Sig.ReorderFrames,
+		Timeout: cfg.Sig.ReorderTimeout.Duration,
+	})
 	http.HandleFunc("/config", configHandler)
 	http.HandleFunc("/info", env.InfoHandler)
 	cfg.Metrics.StartPrometheus()
+	startMgmtAPI()
 	select {
 	case <-fatal.ShutdownChan():
 		return 0
@@ -145,6 +171,63 @@ func validateConfig() error {
 	return nil
 }
 
+// startMgmtAPI serves the management API on its own address, such that it is
+// not exposed together with the metrics.
+func startMgmtAPI() {
+	if cfg.Sig.MgmtAddr == "" {
+		return
+	}
+	mux := http.NewServeMux()
+	mux.Handle(mgmtapi.Prefix, mgmtapi.Handler{Map: asmap.Map})
+	log.Info("Serving management API", "addr", cfg.Sig.MgmtAddr)
+	go func() {
+		defer log.HandlePanic()
+		if err := http.ListenAndServe(cfg.Sig.MgmtAddr, mux); err != nil {
+			fatal.Fatal(serrors.WrapStr("Management API ListenAndServe error", err))
+		}
+	}()
+}
+
+// setupCrypto loads the certificate chains and keys used to exchange the frame
+// keys with other SIGs. The chains of the remote ASes must be present in the
+// crypto directory, they are not fetched from the network.
+func setupCrypto() error {
+	trustDB, err := trustdbsqlite.New(":memory:")
+	if err != nil {
+		return serrors.WrapStr("Unable to create trust database", err)
+	}
+	inserter := trust.DefaultInserter{
+		BaseInserter: trust.BaseInserter{DB: trustDB},
+	}
+	provider := trust.Provider{
+		DB:       trustDB,
+		Recurser: trust.LocalOnlyRecurser{},
+		Router:   trust.LocalRouter{IA: cfg.Sig.IA},
+	}
+	trustStore := trust.Store{
+		Inspector:      trust.DefaultInspector{Provider: provider},
+		CryptoProvider: provider,
+		Inserter:       inserter,
+		DB:             trustDB,
+	}
+	certsDir := filepath.Join(cfg.Sig.CryptoDir, "certs")
+	if err := trustStore.LoadCryptoMaterial(context.Background(), certsDir); err != nil {
+		return serrors.WrapStr("Unable to load crypto material", err, "dir", certsDir)
+	}
+	opts := infra.ChainOpts{TrustStoreOpts: infra.TrustStoreOpts{LocalOnly: true}}
+	sigcrypto.Provider = sigcrypto.ChainKeyProvider{
+		IA: cfg.Sig.IA,
+		Chains: func(ctx context.Context, ia addr.IA, version scrypto.Version) ([]byte, error) {
+			return provider.GetRawChain(ctx, trust.ChainID{IA: ia, Version: version}, opts)
+		},
+		Keys: keyconf.LoadingRing{
+			Dir: filepath.Join(cfg.Sig.CryptoDir, "keys"),
+			IA:  cfg.Sig.IA,
+		},
+	}
+	return nil
+}
+
 func setupTun() (io.ReadWriteCloser, error) {
 	if err := checkPerms(); err != nil {
 		return nil, serrors.WrapStr("Permissions checks failed", err)